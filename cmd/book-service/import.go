@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/hinha/library-management-synapsis/cmd/config"
+	pb "github.com/hinha/library-management-synapsis/gen/api/proto/book"
+	"github.com/hinha/library-management-synapsis/internal/infrastructure/client"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+)
+
+// importChunkSize is how much of the file each ImportBooks message carries
+const importChunkSize = 32 * 1024
+
+// columnFlag collects repeated -column field=header flags
+type columnFlag map[string]string
+
+func (c columnFlag) String() string {
+	pairs := make([]string, 0, len(c))
+	for field, header := range c {
+		pairs = append(pairs, field+"="+header)
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (c columnFlag) Set(value string) error {
+	field, header, ok := strings.Cut(value, "=")
+	if !ok || field == "" || header == "" {
+		return fmt.Errorf("want field=header, got %q", value)
+	}
+	c[field] = header
+	return nil
+}
+
+// runImport streams a catalogue file to a running book service's
+// ImportBooks RPC and prints the report:
+//
+//	book-service import [-format csv|marc21] [-column field=header]... [-dry-run] FILE
+func runImport(args []string) error {
+	columns := columnFlag{}
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	addr := flags.String("addr", config.SharedGrpcBookServiceAddr, "book service gRPC address")
+	token := flags.String("token", os.Getenv("LIBRARY_ADMIN_TOKEN"), "admin access token (default $LIBRARY_ADMIN_TOKEN)")
+	format := flags.String("format", "", "csv or marc21 (default from the file extension)")
+	dryRun := flags.Bool("dry-run", false, "validate and report without saving anything")
+	batchSize := flags.Int("batch-size", 0, "records per transaction (default 100)")
+	defaultStock := flags.Int("default-stock", 0, "stock of records that carry none, such as MARC21 records")
+	flags.Var(columns, "column", "CSV column for a book field as field=header, repeatable")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("usage: book-service import [flags] FILE")
+	}
+	if *token == "" {
+		return errors.New("an admin token is required, set -token or LIBRARY_ADMIN_TOKEN")
+	}
+
+	path := flags.Arg(0)
+	if *format == "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".csv":
+			*format = "csv"
+		case ".mrc", ".marc":
+			*format = "marc21"
+		default:
+			return fmt.Errorf("cannot tell the format of %s, set -format", path)
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	conn, err := client.NewGRPCClient(context.Background(), *addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+*token)
+	stream, err := pb.NewBookServiceClient(conn).ImportBooks(ctx)
+	if err != nil {
+		return importStatus(err)
+	}
+
+	req := &pb.ImportBooksRequest{Options: &pb.ImportOptions{
+		Format:       *format,
+		Columns:      columns,
+		DryRun:       *dryRun,
+		BatchSize:    int32(*batchSize),
+		DefaultStock: int32(*defaultStock),
+	}}
+	buf := make([]byte, importChunkSize)
+	for {
+		n, readErr := file.Read(buf)
+		if n > 0 || req.Options != nil {
+			req.Data = buf[:n]
+			// The server only answers once the stream is closed, so a
+			// failed send shows up in CloseAndRecv
+			if err := stream.Send(req); err != nil {
+				break
+			}
+			req = &pb.ImportBooksRequest{}
+		}
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if readErr != nil {
+			return readErr
+		}
+	}
+
+	report, err := stream.CloseAndRecv()
+	if err != nil {
+		return importStatus(err)
+	}
+	printImportReport(os.Stdout, report)
+	return nil
+}
+
+// importStatus turns a gRPC status into a plain error message
+func importStatus(err error) error {
+	if st, ok := status.FromError(err); ok {
+		return fmt.Errorf("%s: %s", st.Code(), st.Message())
+	}
+	return err
+}
+
+// printImportReport writes the outcome of every record and the totals
+func printImportReport(w io.Writer, report *pb.ImportBooksResponse) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ROW\tSTATUS\tBOOK ID\tISBN\tTITLE\tREASON")
+	for _, result := range report.GetResults() {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\n", result.GetRow(), result.GetStatus(), result.GetBookId(), result.GetIsbn(), result.GetTitle(), result.GetReason())
+	}
+	_ = tw.Flush()
+
+	summary := fmt.Sprintf("%d created, %d updated, %d rejected", report.GetCreated(), report.GetUpdated(), report.GetRejected())
+	if report.GetDryRun() {
+		summary += " (dry run, nothing was saved)"
+	}
+	fmt.Fprintln(w, summary)
+}
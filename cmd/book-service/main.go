@@ -2,25 +2,27 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/hinha/library-management-synapsis/cmd/config"
 	pb "github.com/hinha/library-management-synapsis/gen/api/proto/book"
+	"github.com/hinha/library-management-synapsis/internal/delivery/gateway"
 	grpcHandler "github.com/hinha/library-management-synapsis/internal/delivery/grpc"
 	"github.com/hinha/library-management-synapsis/internal/delivery/middleware"
-	"github.com/hinha/library-management-synapsis/internal/domain"
 	"github.com/hinha/library-management-synapsis/internal/domain/book"
 	"github.com/hinha/library-management-synapsis/internal/infrastructure/client"
+	"github.com/hinha/library-management-synapsis/internal/infrastructure/lifecycle"
+	"github.com/hinha/library-management-synapsis/internal/infrastructure/migration"
+	"github.com/hinha/library-management-synapsis/internal/infrastructure/outbox"
 	"github.com/hinha/library-management-synapsis/internal/infrastructure/persistance"
+	"github.com/hinha/library-management-synapsis/migrate"
 	"github.com/hinha/library-management-synapsis/pkg/logger"
 	"github.com/joho/godotenv"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
-	"net"
 	"net/http"
 	"os"
-	"os/signal"
-	"syscall"
 )
 
 func main() {
@@ -29,12 +31,32 @@ func main() {
 		log.Info().Msg("Warning: .env file not found")
 	}
 
+	// Admin subcommands talk to a running service instead of starting one
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		if err := runImport(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, "import:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Load book service configuration
 	cfg := config.LoadBookServiceConfig()
 
 	// Initialize loggers
 	gormLogger, grpcInterceptor, httpMiddleware := logger.NewLogger()
 
+	server, err := lifecycle.New(lifecycle.Config{
+		Name:            "Book service",
+		GrpcAddr:        cfg.GrpcAddr,
+		HttpAddr:        cfg.HttpAddr,
+		ReadinessDelay:  config.ShutdownReadinessDelay,
+		ShutdownTimeout: config.ShutdownTimeout,
+	})
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid SHUTDOWN_TIMEOUT")
+	}
+
 	// Initialize database connection
 	db, err := persistance.NewDatabaseConnection(cfg, gormLogger)
 	if err != nil {
@@ -44,87 +66,129 @@ func main() {
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to get database connection")
 	}
-	defer dbClose.Close()
+	server.OnStop("database", func(context.Context) error { return dbClose.Close() })
+
+	migrator, err := migration.New(db, migrate.Book(), "book-service")
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to load migrations")
+	}
 
-	// Auto migrate the schema
-	if err := db.AutoMigrate(&domain.Book{}); err != nil {
-		log.Fatal().Err(err).Msg("Failed to migrate database")
+	// Schema changes run as a subcommand, ahead of rolling out the service
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := migration.Run(context.Background(), migrator, os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, "migrate:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Refuse to start on a schema older than this build
+	if err := migrator.Check(context.Background()); err != nil {
+		log.Fatal().Err(err).Msg("Database schema is not up to date, run `book-service migrate up`")
 	}
 
 	grpcClient, err := client.NewGRPCClient(context.Background(), config.SharedGrpcAuthServiceAddr)
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to connect to auth service")
 	}
-	defer grpcClient.Close()
+	server.OnStop("user service client", func(context.Context) error { return grpcClient.Close() })
 
-	middlewareHandler := middleware.NewMiddleware(nil, grpcClient)
+	middlewareHandler := middleware.NewMiddleware(nil, grpcClient, config.ServiceToken)
 
 	// Initialize repositories
 	bookRepo := book.NewDbRepository(db)
+	if fullText, err := bookRepo.DetectFullTextSearch(context.Background()); err != nil || !fullText {
+		log.Warn().Err(err).Msg("Full-text search unavailable, falling back to ILIKE matching")
+	}
 
-	// Initialize services
-	bookService := book.NewService(bookRepo)
-
-	// Initialize gRPC handlers
-	bookHandler := grpcHandler.NewBookHandler(bookService)
-
-	// Start gRPC server
-	grpcReady := make(chan struct{})
-	go startGRPCServer(cfg.GrpcAddr, bookHandler, middlewareHandler, grpcInterceptor, grpcReady)
-
-	// Wait for gRPC server to be ready
-	<-grpcReady
+	copyRepo := book.NewCopyRepository(db)
+	loanRepo := book.NewLoanRepository(db)
+	stockRepo := book.NewStockRepository(db)
+	reservationRepo := book.NewReservationRepository(db)
 
-	// Start HTTP gateway
-	go startHTTPServer(cfg.HttpAddr, cfg.GrpcAddr, httpMiddleware)
+	// Initialize services
+	bookService := book.NewService(bookRepo, copyRepo, loanRepo, stockRepo, reservationRepo, book.RecommendPolicy{
+		PopularWindow: config.RecommendPopularWindow,
+		TopCategories: config.RecommendTopCategories,
+	})
+
+	// Follow the loans opened in the transaction service for recommendations
+	if config.ServiceToken != "" {
+		transactionConn, err := client.NewGRPCClient(context.Background(), config.SharedGrpcTransactionServiceAddr)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to connect to transaction service")
+		}
+		server.OnStop("transaction service client", func(context.Context) error { return transactionConn.Close() })
+
+		loanFeed := middleware.NewLoanFeedAdapter(middleware.NewTransactionServiceClient(transactionConn), config.ServiceToken)
+		server.ReadyWhen("loan feed", loanFeed.Check)
+		server.Go("loan feed", func(ctx context.Context) {
+			bookService.RunLoanFeed(ctx, loanFeed, config.RecommendFeedRetryInterval)
+		})
+	} else {
+		log.Warn().Msg("SERVICE_TOKEN not set, recommendations only include new arrivals")
+	}
 
-	// Wait for termination signal
-	waitForTermination()
-}
+	// Purge records soft-deleted longer ago than the retention
+	if config.SoftDeleteRetention > 0 {
+		server.Go("retention", func(ctx context.Context) {
+			bookService.RunRetention(ctx, config.PurgeInterval, config.SoftDeleteRetention)
+		})
+	} else {
+		log.Info().Msg("SOFT_DELETE_RETENTION is 0, deleted books are kept")
+	}
 
-func startGRPCServer(addr string, bookHandler *grpcHandler.BookHandler, mw *middleware.Middleware, logUnary grpc.UnaryServerInterceptor, ready chan struct{}) {
-	lis, err := net.Listen("tcp", addr)
+	// Initialize the outbox relay and the event feed served to subscribers
+	outboxRepo := outbox.NewDbRepository(db)
+	eventBroker := outbox.NewBroker(config.OutboxBatchSize)
+	relay := outbox.NewRelay(outboxRepo, outbox.MultiPublisher{outbox.NewLogPublisher(), eventBroker}, config.OutboxBatchSize)
+	server.Go("outbox relay", func(ctx context.Context) {
+		relay.Run(ctx, config.OutboxRelayInterval)
+	})
+	eventFeed, err := outbox.NewFeed(outboxRepo, eventBroker, config.OutboxBatchSize)
 	if err != nil {
-		log.Fatal().Err(err).Msgf("Failed to listen on %s", addr)
+		log.Fatal().Err(err).Msg("Invalid OUTBOX_BATCH_SIZE")
 	}
 
-	s := grpc.NewServer(grpc.ChainUnaryInterceptor(logUnary, mw.CrossValidateToken()))
-	pb.RegisterBookServiceServer(s, bookHandler)
-
-	log.Info().Msgf("Book service gRPC server listening at %v", lis.Addr())
+	// Initialize gRPC handlers
+	bookHandler := grpcHandler.NewBookHandler(bookService, eventFeed)
 
-	// Signal that the server is ready
-	close(ready)
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(grpcInterceptor, middlewareHandler.CrossValidateToken()),
+		grpc.ChainStreamInterceptor(middlewareHandler.CrossStreamAdmin()),
+	)
+	pb.RegisterBookServiceServer(grpcServer, bookHandler)
 
-	if err := s.Serve(lis); err != nil {
-		log.Fatal().Err(err).Msg("Failed to serve")
+	// Serve until terminated, then drain and close the connections
+	if err := server.Run(context.Background(), grpcServer, newGateway(httpMiddleware)); err != nil {
+		log.Fatal().Err(err).Msg("Book service stopped with an error")
 	}
 }
 
-func startHTTPServer(httpAddr, grpcAddr string, httpMiddleware func(http.Handler) http.Handler) {
-	ctx := context.Background()
-	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
-
-	mux := runtime.NewServeMux()
-	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
-
-	if err := pb.RegisterBookServiceHandlerFromEndpoint(ctx, mux, grpcAddr, opts); err != nil {
-		log.Fatal().Err(err).Msg("Failed to register gateway")
+// newGateway returns the HTTP gateway of the book service
+func newGateway(httpMiddleware func(http.Handler) http.Handler) lifecycle.Gateway {
+	return func(ctx context.Context, grpcAddr string) (http.Handler, error) {
+		mux := runtime.NewServeMux(runtime.WithOutgoingHeaderMatcher(gateway.OutgoingHeaderMatcher))
+		opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+
+		if err := pb.RegisterBookServiceHandlerFromEndpoint(ctx, mux, grpcAddr, opts); err != nil {
+			return nil, err
+		}
+
+		// Serve the catalogue export as a file download
+		conn, err := client.NewGRPCClient(ctx, grpcAddr)
+		if err != nil {
+			return nil, err
+		}
+		go func() {
+			<-ctx.Done()
+			conn.Close()
+		}()
+		if err := gateway.RegisterBookExport(mux, pb.NewBookServiceClient(conn)); err != nil {
+			return nil, err
+		}
+
+		// Apply HTTP middleware for logging
+		return httpMiddleware(mux), nil
 	}
-
-	// Apply HTTP middleware for logging
-	handler := httpMiddleware(mux)
-
-	log.Info().Msgf("Book service HTTP server listening at %v", httpAddr)
-	if err := http.ListenAndServe(httpAddr, handler); err != nil {
-		log.Fatal().Err(err).Msg("Failed to serve HTTP")
-	}
-}
-
-func waitForTermination() {
-	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
-	<-sigCh
-	log.Info().Msg("Shutting down book service...")
 }
@@ -3,6 +3,8 @@ package config
 import (
 	"github.com/joho/godotenv"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -17,6 +19,54 @@ func GetEnv(key, defaultValue string) string {
 	return value
 }
 
+// parseDurations parses a comma separated list of key=duration pairs such as
+// "reference=72h,fiction=504h", skipping malformed entries
+func parseDurations(value string) map[string]time.Duration {
+	durations := make(map[string]time.Duration)
+	for _, pair := range strings.Split(value, ",") {
+		key, raw, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		duration, err := time.ParseDuration(strings.TrimSpace(raw))
+		if err != nil {
+			continue
+		}
+		durations[strings.TrimSpace(key)] = duration
+	}
+	return durations
+}
+
+// parseInts parses a comma separated list of key=integer pairs such as
+// "reference=500,fiction=100", skipping malformed entries
+func parseInts(value string) map[string]int64 {
+	amounts := make(map[string]int64)
+	for _, pair := range strings.Split(value, ",") {
+		key, raw, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		amount, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+		if err != nil {
+			continue
+		}
+		amounts[strings.TrimSpace(key)] = amount
+	}
+	return amounts
+}
+
+// parseList parses a comma separated list such as "admin,operation",
+// skipping empty entries
+func parseList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // ServiceConfig holds configuration for a microservice
 type ServiceConfig struct {
 	// Database configuration
@@ -49,8 +99,61 @@ var (
 
 	RedisKeyUserPrefix = GetEnv("REDIS_KEY_USER_PREFIX", "user:")
 
-	SharedGrpcAuthServiceAddr = GetEnv("CLIENT_USER_GRPC_ADDR", ":50051")
-	SharedGrpcBookServiceAddr = GetEnv("CLIENT_BOOK_GRPC_ADDR", ":50052")
+	// The services of a deployment authenticate to each other with a shared
+	// service token, such as the transaction service changing book stock
+	ServiceToken = GetEnv("SERVICE_TOKEN", "")
+
+	SagaRecoveryInterval, _ = time.ParseDuration(GetEnv("SAGA_RECOVERY_INTERVAL", "1m"))
+	SagaStaleAfter, _       = time.ParseDuration(GetEnv("SAGA_STALE_AFTER", "5m"))
+
+	LoanPeriodDefault, _ = time.ParseDuration(GetEnv("LOAN_PERIOD_DEFAULT", "336h"))
+	LoanPeriodByCategory = parseDurations(GetEnv("LOAN_PERIOD_BY_CATEGORY", ""))
+	LoanPeriodByRole     = parseDurations(GetEnv("LOAN_PERIOD_BY_ROLE", ""))
+	LoanMaxRenewals, _   = strconv.Atoi(GetEnv("LOAN_MAX_RENEWALS", "2"))
+	LoanRenewalGrace, _  = time.ParseDuration(GetEnv("LOAN_RENEWAL_GRACE", "72h"))
+
+	HoldPickupPeriod, _   = time.ParseDuration(GetEnv("HOLD_PICKUP_PERIOD", "72h"))
+	HoldExpiryInterval, _ = time.ParseDuration(GetEnv("HOLD_EXPIRY_INTERVAL", "5m"))
+
+	// Fine amounts are in minor currency units, e.g. cents
+	FinePerDay, _             = strconv.ParseInt(GetEnv("FINE_PER_DAY", "100"), 10, 64)
+	FinePerDayByCategory      = parseInts(GetEnv("FINE_PER_DAY_BY_CATEGORY", ""))
+	FineMax, _                = strconv.ParseInt(GetEnv("FINE_MAX", "2000"), 10, 64)
+	FineBorrowLimit, _        = strconv.ParseInt(GetEnv("FINE_BORROW_LIMIT", "1000"), 10, 64)
+	FineReplacement, _        = strconv.ParseInt(GetEnv("FINE_REPLACEMENT", "5000"), 10, 64)
+	FineReplacementByCategory = parseInts(GetEnv("FINE_REPLACEMENT_BY_CATEGORY", ""))
+
+	// Borrowing limits of zero are unlimited
+	BorrowMaxActiveLoans, _       = strconv.Atoi(GetEnv("BORROW_MAX_ACTIVE_LOANS", "5"))
+	BorrowMaxActiveLoansByRole    = parseInts(GetEnv("BORROW_MAX_ACTIVE_LOANS_BY_ROLE", ""))
+	BorrowMaxCopiesPerTitle, _    = strconv.Atoi(GetEnv("BORROW_MAX_COPIES_PER_TITLE", "1"))
+	BorrowMaxCopiesPerTitleByRole = parseInts(GetEnv("BORROW_MAX_COPIES_PER_TITLE_BY_ROLE", ""))
+	BorrowAllowOverdueRoles       = parseList(GetEnv("BORROW_ALLOW_OVERDUE_ROLES", ""))
+
+	// The book service follows the transaction service's loan events with the
+	// service token to compute recommendations, resubscribing after the
+	// retry interval when the stream breaks
+	RecommendFeedRetryInterval, _ = time.ParseDuration(GetEnv("RECOMMEND_FEED_RETRY_INTERVAL", "30s"))
+	RecommendPopularWindow, _     = time.ParseDuration(GetEnv("RECOMMEND_POPULAR_WINDOW", "720h"))
+	RecommendTopCategories, _     = strconv.Atoi(GetEnv("RECOMMEND_TOP_CATEGORIES", "3"))
+
+	// Soft-deleted records are purged once deleted longer ago than the
+	// retention; a retention of zero keeps them forever
+	SoftDeleteRetention, _ = time.ParseDuration(GetEnv("SOFT_DELETE_RETENTION", "2160h"))
+	PurgeInterval, _       = time.ParseDuration(GetEnv("PURGE_INTERVAL", "24h"))
+
+	// On SIGTERM a service reports not ready and keeps serving for the
+	// readiness delay, then gives in-flight requests the shutdown timeout. A
+	// shutdown timeout that does not parse is zero, which services refuse.
+	ShutdownReadinessDelay, _ = time.ParseDuration(GetEnv("SHUTDOWN_READINESS_DELAY", "5s"))
+	ShutdownTimeout, _        = time.ParseDuration(GetEnv("SHUTDOWN_TIMEOUT", "30s"))
+
+	OutboxRelayInterval, _ = time.ParseDuration(GetEnv("OUTBOX_RELAY_INTERVAL", "1s"))
+	OutboxBatchSize, _     = strconv.Atoi(GetEnv("OUTBOX_BATCH_SIZE", "100"))
+
+	SharedGrpcAuthServiceAddr        = GetEnv("CLIENT_USER_GRPC_ADDR", ":50051")
+	SharedGrpcBookServiceAddr        = GetEnv("CLIENT_BOOK_GRPC_ADDR", ":50052")
+	SharedGrpcTransactionServiceAddr = GetEnv("CLIENT_TRANSACTION_GRPC_ADDR", ":50053")
 )
 
 // LoadUserServiceConfig loads configuration for the user service
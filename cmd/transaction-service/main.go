@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/hinha/library-management-synapsis/cmd/config"
 	pb "github.com/hinha/library-management-synapsis/gen/api/proto/transaction"
@@ -10,17 +11,20 @@ import (
 	"github.com/hinha/library-management-synapsis/internal/domain"
 	"github.com/hinha/library-management-synapsis/internal/domain/transaction"
 	"github.com/hinha/library-management-synapsis/internal/infrastructure/client"
+	"github.com/hinha/library-management-synapsis/internal/infrastructure/lifecycle"
+	"github.com/hinha/library-management-synapsis/internal/infrastructure/migration"
+	"github.com/hinha/library-management-synapsis/internal/infrastructure/outbox"
 	"github.com/hinha/library-management-synapsis/internal/infrastructure/persistance"
+	"github.com/hinha/library-management-synapsis/migrate"
 	"github.com/hinha/library-management-synapsis/pkg/logger"
 	"github.com/joho/godotenv"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
-	"net"
 	"net/http"
 	"os"
-	"os/signal"
-	"syscall"
+	"slices"
+	"time"
 )
 
 func main() {
@@ -35,6 +39,17 @@ func main() {
 	// Initialize loggers
 	gormLogger, grpcInterceptor, httpMiddleware := logger.NewLogger()
 
+	server, err := lifecycle.New(lifecycle.Config{
+		Name:            "Transaction service",
+		GrpcAddr:        cfg.GrpcAddr,
+		HttpAddr:        cfg.HttpAddr,
+		ReadinessDelay:  config.ShutdownReadinessDelay,
+		ShutdownTimeout: config.ShutdownTimeout,
+	})
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid SHUTDOWN_TIMEOUT")
+	}
+
 	db, err := persistance.NewDatabaseConnection(cfg, gormLogger)
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to connect to database")
@@ -43,11 +58,25 @@ func main() {
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to get database connection")
 	}
-	defer dbClose.Close()
+	server.OnStop("database", func(context.Context) error { return dbClose.Close() })
+
+	migrator, err := migration.New(db, migrate.Transaction(), "transaction-service")
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to load migrations")
+	}
+
+	// Schema changes run as a subcommand, ahead of rolling out the service
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := migration.Run(context.Background(), migrator, os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, "migrate:", err)
+			os.Exit(1)
+		}
+		return
+	}
 
-	// Auto migrate the schema
-	if err := db.AutoMigrate(&domain.Transaction{}); err != nil {
-		log.Fatal().Err(err).Msg("Failed to migrate database")
+	// Refuse to start on a schema older than this build
+	if err := migrator.Check(context.Background()); err != nil {
+		log.Fatal().Err(err).Msg("Database schema is not up to date, run `transaction-service migrate up`")
 	}
 
 	ctx := context.Background()
@@ -56,85 +85,128 @@ func main() {
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to connect to book service")
 	}
-	defer bookConn.Close()
+	server.OnStop("book service client", func(context.Context) error { return bookConn.Close() })
 
 	// Connect to auth service
 	authConn, err := client.NewGRPCClient(ctx, config.SharedGrpcAuthServiceAddr)
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to connect to book service")
+		log.Fatal().Err(err).Msg("Failed to connect to auth service")
 	}
-	defer bookConn.Close()
+	server.OnStop("user service client", func(context.Context) error { return authConn.Close() })
 
-	middlewareHandler := middleware.NewMiddleware(nil, authConn)
+	// Stock changes are made on the book service with the service token
+	if config.ServiceToken == "" {
+		log.Fatal().Msg("SERVICE_TOKEN is required to call the book service")
+	}
+	middlewareHandler := middleware.NewMiddleware(nil, authConn, config.ServiceToken)
 	bookClient := middleware.NewBookServiceClient(bookConn)
-	bookRepo := middleware.NewBookRepositoryAdapter(bookClient)
+	bookRepo := middleware.NewBookRepositoryAdapter(bookClient, config.ServiceToken)
+	userClient := middleware.NewUserServiceClient(authConn)
+	userRepo := middleware.NewUserRepositoryAdapter(userClient)
 
 	// Initialize repositories
 	transactionRepo := transaction.NewGormRepository(db)
+	holdRepo := transaction.NewHoldRepository(db)
+	ledgerRepo := transaction.NewLedgerRepository(db)
+	blockRepo := transaction.NewBlockRepository(db)
+	sagaRepo := transaction.NewSagaRepository(db)
 
 	// Initialize services
-	transactionService := transaction.NewService(transactionRepo, bookRepo)
-
-	// Initialize gRPC handlers
-	transactionHandler := grpcHandler.NewTransactionHandler(transactionService)
-
-	// Start gRPC server
-	grpcReady := make(chan struct{})
-	go startGRPCServer(cfg.GrpcAddr, transactionHandler, middlewareHandler, grpcInterceptor, grpcReady)
-
-	// Wait for gRPC server to be ready
-	<-grpcReady
-
-	// Start HTTP gateway
-	go startHTTPServer(cfg.HttpAddr, cfg.GrpcAddr, httpMiddleware)
-
-	// Wait for termination signal
-	waitForTermination()
-}
+	loanPolicy := transaction.LoanPolicy{
+		DefaultPeriod:    config.LoanPeriodDefault,
+		CategoryPeriods:  config.LoanPeriodByCategory,
+		RolePeriods:      make(map[domain.Role]time.Duration, len(config.LoanPeriodByRole)),
+		MaxRenewals:      int32(config.LoanMaxRenewals),
+		RenewalGrace:     config.LoanRenewalGrace,
+		HoldPickupPeriod: config.HoldPickupPeriod,
+		Fines: transaction.FinePolicy{
+			PerDay:              config.FinePerDay,
+			CategoryPerDay:      config.FinePerDayByCategory,
+			Max:                 config.FineMax,
+			BorrowLimit:         config.FineBorrowLimit,
+			Replacement:         config.FineReplacement,
+			CategoryReplacement: config.FineReplacementByCategory,
+		},
+		DefaultLimits: transaction.BorrowLimits{
+			MaxActiveLoans:    int32(config.BorrowMaxActiveLoans),
+			MaxCopiesPerTitle: int32(config.BorrowMaxCopiesPerTitle),
+		},
+		RoleLimits: make(map[domain.Role]transaction.BorrowLimits),
+	}
+	for role, period := range config.LoanPeriodByRole {
+		loanPolicy.RolePeriods[domain.Role(role)] = period
+	}
+	for _, role := range []domain.Role{domain.RoleAdmin, domain.RoleOperation} {
+		limits := loanPolicy.DefaultLimits
+		if limit, ok := config.BorrowMaxActiveLoansByRole[string(role)]; ok {
+			limits.MaxActiveLoans = int32(limit)
+		}
+		if limit, ok := config.BorrowMaxCopiesPerTitleByRole[string(role)]; ok {
+			limits.MaxCopiesPerTitle = int32(limit)
+		}
+		limits.AllowOverdue = slices.Contains(config.BorrowAllowOverdueRoles, string(role))
+		loanPolicy.RoleLimits[role] = limits
+	}
+	sagaCoordinator := transaction.NewSagaCoordinator(transactionRepo, sagaRepo, bookRepo)
+	transactionService := transaction.NewService(transactionRepo, holdRepo, ledgerRepo, blockRepo, bookRepo, userRepo, sagaCoordinator, loanPolicy)
+
+	// Finish or roll back borrows and returns left half-done by a previous run
+	server.Go("saga recovery", func(ctx context.Context) {
+		sagaCoordinator.RunRecovery(ctx, config.SagaRecoveryInterval, config.SagaStaleAfter)
+	})
+
+	// Pass copies not picked up in time on to the next patron in line
+	server.Go("hold expiry", func(ctx context.Context) {
+		transactionService.RunHoldExpiry(ctx, config.HoldExpiryInterval)
+	})
+
+	// Purge records soft-deleted longer ago than the retention
+	if config.SoftDeleteRetention > 0 {
+		server.Go("retention", func(ctx context.Context) {
+			transactionService.RunRetention(ctx, config.PurgeInterval, config.SoftDeleteRetention)
+		})
+	} else {
+		log.Info().Msg("SOFT_DELETE_RETENTION is 0, deleted transactions are kept")
+	}
 
-func startGRPCServer(addr string, transactionHandler *grpcHandler.TransactionHandler, mw *middleware.Middleware, logUnary grpc.UnaryServerInterceptor, ready chan struct{}) {
-	lis, err := net.Listen("tcp", addr)
+	// Initialize the outbox relay and the event feed served to subscribers
+	outboxRepo := outbox.NewDbRepository(db)
+	eventBroker := outbox.NewBroker(config.OutboxBatchSize)
+	relay := outbox.NewRelay(outboxRepo, outbox.MultiPublisher{outbox.NewLogPublisher(), eventBroker}, config.OutboxBatchSize)
+	server.Go("outbox relay", func(ctx context.Context) {
+		relay.Run(ctx, config.OutboxRelayInterval)
+	})
+	eventFeed, err := outbox.NewFeed(outboxRepo, eventBroker, config.OutboxBatchSize)
 	if err != nil {
-		log.Fatal().Err(err).Msgf("Failed to listen on %s", addr)
+		log.Fatal().Err(err).Msg("Invalid OUTBOX_BATCH_SIZE")
 	}
 
-	s := grpc.NewServer(grpc.ChainUnaryInterceptor(logUnary, mw.CrossValidateToken()))
-	pb.RegisterTransactionServiceServer(s, transactionHandler)
-
-	log.Info().Msgf("Transaction service gRPC server listening at %v", lis.Addr())
+	// Initialize gRPC handlers
+	transactionHandler := grpcHandler.NewTransactionHandler(transactionService, eventFeed)
 
-	// Signal that the server is ready
-	close(ready)
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(grpcInterceptor, middlewareHandler.CrossValidateToken()),
+		grpc.ChainStreamInterceptor(middlewareHandler.CrossStreamAdmin()),
+	)
+	pb.RegisterTransactionServiceServer(grpcServer, transactionHandler)
 
-	if err := s.Serve(lis); err != nil {
-		log.Fatal().Err(err).Msg("Failed to serve")
+	// Serve until terminated, then drain and close the connections
+	if err := server.Run(ctx, grpcServer, newGateway(httpMiddleware)); err != nil {
+		log.Fatal().Err(err).Msg("Transaction service stopped with an error")
 	}
 }
 
-func startHTTPServer(httpAddr, grpcAddr string, httpMiddleware func(http.Handler) http.Handler) {
-	ctx := context.Background()
-	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
+// newGateway returns the HTTP gateway of the transaction service
+func newGateway(httpMiddleware func(http.Handler) http.Handler) lifecycle.Gateway {
+	return func(ctx context.Context, grpcAddr string) (http.Handler, error) {
+		mux := runtime.NewServeMux()
+		opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
 
-	mux := runtime.NewServeMux()
-	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+		if err := pb.RegisterTransactionServiceHandlerFromEndpoint(ctx, mux, grpcAddr, opts); err != nil {
+			return nil, err
+		}
 
-	if err := pb.RegisterTransactionServiceHandlerFromEndpoint(ctx, mux, grpcAddr, opts); err != nil {
-		log.Fatal().Err(err).Msg("Failed to register gateway")
+		// Apply HTTP middleware for logging
+		return httpMiddleware(mux), nil
 	}
-
-	// Apply HTTP middleware for logging
-	handler := httpMiddleware(mux)
-
-	log.Info().Msgf("Transaction service HTTP server listening at %v", httpAddr)
-	if err := http.ListenAndServe(httpAddr, handler); err != nil {
-		log.Fatal().Err(err).Msg("Failed to serve HTTP")
-	}
-}
-
-func waitForTermination() {
-	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
-	<-sigCh
-	log.Info().Msg("Shutting down transaction service...")
 }
@@ -2,23 +2,25 @@ package main
 
 import (
 	"context"
-	"github.com/hinha/library-management-synapsis/internal/domain"
+	"fmt"
+	"github.com/hinha/library-management-synapsis/internal/infrastructure/lifecycle"
+	"github.com/hinha/library-management-synapsis/internal/infrastructure/migration"
+	"github.com/hinha/library-management-synapsis/internal/infrastructure/outbox"
 	"github.com/hinha/library-management-synapsis/internal/infrastructure/persistance"
 	"github.com/hinha/library-management-synapsis/pkg/logger"
-	"net"
 	"net/http"
 	"os"
-	"os/signal"
-	"syscall"
 	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/hinha/library-management-synapsis/cmd/config"
 	pb "github.com/hinha/library-management-synapsis/gen/api/proto/user"
+	"github.com/hinha/library-management-synapsis/internal/delivery/gateway"
 	grpcHandler "github.com/hinha/library-management-synapsis/internal/delivery/grpc"
 	"github.com/hinha/library-management-synapsis/internal/delivery/middleware"
 	"github.com/hinha/library-management-synapsis/internal/domain/user"
 	"github.com/hinha/library-management-synapsis/internal/seeder"
+	"github.com/hinha/library-management-synapsis/migrate"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -30,11 +32,21 @@ func main() {
 
 	gormLogger, grpcInterceptor, httpMiddleware := logger.NewLogger()
 
+	server, err := lifecycle.New(lifecycle.Config{
+		Name:            "User service",
+		GrpcAddr:        cfg.GrpcAddr,
+		HttpAddr:        cfg.HttpAddr,
+		ReadinessDelay:  config.ShutdownReadinessDelay,
+		ShutdownTimeout: config.ShutdownTimeout,
+	})
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid SHUTDOWN_TIMEOUT")
+	}
+
 	rdsClient, err := persistance.NewRedisConnection(cfg)
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to connect to Redis")
 	}
-	defer rdsClient.Close()
 
 	// Initialize database connection
 	db, err := persistance.NewDatabaseConnection(cfg, gormLogger)
@@ -45,22 +57,40 @@ func main() {
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to get database connection")
 	}
-	defer dbClose.Close()
+	server.OnStop("database", func(context.Context) error { return dbClose.Close() })
+	server.OnStop("redis", func(context.Context) error { return rdsClient.Close() })
+
+	migrator, err := migration.New(db, migrate.User(), "user-service")
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to load migrations")
+	}
+
+	// Schema changes run as a subcommand, ahead of rolling out the service
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := migration.Run(context.Background(), migrator, os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, "migrate:", err)
+			os.Exit(1)
+		}
+		return
+	}
 
-	// Auto migrate the schema
-	if err := db.AutoMigrate(&domain.User{}); err != nil {
-		log.Fatal().Err(err).Msg("Failed to migrate database")
+	// Refuse to start on a schema older than this build
+	if err := migrator.Check(context.Background()); err != nil {
+		log.Fatal().Err(err).Msg("Database schema is not up to date, run `user-service migrate up`")
 	}
 
 	// Initialize repositories
 	userRepoCache := user.NewCacheRepository(rdsClient)
 	userRepoDb := user.NewDbRepository(db)
 
-	// Initialize and run seeder
+	// Seed the database before taking requests
 	userSeeder := seeder.NewUserSeeder(userRepoDb)
-	if err := userSeeder.Seed(context.Background()); err != nil {
-		log.Error().Err(err).Msg("Failed to seed database")
-	}
+	server.OnStart("seeder", func(ctx context.Context) error {
+		if err := userSeeder.Seed(ctx); err != nil {
+			log.Error().Err(err).Msg("Failed to seed database")
+		}
+		return nil
+	})
 
 	// Initialize services
 	jwtConfig := user.JWTConfig{
@@ -69,77 +99,64 @@ func main() {
 	}
 	userService := user.NewService(userRepoDb, userRepoCache, jwtConfig)
 
-	// Initialize gRPC handlers
-	userHandler := grpcHandler.NewUserHandler(userService)
-	userMiddleware := middleware.NewMiddleware(userService, nil)
-
-	// Start gRPC server
-	grpcReady := make(chan struct{})
-	go startGRPCServer(cfg.GrpcAddr, userHandler, userMiddleware, grpcInterceptor, grpcReady)
-
-	// Wait for gRPC server to be ready
-	<-grpcReady
-
-	// Start HTTP gateway
-	go startHTTPServer(cfg.HttpAddr, cfg.GrpcAddr, httpMiddleware)
-
-	// Wait for termination signal
-	waitForTermination()
-}
+	// Purge records soft-deleted longer ago than the retention
+	if config.SoftDeleteRetention > 0 {
+		server.Go("retention", func(ctx context.Context) {
+			userService.RunRetention(ctx, config.PurgeInterval, config.SoftDeleteRetention)
+		})
+	} else {
+		log.Info().Msg("SOFT_DELETE_RETENTION is 0, deleted users are kept")
+	}
 
-func startGRPCServer(addr string, userHandler *grpcHandler.UserHandler, mw *middleware.Middleware, logUnary grpc.UnaryServerInterceptor, ready chan struct{}) {
-	lis, err := net.Listen("tcp", addr)
+	// Initialize the outbox relay and the event feed served to subscribers
+	outboxRepo := outbox.NewDbRepository(db)
+	eventBroker := outbox.NewBroker(config.OutboxBatchSize)
+	relay := outbox.NewRelay(outboxRepo, outbox.MultiPublisher{outbox.NewLogPublisher(), eventBroker}, config.OutboxBatchSize)
+	server.Go("outbox relay", func(ctx context.Context) {
+		relay.Run(ctx, config.OutboxRelayInterval)
+	})
+	eventFeed, err := outbox.NewFeed(outboxRepo, eventBroker, config.OutboxBatchSize)
 	if err != nil {
-		log.Fatal().Err(err).Msgf("Failed to listen on %s", addr)
+		log.Fatal().Err(err).Msg("Invalid OUTBOX_BATCH_SIZE")
 	}
 
-	s := grpc.NewServer(grpc.ChainUnaryInterceptor(logUnary, mw.AuthValidateToken()))
-	pb.RegisterUserServiceServer(s, userHandler)
-
-	log.Info().Msgf("User service gRPC server listening at %v", lis.Addr())
-
-	// Signal that the server is ready
-	close(ready)
-
-	if err := s.Serve(lis); err != nil {
-		log.Fatal().Err(err).Msg("Failed to serve")
+	// Initialize gRPC handlers
+	userHandler := grpcHandler.NewUserHandler(userService, eventFeed)
+	userMiddleware := middleware.NewMiddleware(userService, nil, "")
+
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(grpcInterceptor, userMiddleware.AuthValidateToken()),
+		grpc.ChainStreamInterceptor(userMiddleware.AuthStreamAdmin()),
+	)
+	pb.RegisterUserServiceServer(grpcServer, userHandler)
+
+	// Serve until terminated, then drain and close the connections
+	if err := server.Run(context.Background(), grpcServer, newGateway(httpMiddleware)); err != nil {
+		log.Fatal().Err(err).Msg("User service stopped with an error")
 	}
 }
 
-func startHTTPServer(httpAddr, grpcAddr string, httpMiddleware func(http.Handler) http.Handler) {
-	ctx := context.Background()
-	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
-
-	mux := runtime.NewServeMux()
-	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
-
-	// Add a retry mechanism for connecting to the gRPC server
-	var err error
-	for i := 0; i < 5; i++ {
-		err = pb.RegisterUserServiceHandlerFromEndpoint(ctx, mux, grpcAddr, opts)
-		if err == nil {
-			break
+// newGateway returns the HTTP gateway of the user service
+func newGateway(httpMiddleware func(http.Handler) http.Handler) lifecycle.Gateway {
+	return func(ctx context.Context, grpcAddr string) (http.Handler, error) {
+		mux := runtime.NewServeMux(runtime.WithOutgoingHeaderMatcher(gateway.OutgoingHeaderMatcher))
+		opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+
+		// Add a retry mechanism for connecting to the gRPC server
+		var err error
+		for i := 0; i < 5; i++ {
+			err = pb.RegisterUserServiceHandlerFromEndpoint(ctx, mux, grpcAddr, opts)
+			if err == nil {
+				break
+			}
+			log.Warn().Err(err).Msgf("Failed to register gateway, retrying in 1 second (attempt %d/5)", i+1)
+			time.Sleep(time.Second)
+		}
+		if err != nil {
+			return nil, err
 		}
-		log.Warn().Err(err).Msgf("Failed to register gateway, retrying in 1 second (attempt %d/5)", i+1)
-		time.Sleep(time.Second)
-	}
-	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to register gateway after multiple attempts")
-	}
-
-	// Apply HTTP middleware for logging
-	handler := httpMiddleware(mux)
 
-	log.Info().Msgf("User service HTTP server listening at %v", httpAddr)
-	if err := http.ListenAndServe(httpAddr, handler); err != nil {
-		log.Fatal().Err(err).Msg("Failed to serve HTTP")
+		// Apply HTTP middleware for logging
+		return httpMiddleware(mux), nil
 	}
 }
-
-func waitForTermination() {
-	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
-	<-sigCh
-	log.Info().Msg("Shutting down user service...")
-}
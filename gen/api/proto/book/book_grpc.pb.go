@@ -8,6 +8,8 @@ package book
 
 import (
 	context "context"
+	common "github.com/hinha/library-management-synapsis/gen/api/proto/common"
+	httpbody "google.golang.org/genproto/googleapis/api/httpbody"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -25,7 +27,74 @@ type BookServiceClient interface {
 	Create(ctx context.Context, in *CreateBookRequest, opts ...grpc.CallOption) (*BookResponse, error)
 	ListBooks(ctx context.Context, in *ListBooksRequest, opts ...grpc.CallOption) (*ListBooksResponse, error)
 	GetBook(ctx context.Context, in *GetBookRequest, opts ...grpc.CallOption) (*BookResponse, error)
+	// GetBookByISBN looks a book up by its ISBN-10 or ISBN-13
+	GetBookByISBN(ctx context.Context, in *GetBookByISBNRequest, opts ...grpc.CallOption) (*BookResponse, error)
+	UpdateBook(ctx context.Context, in *UpdateBookRequest, opts ...grpc.CallOption) (*BookResponse, error)
+	DeleteBook(ctx context.Context, in *DeleteBookRequest, opts ...grpc.CallOption) (*DeleteBookResponse, error)
+	// ReserveStock takes one copy out of stock, failing with FAILED_PRECONDITION
+	// when none are left. Used by the transaction service when a book is borrowed.
+	// For books with tracked copies the copy is checked out and returned.
+	// Reservations are keyed by transaction_id: a retry returns the copy
+	// already reserved, and a loan whose borrow was cancelled fails with ABORTED.
+	ReserveStock(ctx context.Context, in *ReserveStockRequest, opts ...grpc.CallOption) (*ReserveStockResponse, error)
+	// ReleaseStock puts the copy a loan reserved back into stock when the book
+	// is returned, or when its borrow is cancelled. Releasing it again changes
+	// nothing.
+	ReleaseStock(ctx context.Context, in *ReleaseStockRequest, opts ...grpc.CallOption) (*BookResponse, error)
+	// WriteOffStock takes a copy a patron lost or damaged out of circulation
+	// for good. Used by the transaction service when a loan is closed as lost
+	// or damaged. The copy the loan reserved is marked lost, or damaged and
+	// withdrawn. Writing it off again changes nothing.
+	WriteOffStock(ctx context.Context, in *WriteOffStockRequest, opts ...grpc.CallOption) (*BookResponse, error)
+	// RestoreStock puts the copy a loan lost back into stock when it is found
+	// again. Restoring it again changes nothing.
+	RestoreStock(ctx context.Context, in *RestoreStockRequest, opts ...grpc.CallOption) (*BookResponse, error)
+	// AddCopy registers a physical copy of a book. Admin only.
+	AddCopy(ctx context.Context, in *AddCopyRequest, opts ...grpc.CallOption) (*BookCopy, error)
+	// ListCopies returns every copy of a book, withdrawn ones included
+	ListCopies(ctx context.Context, in *ListCopiesRequest, opts ...grpc.CallOption) (*ListCopiesResponse, error)
+	// GetCopy looks a copy up by its barcode
+	GetCopy(ctx context.Context, in *GetCopyRequest, opts ...grpc.CallOption) (*BookCopy, error)
+	// UpdateCopy changes a copy's shelf location, condition or status. Admin only.
+	UpdateCopy(ctx context.Context, in *UpdateCopyRequest, opts ...grpc.CallOption) (*BookCopy, error)
+	// WithdrawCopy takes a copy out of circulation for good. Admin only.
+	WithdrawCopy(ctx context.Context, in *WithdrawCopyRequest, opts ...grpc.CallOption) (*BookCopy, error)
+	// ListStockMovements returns a book's stock ledger, newest first. Every
+	// change to a book's stock appends a movement in the same database
+	// transaction. Admin only.
+	ListStockMovements(ctx context.Context, in *ListStockMovementsRequest, opts ...grpc.CallOption) (*ListStockMovementsResponse, error)
+	// ReconcileStock recomputes the stock of the books from their stock ledgers
+	// and reports the books whose stock has drifted from it. Admin only.
+	ReconcileStock(ctx context.Context, in *ReconcileStockRequest, opts ...grpc.CallOption) (*ReconcileStockResponse, error)
+	// ImportBooks loads a catalogue file streamed in chunks. The first message
+	// carries the import options. Every record is validated like a
+	// CreateBookRequest and upserted by ISBN, in batches of one database
+	// transaction each. Admin only.
+	ImportBooks(ctx context.Context, opts ...grpc.CallOption) (BookService_ImportBooksClient, error)
+	// ExportBooks streams the books matching the same filters as ListBooks as
+	// a CSV, JSON Lines or MARC21 file, in chunks that each carry the file's
+	// content type. The gateway serves it as a download at GET
+	// /api/books/export. Admin only.
+	ExportBooks(ctx context.Context, in *ExportBooksRequest, opts ...grpc.CallOption) (BookService_ExportBooksClient, error)
+	// Recommend suggests in-stock books the user has not borrowed yet, based on
+	// what similar borrowers read, the user's favourite categories and what is
+	// popular right now.
 	Recommend(ctx context.Context, in *RecommendRequest, opts ...grpc.CallOption) (*ListBooksResponse, error)
+	// SearchBooks ranks books whose title, author or category match q.
+	// q takes plain words, "quoted phrases" and prefix* terms, all of which must match.
+	SearchBooks(ctx context.Context, in *SearchBooksRequest, opts ...grpc.CallOption) (*SearchBooksResponse, error)
+	// SubscribeEvents streams the service's domain events, replaying stored
+	// events after the request cursor before tailing new ones. Admin only.
+	SubscribeEvents(ctx context.Context, in *common.SubscribeEventsRequest, opts ...grpc.CallOption) (BookService_SubscribeEventsClient, error)
+	// ListDeletedBooks returns the soft-deleted books, most recently deleted
+	// first. Admin only.
+	ListDeletedBooks(ctx context.Context, in *common.ListDeletedRequest, opts ...grpc.CallOption) (*ListDeletedBooksResponse, error)
+	// RestoreBook brings a soft-deleted book back, failing with ALREADY_EXISTS
+	// when another book has taken its ISBN since. Admin only.
+	RestoreBook(ctx context.Context, in *RestoreBookRequest, opts ...grpc.CallOption) (*BookResponse, error)
+	// PurgeDeletedBooks permanently removes the books, and their copies,
+	// soft-deleted longer ago than the retention window. Admin only.
+	PurgeDeletedBooks(ctx context.Context, in *common.PurgeDeletedRequest, opts ...grpc.CallOption) (*common.PurgeDeletedResponse, error)
 	HealthCheck(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error)
 }
 
@@ -64,6 +133,198 @@ func (c *bookServiceClient) GetBook(ctx context.Context, in *GetBookRequest, opt
 	return out, nil
 }
 
+func (c *bookServiceClient) GetBookByISBN(ctx context.Context, in *GetBookByISBNRequest, opts ...grpc.CallOption) (*BookResponse, error) {
+	out := new(BookResponse)
+	err := c.cc.Invoke(ctx, "/book.BookService/GetBookByISBN", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) UpdateBook(ctx context.Context, in *UpdateBookRequest, opts ...grpc.CallOption) (*BookResponse, error) {
+	out := new(BookResponse)
+	err := c.cc.Invoke(ctx, "/book.BookService/UpdateBook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) DeleteBook(ctx context.Context, in *DeleteBookRequest, opts ...grpc.CallOption) (*DeleteBookResponse, error) {
+	out := new(DeleteBookResponse)
+	err := c.cc.Invoke(ctx, "/book.BookService/DeleteBook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) ReserveStock(ctx context.Context, in *ReserveStockRequest, opts ...grpc.CallOption) (*ReserveStockResponse, error) {
+	out := new(ReserveStockResponse)
+	err := c.cc.Invoke(ctx, "/book.BookService/ReserveStock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) ReleaseStock(ctx context.Context, in *ReleaseStockRequest, opts ...grpc.CallOption) (*BookResponse, error) {
+	out := new(BookResponse)
+	err := c.cc.Invoke(ctx, "/book.BookService/ReleaseStock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) WriteOffStock(ctx context.Context, in *WriteOffStockRequest, opts ...grpc.CallOption) (*BookResponse, error) {
+	out := new(BookResponse)
+	err := c.cc.Invoke(ctx, "/book.BookService/WriteOffStock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) RestoreStock(ctx context.Context, in *RestoreStockRequest, opts ...grpc.CallOption) (*BookResponse, error) {
+	out := new(BookResponse)
+	err := c.cc.Invoke(ctx, "/book.BookService/RestoreStock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) AddCopy(ctx context.Context, in *AddCopyRequest, opts ...grpc.CallOption) (*BookCopy, error) {
+	out := new(BookCopy)
+	err := c.cc.Invoke(ctx, "/book.BookService/AddCopy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) ListCopies(ctx context.Context, in *ListCopiesRequest, opts ...grpc.CallOption) (*ListCopiesResponse, error) {
+	out := new(ListCopiesResponse)
+	err := c.cc.Invoke(ctx, "/book.BookService/ListCopies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) GetCopy(ctx context.Context, in *GetCopyRequest, opts ...grpc.CallOption) (*BookCopy, error) {
+	out := new(BookCopy)
+	err := c.cc.Invoke(ctx, "/book.BookService/GetCopy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) UpdateCopy(ctx context.Context, in *UpdateCopyRequest, opts ...grpc.CallOption) (*BookCopy, error) {
+	out := new(BookCopy)
+	err := c.cc.Invoke(ctx, "/book.BookService/UpdateCopy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) WithdrawCopy(ctx context.Context, in *WithdrawCopyRequest, opts ...grpc.CallOption) (*BookCopy, error) {
+	out := new(BookCopy)
+	err := c.cc.Invoke(ctx, "/book.BookService/WithdrawCopy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) ListStockMovements(ctx context.Context, in *ListStockMovementsRequest, opts ...grpc.CallOption) (*ListStockMovementsResponse, error) {
+	out := new(ListStockMovementsResponse)
+	err := c.cc.Invoke(ctx, "/book.BookService/ListStockMovements", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) ReconcileStock(ctx context.Context, in *ReconcileStockRequest, opts ...grpc.CallOption) (*ReconcileStockResponse, error) {
+	out := new(ReconcileStockResponse)
+	err := c.cc.Invoke(ctx, "/book.BookService/ReconcileStock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) ImportBooks(ctx context.Context, opts ...grpc.CallOption) (BookService_ImportBooksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &BookService_ServiceDesc.Streams[0], "/book.BookService/ImportBooks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &bookServiceImportBooksClient{stream}
+	return x, nil
+}
+
+type BookService_ImportBooksClient interface {
+	Send(*ImportBooksRequest) error
+	CloseAndRecv() (*ImportBooksResponse, error)
+	grpc.ClientStream
+}
+
+type bookServiceImportBooksClient struct {
+	grpc.ClientStream
+}
+
+func (x *bookServiceImportBooksClient) Send(m *ImportBooksRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *bookServiceImportBooksClient) CloseAndRecv() (*ImportBooksResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ImportBooksResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *bookServiceClient) ExportBooks(ctx context.Context, in *ExportBooksRequest, opts ...grpc.CallOption) (BookService_ExportBooksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &BookService_ServiceDesc.Streams[1], "/book.BookService/ExportBooks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &bookServiceExportBooksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BookService_ExportBooksClient interface {
+	Recv() (*httpbody.HttpBody, error)
+	grpc.ClientStream
+}
+
+type bookServiceExportBooksClient struct {
+	grpc.ClientStream
+}
+
+func (x *bookServiceExportBooksClient) Recv() (*httpbody.HttpBody, error) {
+	m := new(httpbody.HttpBody)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *bookServiceClient) Recommend(ctx context.Context, in *RecommendRequest, opts ...grpc.CallOption) (*ListBooksResponse, error) {
 	out := new(ListBooksResponse)
 	err := c.cc.Invoke(ctx, "/book.BookService/Recommend", in, out, opts...)
@@ -73,6 +334,74 @@ func (c *bookServiceClient) Recommend(ctx context.Context, in *RecommendRequest,
 	return out, nil
 }
 
+func (c *bookServiceClient) SearchBooks(ctx context.Context, in *SearchBooksRequest, opts ...grpc.CallOption) (*SearchBooksResponse, error) {
+	out := new(SearchBooksResponse)
+	err := c.cc.Invoke(ctx, "/book.BookService/SearchBooks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) SubscribeEvents(ctx context.Context, in *common.SubscribeEventsRequest, opts ...grpc.CallOption) (BookService_SubscribeEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &BookService_ServiceDesc.Streams[2], "/book.BookService/SubscribeEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &bookServiceSubscribeEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BookService_SubscribeEventsClient interface {
+	Recv() (*common.Event, error)
+	grpc.ClientStream
+}
+
+type bookServiceSubscribeEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *bookServiceSubscribeEventsClient) Recv() (*common.Event, error) {
+	m := new(common.Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *bookServiceClient) ListDeletedBooks(ctx context.Context, in *common.ListDeletedRequest, opts ...grpc.CallOption) (*ListDeletedBooksResponse, error) {
+	out := new(ListDeletedBooksResponse)
+	err := c.cc.Invoke(ctx, "/book.BookService/ListDeletedBooks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) RestoreBook(ctx context.Context, in *RestoreBookRequest, opts ...grpc.CallOption) (*BookResponse, error) {
+	out := new(BookResponse)
+	err := c.cc.Invoke(ctx, "/book.BookService/RestoreBook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) PurgeDeletedBooks(ctx context.Context, in *common.PurgeDeletedRequest, opts ...grpc.CallOption) (*common.PurgeDeletedResponse, error) {
+	out := new(common.PurgeDeletedResponse)
+	err := c.cc.Invoke(ctx, "/book.BookService/PurgeDeletedBooks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *bookServiceClient) HealthCheck(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error) {
 	out := new(HealthCheckResponse)
 	err := c.cc.Invoke(ctx, "/book.BookService/HealthCheck", in, out, opts...)
@@ -89,7 +418,74 @@ type BookServiceServer interface {
 	Create(context.Context, *CreateBookRequest) (*BookResponse, error)
 	ListBooks(context.Context, *ListBooksRequest) (*ListBooksResponse, error)
 	GetBook(context.Context, *GetBookRequest) (*BookResponse, error)
+	// GetBookByISBN looks a book up by its ISBN-10 or ISBN-13
+	GetBookByISBN(context.Context, *GetBookByISBNRequest) (*BookResponse, error)
+	UpdateBook(context.Context, *UpdateBookRequest) (*BookResponse, error)
+	DeleteBook(context.Context, *DeleteBookRequest) (*DeleteBookResponse, error)
+	// ReserveStock takes one copy out of stock, failing with FAILED_PRECONDITION
+	// when none are left. Used by the transaction service when a book is borrowed.
+	// For books with tracked copies the copy is checked out and returned.
+	// Reservations are keyed by transaction_id: a retry returns the copy
+	// already reserved, and a loan whose borrow was cancelled fails with ABORTED.
+	ReserveStock(context.Context, *ReserveStockRequest) (*ReserveStockResponse, error)
+	// ReleaseStock puts the copy a loan reserved back into stock when the book
+	// is returned, or when its borrow is cancelled. Releasing it again changes
+	// nothing.
+	ReleaseStock(context.Context, *ReleaseStockRequest) (*BookResponse, error)
+	// WriteOffStock takes a copy a patron lost or damaged out of circulation
+	// for good. Used by the transaction service when a loan is closed as lost
+	// or damaged. The copy the loan reserved is marked lost, or damaged and
+	// withdrawn. Writing it off again changes nothing.
+	WriteOffStock(context.Context, *WriteOffStockRequest) (*BookResponse, error)
+	// RestoreStock puts the copy a loan lost back into stock when it is found
+	// again. Restoring it again changes nothing.
+	RestoreStock(context.Context, *RestoreStockRequest) (*BookResponse, error)
+	// AddCopy registers a physical copy of a book. Admin only.
+	AddCopy(context.Context, *AddCopyRequest) (*BookCopy, error)
+	// ListCopies returns every copy of a book, withdrawn ones included
+	ListCopies(context.Context, *ListCopiesRequest) (*ListCopiesResponse, error)
+	// GetCopy looks a copy up by its barcode
+	GetCopy(context.Context, *GetCopyRequest) (*BookCopy, error)
+	// UpdateCopy changes a copy's shelf location, condition or status. Admin only.
+	UpdateCopy(context.Context, *UpdateCopyRequest) (*BookCopy, error)
+	// WithdrawCopy takes a copy out of circulation for good. Admin only.
+	WithdrawCopy(context.Context, *WithdrawCopyRequest) (*BookCopy, error)
+	// ListStockMovements returns a book's stock ledger, newest first. Every
+	// change to a book's stock appends a movement in the same database
+	// transaction. Admin only.
+	ListStockMovements(context.Context, *ListStockMovementsRequest) (*ListStockMovementsResponse, error)
+	// ReconcileStock recomputes the stock of the books from their stock ledgers
+	// and reports the books whose stock has drifted from it. Admin only.
+	ReconcileStock(context.Context, *ReconcileStockRequest) (*ReconcileStockResponse, error)
+	// ImportBooks loads a catalogue file streamed in chunks. The first message
+	// carries the import options. Every record is validated like a
+	// CreateBookRequest and upserted by ISBN, in batches of one database
+	// transaction each. Admin only.
+	ImportBooks(BookService_ImportBooksServer) error
+	// ExportBooks streams the books matching the same filters as ListBooks as
+	// a CSV, JSON Lines or MARC21 file, in chunks that each carry the file's
+	// content type. The gateway serves it as a download at GET
+	// /api/books/export. Admin only.
+	ExportBooks(*ExportBooksRequest, BookService_ExportBooksServer) error
+	// Recommend suggests in-stock books the user has not borrowed yet, based on
+	// what similar borrowers read, the user's favourite categories and what is
+	// popular right now.
 	Recommend(context.Context, *RecommendRequest) (*ListBooksResponse, error)
+	// SearchBooks ranks books whose title, author or category match q.
+	// q takes plain words, "quoted phrases" and prefix* terms, all of which must match.
+	SearchBooks(context.Context, *SearchBooksRequest) (*SearchBooksResponse, error)
+	// SubscribeEvents streams the service's domain events, replaying stored
+	// events after the request cursor before tailing new ones. Admin only.
+	SubscribeEvents(*common.SubscribeEventsRequest, BookService_SubscribeEventsServer) error
+	// ListDeletedBooks returns the soft-deleted books, most recently deleted
+	// first. Admin only.
+	ListDeletedBooks(context.Context, *common.ListDeletedRequest) (*ListDeletedBooksResponse, error)
+	// RestoreBook brings a soft-deleted book back, failing with ALREADY_EXISTS
+	// when another book has taken its ISBN since. Admin only.
+	RestoreBook(context.Context, *RestoreBookRequest) (*BookResponse, error)
+	// PurgeDeletedBooks permanently removes the books, and their copies,
+	// soft-deleted longer ago than the retention window. Admin only.
+	PurgeDeletedBooks(context.Context, *common.PurgeDeletedRequest) (*common.PurgeDeletedResponse, error)
 	HealthCheck(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error)
 	mustEmbedUnimplementedBookServiceServer()
 }
@@ -107,9 +503,72 @@ func (UnimplementedBookServiceServer) ListBooks(context.Context, *ListBooksReque
 func (UnimplementedBookServiceServer) GetBook(context.Context, *GetBookRequest) (*BookResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBook not implemented")
 }
+func (UnimplementedBookServiceServer) GetBookByISBN(context.Context, *GetBookByISBNRequest) (*BookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBookByISBN not implemented")
+}
+func (UnimplementedBookServiceServer) UpdateBook(context.Context, *UpdateBookRequest) (*BookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateBook not implemented")
+}
+func (UnimplementedBookServiceServer) DeleteBook(context.Context, *DeleteBookRequest) (*DeleteBookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteBook not implemented")
+}
+func (UnimplementedBookServiceServer) ReserveStock(context.Context, *ReserveStockRequest) (*ReserveStockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveStock not implemented")
+}
+func (UnimplementedBookServiceServer) ReleaseStock(context.Context, *ReleaseStockRequest) (*BookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseStock not implemented")
+}
+func (UnimplementedBookServiceServer) WriteOffStock(context.Context, *WriteOffStockRequest) (*BookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WriteOffStock not implemented")
+}
+func (UnimplementedBookServiceServer) RestoreStock(context.Context, *RestoreStockRequest) (*BookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreStock not implemented")
+}
+func (UnimplementedBookServiceServer) AddCopy(context.Context, *AddCopyRequest) (*BookCopy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddCopy not implemented")
+}
+func (UnimplementedBookServiceServer) ListCopies(context.Context, *ListCopiesRequest) (*ListCopiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCopies not implemented")
+}
+func (UnimplementedBookServiceServer) GetCopy(context.Context, *GetCopyRequest) (*BookCopy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCopy not implemented")
+}
+func (UnimplementedBookServiceServer) UpdateCopy(context.Context, *UpdateCopyRequest) (*BookCopy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCopy not implemented")
+}
+func (UnimplementedBookServiceServer) WithdrawCopy(context.Context, *WithdrawCopyRequest) (*BookCopy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WithdrawCopy not implemented")
+}
+func (UnimplementedBookServiceServer) ListStockMovements(context.Context, *ListStockMovementsRequest) (*ListStockMovementsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStockMovements not implemented")
+}
+func (UnimplementedBookServiceServer) ReconcileStock(context.Context, *ReconcileStockRequest) (*ReconcileStockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReconcileStock not implemented")
+}
+func (UnimplementedBookServiceServer) ImportBooks(BookService_ImportBooksServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImportBooks not implemented")
+}
+func (UnimplementedBookServiceServer) ExportBooks(*ExportBooksRequest, BookService_ExportBooksServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportBooks not implemented")
+}
 func (UnimplementedBookServiceServer) Recommend(context.Context, *RecommendRequest) (*ListBooksResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Recommend not implemented")
 }
+func (UnimplementedBookServiceServer) SearchBooks(context.Context, *SearchBooksRequest) (*SearchBooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchBooks not implemented")
+}
+func (UnimplementedBookServiceServer) SubscribeEvents(*common.SubscribeEventsRequest, BookService_SubscribeEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeEvents not implemented")
+}
+func (UnimplementedBookServiceServer) ListDeletedBooks(context.Context, *common.ListDeletedRequest) (*ListDeletedBooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeletedBooks not implemented")
+}
+func (UnimplementedBookServiceServer) RestoreBook(context.Context, *RestoreBookRequest) (*BookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreBook not implemented")
+}
+func (UnimplementedBookServiceServer) PurgeDeletedBooks(context.Context, *common.PurgeDeletedRequest) (*common.PurgeDeletedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeDeletedBooks not implemented")
+}
 func (UnimplementedBookServiceServer) HealthCheck(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method HealthCheck not implemented")
 }
@@ -180,6 +639,305 @@ func _BookService_GetBook_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BookService_GetBookByISBN_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBookByISBNRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).GetBookByISBN(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/book.BookService/GetBookByISBN",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).GetBookByISBN(ctx, req.(*GetBookByISBNRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_UpdateBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateBookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).UpdateBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/book.BookService/UpdateBook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).UpdateBook(ctx, req.(*UpdateBookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_DeleteBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).DeleteBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/book.BookService/DeleteBook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).DeleteBook(ctx, req.(*DeleteBookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_ReserveStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).ReserveStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/book.BookService/ReserveStock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).ReserveStock(ctx, req.(*ReserveStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_ReleaseStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).ReleaseStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/book.BookService/ReleaseStock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).ReleaseStock(ctx, req.(*ReleaseStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_WriteOffStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteOffStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).WriteOffStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/book.BookService/WriteOffStock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).WriteOffStock(ctx, req.(*WriteOffStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_RestoreStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).RestoreStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/book.BookService/RestoreStock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).RestoreStock(ctx, req.(*RestoreStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_AddCopy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddCopyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).AddCopy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/book.BookService/AddCopy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).AddCopy(ctx, req.(*AddCopyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_ListCopies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCopiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).ListCopies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/book.BookService/ListCopies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).ListCopies(ctx, req.(*ListCopiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_GetCopy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCopyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).GetCopy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/book.BookService/GetCopy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).GetCopy(ctx, req.(*GetCopyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_UpdateCopy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCopyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).UpdateCopy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/book.BookService/UpdateCopy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).UpdateCopy(ctx, req.(*UpdateCopyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_WithdrawCopy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WithdrawCopyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).WithdrawCopy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/book.BookService/WithdrawCopy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).WithdrawCopy(ctx, req.(*WithdrawCopyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_ListStockMovements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStockMovementsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).ListStockMovements(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/book.BookService/ListStockMovements",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).ListStockMovements(ctx, req.(*ListStockMovementsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_ReconcileStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcileStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).ReconcileStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/book.BookService/ReconcileStock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).ReconcileStock(ctx, req.(*ReconcileStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_ImportBooks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BookServiceServer).ImportBooks(&bookServiceImportBooksServer{stream})
+}
+
+type BookService_ImportBooksServer interface {
+	SendAndClose(*ImportBooksResponse) error
+	Recv() (*ImportBooksRequest, error)
+	grpc.ServerStream
+}
+
+type bookServiceImportBooksServer struct {
+	grpc.ServerStream
+}
+
+func (x *bookServiceImportBooksServer) SendAndClose(m *ImportBooksResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *bookServiceImportBooksServer) Recv() (*ImportBooksRequest, error) {
+	m := new(ImportBooksRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _BookService_ExportBooks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportBooksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BookServiceServer).ExportBooks(m, &bookServiceExportBooksServer{stream})
+}
+
+type BookService_ExportBooksServer interface {
+	Send(*httpbody.HttpBody) error
+	grpc.ServerStream
+}
+
+type bookServiceExportBooksServer struct {
+	grpc.ServerStream
+}
+
+func (x *bookServiceExportBooksServer) Send(m *httpbody.HttpBody) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _BookService_Recommend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RecommendRequest)
 	if err := dec(in); err != nil {
@@ -198,6 +956,99 @@ func _BookService_Recommend_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BookService_SearchBooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchBooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).SearchBooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/book.BookService/SearchBooks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).SearchBooks(ctx, req.(*SearchBooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_SubscribeEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(common.SubscribeEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BookServiceServer).SubscribeEvents(m, &bookServiceSubscribeEventsServer{stream})
+}
+
+type BookService_SubscribeEventsServer interface {
+	Send(*common.Event) error
+	grpc.ServerStream
+}
+
+type bookServiceSubscribeEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *bookServiceSubscribeEventsServer) Send(m *common.Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BookService_ListDeletedBooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.ListDeletedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).ListDeletedBooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/book.BookService/ListDeletedBooks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).ListDeletedBooks(ctx, req.(*common.ListDeletedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_RestoreBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreBookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).RestoreBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/book.BookService/RestoreBook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).RestoreBook(ctx, req.(*RestoreBookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_PurgeDeletedBooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.PurgeDeletedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).PurgeDeletedBooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/book.BookService/PurgeDeletedBooks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).PurgeDeletedBooks(ctx, req.(*common.PurgeDeletedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BookService_HealthCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(HealthCheckRequest)
 	if err := dec(in); err != nil {
@@ -235,15 +1086,103 @@ var BookService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetBook",
 			Handler:    _BookService_GetBook_Handler,
 		},
+		{
+			MethodName: "GetBookByISBN",
+			Handler:    _BookService_GetBookByISBN_Handler,
+		},
+		{
+			MethodName: "UpdateBook",
+			Handler:    _BookService_UpdateBook_Handler,
+		},
+		{
+			MethodName: "DeleteBook",
+			Handler:    _BookService_DeleteBook_Handler,
+		},
+		{
+			MethodName: "ReserveStock",
+			Handler:    _BookService_ReserveStock_Handler,
+		},
+		{
+			MethodName: "ReleaseStock",
+			Handler:    _BookService_ReleaseStock_Handler,
+		},
+		{
+			MethodName: "WriteOffStock",
+			Handler:    _BookService_WriteOffStock_Handler,
+		},
+		{
+			MethodName: "RestoreStock",
+			Handler:    _BookService_RestoreStock_Handler,
+		},
+		{
+			MethodName: "AddCopy",
+			Handler:    _BookService_AddCopy_Handler,
+		},
+		{
+			MethodName: "ListCopies",
+			Handler:    _BookService_ListCopies_Handler,
+		},
+		{
+			MethodName: "GetCopy",
+			Handler:    _BookService_GetCopy_Handler,
+		},
+		{
+			MethodName: "UpdateCopy",
+			Handler:    _BookService_UpdateCopy_Handler,
+		},
+		{
+			MethodName: "WithdrawCopy",
+			Handler:    _BookService_WithdrawCopy_Handler,
+		},
+		{
+			MethodName: "ListStockMovements",
+			Handler:    _BookService_ListStockMovements_Handler,
+		},
+		{
+			MethodName: "ReconcileStock",
+			Handler:    _BookService_ReconcileStock_Handler,
+		},
 		{
 			MethodName: "Recommend",
 			Handler:    _BookService_Recommend_Handler,
 		},
+		{
+			MethodName: "SearchBooks",
+			Handler:    _BookService_SearchBooks_Handler,
+		},
+		{
+			MethodName: "ListDeletedBooks",
+			Handler:    _BookService_ListDeletedBooks_Handler,
+		},
+		{
+			MethodName: "RestoreBook",
+			Handler:    _BookService_RestoreBook_Handler,
+		},
+		{
+			MethodName: "PurgeDeletedBooks",
+			Handler:    _BookService_PurgeDeletedBooks_Handler,
+		},
 		{
 			MethodName: "HealthCheck",
 			Handler:    _BookService_HealthCheck_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ImportBooks",
+			Handler:       _BookService_ImportBooks_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportBooks",
+			Handler:       _BookService_ExportBooks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeEvents",
+			Handler:       _BookService_SubscribeEvents_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "api/proto/book/book.proto",
 }
@@ -8,6 +8,7 @@ package transaction
 
 import (
 	context "context"
+	common "github.com/hinha/library-management-synapsis/gen/api/proto/common"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -24,7 +25,58 @@ const _ = grpc.SupportPackageIsVersion7
 type TransactionServiceClient interface {
 	Borrow(ctx context.Context, in *BorrowRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
 	Return(ctx context.Context, in *ReturnRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
+	// BatchBorrow checks out a basket of books for one patron. The borrowing
+	// rules are checked for the whole basket before any copy is reserved.
+	BatchBorrow(ctx context.Context, in *BatchBorrowRequest, opts ...grpc.CallOption) (*BatchResponse, error)
+	// BatchReturn checks in a basket of books handed back by one patron
+	BatchReturn(ctx context.Context, in *BatchReturnRequest, opts ...grpc.CallOption) (*BatchResponse, error)
+	// Renew pushes the due date of an open loan forward by its loan period
+	Renew(ctx context.Context, in *RenewRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
+	// DeclareLost closes a loan whose book the patron lost, charges the
+	// replacement fee and writes the copy off in the book service. Admin only.
+	DeclareLost(ctx context.Context, in *DeclareLostRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
+	// ReportDamage closes a loan whose book came back damaged beyond repair,
+	// charges the replacement fee and withdraws the copy. Admin only.
+	ReportDamage(ctx context.Context, in *ReportDamageRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
+	// MarkFound reverses DeclareLost once the book turns up: the copy goes
+	// back into stock and the replacement fee is refunded. Admin only.
+	MarkFound(ctx context.Context, in *MarkFoundRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
+	// History returns a user's loans a page at a time, newest first by default
 	History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryResponse, error)
+	// ListTransactions returns the loans of every user a page at a time,
+	// newest first by default. Admin only.
+	ListTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (*ListTransactionsResponse, error)
+	// ListOverdue returns open loans past their due date, most overdue first. Admin only.
+	ListOverdue(ctx context.Context, in *ListOverdueRequest, opts ...grpc.CallOption) (*ListOverdueResponse, error)
+	// PlaceHold puts the user in line for a book that has no copy available
+	PlaceHold(ctx context.Context, in *PlaceHoldRequest, opts ...grpc.CallOption) (*HoldResponse, error)
+	CancelHold(ctx context.Context, in *CancelHoldRequest, opts ...grpc.CallOption) (*HoldResponse, error)
+	// ListHolds returns the active holds of a user or the queue of a book
+	ListHolds(ctx context.Context, in *ListHoldsRequest, opts ...grpc.CallOption) (*ListHoldsResponse, error)
+	// GetBalance returns what a user owes in fines, in minor currency units
+	GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*BalanceResponse, error)
+	// ListLedger returns a user's fine charges, payments and waivers, oldest first
+	ListLedger(ctx context.Context, in *ListLedgerRequest, opts ...grpc.CallOption) (*ListLedgerResponse, error)
+	RecordPayment(ctx context.Context, in *RecordPaymentRequest, opts ...grpc.CallOption) (*LedgerEntryResponse, error)
+	// WaiveFine forgives part of a user's balance. Admin only.
+	WaiveFine(ctx context.Context, in *WaiveFineRequest, opts ...grpc.CallOption) (*LedgerEntryResponse, error)
+	// BlockBorrower stops a user from borrowing until the block expires or is lifted. Admin only.
+	BlockBorrower(ctx context.Context, in *BlockBorrowerRequest, opts ...grpc.CallOption) (*BorrowerBlockResponse, error)
+	// UnblockBorrower lifts a user's borrowing block. Admin only.
+	UnblockBorrower(ctx context.Context, in *UnblockBorrowerRequest, opts ...grpc.CallOption) (*BorrowerBlockResponse, error)
+	// SubscribeEvents streams the service's domain events, replaying stored
+	// events after the request cursor before tailing new ones. Admin only.
+	SubscribeEvents(ctx context.Context, in *common.SubscribeEventsRequest, opts ...grpc.CallOption) (TransactionService_SubscribeEventsClient, error)
+	// ListDeletedTransactions returns the voided loans, most recently voided
+	// first. Admin only.
+	ListDeletedTransactions(ctx context.Context, in *common.ListDeletedRequest, opts ...grpc.CallOption) (*ListDeletedTransactionsResponse, error)
+	// RestoreTransaction brings a voided loan back. A loan that was not returned
+	// takes its copy out of stock again, failing with FAILED_PRECONDITION when
+	// none is left. Admin only.
+	RestoreTransaction(ctx context.Context, in *RestoreTransactionRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
+	// PurgeDeletedTransactions permanently removes the loans voided longer ago
+	// than the retention window. Admin only.
+	PurgeDeletedTransactions(ctx context.Context, in *common.PurgeDeletedRequest, opts ...grpc.CallOption) (*common.PurgeDeletedResponse, error)
 	HealthCheck(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error)
 }
 
@@ -54,6 +106,60 @@ func (c *transactionServiceClient) Return(ctx context.Context, in *ReturnRequest
 	return out, nil
 }
 
+func (c *transactionServiceClient) BatchBorrow(ctx context.Context, in *BatchBorrowRequest, opts ...grpc.CallOption) (*BatchResponse, error) {
+	out := new(BatchResponse)
+	err := c.cc.Invoke(ctx, "/transaction.TransactionService/BatchBorrow", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) BatchReturn(ctx context.Context, in *BatchReturnRequest, opts ...grpc.CallOption) (*BatchResponse, error) {
+	out := new(BatchResponse)
+	err := c.cc.Invoke(ctx, "/transaction.TransactionService/BatchReturn", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) Renew(ctx context.Context, in *RenewRequest, opts ...grpc.CallOption) (*TransactionResponse, error) {
+	out := new(TransactionResponse)
+	err := c.cc.Invoke(ctx, "/transaction.TransactionService/Renew", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) DeclareLost(ctx context.Context, in *DeclareLostRequest, opts ...grpc.CallOption) (*TransactionResponse, error) {
+	out := new(TransactionResponse)
+	err := c.cc.Invoke(ctx, "/transaction.TransactionService/DeclareLost", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) ReportDamage(ctx context.Context, in *ReportDamageRequest, opts ...grpc.CallOption) (*TransactionResponse, error) {
+	out := new(TransactionResponse)
+	err := c.cc.Invoke(ctx, "/transaction.TransactionService/ReportDamage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) MarkFound(ctx context.Context, in *MarkFoundRequest, opts ...grpc.CallOption) (*TransactionResponse, error) {
+	out := new(TransactionResponse)
+	err := c.cc.Invoke(ctx, "/transaction.TransactionService/MarkFound", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *transactionServiceClient) History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryResponse, error) {
 	out := new(HistoryResponse)
 	err := c.cc.Invoke(ctx, "/transaction.TransactionService/History", in, out, opts...)
@@ -63,6 +169,164 @@ func (c *transactionServiceClient) History(ctx context.Context, in *HistoryReque
 	return out, nil
 }
 
+func (c *transactionServiceClient) ListTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (*ListTransactionsResponse, error) {
+	out := new(ListTransactionsResponse)
+	err := c.cc.Invoke(ctx, "/transaction.TransactionService/ListTransactions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) ListOverdue(ctx context.Context, in *ListOverdueRequest, opts ...grpc.CallOption) (*ListOverdueResponse, error) {
+	out := new(ListOverdueResponse)
+	err := c.cc.Invoke(ctx, "/transaction.TransactionService/ListOverdue", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) PlaceHold(ctx context.Context, in *PlaceHoldRequest, opts ...grpc.CallOption) (*HoldResponse, error) {
+	out := new(HoldResponse)
+	err := c.cc.Invoke(ctx, "/transaction.TransactionService/PlaceHold", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) CancelHold(ctx context.Context, in *CancelHoldRequest, opts ...grpc.CallOption) (*HoldResponse, error) {
+	out := new(HoldResponse)
+	err := c.cc.Invoke(ctx, "/transaction.TransactionService/CancelHold", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) ListHolds(ctx context.Context, in *ListHoldsRequest, opts ...grpc.CallOption) (*ListHoldsResponse, error) {
+	out := new(ListHoldsResponse)
+	err := c.cc.Invoke(ctx, "/transaction.TransactionService/ListHolds", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*BalanceResponse, error) {
+	out := new(BalanceResponse)
+	err := c.cc.Invoke(ctx, "/transaction.TransactionService/GetBalance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) ListLedger(ctx context.Context, in *ListLedgerRequest, opts ...grpc.CallOption) (*ListLedgerResponse, error) {
+	out := new(ListLedgerResponse)
+	err := c.cc.Invoke(ctx, "/transaction.TransactionService/ListLedger", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) RecordPayment(ctx context.Context, in *RecordPaymentRequest, opts ...grpc.CallOption) (*LedgerEntryResponse, error) {
+	out := new(LedgerEntryResponse)
+	err := c.cc.Invoke(ctx, "/transaction.TransactionService/RecordPayment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) WaiveFine(ctx context.Context, in *WaiveFineRequest, opts ...grpc.CallOption) (*LedgerEntryResponse, error) {
+	out := new(LedgerEntryResponse)
+	err := c.cc.Invoke(ctx, "/transaction.TransactionService/WaiveFine", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) BlockBorrower(ctx context.Context, in *BlockBorrowerRequest, opts ...grpc.CallOption) (*BorrowerBlockResponse, error) {
+	out := new(BorrowerBlockResponse)
+	err := c.cc.Invoke(ctx, "/transaction.TransactionService/BlockBorrower", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) UnblockBorrower(ctx context.Context, in *UnblockBorrowerRequest, opts ...grpc.CallOption) (*BorrowerBlockResponse, error) {
+	out := new(BorrowerBlockResponse)
+	err := c.cc.Invoke(ctx, "/transaction.TransactionService/UnblockBorrower", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) SubscribeEvents(ctx context.Context, in *common.SubscribeEventsRequest, opts ...grpc.CallOption) (TransactionService_SubscribeEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TransactionService_ServiceDesc.Streams[0], "/transaction.TransactionService/SubscribeEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &transactionServiceSubscribeEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TransactionService_SubscribeEventsClient interface {
+	Recv() (*common.Event, error)
+	grpc.ClientStream
+}
+
+type transactionServiceSubscribeEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *transactionServiceSubscribeEventsClient) Recv() (*common.Event, error) {
+	m := new(common.Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *transactionServiceClient) ListDeletedTransactions(ctx context.Context, in *common.ListDeletedRequest, opts ...grpc.CallOption) (*ListDeletedTransactionsResponse, error) {
+	out := new(ListDeletedTransactionsResponse)
+	err := c.cc.Invoke(ctx, "/transaction.TransactionService/ListDeletedTransactions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) RestoreTransaction(ctx context.Context, in *RestoreTransactionRequest, opts ...grpc.CallOption) (*TransactionResponse, error) {
+	out := new(TransactionResponse)
+	err := c.cc.Invoke(ctx, "/transaction.TransactionService/RestoreTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) PurgeDeletedTransactions(ctx context.Context, in *common.PurgeDeletedRequest, opts ...grpc.CallOption) (*common.PurgeDeletedResponse, error) {
+	out := new(common.PurgeDeletedResponse)
+	err := c.cc.Invoke(ctx, "/transaction.TransactionService/PurgeDeletedTransactions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *transactionServiceClient) HealthCheck(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error) {
 	out := new(HealthCheckResponse)
 	err := c.cc.Invoke(ctx, "/transaction.TransactionService/HealthCheck", in, out, opts...)
@@ -78,7 +342,58 @@ func (c *transactionServiceClient) HealthCheck(ctx context.Context, in *HealthCh
 type TransactionServiceServer interface {
 	Borrow(context.Context, *BorrowRequest) (*TransactionResponse, error)
 	Return(context.Context, *ReturnRequest) (*TransactionResponse, error)
+	// BatchBorrow checks out a basket of books for one patron. The borrowing
+	// rules are checked for the whole basket before any copy is reserved.
+	BatchBorrow(context.Context, *BatchBorrowRequest) (*BatchResponse, error)
+	// BatchReturn checks in a basket of books handed back by one patron
+	BatchReturn(context.Context, *BatchReturnRequest) (*BatchResponse, error)
+	// Renew pushes the due date of an open loan forward by its loan period
+	Renew(context.Context, *RenewRequest) (*TransactionResponse, error)
+	// DeclareLost closes a loan whose book the patron lost, charges the
+	// replacement fee and writes the copy off in the book service. Admin only.
+	DeclareLost(context.Context, *DeclareLostRequest) (*TransactionResponse, error)
+	// ReportDamage closes a loan whose book came back damaged beyond repair,
+	// charges the replacement fee and withdraws the copy. Admin only.
+	ReportDamage(context.Context, *ReportDamageRequest) (*TransactionResponse, error)
+	// MarkFound reverses DeclareLost once the book turns up: the copy goes
+	// back into stock and the replacement fee is refunded. Admin only.
+	MarkFound(context.Context, *MarkFoundRequest) (*TransactionResponse, error)
+	// History returns a user's loans a page at a time, newest first by default
 	History(context.Context, *HistoryRequest) (*HistoryResponse, error)
+	// ListTransactions returns the loans of every user a page at a time,
+	// newest first by default. Admin only.
+	ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsResponse, error)
+	// ListOverdue returns open loans past their due date, most overdue first. Admin only.
+	ListOverdue(context.Context, *ListOverdueRequest) (*ListOverdueResponse, error)
+	// PlaceHold puts the user in line for a book that has no copy available
+	PlaceHold(context.Context, *PlaceHoldRequest) (*HoldResponse, error)
+	CancelHold(context.Context, *CancelHoldRequest) (*HoldResponse, error)
+	// ListHolds returns the active holds of a user or the queue of a book
+	ListHolds(context.Context, *ListHoldsRequest) (*ListHoldsResponse, error)
+	// GetBalance returns what a user owes in fines, in minor currency units
+	GetBalance(context.Context, *GetBalanceRequest) (*BalanceResponse, error)
+	// ListLedger returns a user's fine charges, payments and waivers, oldest first
+	ListLedger(context.Context, *ListLedgerRequest) (*ListLedgerResponse, error)
+	RecordPayment(context.Context, *RecordPaymentRequest) (*LedgerEntryResponse, error)
+	// WaiveFine forgives part of a user's balance. Admin only.
+	WaiveFine(context.Context, *WaiveFineRequest) (*LedgerEntryResponse, error)
+	// BlockBorrower stops a user from borrowing until the block expires or is lifted. Admin only.
+	BlockBorrower(context.Context, *BlockBorrowerRequest) (*BorrowerBlockResponse, error)
+	// UnblockBorrower lifts a user's borrowing block. Admin only.
+	UnblockBorrower(context.Context, *UnblockBorrowerRequest) (*BorrowerBlockResponse, error)
+	// SubscribeEvents streams the service's domain events, replaying stored
+	// events after the request cursor before tailing new ones. Admin only.
+	SubscribeEvents(*common.SubscribeEventsRequest, TransactionService_SubscribeEventsServer) error
+	// ListDeletedTransactions returns the voided loans, most recently voided
+	// first. Admin only.
+	ListDeletedTransactions(context.Context, *common.ListDeletedRequest) (*ListDeletedTransactionsResponse, error)
+	// RestoreTransaction brings a voided loan back. A loan that was not returned
+	// takes its copy out of stock again, failing with FAILED_PRECONDITION when
+	// none is left. Admin only.
+	RestoreTransaction(context.Context, *RestoreTransactionRequest) (*TransactionResponse, error)
+	// PurgeDeletedTransactions permanently removes the loans voided longer ago
+	// than the retention window. Admin only.
+	PurgeDeletedTransactions(context.Context, *common.PurgeDeletedRequest) (*common.PurgeDeletedResponse, error)
 	HealthCheck(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error)
 	mustEmbedUnimplementedTransactionServiceServer()
 }
@@ -93,9 +408,72 @@ func (UnimplementedTransactionServiceServer) Borrow(context.Context, *BorrowRequ
 func (UnimplementedTransactionServiceServer) Return(context.Context, *ReturnRequest) (*TransactionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Return not implemented")
 }
+func (UnimplementedTransactionServiceServer) BatchBorrow(context.Context, *BatchBorrowRequest) (*BatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchBorrow not implemented")
+}
+func (UnimplementedTransactionServiceServer) BatchReturn(context.Context, *BatchReturnRequest) (*BatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchReturn not implemented")
+}
+func (UnimplementedTransactionServiceServer) Renew(context.Context, *RenewRequest) (*TransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Renew not implemented")
+}
+func (UnimplementedTransactionServiceServer) DeclareLost(context.Context, *DeclareLostRequest) (*TransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeclareLost not implemented")
+}
+func (UnimplementedTransactionServiceServer) ReportDamage(context.Context, *ReportDamageRequest) (*TransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportDamage not implemented")
+}
+func (UnimplementedTransactionServiceServer) MarkFound(context.Context, *MarkFoundRequest) (*TransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkFound not implemented")
+}
 func (UnimplementedTransactionServiceServer) History(context.Context, *HistoryRequest) (*HistoryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method History not implemented")
 }
+func (UnimplementedTransactionServiceServer) ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTransactions not implemented")
+}
+func (UnimplementedTransactionServiceServer) ListOverdue(context.Context, *ListOverdueRequest) (*ListOverdueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOverdue not implemented")
+}
+func (UnimplementedTransactionServiceServer) PlaceHold(context.Context, *PlaceHoldRequest) (*HoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlaceHold not implemented")
+}
+func (UnimplementedTransactionServiceServer) CancelHold(context.Context, *CancelHoldRequest) (*HoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelHold not implemented")
+}
+func (UnimplementedTransactionServiceServer) ListHolds(context.Context, *ListHoldsRequest) (*ListHoldsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListHolds not implemented")
+}
+func (UnimplementedTransactionServiceServer) GetBalance(context.Context, *GetBalanceRequest) (*BalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalance not implemented")
+}
+func (UnimplementedTransactionServiceServer) ListLedger(context.Context, *ListLedgerRequest) (*ListLedgerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLedger not implemented")
+}
+func (UnimplementedTransactionServiceServer) RecordPayment(context.Context, *RecordPaymentRequest) (*LedgerEntryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordPayment not implemented")
+}
+func (UnimplementedTransactionServiceServer) WaiveFine(context.Context, *WaiveFineRequest) (*LedgerEntryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WaiveFine not implemented")
+}
+func (UnimplementedTransactionServiceServer) BlockBorrower(context.Context, *BlockBorrowerRequest) (*BorrowerBlockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BlockBorrower not implemented")
+}
+func (UnimplementedTransactionServiceServer) UnblockBorrower(context.Context, *UnblockBorrowerRequest) (*BorrowerBlockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnblockBorrower not implemented")
+}
+func (UnimplementedTransactionServiceServer) SubscribeEvents(*common.SubscribeEventsRequest, TransactionService_SubscribeEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeEvents not implemented")
+}
+func (UnimplementedTransactionServiceServer) ListDeletedTransactions(context.Context, *common.ListDeletedRequest) (*ListDeletedTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeletedTransactions not implemented")
+}
+func (UnimplementedTransactionServiceServer) RestoreTransaction(context.Context, *RestoreTransactionRequest) (*TransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreTransaction not implemented")
+}
+func (UnimplementedTransactionServiceServer) PurgeDeletedTransactions(context.Context, *common.PurgeDeletedRequest) (*common.PurgeDeletedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeDeletedTransactions not implemented")
+}
 func (UnimplementedTransactionServiceServer) HealthCheck(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method HealthCheck not implemented")
 }
@@ -148,6 +526,114 @@ func _TransactionService_Return_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TransactionService_BatchBorrow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchBorrowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).BatchBorrow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transaction.TransactionService/BatchBorrow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).BatchBorrow(ctx, req.(*BatchBorrowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_BatchReturn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchReturnRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).BatchReturn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transaction.TransactionService/BatchReturn",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).BatchReturn(ctx, req.(*BatchReturnRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_Renew_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).Renew(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transaction.TransactionService/Renew",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).Renew(ctx, req.(*RenewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_DeclareLost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeclareLostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).DeclareLost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transaction.TransactionService/DeclareLost",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).DeclareLost(ctx, req.(*DeclareLostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_ReportDamage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportDamageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).ReportDamage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transaction.TransactionService/ReportDamage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).ReportDamage(ctx, req.(*ReportDamageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_MarkFound_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkFoundRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).MarkFound(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transaction.TransactionService/MarkFound",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).MarkFound(ctx, req.(*MarkFoundRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TransactionService_History_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(HistoryRequest)
 	if err := dec(in); err != nil {
@@ -166,6 +652,279 @@ func _TransactionService_History_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TransactionService_ListTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).ListTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transaction.TransactionService/ListTransactions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).ListTransactions(ctx, req.(*ListTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_ListOverdue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOverdueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).ListOverdue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transaction.TransactionService/ListOverdue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).ListOverdue(ctx, req.(*ListOverdueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_PlaceHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlaceHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).PlaceHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transaction.TransactionService/PlaceHold",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).PlaceHold(ctx, req.(*PlaceHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_CancelHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).CancelHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transaction.TransactionService/CancelHold",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).CancelHold(ctx, req.(*CancelHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_ListHolds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListHoldsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).ListHolds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transaction.TransactionService/ListHolds",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).ListHolds(ctx, req.(*ListHoldsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_GetBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).GetBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transaction.TransactionService/GetBalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).GetBalance(ctx, req.(*GetBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_ListLedger_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLedgerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).ListLedger(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transaction.TransactionService/ListLedger",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).ListLedger(ctx, req.(*ListLedgerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_RecordPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordPaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).RecordPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transaction.TransactionService/RecordPayment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).RecordPayment(ctx, req.(*RecordPaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_WaiveFine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WaiveFineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).WaiveFine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transaction.TransactionService/WaiveFine",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).WaiveFine(ctx, req.(*WaiveFineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_BlockBorrower_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockBorrowerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).BlockBorrower(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transaction.TransactionService/BlockBorrower",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).BlockBorrower(ctx, req.(*BlockBorrowerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_UnblockBorrower_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnblockBorrowerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).UnblockBorrower(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transaction.TransactionService/UnblockBorrower",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).UnblockBorrower(ctx, req.(*UnblockBorrowerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_SubscribeEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(common.SubscribeEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TransactionServiceServer).SubscribeEvents(m, &transactionServiceSubscribeEventsServer{stream})
+}
+
+type TransactionService_SubscribeEventsServer interface {
+	Send(*common.Event) error
+	grpc.ServerStream
+}
+
+type transactionServiceSubscribeEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *transactionServiceSubscribeEventsServer) Send(m *common.Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TransactionService_ListDeletedTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.ListDeletedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).ListDeletedTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transaction.TransactionService/ListDeletedTransactions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).ListDeletedTransactions(ctx, req.(*common.ListDeletedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_RestoreTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).RestoreTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transaction.TransactionService/RestoreTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).RestoreTransaction(ctx, req.(*RestoreTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_PurgeDeletedTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.PurgeDeletedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).PurgeDeletedTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transaction.TransactionService/PurgeDeletedTransactions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).PurgeDeletedTransactions(ctx, req.(*common.PurgeDeletedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TransactionService_HealthCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(HealthCheckRequest)
 	if err := dec(in); err != nil {
@@ -199,15 +958,101 @@ var TransactionService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Return",
 			Handler:    _TransactionService_Return_Handler,
 		},
+		{
+			MethodName: "BatchBorrow",
+			Handler:    _TransactionService_BatchBorrow_Handler,
+		},
+		{
+			MethodName: "BatchReturn",
+			Handler:    _TransactionService_BatchReturn_Handler,
+		},
+		{
+			MethodName: "Renew",
+			Handler:    _TransactionService_Renew_Handler,
+		},
+		{
+			MethodName: "DeclareLost",
+			Handler:    _TransactionService_DeclareLost_Handler,
+		},
+		{
+			MethodName: "ReportDamage",
+			Handler:    _TransactionService_ReportDamage_Handler,
+		},
+		{
+			MethodName: "MarkFound",
+			Handler:    _TransactionService_MarkFound_Handler,
+		},
 		{
 			MethodName: "History",
 			Handler:    _TransactionService_History_Handler,
 		},
+		{
+			MethodName: "ListTransactions",
+			Handler:    _TransactionService_ListTransactions_Handler,
+		},
+		{
+			MethodName: "ListOverdue",
+			Handler:    _TransactionService_ListOverdue_Handler,
+		},
+		{
+			MethodName: "PlaceHold",
+			Handler:    _TransactionService_PlaceHold_Handler,
+		},
+		{
+			MethodName: "CancelHold",
+			Handler:    _TransactionService_CancelHold_Handler,
+		},
+		{
+			MethodName: "ListHolds",
+			Handler:    _TransactionService_ListHolds_Handler,
+		},
+		{
+			MethodName: "GetBalance",
+			Handler:    _TransactionService_GetBalance_Handler,
+		},
+		{
+			MethodName: "ListLedger",
+			Handler:    _TransactionService_ListLedger_Handler,
+		},
+		{
+			MethodName: "RecordPayment",
+			Handler:    _TransactionService_RecordPayment_Handler,
+		},
+		{
+			MethodName: "WaiveFine",
+			Handler:    _TransactionService_WaiveFine_Handler,
+		},
+		{
+			MethodName: "BlockBorrower",
+			Handler:    _TransactionService_BlockBorrower_Handler,
+		},
+		{
+			MethodName: "UnblockBorrower",
+			Handler:    _TransactionService_UnblockBorrower_Handler,
+		},
+		{
+			MethodName: "ListDeletedTransactions",
+			Handler:    _TransactionService_ListDeletedTransactions_Handler,
+		},
+		{
+			MethodName: "RestoreTransaction",
+			Handler:    _TransactionService_RestoreTransaction_Handler,
+		},
+		{
+			MethodName: "PurgeDeletedTransactions",
+			Handler:    _TransactionService_PurgeDeletedTransactions_Handler,
+		},
 		{
 			MethodName: "HealthCheck",
 			Handler:    _TransactionService_HealthCheck_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeEvents",
+			Handler:       _TransactionService_SubscribeEvents_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "api/proto/transaction/transaction.proto",
 }
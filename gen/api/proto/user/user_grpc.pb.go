@@ -8,6 +8,7 @@ package user
 
 import (
 	context "context"
+	common "github.com/hinha/library-management-synapsis/gen/api/proto/common"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -27,6 +28,18 @@ type UserServiceClient interface {
 	Update(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*UserResponse, error)
 	Get(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*UserResponse, error)
 	ValidateToken(ctx context.Context, in *ValidateTokenRequest, opts ...grpc.CallOption) (*ValidateTokenResponse, error)
+	// SubscribeEvents streams the service's domain events, replaying stored
+	// events after the request cursor before tailing new ones. Admin only.
+	SubscribeEvents(ctx context.Context, in *common.SubscribeEventsRequest, opts ...grpc.CallOption) (UserService_SubscribeEventsClient, error)
+	// ListDeletedUsers returns the soft-deleted users, most recently deleted
+	// first. Admin only.
+	ListDeletedUsers(ctx context.Context, in *common.ListDeletedRequest, opts ...grpc.CallOption) (*ListDeletedUsersResponse, error)
+	// RestoreUser brings a soft-deleted user back, failing with ALREADY_EXISTS
+	// when another user has taken its email since. Admin only.
+	RestoreUser(ctx context.Context, in *RestoreUserRequest, opts ...grpc.CallOption) (*UserResponse, error)
+	// PurgeDeletedUsers permanently removes the users soft-deleted longer ago
+	// than the retention window. Admin only.
+	PurgeDeletedUsers(ctx context.Context, in *common.PurgeDeletedRequest, opts ...grpc.CallOption) (*common.PurgeDeletedResponse, error)
 	HealthCheck(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error)
 }
 
@@ -83,6 +96,65 @@ func (c *userServiceClient) ValidateToken(ctx context.Context, in *ValidateToken
 	return out, nil
 }
 
+func (c *userServiceClient) SubscribeEvents(ctx context.Context, in *common.SubscribeEventsRequest, opts ...grpc.CallOption) (UserService_SubscribeEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &UserService_ServiceDesc.Streams[0], "/user.UserService/SubscribeEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &userServiceSubscribeEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type UserService_SubscribeEventsClient interface {
+	Recv() (*common.Event, error)
+	grpc.ClientStream
+}
+
+type userServiceSubscribeEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *userServiceSubscribeEventsClient) Recv() (*common.Event, error) {
+	m := new(common.Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *userServiceClient) ListDeletedUsers(ctx context.Context, in *common.ListDeletedRequest, opts ...grpc.CallOption) (*ListDeletedUsersResponse, error) {
+	out := new(ListDeletedUsersResponse)
+	err := c.cc.Invoke(ctx, "/user.UserService/ListDeletedUsers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RestoreUser(ctx context.Context, in *RestoreUserRequest, opts ...grpc.CallOption) (*UserResponse, error) {
+	out := new(UserResponse)
+	err := c.cc.Invoke(ctx, "/user.UserService/RestoreUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) PurgeDeletedUsers(ctx context.Context, in *common.PurgeDeletedRequest, opts ...grpc.CallOption) (*common.PurgeDeletedResponse, error) {
+	out := new(common.PurgeDeletedResponse)
+	err := c.cc.Invoke(ctx, "/user.UserService/PurgeDeletedUsers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) HealthCheck(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error) {
 	out := new(HealthCheckResponse)
 	err := c.cc.Invoke(ctx, "/user.UserService/HealthCheck", in, out, opts...)
@@ -101,6 +173,18 @@ type UserServiceServer interface {
 	Update(context.Context, *UpdateUserRequest) (*UserResponse, error)
 	Get(context.Context, *GetUserRequest) (*UserResponse, error)
 	ValidateToken(context.Context, *ValidateTokenRequest) (*ValidateTokenResponse, error)
+	// SubscribeEvents streams the service's domain events, replaying stored
+	// events after the request cursor before tailing new ones. Admin only.
+	SubscribeEvents(*common.SubscribeEventsRequest, UserService_SubscribeEventsServer) error
+	// ListDeletedUsers returns the soft-deleted users, most recently deleted
+	// first. Admin only.
+	ListDeletedUsers(context.Context, *common.ListDeletedRequest) (*ListDeletedUsersResponse, error)
+	// RestoreUser brings a soft-deleted user back, failing with ALREADY_EXISTS
+	// when another user has taken its email since. Admin only.
+	RestoreUser(context.Context, *RestoreUserRequest) (*UserResponse, error)
+	// PurgeDeletedUsers permanently removes the users soft-deleted longer ago
+	// than the retention window. Admin only.
+	PurgeDeletedUsers(context.Context, *common.PurgeDeletedRequest) (*common.PurgeDeletedResponse, error)
 	HealthCheck(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error)
 	mustEmbedUnimplementedUserServiceServer()
 }
@@ -124,6 +208,18 @@ func (UnimplementedUserServiceServer) Get(context.Context, *GetUserRequest) (*Us
 func (UnimplementedUserServiceServer) ValidateToken(context.Context, *ValidateTokenRequest) (*ValidateTokenResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ValidateToken not implemented")
 }
+func (UnimplementedUserServiceServer) SubscribeEvents(*common.SubscribeEventsRequest, UserService_SubscribeEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeEvents not implemented")
+}
+func (UnimplementedUserServiceServer) ListDeletedUsers(context.Context, *common.ListDeletedRequest) (*ListDeletedUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeletedUsers not implemented")
+}
+func (UnimplementedUserServiceServer) RestoreUser(context.Context, *RestoreUserRequest) (*UserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreUser not implemented")
+}
+func (UnimplementedUserServiceServer) PurgeDeletedUsers(context.Context, *common.PurgeDeletedRequest) (*common.PurgeDeletedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeDeletedUsers not implemented")
+}
 func (UnimplementedUserServiceServer) HealthCheck(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method HealthCheck not implemented")
 }
@@ -230,6 +326,81 @@ func _UserService_ValidateToken_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_SubscribeEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(common.SubscribeEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserServiceServer).SubscribeEvents(m, &userServiceSubscribeEventsServer{stream})
+}
+
+type UserService_SubscribeEventsServer interface {
+	Send(*common.Event) error
+	grpc.ServerStream
+}
+
+type userServiceSubscribeEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *userServiceSubscribeEventsServer) Send(m *common.Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _UserService_ListDeletedUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.ListDeletedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListDeletedUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.UserService/ListDeletedUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListDeletedUsers(ctx, req.(*common.ListDeletedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RestoreUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RestoreUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.UserService/RestoreUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RestoreUser(ctx, req.(*RestoreUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_PurgeDeletedUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.PurgeDeletedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).PurgeDeletedUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.UserService/PurgeDeletedUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).PurgeDeletedUsers(ctx, req.(*common.PurgeDeletedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_HealthCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(HealthCheckRequest)
 	if err := dec(in); err != nil {
@@ -275,11 +446,29 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ValidateToken",
 			Handler:    _UserService_ValidateToken_Handler,
 		},
+		{
+			MethodName: "ListDeletedUsers",
+			Handler:    _UserService_ListDeletedUsers_Handler,
+		},
+		{
+			MethodName: "RestoreUser",
+			Handler:    _UserService_RestoreUser_Handler,
+		},
+		{
+			MethodName: "PurgeDeletedUsers",
+			Handler:    _UserService_PurgeDeletedUsers_Handler,
+		},
 		{
 			MethodName: "HealthCheck",
 			Handler:    _UserService_HealthCheck_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeEvents",
+			Handler:       _UserService_SubscribeEvents_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "api/proto/user/user.proto",
 }
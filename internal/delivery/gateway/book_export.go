@@ -0,0 +1,88 @@
+// Package gateway holds the REST endpoints the generated gRPC gateway cannot
+// serve, such as file downloads
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	pb "github.com/hinha/library-management-synapsis/gen/api/proto/book"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// BookExportPath is where the catalogue export is served
+const BookExportPath = "/api/books/export"
+
+// exportFileNames are the download names of the export formats
+var exportFileNames = map[string]string{
+	"csv":    "books.csv",
+	"ndjson": "books.ndjson",
+	"marc21": "books.mrc",
+}
+
+// RegisterBookExport serves GET /api/books/export on mux as a file download
+// of the ExportBooks stream. The query parameters are the fields of an
+// ExportBooksRequest. The generated gateway handler cannot be used because
+// it writes a newline after every chunk of the stream.
+func RegisterBookExport(mux *runtime.ServeMux, client pb.BookServiceClient) error {
+	return mux.HandlePath(http.MethodGet, BookExportPath, func(w http.ResponseWriter, req *http.Request, _ map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		annotatedContext, err := runtime.AnnotateContext(ctx, mux, req, "/book.BookService/ExportBooks", runtime.WithHTTPPathPattern(BookExportPath))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+
+		var protoReq pb.ExportBooksRequest
+		if err := req.ParseForm(); err != nil {
+			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, status.Errorf(codes.InvalidArgument, "%v", err))
+			return
+		}
+		if err := runtime.PopulateQueryParameters(&protoReq, req.Form, &utilities.DoubleArray{}); err != nil {
+			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, status.Errorf(codes.InvalidArgument, "%v", err))
+			return
+		}
+
+		stream, err := client.ExportBooks(annotatedContext, &protoReq)
+		if err != nil {
+			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
+			return
+		}
+
+		// Errors before the first chunk, such as a rejected filter, still get
+		// an error response
+		chunk, err := stream.Recv()
+		if err != nil {
+			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		w.Header().Set("Content-Type", chunk.GetContentType())
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", exportFileNames[protoReq.GetFormat()]))
+		w.WriteHeader(http.StatusOK)
+
+		for {
+			if _, err := w.Write(chunk.GetData()); err != nil {
+				return
+			}
+			chunk, err = stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				// The status line is gone, dropping the connection keeps a
+				// partial file from looking complete
+				log.Error().Err(err).Msg("Book export failed midway")
+				panic(http.ErrAbortHandler)
+			}
+		}
+	})
+}
@@ -0,0 +1,17 @@
+package gateway
+
+import (
+	"fmt"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+// OutgoingHeaderMatcher sends the etag response metadata of the book and
+// user services as the ETag header, so clients can send it back in If-Match.
+// Other metadata keeps the gateway's Grpc-Metadata- prefix.
+func OutgoingHeaderMatcher(key string) (string, bool) {
+	if key == "etag" {
+		return "ETag", true
+	}
+	return fmt.Sprintf("%s%s", runtime.MetadataHeaderPrefix, key), true
+}
@@ -0,0 +1,91 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	pb "github.com/hinha/library-management-synapsis/gen/api/proto/book"
+	commonPb "github.com/hinha/library-management-synapsis/gen/api/proto/common"
+	"github.com/hinha/library-management-synapsis/internal/delivery/middleware"
+	domain "github.com/hinha/library-management-synapsis/internal/domain/book"
+	"github.com/hinha/library-management-synapsis/pkg/validator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListDeletedBooks handles listing one page of the soft-deleted books
+func (h *BookHandler) ListDeletedBooks(ctx context.Context, req *commonPb.ListDeletedRequest) (*pb.ListDeletedBooksResponse, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := middleware.AuthorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	books, nextPageToken, err := h.service.ListDeletedBooks(ctx, int(req.GetPageSize()), req.GetPageToken())
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidPageToken) {
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
+		return nil, status.Error(codes.Internal, "failed to list deleted books")
+	}
+
+	response := &pb.ListDeletedBooksResponse{
+		Books:         make([]*pb.BookResponse, len(books)),
+		NextPageToken: nextPageToken,
+	}
+
+	for i, book := range books {
+		response.Books[i] = book.ToProto()
+	}
+
+	return response, nil
+}
+
+// RestoreBook handles bringing a soft-deleted book back
+func (h *BookHandler) RestoreBook(ctx context.Context, req *pb.RestoreBookRequest) (*pb.BookResponse, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := middleware.AuthorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	book, err := h.service.RestoreBook(ctx, req.GetId())
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrBookNotFound):
+			return nil, status.Error(codes.NotFound, "deleted book not found")
+		case errors.Is(err, domain.ErrDuplicateISBN):
+			return nil, status.Error(codes.AlreadyExists, "another book has the isbn")
+		case errors.Is(err, domain.ErrInvalidInput):
+			return nil, status.Error(codes.InvalidArgument, "invalid input")
+		default:
+			return nil, status.Error(codes.Internal, "failed to restore book")
+		}
+	}
+
+	setETag(ctx, book.Version)
+	return book.ToProto(), nil
+}
+
+// PurgeDeletedBooks handles permanently removing books deleted longer ago
+// than the retention window
+func (h *BookHandler) PurgeDeletedBooks(ctx context.Context, req *commonPb.PurgeDeletedRequest) (*commonPb.PurgeDeletedResponse, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := middleware.AuthorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	purged, before, err := h.service.PurgeDeletedBooks(ctx, retentionWindow(req))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			return nil, status.Error(codes.InvalidArgument, "invalid input")
+		}
+		return nil, status.Error(codes.Internal, "failed to purge deleted books")
+	}
+
+	return purgeResponse(purged, before), nil
+}
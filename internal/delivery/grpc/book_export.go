@@ -0,0 +1,93 @@
+package grpc
+
+import (
+	"bufio"
+	"errors"
+
+	pb "github.com/hinha/library-management-synapsis/gen/api/proto/book"
+	entity "github.com/hinha/library-management-synapsis/internal/domain"
+	domain "github.com/hinha/library-management-synapsis/internal/domain/book"
+	"github.com/hinha/library-management-synapsis/pkg/validator"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// exportChunkSize is how much of the file each ExportBooks message carries
+const exportChunkSize = 32 * 1024
+
+// exportContentTypes are the media types of the export formats
+var exportContentTypes = map[string]string{
+	"csv":    "text/csv; charset=utf-8",
+	"ndjson": "application/x-ndjson",
+	"marc21": "application/marc",
+}
+
+// ExportBooks handles streaming the catalogue to an admin as a file. Admins
+// are checked by the stream interceptor.
+func (h *BookHandler) ExportBooks(req *pb.ExportBooksRequest, stream pb.BookService_ExportBooksServer) error {
+	if err := validator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	filter, err := listFilter(req)
+	if err != nil {
+		return err
+	}
+
+	chunks := &exportStream{stream: stream, contentType: exportContentTypes[req.GetFormat()]}
+	buf := bufio.NewWriterSize(chunks, exportChunkSize)
+	var books entity.ExportWriter
+	switch req.GetFormat() {
+	case "csv":
+		books = domain.NewCSVExportWriter(buf)
+	case "ndjson":
+		books = domain.NewJSONExportWriter(buf)
+	case "marc21":
+		books = domain.NewMARCExportWriter(buf)
+	}
+
+	if err := h.service.ExportBooks(stream.Context(), filter, books); err != nil {
+		return exportError(err)
+	}
+	if err := buf.Flush(); err != nil {
+		return exportError(err)
+	}
+	// An empty file still tells the client its content type
+	if !chunks.sent {
+		return stream.Send(&httpbody.HttpBody{ContentType: chunks.contentType})
+	}
+	return nil
+}
+
+// exportError maps an error that stopped an export
+func exportError(err error) error {
+	if errors.Is(err, domain.ErrInvalidInput) {
+		return status.Error(codes.InvalidArgument, "invalid input")
+	}
+	// Errors sending the file are already statuses
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	log.Error().Err(err).Msg("Failed to export books")
+	return status.Error(codes.Internal, "failed to export books")
+}
+
+// exportStream sends what is written to it as the chunks of an ExportBooks
+// stream
+type exportStream struct {
+	stream      pb.BookService_ExportBooksServer
+	contentType string
+	sent        bool
+}
+
+// Write implements io.Writer. The chunk is serialized before Send returns,
+// so p is not kept.
+func (s *exportStream) Write(p []byte) (int, error) {
+	if err := s.stream.Send(&httpbody.HttpBody{ContentType: s.contentType, Data: p}); err != nil {
+		return 0, err
+	}
+	s.sent = true
+	return len(p), nil
+}
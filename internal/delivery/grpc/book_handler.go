@@ -6,21 +6,28 @@ import (
 	"github.com/hinha/library-management-synapsis/pkg/validator"
 
 	pb "github.com/hinha/library-management-synapsis/gen/api/proto/book"
+	commonPb "github.com/hinha/library-management-synapsis/gen/api/proto/common"
+	"github.com/hinha/library-management-synapsis/internal/delivery/middleware"
+	entity "github.com/hinha/library-management-synapsis/internal/domain"
 	domain "github.com/hinha/library-management-synapsis/internal/domain/book"
+	"github.com/hinha/library-management-synapsis/internal/infrastructure/outbox"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"time"
 )
 
 // BookHandler implements the BookService gRPC interface
 type BookHandler struct {
 	pb.BookServiceServer
 	service domain.Service
+	feed    *outbox.Feed
 }
 
 // NewBookHandler creates a new BookHandler
-func NewBookHandler(service domain.Service) *BookHandler {
+func NewBookHandler(service domain.Service, feed *outbox.Feed) *BookHandler {
 	return &BookHandler{
 		service: service,
+		feed:    feed,
 	}
 }
 
@@ -29,27 +36,62 @@ func (h *BookHandler) Create(ctx context.Context, req *pb.CreateBookRequest) (*p
 	if err := validator.ValidateStruct(req); err != nil {
 		return nil, err
 	}
+	if err := middleware.AuthorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
 
-	book, err := h.service.CreateBook(ctx, req.GetTitle(), req.GetAuthor(), req.GetCategory(), req.GetStock())
+	metadata := entity.BookMetadata{
+		ISBN:            req.GetIsbn(),
+		Publisher:       req.GetPublisher(),
+		PublicationYear: req.GetPublicationYear(),
+		Language:        req.GetLanguage(),
+		PageCount:       req.GetPageCount(),
+		Edition:         req.GetEdition(),
+		Description:     req.GetDescription(),
+	}
+	book, err := h.service.CreateBook(withStockOrigin(ctx, ""), req.GetTitle(), req.GetAuthor(), req.GetCategory(), req.GetStock(), metadata)
 	if err != nil {
+		if errors.Is(err, domain.ErrDuplicateISBN) {
+			return nil, status.Error(codes.AlreadyExists, "isbn already exists")
+		}
+		if errors.Is(err, entity.ErrInvalidISBN) {
+			return nil, status.Error(codes.InvalidArgument, "invalid isbn")
+		}
 		if errors.Is(err, domain.ErrInvalidInput) {
 			return nil, status.Error(codes.InvalidArgument, "invalid input")
 		}
 		return nil, status.Error(codes.Internal, "failed to create book")
 	}
 
+	setETag(ctx, book.Version)
 	return book.ToProto(), nil
 }
 
-// ListBooks handles listing all books
+// ListBooks handles listing one page of books
 func (h *BookHandler) ListBooks(ctx context.Context, req *pb.ListBooksRequest) (*pb.ListBooksResponse, error) {
-	books, err := h.service.ListBooks(ctx)
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+
+	filter, err := listFilter(req)
+	if err != nil {
+		return nil, err
+	}
+	books, nextPageToken, total, err := h.service.ListBooks(ctx, filter, int(req.GetPageSize()), req.GetPageToken())
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidPageToken) {
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
+		if errors.Is(err, domain.ErrInvalidInput) {
+			return nil, status.Error(codes.InvalidArgument, "invalid input")
+		}
 		return nil, status.Error(codes.Internal, "failed to list books")
 	}
 
 	response := &pb.ListBooksResponse{
-		Books: make([]*pb.BookResponse, len(books)),
+		Books:         make([]*pb.BookResponse, len(books)),
+		NextPageToken: nextPageToken,
+		TotalSize:     int32(total),
 	}
 
 	for i, book := range books {
@@ -76,12 +118,198 @@ func (h *BookHandler) GetBook(ctx context.Context, req *pb.GetBookRequest) (*pb.
 		return nil, status.Error(codes.Internal, "failed to get book")
 	}
 
+	setETag(ctx, book.Version)
+	return book.ToProto(), nil
+}
+
+// GetBookByISBN handles retrieving a book by ISBN
+func (h *BookHandler) GetBookByISBN(ctx context.Context, req *pb.GetBookByISBNRequest) (*pb.BookResponse, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+
+	book, err := h.service.GetBookByISBN(ctx, req.GetIsbn())
+	if err != nil {
+		if errors.Is(err, domain.ErrBookNotFound) {
+			return nil, status.Error(codes.NotFound, "book not found")
+		}
+		if errors.Is(err, entity.ErrInvalidISBN) {
+			return nil, status.Error(codes.InvalidArgument, "invalid isbn")
+		}
+		return nil, status.Error(codes.Internal, "failed to get book")
+	}
+
+	setETag(ctx, book.Version)
+	return book.ToProto(), nil
+}
+
+// UpdateBook handles updating a book, honouring the request's field mask.
+// The update must be based on the book's current version.
+func (h *BookHandler) UpdateBook(ctx context.Context, req *pb.UpdateBookRequest) (*pb.BookResponse, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := middleware.AuthorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+	version, err := requestVersion(ctx, req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := entity.BookMetadata{
+		ISBN:            req.GetIsbn(),
+		Publisher:       req.GetPublisher(),
+		PublicationYear: req.GetPublicationYear(),
+		Language:        req.GetLanguage(),
+		PageCount:       req.GetPageCount(),
+		Edition:         req.GetEdition(),
+		Description:     req.GetDescription(),
+	}
+	book, err := h.service.UpdateBook(withStockOrigin(ctx, ""), req.GetId(), version, req.GetTitle(), req.GetAuthor(), req.GetCategory(), req.GetStock(), metadata, req.GetUpdateMask().GetPaths())
+	if err != nil {
+		if errors.Is(err, domain.ErrBookNotFound) {
+			return nil, status.Error(codes.NotFound, "book not found")
+		}
+		if errors.Is(err, domain.ErrDuplicateISBN) {
+			return nil, status.Error(codes.AlreadyExists, "isbn already exists")
+		}
+		if errors.Is(err, entity.ErrInvalidISBN) {
+			return nil, status.Error(codes.InvalidArgument, "invalid isbn")
+		}
+		if errors.Is(err, domain.ErrStockTracked) {
+			return nil, status.Error(codes.FailedPrecondition, "stock is derived from copies, add or update copies instead")
+		}
+		if errors.Is(err, domain.ErrVersionConflict) {
+			return nil, status.Error(codes.Aborted, "book was modified since it was read, reload it and retry")
+		}
+		if errors.Is(err, domain.ErrInvalidInput) {
+			return nil, status.Error(codes.InvalidArgument, "invalid input")
+		}
+		return nil, status.Error(codes.Internal, "failed to update book")
+	}
+
+	setETag(ctx, book.Version)
+	return book.ToProto(), nil
+}
+
+// DeleteBook handles deleting a book by ID
+func (h *BookHandler) DeleteBook(ctx context.Context, req *pb.DeleteBookRequest) (*pb.DeleteBookResponse, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := middleware.AuthorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := h.service.DeleteBook(ctx, req.GetId()); err != nil {
+		if errors.Is(err, domain.ErrBookNotFound) {
+			return nil, status.Error(codes.NotFound, "book not found")
+		}
+		if errors.Is(err, domain.ErrInvalidInput) {
+			return nil, status.Error(codes.InvalidArgument, "invalid input")
+		}
+		return nil, status.Error(codes.Internal, "failed to delete book")
+	}
+
+	return &pb.DeleteBookResponse{}, nil
+}
+
+// ReserveStock handles taking one copy of a book out of stock
+func (h *BookHandler) ReserveStock(ctx context.Context, req *pb.ReserveStockRequest) (*pb.ReserveStockResponse, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := middleware.AuthorizeService(ctx); err != nil {
+		return nil, err
+	}
+
+	book, bookCopy, err := h.service.ReserveStock(withStockOrigin(ctx, req.GetTransactionId()), req.GetId(), req.GetBarcode(), req.GetTransactionId(), req.GetReopen())
+	if err != nil {
+		return nil, stockError(err, "failed to reserve stock")
+	}
+
+	response := &pb.ReserveStockResponse{Book: book.ToProto()}
+	if bookCopy != nil {
+		response.Copy = bookCopy.ToProto()
+	}
+	return response, nil
+}
+
+// ReleaseStock handles putting the copy a loan reserved back into stock,
+// when the book is returned or its borrow is cancelled
+func (h *BookHandler) ReleaseStock(ctx context.Context, req *pb.ReleaseStockRequest) (*pb.BookResponse, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := middleware.AuthorizeService(ctx); err != nil {
+		return nil, err
+	}
+
+	ctx = withStockOrigin(ctx, req.GetTransactionId())
+	var book *entity.Book
+	var err error
+	if req.GetCancel() {
+		book, err = h.service.CancelStock(ctx, req.GetId(), req.GetTransactionId())
+	} else {
+		book, err = h.service.ReleaseStock(ctx, req.GetId(), req.GetBarcode(), req.GetTransactionId())
+	}
+	if err != nil {
+		return nil, stockError(err, "failed to release stock")
+	}
+
+	return book.ToProto(), nil
+}
+
+// WriteOffStock handles taking a lost or damaged copy of a book out of circulation
+func (h *BookHandler) WriteOffStock(ctx context.Context, req *pb.WriteOffStockRequest) (*pb.BookResponse, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := middleware.AuthorizeService(ctx); err != nil {
+		return nil, err
+	}
+
+	book, err := h.service.WriteOffStock(withStockOrigin(ctx, req.GetTransactionId()), req.GetId(), req.GetBarcode(), req.GetTransactionId(), req.GetDamaged())
+	if err != nil {
+		return nil, stockError(err, "failed to write off stock")
+	}
+
+	return book.ToProto(), nil
+}
+
+// RestoreStock handles putting a copy that was lost back into stock
+func (h *BookHandler) RestoreStock(ctx context.Context, req *pb.RestoreStockRequest) (*pb.BookResponse, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := middleware.AuthorizeService(ctx); err != nil {
+		return nil, err
+	}
+
+	book, err := h.service.RestoreStock(withStockOrigin(ctx, req.GetTransactionId()), req.GetId(), req.GetBarcode(), req.GetTransactionId())
+	if err != nil {
+		return nil, stockError(err, "failed to restore stock")
+	}
+
 	return book.ToProto(), nil
 }
 
 // Recommend handles book recommendations
 func (h *BookHandler) Recommend(ctx context.Context, req *pb.RecommendRequest) (*pb.ListBooksResponse, error) {
-	books, err := h.service.RecommendBooks(ctx)
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+
+	userID := req.GetUserId()
+	if principal, ok := middleware.PrincipalFromContext(ctx); ok && userID == "" {
+		userID = principal.UserID
+	}
+	if err := middleware.AuthorizeOwner(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	books, err := h.service.RecommendBooks(ctx, userID, int(req.GetLimit()))
 	if err != nil {
 		return nil, status.Error(codes.Internal, "failed to recommend books")
 	}
@@ -100,3 +328,206 @@ func (h *BookHandler) Recommend(ctx context.Context, req *pb.RecommendRequest) (
 func (h *BookHandler) HealthCheck(ctx context.Context, _ *pb.HealthCheckRequest) (*pb.HealthCheckResponse, error) {
 	return h.service.Health(ctx)
 }
+
+func stockError(err error, msg string) error {
+	switch {
+	case errors.Is(err, domain.ErrBookNotFound):
+		return status.Error(codes.NotFound, "book not found")
+	case errors.Is(err, domain.ErrReservationCancelled), errors.Is(err, domain.ErrReservationReleased):
+		return status.Error(codes.Aborted, err.Error())
+	default:
+		return copyError(err, msg)
+	}
+}
+
+// AddCopy handles registering a copy of a book
+func (h *BookHandler) AddCopy(ctx context.Context, req *pb.AddCopyRequest) (*pb.BookCopy, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := middleware.AuthorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	bookCopy, err := h.service.AddCopy(withStockOrigin(ctx, ""), req.GetBookId(), req.GetBarcode(), req.GetShelfLocation(), entity.CopyCondition(req.GetCondition()))
+	if err != nil {
+		if errors.Is(err, domain.ErrBookNotFound) {
+			return nil, status.Error(codes.NotFound, "book not found")
+		}
+		return nil, copyError(err, "failed to add copy")
+	}
+
+	return bookCopy.ToProto(), nil
+}
+
+// ListCopies handles listing the copies of a book
+func (h *BookHandler) ListCopies(ctx context.Context, req *pb.ListCopiesRequest) (*pb.ListCopiesResponse, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+
+	copies, err := h.service.ListCopies(ctx, req.GetBookId())
+	if err != nil {
+		if errors.Is(err, domain.ErrBookNotFound) {
+			return nil, status.Error(codes.NotFound, "book not found")
+		}
+		return nil, copyError(err, "failed to list copies")
+	}
+
+	response := &pb.ListCopiesResponse{
+		Copies: make([]*pb.BookCopy, len(copies)),
+	}
+
+	for i, bookCopy := range copies {
+		response.Copies[i] = bookCopy.ToProto()
+	}
+
+	return response, nil
+}
+
+// GetCopy handles looking a copy up by barcode
+func (h *BookHandler) GetCopy(ctx context.Context, req *pb.GetCopyRequest) (*pb.BookCopy, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+
+	bookCopy, err := h.service.GetCopy(ctx, req.GetBarcode())
+	if err != nil {
+		return nil, copyError(err, "failed to get copy")
+	}
+
+	return bookCopy.ToProto(), nil
+}
+
+// UpdateCopy handles updating a copy, honouring the request's field mask
+func (h *BookHandler) UpdateCopy(ctx context.Context, req *pb.UpdateCopyRequest) (*pb.BookCopy, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := middleware.AuthorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	bookCopy, err := h.service.UpdateCopy(withStockOrigin(ctx, ""), req.GetBarcode(), req.GetShelfLocation(), entity.CopyCondition(req.GetCondition()),
+		entity.CopyStatus(req.GetStatus()), req.GetUpdateMask().GetPaths())
+	if err != nil {
+		return nil, copyError(err, "failed to update copy")
+	}
+
+	return bookCopy.ToProto(), nil
+}
+
+// WithdrawCopy handles taking a copy out of circulation
+func (h *BookHandler) WithdrawCopy(ctx context.Context, req *pb.WithdrawCopyRequest) (*pb.BookCopy, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := middleware.AuthorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	bookCopy, err := h.service.WithdrawCopy(withStockOrigin(ctx, ""), req.GetBarcode())
+	if err != nil {
+		return nil, copyError(err, "failed to withdraw copy")
+	}
+
+	return bookCopy.ToProto(), nil
+}
+
+func copyError(err error, msg string) error {
+	if reason, ok := domain.PreconditionReason(err); ok {
+		return validator.NewPreconditionGRPCError(reason, err.Error(), nil)
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrCopyNotFound):
+		return status.Error(codes.NotFound, "copy not found")
+	case errors.Is(err, domain.ErrDuplicateBarcode):
+		return status.Error(codes.AlreadyExists, "barcode already exists")
+	case errors.Is(err, domain.ErrInvalidInput):
+		return status.Error(codes.InvalidArgument, "invalid input")
+	default:
+		return status.Error(codes.Internal, msg)
+	}
+}
+
+// SubscribeEvents streams book events to admins
+func (h *BookHandler) SubscribeEvents(req *commonPb.SubscribeEventsRequest, stream pb.BookService_SubscribeEventsServer) error {
+	return streamEvents(h.feed, req, stream)
+}
+
+// SearchBooks handles catalogue search
+func (h *BookHandler) SearchBooks(ctx context.Context, req *pb.SearchBooksRequest) (*pb.SearchBooksResponse, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+
+	matches, err := h.service.SearchBooks(ctx, req.GetQ(), int(req.GetLimit()))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			return nil, status.Error(codes.InvalidArgument, "search query has no words to search for")
+		}
+		return nil, status.Error(codes.Internal, "failed to search books")
+	}
+
+	response := &pb.SearchBooksResponse{
+		Results: make([]*pb.SearchResult, len(matches)),
+	}
+
+	for i, match := range matches {
+		response.Results[i] = match.ToProto()
+	}
+
+	return response, nil
+}
+
+// bookFilterRequest is a request carrying the filters of a book listing
+type bookFilterRequest interface {
+	GetCategory() string
+	GetAuthor() string
+	GetInStock() bool
+	GetCreatedAfter() string
+	GetCreatedBefore() string
+	GetSortBy() string
+	GetSortOrder() string
+}
+
+// listFilter builds the listing filter of a validated request
+func listFilter(req bookFilterRequest) (domain.ListFilter, error) {
+	createdAfter, err := optionalTime(req.GetCreatedAfter(), "created_after")
+	if err != nil {
+		return domain.ListFilter{}, err
+	}
+	createdBefore, err := optionalTime(req.GetCreatedBefore(), "created_before")
+	if err != nil {
+		return domain.ListFilter{}, err
+	}
+
+	// Newest first by default, alphabetical or ascending for the other fields
+	descending := req.GetSortOrder() == "desc"
+	if req.GetSortOrder() == "" {
+		descending = req.GetSortBy() == "" || req.GetSortBy() == domain.SortByCreatedAt
+	}
+
+	return domain.ListFilter{
+		Category:      req.GetCategory(),
+		Author:        req.GetAuthor(),
+		InStock:       req.GetInStock(),
+		CreatedAfter:  createdAfter,
+		CreatedBefore: createdBefore,
+		SortBy:        req.GetSortBy(),
+		Descending:    descending,
+	}, nil
+}
+
+// optionalTime parses an optional RFC 3339 request field
+func optionalTime(value, field string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid "+field)
+	}
+	return &parsed, nil
+}
@@ -0,0 +1,1384 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	pb "github.com/hinha/library-management-synapsis/gen/api/proto/book"
+	commonPb "github.com/hinha/library-management-synapsis/gen/api/proto/common"
+	"github.com/hinha/library-management-synapsis/internal/delivery/mocks"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"github.com/hinha/library-management-synapsis/internal/domain/book"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"io"
+	"testing"
+	"time"
+)
+
+var testBook = &domain.Book{ID: "book-1", Title: "Dune", Author: "Frank Herbert", Category: "Fiction", Stock: 2}
+
+func TestBookHandler_Create(t *testing.T) {
+	req := &pb.CreateBookRequest{Title: "Dune", Author: "Frank Herbert", Category: "Fiction", Stock: 2}
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		req        *pb.CreateBookRequest
+		mockSetup  func(svc *mocks.BookService)
+		statusCode codes.Code
+	}{
+		{
+			name: "admin creates",
+			ctx:  adminCtx,
+			req:  req,
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("CreateBook", mock.Anything, "Dune", "Frank Herbert", "Fiction", int32(2), domain.BookMetadata{}).Return(testBook, nil)
+			},
+		},
+		{
+			name:       "operation user",
+			ctx:        ownerCtx,
+			req:        req,
+			statusCode: codes.PermissionDenied,
+		},
+		{
+			name:       "validation error",
+			ctx:        adminCtx,
+			req:        &pb.CreateBookRequest{Title: "Dune"},
+			statusCode: codes.InvalidArgument,
+		},
+		{
+			name: "internal error",
+			ctx:  adminCtx,
+			req:  req,
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("CreateBook", mock.Anything, "Dune", "Frank Herbert", "Fiction", int32(2), domain.BookMetadata{}).Return(nil, errors.New("db error"))
+			},
+			statusCode: codes.Internal,
+		},
+		{
+			name: "duplicate isbn",
+			ctx:  adminCtx,
+			req:  &pb.CreateBookRequest{Title: "Dune", Author: "Frank Herbert", Category: "Fiction", Stock: 2, Isbn: "9780441013593"},
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("CreateBook", mock.Anything, "Dune", "Frank Herbert", "Fiction", int32(2), domain.BookMetadata{ISBN: "9780441013593"}).
+					Return(nil, book.ErrDuplicateISBN)
+			},
+			statusCode: codes.AlreadyExists,
+		},
+		{
+			name:       "malformed isbn",
+			ctx:        adminCtx,
+			req:        &pb.CreateBookRequest{Title: "Dune", Author: "Frank Herbert", Category: "Fiction", Stock: 2, Isbn: "not-an-isbn"},
+			statusCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.BookService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewBookHandler(mockSvc, nil)
+
+			got, err := h.Create(tt.ctx, tt.req)
+
+			assertStatus(t, err, tt.statusCode)
+			if tt.statusCode == codes.OK {
+				assert.Equal(t, testBook.ToProto(), got)
+			}
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBookHandler_ListBooks(t *testing.T) {
+	after := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		req        *pb.ListBooksRequest
+		mockSetup  func(svc *mocks.BookService)
+		want       *pb.ListBooksResponse
+		statusCode codes.Code
+	}{
+		{
+			name: "operation user lists newest first",
+			req:  &pb.ListBooksRequest{},
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("ListBooks", mock.Anything, book.ListFilter{Descending: true}, 0, "").
+					Return([]*domain.Book{testBook}, "next", int64(4), nil)
+			},
+			want: &pb.ListBooksResponse{Books: []*pb.BookResponse{testBook.ToProto()}, NextPageToken: "next", TotalSize: 4},
+		},
+		{
+			name: "filters and sorts",
+			req: &pb.ListBooksRequest{
+				PageSize:     10,
+				PageToken:    "token",
+				Category:     "Fiction",
+				Author:       "Frank Herbert",
+				InStock:      true,
+				CreatedAfter: "2025-01-01T00:00:00Z",
+				SortBy:       "title",
+			},
+			mockSetup: func(svc *mocks.BookService) {
+				filter := book.ListFilter{Category: "Fiction", Author: "Frank Herbert", InStock: true, CreatedAfter: &after, SortBy: "title"}
+				svc.On("ListBooks", mock.Anything, filter, 10, "token").Return([]*domain.Book{testBook}, "", int64(1), nil)
+			},
+			want: &pb.ListBooksResponse{Books: []*pb.BookResponse{testBook.ToProto()}, TotalSize: 1},
+		},
+		{
+			name: "explicit sort order",
+			req:  &pb.ListBooksRequest{SortBy: "stock", SortOrder: "desc"},
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("ListBooks", mock.Anything, book.ListFilter{SortBy: "stock", Descending: true}, 0, "").
+					Return([]*domain.Book{}, "", int64(0), nil)
+			},
+			want: &pb.ListBooksResponse{Books: []*pb.BookResponse{}},
+		},
+		{
+			name:       "unknown sort field",
+			req:        &pb.ListBooksRequest{SortBy: "isbn"},
+			statusCode: codes.InvalidArgument,
+		},
+		{
+			name:       "page size too large",
+			req:        &pb.ListBooksRequest{PageSize: 500},
+			statusCode: codes.InvalidArgument,
+		},
+		{
+			name:       "malformed date",
+			req:        &pb.ListBooksRequest{CreatedBefore: "yesterday"},
+			statusCode: codes.InvalidArgument,
+		},
+		{
+			name: "invalid page token",
+			req:  &pb.ListBooksRequest{PageToken: "stale"},
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("ListBooks", mock.Anything, mock.Anything, 0, "stale").Return(nil, "", int64(0), book.ErrInvalidPageToken)
+			},
+			statusCode: codes.InvalidArgument,
+		},
+		{
+			name: "internal error",
+			req:  &pb.ListBooksRequest{},
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("ListBooks", mock.Anything, mock.Anything, 0, "").Return(nil, "", int64(0), errors.New("db error"))
+			},
+			statusCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.BookService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewBookHandler(mockSvc, nil)
+
+			got, err := h.ListBooks(ownerCtx, tt.req)
+
+			assertStatus(t, err, tt.statusCode)
+			if tt.statusCode == codes.OK {
+				assert.Equal(t, tt.want, got)
+			}
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBookHandler_GetBook(t *testing.T) {
+	tests := []struct {
+		name       string
+		mockSetup  func(svc *mocks.BookService)
+		statusCode codes.Code
+	}{
+		{
+			name: "operation user reads",
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("GetBook", mock.Anything, "book-1").Return(testBook, nil)
+			},
+		},
+		{
+			name: "not found",
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("GetBook", mock.Anything, "book-1").Return(nil, book.ErrBookNotFound)
+			},
+			statusCode: codes.NotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.BookService)
+			tt.mockSetup(mockSvc)
+			h := NewBookHandler(mockSvc, nil)
+
+			_, err := h.GetBook(ownerCtx, &pb.GetBookRequest{Id: "book-1"})
+
+			assertStatus(t, err, tt.statusCode)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBookHandler_GetBookByISBN(t *testing.T) {
+	tests := []struct {
+		name       string
+		mockSetup  func(svc *mocks.BookService)
+		statusCode codes.Code
+	}{
+		{
+			name: "operation user reads",
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("GetBookByISBN", mock.Anything, "978-0-441-01359-3").Return(testBook, nil)
+			},
+		},
+		{
+			name: "not found",
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("GetBookByISBN", mock.Anything, "978-0-441-01359-3").Return(nil, book.ErrBookNotFound)
+			},
+			statusCode: codes.NotFound,
+		},
+		{
+			name: "bad check digit",
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("GetBookByISBN", mock.Anything, "978-0-441-01359-3").Return(nil, domain.ErrInvalidISBN)
+			},
+			statusCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.BookService)
+			tt.mockSetup(mockSvc)
+			h := NewBookHandler(mockSvc, nil)
+
+			_, err := h.GetBookByISBN(ownerCtx, &pb.GetBookByISBNRequest{Isbn: "978-0-441-01359-3"})
+
+			assertStatus(t, err, tt.statusCode)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBookHandler_UpdateBook(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		version    int64
+		mockSetup  func(svc *mocks.BookService)
+		statusCode codes.Code
+	}{
+		{
+			name:    "admin updates",
+			ctx:     adminCtx,
+			version: 1,
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("UpdateBook", mock.Anything, "book-1", int64(1), "", "", "", int32(5), domain.BookMetadata{}, []string{"stock"}).Return(testBook, nil)
+			},
+		},
+		{
+			name: "version from if-match header",
+			ctx:  metadata.NewIncomingContext(adminCtx, metadata.Pairs("grpcgateway-if-match", `W/"4"`)),
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("UpdateBook", mock.Anything, "book-1", int64(4), "", "", "", int32(5), domain.BookMetadata{}, []string{"stock"}).Return(testBook, nil)
+			},
+		},
+		{
+			name:       "operation user",
+			ctx:        ownerCtx,
+			version:    1,
+			statusCode: codes.PermissionDenied,
+		},
+		{
+			name:       "missing version",
+			ctx:        adminCtx,
+			statusCode: codes.InvalidArgument,
+		},
+		{
+			name:       "malformed if-match header",
+			ctx:        metadata.NewIncomingContext(adminCtx, metadata.Pairs("if-match", "4")),
+			statusCode: codes.InvalidArgument,
+		},
+		{
+			name:    "modified since read",
+			ctx:     adminCtx,
+			version: 1,
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("UpdateBook", mock.Anything, "book-1", int64(1), "", "", "", int32(5), domain.BookMetadata{}, []string{"stock"}).Return(nil, book.ErrVersionConflict)
+			},
+			statusCode: codes.Aborted,
+		},
+		{
+			name:    "not found",
+			ctx:     adminCtx,
+			version: 1,
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("UpdateBook", mock.Anything, "book-1", int64(1), "", "", "", int32(5), domain.BookMetadata{}, []string{"stock"}).Return(nil, book.ErrBookNotFound)
+			},
+			statusCode: codes.NotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.BookService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewBookHandler(mockSvc, nil)
+
+			_, err := h.UpdateBook(tt.ctx, &pb.UpdateBookRequest{
+				Id:         "book-1",
+				Stock:      5,
+				Version:    tt.version,
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"stock"}},
+			})
+
+			assertStatus(t, err, tt.statusCode)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBookHandler_DeleteBook(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		mockSetup  func(svc *mocks.BookService)
+		statusCode codes.Code
+	}{
+		{
+			name: "admin deletes",
+			ctx:  adminCtx,
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("DeleteBook", mock.Anything, "book-1").Return(nil)
+			},
+		},
+		{
+			name:       "operation user",
+			ctx:        ownerCtx,
+			statusCode: codes.PermissionDenied,
+		},
+		{
+			name:       "no caller identity",
+			ctx:        context.Background(),
+			statusCode: codes.Unauthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.BookService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewBookHandler(mockSvc, nil)
+
+			_, err := h.DeleteBook(tt.ctx, &pb.DeleteBookRequest{Id: "book-1"})
+
+			assertStatus(t, err, tt.statusCode)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBookHandler_ReserveStock(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		mockSetup  func(svc *mocks.BookService)
+		statusCode codes.Code
+	}{
+		{
+			name: "reserves a copy",
+			ctx:  serviceCtx,
+			mockSetup: func(svc *mocks.BookService) {
+				// The movement is attributed to the borrower and the loan
+				svc.On("ReserveStock", mock.MatchedBy(func(ctx context.Context) bool {
+					return domain.StockOriginFromContext(ctx) == domain.StockOrigin{ActorID: "1", CorrelationID: "tx-1"}
+				}), "book-1", "", "tx-1", false).Return(testBook, nil, nil)
+			},
+		},
+		{
+			name: "admin reserves",
+			ctx:  adminCtx,
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("ReserveStock", mock.Anything, "book-1", "", "tx-1", false).Return(testBook, nil, nil)
+			},
+		},
+		{
+			name: "out of stock",
+			ctx:  serviceCtx,
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("ReserveStock", mock.Anything, "book-1", "", "tx-1", false).Return(nil, nil, book.ErrInsufficientStock)
+			},
+			statusCode: codes.FailedPrecondition,
+		},
+		{
+			name: "cancelled borrow",
+			ctx:  serviceCtx,
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("ReserveStock", mock.Anything, "book-1", "", "tx-1", false).Return(nil, nil, book.ErrReservationCancelled)
+			},
+			statusCode: codes.Aborted,
+		},
+		{
+			name:       "patron",
+			ctx:        ownerCtx,
+			statusCode: codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.BookService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewBookHandler(mockSvc, nil)
+
+			_, err := h.ReserveStock(tt.ctx, &pb.ReserveStockRequest{Id: "book-1", TransactionId: "tx-1"})
+
+			assertStatus(t, err, tt.statusCode)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBookHandler_ReleaseStock(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		cancel     bool
+		mockSetup  func(svc *mocks.BookService)
+		statusCode codes.Code
+	}{
+		{
+			name: "releases a copy",
+			ctx:  serviceCtx,
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("ReleaseStock", mock.Anything, "book-1", "", "tx-1").Return(testBook, nil)
+			},
+		},
+		{
+			name:   "cancels a borrow",
+			ctx:    serviceCtx,
+			cancel: true,
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("CancelStock", mock.Anything, "book-1", "tx-1").Return(testBook, nil)
+			},
+		},
+		{
+			name: "not found",
+			ctx:  serviceCtx,
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("ReleaseStock", mock.Anything, "book-1", "", "tx-1").Return(nil, book.ErrBookNotFound)
+			},
+			statusCode: codes.NotFound,
+		},
+		{
+			name:       "patron",
+			ctx:        ownerCtx,
+			statusCode: codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.BookService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewBookHandler(mockSvc, nil)
+
+			_, err := h.ReleaseStock(tt.ctx, &pb.ReleaseStockRequest{Id: "book-1", TransactionId: "tx-1", Cancel: tt.cancel})
+
+			assertStatus(t, err, tt.statusCode)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBookHandler_WriteOffStock(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		mockSetup  func(svc *mocks.BookService)
+		statusCode codes.Code
+	}{
+		{
+			name: "writes the copy off",
+			ctx:  adminCtx,
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("WriteOffStock", mock.Anything, "book-1", "LIB-0001", "tx-1", true).Return(testBook, nil)
+			},
+		},
+		{
+			name: "copy not on loan",
+			ctx:  adminCtx,
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("WriteOffStock", mock.Anything, "book-1", "LIB-0001", "tx-1", true).Return(nil, book.ErrCopyNotOnLoan)
+			},
+			statusCode: codes.FailedPrecondition,
+		},
+		{
+			name: "service writes the copy off",
+			ctx:  serviceCtx,
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("WriteOffStock", mock.Anything, "book-1", "LIB-0001", "tx-1", true).Return(testBook, nil)
+			},
+		},
+		{
+			name:       "patron",
+			ctx:        ownerCtx,
+			statusCode: codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.BookService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewBookHandler(mockSvc, nil)
+
+			_, err := h.WriteOffStock(tt.ctx, &pb.WriteOffStockRequest{Id: "book-1", Barcode: "LIB-0001", TransactionId: "tx-1", Damaged: true})
+
+			assertStatus(t, err, tt.statusCode)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBookHandler_StockReasonDetails(t *testing.T) {
+	tests := []struct {
+		err    error
+		reason string
+	}{
+		{err: book.ErrInsufficientStock, reason: book.ReasonInsufficientStock},
+		{err: book.ErrCopyNotAvailable, reason: book.ReasonCopyNotAvailable},
+		{err: book.ErrCopyNotOnLoan, reason: book.ReasonCopyNotOnLoan},
+		{err: book.ErrCopyOnLoan, reason: book.ReasonCopyOnLoan},
+		{err: book.ErrCopyWithdrawn, reason: book.ReasonCopyWithdrawn},
+		{err: book.ErrCopyNotLost, reason: book.ReasonCopyNotLost},
+		{err: book.ErrCounterLoansOpen, reason: book.ReasonCounterLoansOpen},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reason, func(t *testing.T) {
+			mockSvc := new(mocks.BookService)
+			mockSvc.On("WriteOffStock", mock.Anything, "book-1", "LIB-0001", "tx-1", false).Return(nil, tt.err)
+			h := NewBookHandler(mockSvc, nil)
+
+			_, err := h.WriteOffStock(serviceCtx, &pb.WriteOffStockRequest{Id: "book-1", Barcode: "LIB-0001", TransactionId: "tx-1"})
+
+			st := status.Convert(err)
+			assert.Equal(t, codes.FailedPrecondition, st.Code())
+			if assert.Len(t, st.Details(), 1) {
+				detail, ok := st.Details()[0].(*commonPb.PreconditionFailure)
+				assert.True(t, ok)
+				assert.Equal(t, tt.reason, detail.GetReason())
+			}
+		})
+	}
+}
+func TestBookHandler_RestoreStock(t *testing.T) {
+	tests := []struct {
+		name       string
+		mockSetup  func(svc *mocks.BookService)
+		statusCode codes.Code
+	}{
+		{
+			name: "restores the copy",
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("RestoreStock", mock.Anything, "book-1", "LIB-0001", "tx-1").Return(testBook, nil)
+			},
+		},
+		{
+			name: "copy not lost",
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("RestoreStock", mock.Anything, "book-1", "LIB-0001", "tx-1").Return(nil, book.ErrCopyNotLost)
+			},
+			statusCode: codes.FailedPrecondition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.BookService)
+			tt.mockSetup(mockSvc)
+			h := NewBookHandler(mockSvc, nil)
+
+			_, err := h.RestoreStock(adminCtx, &pb.RestoreStockRequest{Id: "book-1", Barcode: "LIB-0001", TransactionId: "tx-1"})
+
+			assertStatus(t, err, tt.statusCode)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBookHandler_AddCopy(t *testing.T) {
+	req := &pb.AddCopyRequest{BookId: "book-1", Barcode: "LIB-0001", ShelfLocation: "A3"}
+	testCopy := &domain.BookCopy{ID: "c-1", BookID: "book-1", Barcode: "LIB-0001", Status: domain.CopyStatusAvailable}
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		mockSetup  func(svc *mocks.BookService)
+		statusCode codes.Code
+	}{
+		{
+			name: "admin adds",
+			ctx:  adminCtx,
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("AddCopy", mock.Anything, "book-1", "LIB-0001", "A3", domain.CopyCondition("")).Return(testCopy, nil)
+			},
+		},
+		{
+			name:       "operation user",
+			ctx:        ownerCtx,
+			statusCode: codes.PermissionDenied,
+		},
+		{
+			name: "duplicate barcode",
+			ctx:  adminCtx,
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("AddCopy", mock.Anything, "book-1", "LIB-0001", "A3", domain.CopyCondition("")).Return(nil, book.ErrDuplicateBarcode)
+			},
+			statusCode: codes.AlreadyExists,
+		},
+		{
+			name: "book not found",
+			ctx:  adminCtx,
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("AddCopy", mock.Anything, "book-1", "LIB-0001", "A3", domain.CopyCondition("")).Return(nil, book.ErrBookNotFound)
+			},
+			statusCode: codes.NotFound,
+		},
+		{
+			name: "book on loan off its counter",
+			ctx:  adminCtx,
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("AddCopy", mock.Anything, "book-1", "LIB-0001", "A3", domain.CopyCondition("")).Return(nil, book.ErrCounterLoansOpen)
+			},
+			statusCode: codes.FailedPrecondition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.BookService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewBookHandler(mockSvc, nil)
+
+			_, err := h.AddCopy(tt.ctx, req)
+
+			assertStatus(t, err, tt.statusCode)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBookHandler_WithdrawCopy(t *testing.T) {
+	testCopy := &domain.BookCopy{ID: "c-1", BookID: "book-1", Barcode: "LIB-0001", Status: domain.CopyStatusWithdrawn}
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		mockSetup  func(svc *mocks.BookService)
+		statusCode codes.Code
+	}{
+		{
+			name: "admin withdraws",
+			ctx:  adminCtx,
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("WithdrawCopy", mock.Anything, "LIB-0001").Return(testCopy, nil)
+			},
+		},
+		{
+			name:       "operation user",
+			ctx:        ownerCtx,
+			statusCode: codes.PermissionDenied,
+		},
+		{
+			name: "copy on loan",
+			ctx:  adminCtx,
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("WithdrawCopy", mock.Anything, "LIB-0001").Return(nil, book.ErrCopyOnLoan)
+			},
+			statusCode: codes.FailedPrecondition,
+		},
+		{
+			name: "copy not found",
+			ctx:  adminCtx,
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("WithdrawCopy", mock.Anything, "LIB-0001").Return(nil, book.ErrCopyNotFound)
+			},
+			statusCode: codes.NotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.BookService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewBookHandler(mockSvc, nil)
+
+			_, err := h.WithdrawCopy(tt.ctx, &pb.WithdrawCopyRequest{Barcode: "LIB-0001"})
+
+			assertStatus(t, err, tt.statusCode)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+// importBooksStream is the server side of an ImportBooks stream fed from a
+// list of messages
+type importBooksStream struct {
+	grpc.ServerStream
+	requests []*pb.ImportBooksRequest
+	response *pb.ImportBooksResponse
+}
+
+func (s *importBooksStream) Context() context.Context {
+	return adminCtx
+}
+
+func (s *importBooksStream) Recv() (*pb.ImportBooksRequest, error) {
+	if len(s.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := s.requests[0]
+	s.requests = s.requests[1:]
+	return req, nil
+}
+
+func (s *importBooksStream) SendAndClose(response *pb.ImportBooksResponse) error {
+	s.response = response
+	return nil
+}
+
+// drainImport reads every record of an import and rejects the invalid ones
+func drainImport(_ context.Context, records domain.ImportReader, _ int, dryRun bool) *domain.ImportReport {
+	report := &domain.ImportReport{DryRun: dryRun}
+	for {
+		record, err := records.Next()
+		if err != nil {
+			return report
+		}
+		if record.Err != nil {
+			report.Reject(record, record.Err.Error())
+			continue
+		}
+		report.Add(domain.ImportResult{Row: record.Row, Status: domain.ImportStatusCreated, Title: record.Title})
+	}
+}
+
+func TestBookHandler_ImportBooks(t *testing.T) {
+	csvOptions := &pb.ImportOptions{Format: "csv", DryRun: true, BatchSize: 50}
+	tests := []struct {
+		name       string
+		requests   []*pb.ImportBooksRequest
+		mockSetup  func(svc *mocks.BookService)
+		want       []*pb.ImportRecordResult
+		statusCode codes.Code
+	}{
+		{
+			name: "csv split across messages",
+			requests: []*pb.ImportBooksRequest{
+				{Options: csvOptions, Data: []byte("title,author,category,stock\nDune,Frank Her")},
+				{Data: []byte("bert,Fiction,2\n")},
+				{Data: []byte("Emma,Jane Austen,Fiction,0\n")},
+			},
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("ImportBooks", mock.Anything, mock.Anything, 50, true).Return(drainImport, nil)
+			},
+			want: []*pb.ImportRecordResult{
+				{Row: 2, Status: "created", Title: "Dune"},
+				{Row: 3, Status: "rejected", Title: "Emma", Reason: "Stock failed on 'required' validation"},
+			},
+		},
+		{
+			name: "marc21",
+			requests: []*pb.ImportBooksRequest{
+				{Options: &pb.ImportOptions{Format: "marc21", DefaultStock: 1}, Data: []byte("00048nam a2200037   4500245001000000\x1e00\x1faDune.\x1e\x1d")},
+			},
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("ImportBooks", mock.Anything, mock.Anything, 0, false).Return(drainImport, nil)
+			},
+			want: []*pb.ImportRecordResult{
+				{Row: 1, Status: "rejected", Title: "Dune", Reason: "Author failed on 'required' validation; Category failed on 'required' validation"},
+			},
+		},
+		{
+			name:       "no options",
+			requests:   []*pb.ImportBooksRequest{{Data: []byte("title\n")}},
+			statusCode: codes.InvalidArgument,
+		},
+		{
+			name:       "unknown format",
+			requests:   []*pb.ImportBooksRequest{{Options: &pb.ImportOptions{Format: "xlsx"}}},
+			statusCode: codes.InvalidArgument,
+		},
+		{
+			name:       "csv header without a mapped column",
+			requests:   []*pb.ImportBooksRequest{{Options: &pb.ImportOptions{Format: "csv", Columns: map[string]string{"isbn": "ISBN13"}}, Data: []byte("title,author,category\n")}},
+			statusCode: codes.InvalidArgument,
+		},
+		{
+			name:       "empty stream",
+			statusCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.BookService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewBookHandler(mockSvc, nil)
+			stream := &importBooksStream{requests: tt.requests}
+
+			err := h.ImportBooks(stream)
+
+			assertStatus(t, err, tt.statusCode)
+			if tt.want != nil && assert.NotNil(t, stream.response) {
+				assert.Equal(t, tt.want, stream.response.GetResults())
+			}
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+// exportBooksStream is the server side of an ExportBooks stream keeping the
+// chunks sent on it
+type exportBooksStream struct {
+	grpc.ServerStream
+	chunks []*httpbody.HttpBody
+}
+
+func (s *exportBooksStream) Context() context.Context {
+	return adminCtx
+}
+
+func (s *exportBooksStream) Send(chunk *httpbody.HttpBody) error {
+	s.chunks = append(s.chunks, &httpbody.HttpBody{ContentType: chunk.GetContentType(), Data: append([]byte{}, chunk.GetData()...)})
+	return nil
+}
+
+// exportOf writes books to the export writer of an ExportBooks call
+func exportOf(books ...*domain.Book) func(context.Context, domain.BookFilter, domain.ExportWriter) error {
+	return func(_ context.Context, _ domain.BookFilter, w domain.ExportWriter) error {
+		for _, b := range books {
+			if err := w.Write(b); err != nil {
+				return err
+			}
+		}
+		return w.Flush()
+	}
+}
+
+func TestBookHandler_ExportBooks(t *testing.T) {
+	tests := []struct {
+		name            string
+		req             *pb.ExportBooksRequest
+		mockSetup       func(svc *mocks.BookService)
+		wantContentType string
+		wantData        string
+		statusCode      codes.Code
+	}{
+		{
+			name: "ndjson with filters",
+			req:  &pb.ExportBooksRequest{Format: "ndjson", Category: "Fiction", SortBy: "title", CreatedAfter: "2025-01-01T00:00:00Z"},
+			mockSetup: func(svc *mocks.BookService) {
+				createdAfter := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+				svc.On("ExportBooks", mock.Anything, domain.BookFilter{Category: "Fiction", SortBy: "title", CreatedAfter: &createdAfter}, mock.Anything).
+					Return(exportOf(&domain.Book{ID: "book-1", Title: "Dune"}))
+			},
+			wantContentType: "application/x-ndjson",
+			wantData:        `{"id":"book-1","title":"Dune","author":"","category":"","stock":0,"version":0,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}` + "\n",
+		},
+		{
+			name: "empty csv still has a header",
+			req:  &pb.ExportBooksRequest{Format: "csv"},
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("ExportBooks", mock.Anything, domain.BookFilter{Descending: true}, mock.Anything).Return(exportOf())
+			},
+			wantContentType: "text/csv; charset=utf-8",
+			wantData:        "id,title,author,category,stock,isbn,publisher,publication_year,language,page_count,edition,description,created_at,updated_at\n",
+		},
+		{
+			name: "empty marc21 file",
+			req:  &pb.ExportBooksRequest{Format: "marc21"},
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("ExportBooks", mock.Anything, mock.Anything, mock.Anything).Return(exportOf())
+			},
+			wantContentType: "application/marc",
+		},
+		{
+			name:       "unknown format",
+			req:        &pb.ExportBooksRequest{Format: "xlsx"},
+			statusCode: codes.InvalidArgument,
+		},
+		{
+			name:       "malformed date",
+			req:        &pb.ExportBooksRequest{Format: "csv", CreatedBefore: "yesterday"},
+			statusCode: codes.InvalidArgument,
+		},
+		{
+			name: "service error",
+			req:  &pb.ExportBooksRequest{Format: "csv"},
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("ExportBooks", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("db error"))
+			},
+			statusCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.BookService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewBookHandler(mockSvc, nil)
+			stream := &exportBooksStream{}
+
+			err := h.ExportBooks(tt.req, stream)
+
+			assertStatus(t, err, tt.statusCode)
+			if tt.wantContentType != "" {
+				var data []byte
+				for _, chunk := range stream.chunks {
+					assert.Equal(t, tt.wantContentType, chunk.GetContentType())
+					data = append(data, chunk.GetData()...)
+				}
+				assert.NotEmpty(t, stream.chunks)
+				assert.Equal(t, tt.wantData, string(data))
+			}
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBookHandler_Recommend(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		req        *pb.RecommendRequest
+		mockSetup  func(svc *mocks.BookService)
+		statusCode codes.Code
+	}{
+		{
+			name: "recommends for the caller",
+			ctx:  ownerCtx,
+			req:  &pb.RecommendRequest{Limit: 5},
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("RecommendBooks", mock.Anything, "1", 5).Return([]*domain.Book{testBook}, nil)
+			},
+		},
+		{
+			name: "admin recommends for a user",
+			ctx:  adminCtx,
+			req:  &pb.RecommendRequest{UserId: "1"},
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("RecommendBooks", mock.Anything, "1", 0).Return([]*domain.Book{testBook}, nil)
+			},
+		},
+		{
+			name:       "another user's recommendations",
+			ctx:        otherCtx,
+			req:        &pb.RecommendRequest{UserId: "1"},
+			statusCode: codes.PermissionDenied,
+		},
+		{
+			name:       "limit too large",
+			ctx:        ownerCtx,
+			req:        &pb.RecommendRequest{Limit: 500},
+			statusCode: codes.InvalidArgument,
+		},
+		{
+			name: "internal error",
+			ctx:  ownerCtx,
+			req:  &pb.RecommendRequest{},
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("RecommendBooks", mock.Anything, "1", 0).Return(nil, errors.New("db error"))
+			},
+			statusCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.BookService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewBookHandler(mockSvc, nil)
+
+			_, err := h.Recommend(tt.ctx, tt.req)
+
+			assertStatus(t, err, tt.statusCode)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBookHandler_SearchBooks(t *testing.T) {
+	match := &domain.BookMatch{Book: *testBook, Rank: 0.5, Snippet: "<mark>Dune</mark> — Frank Herbert — Fiction"}
+	tests := []struct {
+		name       string
+		req        *pb.SearchBooksRequest
+		mockSetup  func(svc *mocks.BookService)
+		statusCode codes.Code
+	}{
+		{
+			name: "searches",
+			req:  &pb.SearchBooksRequest{Q: "dune", Limit: 5},
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("SearchBooks", mock.Anything, "dune", 5).Return([]*domain.BookMatch{match}, nil)
+			},
+		},
+		{
+			name:       "missing query",
+			req:        &pb.SearchBooksRequest{},
+			statusCode: codes.InvalidArgument,
+		},
+		{
+			name: "no words to search for",
+			req:  &pb.SearchBooksRequest{Q: "*"},
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("SearchBooks", mock.Anything, "*", 0).Return(nil, book.ErrInvalidInput)
+			},
+			statusCode: codes.InvalidArgument,
+		},
+		{
+			name: "internal error",
+			req:  &pb.SearchBooksRequest{Q: "dune"},
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("SearchBooks", mock.Anything, "dune", 0).Return(nil, errors.New("db error"))
+			},
+			statusCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.BookService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewBookHandler(mockSvc, nil)
+
+			got, err := h.SearchBooks(ownerCtx, tt.req)
+
+			assertStatus(t, err, tt.statusCode)
+			if tt.statusCode == codes.OK {
+				assert.Equal(t, []*pb.SearchResult{match.ToProto()}, got.GetResults())
+			}
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBookHandler_ListStockMovements(t *testing.T) {
+	movement := &domain.StockMovement{ID: 7, BookID: "book-1", Delta: -1, Reason: domain.StockReasonLoan, CorrelationID: "tx-1", CreatedAt: time.Now()}
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		req        *pb.ListStockMovementsRequest
+		mockSetup  func(svc *mocks.BookService)
+		statusCode codes.Code
+	}{
+		{
+			name: "admin lists",
+			ctx:  adminCtx,
+			req:  &pb.ListStockMovementsRequest{BookId: "book-1", PageSize: 10},
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("ListStockMovements", mock.Anything, "book-1", 10, "").Return([]*domain.StockMovement{movement}, "next", nil)
+			},
+		},
+		{
+			name:       "operation user",
+			ctx:        ownerCtx,
+			req:        &pb.ListStockMovementsRequest{BookId: "book-1"},
+			statusCode: codes.PermissionDenied,
+		},
+		{
+			name:       "missing book",
+			ctx:        adminCtx,
+			req:        &pb.ListStockMovementsRequest{},
+			statusCode: codes.InvalidArgument,
+		},
+		{
+			name: "invalid page token",
+			ctx:  adminCtx,
+			req:  &pb.ListStockMovementsRequest{BookId: "book-1", PageToken: "bad"},
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("ListStockMovements", mock.Anything, "book-1", 0, "bad").Return(nil, "", book.ErrInvalidPageToken)
+			},
+			statusCode: codes.InvalidArgument,
+		},
+		{
+			name: "book not found",
+			ctx:  adminCtx,
+			req:  &pb.ListStockMovementsRequest{BookId: "book-1"},
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("ListStockMovements", mock.Anything, "book-1", 0, "").Return(nil, "", book.ErrBookNotFound)
+			},
+			statusCode: codes.NotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.BookService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewBookHandler(mockSvc, nil)
+
+			got, err := h.ListStockMovements(tt.ctx, tt.req)
+
+			assertStatus(t, err, tt.statusCode)
+			if tt.statusCode == codes.OK {
+				assert.Equal(t, []*pb.StockMovement{movement.ToProto()}, got.GetMovements())
+				assert.Equal(t, "next", got.GetNextPageToken())
+			}
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBookHandler_ReconcileStock(t *testing.T) {
+	drift := &domain.StockDrift{BookID: "book-1", Title: "Dune", Stock: 5, LedgerStock: 3}
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		mockSetup  func(svc *mocks.BookService)
+		statusCode codes.Code
+	}{
+		{
+			name: "admin reconciles",
+			ctx:  adminCtx,
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("ReconcileStock", mock.Anything, "").Return([]*domain.StockDrift{drift}, int64(12), nil)
+			},
+		},
+		{
+			name:       "operation user",
+			ctx:        ownerCtx,
+			statusCode: codes.PermissionDenied,
+		},
+		{
+			name: "internal error",
+			ctx:  adminCtx,
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("ReconcileStock", mock.Anything, "").Return(nil, int64(0), errors.New("db error"))
+			},
+			statusCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.BookService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewBookHandler(mockSvc, nil)
+
+			got, err := h.ReconcileStock(tt.ctx, &pb.ReconcileStockRequest{})
+
+			assertStatus(t, err, tt.statusCode)
+			if tt.statusCode == codes.OK {
+				assert.Equal(t, int32(12), got.GetBooksChecked())
+				if assert.Len(t, got.GetDrifts(), 1) {
+					assert.Equal(t, int32(2), got.GetDrifts()[0].GetDrift())
+				}
+			}
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBookHandler_ListDeletedBooks(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		req        *commonPb.ListDeletedRequest
+		mockSetup  func(svc *mocks.BookService)
+		statusCode codes.Code
+	}{
+		{
+			name: "admin lists",
+			ctx:  adminCtx,
+			req:  &commonPb.ListDeletedRequest{PageSize: 10},
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("ListDeletedBooks", mock.Anything, 10, "").Return([]*domain.Book{testBook}, "next", nil)
+			},
+		},
+		{
+			name:       "operation user",
+			ctx:        ownerCtx,
+			req:        &commonPb.ListDeletedRequest{},
+			statusCode: codes.PermissionDenied,
+		},
+		{
+			name:       "page size too large",
+			ctx:        adminCtx,
+			req:        &commonPb.ListDeletedRequest{PageSize: 101},
+			statusCode: codes.InvalidArgument,
+		},
+		{
+			name: "invalid page token",
+			ctx:  adminCtx,
+			req:  &commonPb.ListDeletedRequest{PageToken: "bad"},
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("ListDeletedBooks", mock.Anything, 0, "bad").Return(nil, "", book.ErrInvalidPageToken)
+			},
+			statusCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.BookService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewBookHandler(mockSvc, nil)
+
+			got, err := h.ListDeletedBooks(tt.ctx, tt.req)
+
+			assertStatus(t, err, tt.statusCode)
+			if tt.statusCode == codes.OK {
+				assert.Equal(t, []*pb.BookResponse{testBook.ToProto()}, got.GetBooks())
+				assert.Equal(t, "next", got.GetNextPageToken())
+			}
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBookHandler_RestoreBook(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		mockSetup  func(svc *mocks.BookService)
+		statusCode codes.Code
+	}{
+		{
+			name: "admin restores",
+			ctx:  adminCtx,
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("RestoreBook", mock.Anything, "book-1").Return(testBook, nil)
+			},
+		},
+		{
+			name:       "operation user",
+			ctx:        ownerCtx,
+			statusCode: codes.PermissionDenied,
+		},
+		{
+			name: "not deleted",
+			ctx:  adminCtx,
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("RestoreBook", mock.Anything, "book-1").Return(nil, book.ErrBookNotFound)
+			},
+			statusCode: codes.NotFound,
+		},
+		{
+			name: "isbn taken since",
+			ctx:  adminCtx,
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("RestoreBook", mock.Anything, "book-1").Return(nil, book.ErrDuplicateISBN)
+			},
+			statusCode: codes.AlreadyExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.BookService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewBookHandler(mockSvc, nil)
+
+			got, err := h.RestoreBook(tt.ctx, &pb.RestoreBookRequest{Id: "book-1"})
+
+			assertStatus(t, err, tt.statusCode)
+			if tt.statusCode == codes.OK {
+				assert.Equal(t, "book-1", got.GetId())
+			}
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBookHandler_PurgeDeletedBooks(t *testing.T) {
+	before := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		req        *commonPb.PurgeDeletedRequest
+		mockSetup  func(svc *mocks.BookService)
+		statusCode codes.Code
+	}{
+		{
+			name: "admin purges",
+			ctx:  adminCtx,
+			req:  &commonPb.PurgeDeletedRequest{RetentionDays: 30},
+			mockSetup: func(svc *mocks.BookService) {
+				svc.On("PurgeDeletedBooks", mock.Anything, 30*24*time.Hour).Return(int64(2), before, nil)
+			},
+		},
+		{
+			name:       "operation user",
+			ctx:        ownerCtx,
+			req:        &commonPb.PurgeDeletedRequest{RetentionDays: 30},
+			statusCode: codes.PermissionDenied,
+		},
+		{
+			name:       "missing retention",
+			ctx:        adminCtx,
+			req:        &commonPb.PurgeDeletedRequest{},
+			statusCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.BookService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewBookHandler(mockSvc, nil)
+
+			got, err := h.PurgeDeletedBooks(tt.ctx, tt.req)
+
+			assertStatus(t, err, tt.statusCode)
+			if tt.statusCode == codes.OK {
+				assert.Equal(t, int64(2), got.GetPurged())
+				assert.Equal(t, "2025-03-01T00:00:00Z", got.GetDeletedBefore())
+			}
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
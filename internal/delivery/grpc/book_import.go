@@ -0,0 +1,135 @@
+package grpc
+
+import (
+	"errors"
+	"strings"
+
+	pb "github.com/hinha/library-management-synapsis/gen/api/proto/book"
+	commonPb "github.com/hinha/library-management-synapsis/gen/api/proto/common"
+	entity "github.com/hinha/library-management-synapsis/internal/domain"
+	domain "github.com/hinha/library-management-synapsis/internal/domain/book"
+	"github.com/hinha/library-management-synapsis/pkg/validator"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+)
+
+// ImportBooks handles a catalogue import streamed by an admin. Admins are
+// checked by the stream interceptor.
+func (h *BookHandler) ImportBooks(stream pb.BookService_ImportBooksServer) error {
+	first, err := stream.Recv()
+	if errors.Is(err, io.EOF) {
+		return status.Error(codes.InvalidArgument, "missing import options")
+	}
+	if err != nil {
+		return err
+	}
+	options := first.GetOptions()
+	if options == nil {
+		return status.Error(codes.InvalidArgument, "the first message must carry the import options")
+	}
+	if err := validator.ValidateStruct(options); err != nil {
+		return err
+	}
+
+	data := &importStream{stream: stream, buf: first.GetData()}
+	var records entity.ImportReader
+	switch options.GetFormat() {
+	case "csv":
+		records, err = domain.NewCSVImportReader(data, options.GetColumns(), options.GetDefaultStock())
+	case "marc21":
+		records = domain.NewMARCImportReader(data, options.GetDefaultStock())
+	}
+	if err != nil {
+		return importError(err)
+	}
+
+	report, err := h.service.ImportBooks(withStockOrigin(stream.Context(), ""), validatedRecords{records}, int(options.GetBatchSize()), options.GetDryRun())
+	if err != nil {
+		return importError(err)
+	}
+
+	return stream.SendAndClose(report.ToProto())
+}
+
+// importError maps an error that stopped an import
+func importError(err error) error {
+	if errors.Is(err, domain.ErrInvalidImport) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	// Errors receiving the file are already statuses
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	log.Error().Err(err).Msg("Failed to import books")
+	return status.Error(codes.Internal, "failed to import books")
+}
+
+// importStream reads the file chunks of an ImportBooks stream
+type importStream struct {
+	stream pb.BookService_ImportBooksServer
+	buf    []byte
+}
+
+// Read implements io.Reader, returning io.EOF once the client closes the stream
+func (s *importStream) Read(p []byte) (int, error) {
+	for len(s.buf) == 0 {
+		req, err := s.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		s.buf = req.GetData()
+	}
+	n := copy(p, s.buf)
+	s.buf = s.buf[n:]
+	return n, nil
+}
+
+// validatedRecords rejects the import records that a CreateBookRequest with
+// the same fields would fail validation for
+type validatedRecords struct {
+	entity.ImportReader
+}
+
+// Next reads and validates the next record
+func (v validatedRecords) Next() (*entity.ImportRecord, error) {
+	record, err := v.ImportReader.Next()
+	if err != nil || record.Err != nil {
+		return record, err
+	}
+
+	req := &pb.CreateBookRequest{
+		Title:           record.Title,
+		Author:          record.Author,
+		Category:        record.Category,
+		Stock:           record.Stock,
+		Isbn:            record.ISBN,
+		Publisher:       record.Publisher,
+		PublicationYear: record.PublicationYear,
+		Language:        record.Language,
+		PageCount:       record.PageCount,
+		Edition:         record.Edition,
+		Description:     record.Description,
+	}
+	if err := validator.ValidateStruct(req); err != nil {
+		record.Err = errors.New(validationReason(err))
+	}
+	return record, nil
+}
+
+// validationReason lists the fields a validation error complains about,
+// e.g. "Title failed on 'required' validation"
+func validationReason(err error) string {
+	st := status.Convert(err)
+	var reasons []string
+	for _, detail := range st.Details() {
+		if field, ok := detail.(*commonPb.FieldValidationError); ok {
+			reasons = append(reasons, field.GetField()+" "+field.GetMessage())
+		}
+	}
+	if len(reasons) == 0 {
+		return st.Message()
+	}
+	return strings.Join(reasons, "; ")
+}
@@ -0,0 +1,89 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	pb "github.com/hinha/library-management-synapsis/gen/api/proto/book"
+	"github.com/hinha/library-management-synapsis/internal/delivery/middleware"
+	entity "github.com/hinha/library-management-synapsis/internal/domain"
+	domain "github.com/hinha/library-management-synapsis/internal/domain/book"
+	"github.com/hinha/library-management-synapsis/pkg/validator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListStockMovements handles listing one page of a book's stock ledger
+func (h *BookHandler) ListStockMovements(ctx context.Context, req *pb.ListStockMovementsRequest) (*pb.ListStockMovementsResponse, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := middleware.AuthorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	movements, nextPageToken, err := h.service.ListStockMovements(ctx, req.GetBookId(), int(req.GetPageSize()), req.GetPageToken())
+	if err != nil {
+		return nil, ledgerError(err, "failed to list stock movements")
+	}
+
+	response := &pb.ListStockMovementsResponse{
+		Movements:     make([]*pb.StockMovement, len(movements)),
+		NextPageToken: nextPageToken,
+	}
+
+	for i, movement := range movements {
+		response.Movements[i] = movement.ToProto()
+	}
+
+	return response, nil
+}
+
+// ReconcileStock handles checking the stock of the books against their ledger
+func (h *BookHandler) ReconcileStock(ctx context.Context, req *pb.ReconcileStockRequest) (*pb.ReconcileStockResponse, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := middleware.AuthorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	drifts, checked, err := h.service.ReconcileStock(ctx, req.GetBookId())
+	if err != nil {
+		return nil, ledgerError(err, "failed to reconcile stock")
+	}
+
+	response := &pb.ReconcileStockResponse{
+		Drifts:       make([]*pb.StockDrift, len(drifts)),
+		BooksChecked: int32(checked),
+	}
+
+	for i, drift := range drifts {
+		response.Drifts[i] = drift.ToProto()
+	}
+
+	return response, nil
+}
+
+func ledgerError(err error, msg string) error {
+	switch {
+	case errors.Is(err, domain.ErrBookNotFound):
+		return status.Error(codes.NotFound, "book not found")
+	case errors.Is(err, domain.ErrInvalidPageToken):
+		return status.Error(codes.InvalidArgument, "invalid page token")
+	case errors.Is(err, domain.ErrInvalidInput):
+		return status.Error(codes.InvalidArgument, "invalid input")
+	default:
+		return status.Error(codes.Internal, msg)
+	}
+}
+
+// withStockOrigin attributes the stock movements written while serving the
+// call to the user it is made for and, when not empty, to the correlated transaction
+func withStockOrigin(ctx context.Context, correlationID string) context.Context {
+	origin := entity.StockOrigin{CorrelationID: correlationID}
+	if principal, ok := middleware.PrincipalFromContext(ctx); ok {
+		origin.ActorID = principal.ActorID()
+	}
+	return entity.WithStockOrigin(ctx, origin)
+}
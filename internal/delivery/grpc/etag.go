@@ -0,0 +1,53 @@
+package grpc
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// etagHeader is the response header carrying the version of a book or user.
+// The gateway forwards it as the ETag header.
+const etagHeader = "etag"
+
+// setETag sends version as the ETag of the book or user a call returns
+func setETag(ctx context.Context, version int64) {
+	// Only fails outside of a gRPC call, where there is no header to set
+	_ = grpc.SetHeader(ctx, metadata.Pairs(etagHeader, strconv.Quote(strconv.FormatInt(version, 10))))
+}
+
+// requestVersion returns the version an update is based on: version when it
+// is set, otherwise the If-Match header sent by gRPC clients or forwarded by
+// the gateway. Updates without either are rejected.
+func requestVersion(ctx context.Context, version int64) (int64, error) {
+	if version > 0 {
+		return version, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("if-match")
+	if len(values) == 0 {
+		values = md.Get(runtime.MetadataPrefix + "if-match")
+	}
+	if len(values) == 0 {
+		return 0, status.Error(codes.InvalidArgument, "version or If-Match header is required")
+	}
+
+	// Versions are compared strongly, a weak ETag only matches the same version
+	etag := strings.TrimPrefix(strings.TrimSpace(values[0]), "W/")
+	unquoted, err := strconv.Unquote(etag)
+	if err != nil {
+		return 0, status.Error(codes.InvalidArgument, "invalid If-Match header")
+	}
+	version, err = strconv.ParseInt(unquoted, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, status.Error(codes.InvalidArgument, "invalid If-Match header")
+	}
+	return version, nil
+}
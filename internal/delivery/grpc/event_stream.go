@@ -0,0 +1,40 @@
+package grpc
+
+import (
+	commonPb "github.com/hinha/library-management-synapsis/gen/api/proto/common"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"github.com/hinha/library-management-synapsis/internal/infrastructure/outbox"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// eventStream is the server side of a SubscribeEvents stream
+type eventStream interface {
+	grpc.ServerStream
+	Send(*commonPb.Event) error
+}
+
+// streamEvents sends events from feed to stream until the client goes away.
+// The headers go out first, so a subscriber knows it was let in before the
+// first event arrives.
+func streamEvents(feed *outbox.Feed, req *commonPb.SubscribeEventsRequest, stream eventStream) error {
+	if err := stream.SendHeader(nil); err != nil {
+		return err
+	}
+
+	var sendErr error
+	err := feed.Subscribe(stream.Context(), req.GetAfter(), req.GetTypes(), func(event *domain.OutboxEvent) error {
+		sendErr = stream.Send(event.ToProto())
+		return sendErr
+	})
+	if err != nil {
+		if err == sendErr {
+			return err
+		}
+		log.Error().Err(err).Msg("Failed to read outbox events")
+		return status.Error(codes.Internal, "failed to read events")
+	}
+	return nil
+}
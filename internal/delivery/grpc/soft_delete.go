@@ -0,0 +1,19 @@
+package grpc
+
+import (
+	"time"
+
+	commonPb "github.com/hinha/library-management-synapsis/gen/api/proto/common"
+)
+
+// retentionWindow returns how long ago records must have been deleted to be purged
+func retentionWindow(req *commonPb.PurgeDeletedRequest) time.Duration {
+	return time.Duration(req.GetRetentionDays()) * 24 * time.Hour
+}
+
+func purgeResponse(purged int64, before time.Time) *commonPb.PurgeDeletedResponse {
+	return &commonPb.PurgeDeletedResponse{
+		Purged:        purged,
+		DeletedBefore: before.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,91 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"github.com/hinha/library-management-synapsis/pkg/validator"
+
+	pb "github.com/hinha/library-management-synapsis/gen/api/proto/transaction"
+	"github.com/hinha/library-management-synapsis/internal/delivery/middleware"
+	domain "github.com/hinha/library-management-synapsis/internal/domain/transaction"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Statuses of a batch item
+const (
+	batchStatusBorrowed = "borrowed"
+	batchStatusReturned = "returned"
+	batchStatusFailed   = "failed"
+)
+
+// BatchBorrow checks out a basket of books for one user. Items fail on their
+// own; a user who may not borrow the basket fails the whole call.
+func (h *TransactionHandler) BatchBorrow(ctx context.Context, req *pb.BatchBorrowRequest) (*pb.BatchResponse, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := middleware.AuthorizeOwner(ctx, req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	items := make([]domain.BatchItem, len(req.GetItems()))
+	for i, item := range req.GetItems() {
+		items[i] = domain.BatchItem{BookID: item.GetBookId(), Barcode: item.GetBarcode()}
+	}
+
+	outcomes, err := h.service.BatchBorrow(ctx, req.GetUserId(), items)
+	if err != nil {
+		return nil, borrowError(err)
+	}
+
+	return batchResponse(outcomes, batchStatusBorrowed, borrowError), nil
+}
+
+// BatchReturn checks in a basket of books handed back by one user. Items
+// fail on their own, including items that match no open loan of the user.
+func (h *TransactionHandler) BatchReturn(ctx context.Context, req *pb.BatchReturnRequest) (*pb.BatchResponse, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := middleware.AuthorizeOwner(ctx, req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	items := make([]domain.BatchItem, len(req.GetItems()))
+	for i, item := range req.GetItems() {
+		items[i] = domain.BatchItem{TransactionID: item.GetTransactionId(), BookID: item.GetBookId(), Barcode: item.GetBarcode()}
+	}
+
+	outcomes, err := h.service.BatchReturn(ctx, req.GetUserId(), items)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			return nil, status.Error(codes.InvalidArgument, "invalid input")
+		}
+		return nil, status.Error(codes.Internal, "failed to return books")
+	}
+
+	return batchResponse(outcomes, batchStatusReturned, returnError), nil
+}
+
+// batchResponse reports the outcome of every item of a basket, with the
+// gRPC status toStatus maps the error of a failed item to
+func batchResponse(outcomes []*domain.BatchOutcome, succeeded string, toStatus func(error) error) *pb.BatchResponse {
+	response := &pb.BatchResponse{Results: make([]*pb.BatchItemResult, len(outcomes))}
+	for i, outcome := range outcomes {
+		result := &pb.BatchItemResult{Index: int32(i)}
+		if outcome.Err != nil {
+			st := status.Convert(toStatus(outcome.Err))
+			result.Status = batchStatusFailed
+			result.Code = st.Code().String()
+			result.Reason = st.Message()
+			response.Failed++
+		} else {
+			result.Status = succeeded
+			result.Transaction = outcome.Transaction.ToProto()
+			response.Succeeded++
+		}
+		response.Results[i] = result
+	}
+	return response
+}
@@ -0,0 +1,99 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	commonPb "github.com/hinha/library-management-synapsis/gen/api/proto/common"
+	pb "github.com/hinha/library-management-synapsis/gen/api/proto/transaction"
+	"github.com/hinha/library-management-synapsis/internal/delivery/middleware"
+	"github.com/hinha/library-management-synapsis/internal/domain/book"
+	domain "github.com/hinha/library-management-synapsis/internal/domain/transaction"
+	"github.com/hinha/library-management-synapsis/internal/domain/user"
+	"github.com/hinha/library-management-synapsis/pkg/validator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListDeletedTransactions handles listing one page of the soft-deleted transactions
+func (h *TransactionHandler) ListDeletedTransactions(ctx context.Context, req *commonPb.ListDeletedRequest) (*pb.ListDeletedTransactionsResponse, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := middleware.AuthorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	transactions, nextPageToken, err := h.service.ListDeletedTransactions(ctx, int(req.GetPageSize()), req.GetPageToken())
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidPageToken) {
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
+		return nil, status.Error(codes.Internal, "failed to list deleted transactions")
+	}
+
+	response := &pb.ListDeletedTransactionsResponse{
+		Transactions:  make([]*pb.TransactionResponse, len(transactions)),
+		NextPageToken: nextPageToken,
+	}
+
+	for i, transaction := range transactions {
+		response.Transactions[i] = transaction.ToProto()
+	}
+
+	return response, nil
+}
+
+// RestoreTransaction handles bringing a soft-deleted transaction back
+func (h *TransactionHandler) RestoreTransaction(ctx context.Context, req *pb.RestoreTransactionRequest) (*pb.TransactionResponse, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := middleware.AuthorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	transaction, err := h.service.RestoreTransaction(ctx, req.GetId())
+	if err != nil {
+		var limitErr *domain.LimitError
+		switch {
+		case errors.As(err, &limitErr):
+			return nil, validator.NewPreconditionGRPCError(limitErr.Reason, limitErr.Error(), limitErr.Metadata)
+		case errors.Is(err, domain.ErrInvalidInput):
+			return nil, status.Error(codes.InvalidArgument, "invalid input")
+		case errors.Is(err, domain.ErrTransactionNotFound):
+			return nil, status.Error(codes.NotFound, "deleted transaction not found")
+		case errors.Is(err, user.ErrUserNotFound):
+			return nil, status.Error(codes.NotFound, "user not found")
+		case errors.Is(err, book.ErrBookNotFound):
+			return nil, status.Error(codes.NotFound, "book not found")
+		case errors.Is(err, domain.ErrBookNotAvailable):
+			return nil, status.Error(codes.FailedPrecondition, "book not available")
+		default:
+			return nil, status.Error(codes.Internal, "failed to restore transaction")
+		}
+	}
+
+	return transaction.ToProto(), nil
+}
+
+// PurgeDeletedTransactions handles permanently removing transactions
+// deleted longer ago than the retention window
+func (h *TransactionHandler) PurgeDeletedTransactions(ctx context.Context, req *commonPb.PurgeDeletedRequest) (*commonPb.PurgeDeletedResponse, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := middleware.AuthorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	purged, before, err := h.service.PurgeDeletedTransactions(ctx, retentionWindow(req))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			return nil, status.Error(codes.InvalidArgument, "invalid input")
+		}
+		return nil, status.Error(codes.Internal, "failed to purge deleted transactions")
+	}
+
+	return purgeResponse(purged, before), nil
+}
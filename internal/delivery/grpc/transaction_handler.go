@@ -5,23 +5,30 @@ import (
 	"errors"
 	"github.com/hinha/library-management-synapsis/pkg/validator"
 
+	commonPb "github.com/hinha/library-management-synapsis/gen/api/proto/common"
 	pb "github.com/hinha/library-management-synapsis/gen/api/proto/transaction"
+	"github.com/hinha/library-management-synapsis/internal/delivery/middleware"
 	"github.com/hinha/library-management-synapsis/internal/domain/book"
 	domain "github.com/hinha/library-management-synapsis/internal/domain/transaction"
+	"github.com/hinha/library-management-synapsis/internal/domain/user"
+	"github.com/hinha/library-management-synapsis/internal/infrastructure/outbox"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"time"
 )
 
 // TransactionHandler implements the TransactionService gRPC interface
 type TransactionHandler struct {
 	pb.TransactionServiceServer
 	service domain.Service
+	feed    *outbox.Feed
 }
 
 // NewTransactionHandler creates a new TransactionHandler
-func NewTransactionHandler(service domain.Service) *TransactionHandler {
+func NewTransactionHandler(service domain.Service, feed *outbox.Feed) *TransactionHandler {
 	return &TransactionHandler{
 		service: service,
+		feed:    feed,
 	}
 }
 
@@ -30,31 +37,74 @@ func (h *TransactionHandler) Borrow(ctx context.Context, req *pb.BorrowRequest)
 	if err := validator.ValidateStruct(req); err != nil {
 		return nil, err
 	}
+	if err := middleware.AuthorizeOwner(ctx, req.GetUserId()); err != nil {
+		return nil, err
+	}
 
-	transaction, err := h.service.BorrowBook(ctx, req.GetUserId(), req.GetBookId())
+	transaction, err := h.service.BorrowBook(ctx, req.GetUserId(), req.GetBookId(), req.GetBarcode())
 	if err != nil {
-		switch {
-		case errors.Is(err, domain.ErrInvalidInput):
-			return nil, status.Error(codes.InvalidArgument, "invalid input")
-		case errors.Is(err, book.ErrBookNotFound):
-			return nil, status.Error(codes.NotFound, "book not found")
-		case errors.Is(err, domain.ErrBookNotAvailable):
-			return nil, status.Error(codes.FailedPrecondition, "book not available")
-		default:
-			return nil, status.Error(codes.Internal, "failed to borrow book")
-		}
+		return nil, borrowError(err)
 	}
 
 	return transaction.ToProto(), nil
 }
 
-// Return handles book returning
+// Return handles book returning, by transaction ID, by the barcode of the
+// copy being handed back or by user and book
 func (h *TransactionHandler) Return(ctx context.Context, req *pb.ReturnRequest) (*pb.TransactionResponse, error) {
 	if err := validator.ValidateStruct(req); err != nil {
 		return nil, err
 	}
 
-	transaction, err := h.service.ReturnBook(ctx, req.GetTransactionId())
+	transactionID := req.GetTransactionId()
+	switch {
+	case transactionID != "":
+		if err := h.authorizeTransaction(ctx, transactionID); err != nil {
+			return nil, err
+		}
+	case req.GetBarcode() != "":
+		// Whoever holds a copy may hand it back, but the loan on it is only
+		// disclosed to its borrower and to admins
+		loan, err := h.service.GetOpenLoanByBarcode(ctx, req.GetBarcode())
+		if err != nil {
+			return nil, openLoanError(err, "no open loan for this copy")
+		}
+		if err := middleware.AuthorizeOwner(ctx, loan.UserID); err != nil {
+			if status.Code(err) == codes.PermissionDenied {
+				return nil, status.Error(codes.NotFound, "no open loan for this copy")
+			}
+			return nil, err
+		}
+		transactionID = loan.ID
+	default:
+		if err := middleware.AuthorizeOwner(ctx, req.GetUserId()); err != nil {
+			return nil, err
+		}
+		loan, err := h.service.GetOpenLoanByBook(ctx, req.GetUserId(), req.GetBookId())
+		if err != nil {
+			return nil, openLoanError(err, "no open loan of this book for the user")
+		}
+		transactionID = loan.ID
+	}
+
+	transaction, err := h.service.ReturnBook(ctx, transactionID)
+	if err != nil {
+		return nil, returnError(err)
+	}
+
+	return transaction.ToProto(), nil
+}
+
+// Renew handles loan renewal
+func (h *TransactionHandler) Renew(ctx context.Context, req *pb.RenewRequest) (*pb.TransactionResponse, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := h.authorizeTransaction(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	transaction, err := h.service.RenewLoan(ctx, req.GetId())
 	if err != nil {
 		switch {
 		case errors.Is(err, domain.ErrInvalidInput):
@@ -63,8 +113,16 @@ func (h *TransactionHandler) Return(ctx context.Context, req *pb.ReturnRequest)
 			return nil, status.Error(codes.NotFound, "transaction not found")
 		case errors.Is(err, domain.ErrAlreadyReturned):
 			return nil, status.Error(codes.FailedPrecondition, "book already returned")
+		case errors.Is(err, domain.ErrRenewalLimitReached):
+			return nil, status.Error(codes.FailedPrecondition, "renewal limit reached")
+		case errors.Is(err, domain.ErrOverdueBeyondGrace):
+			return nil, status.Error(codes.FailedPrecondition, "loan is overdue beyond the grace period")
+		case errors.Is(err, domain.ErrBookOnHold):
+			return nil, status.Error(codes.FailedPrecondition, "another patron is waiting for this book")
+		case errors.Is(err, domain.ErrConcurrentUpdate):
+			return nil, status.Error(codes.Aborted, "loan was modified concurrently, retry")
 		default:
-			return nil, status.Error(codes.Internal, "failed to return book")
+			return nil, status.Error(codes.Internal, "failed to renew loan")
 		}
 	}
 
@@ -76,9 +134,19 @@ func (h *TransactionHandler) History(ctx context.Context, req *pb.HistoryRequest
 	if err := validator.ValidateStruct(req); err != nil {
 		return nil, err
 	}
+	if err := middleware.AuthorizeOwner(ctx, req.GetUserId()); err != nil {
+		return nil, err
+	}
 
-	transactions, err := h.service.GetUserHistory(ctx, req.GetUserId())
+	filter, err := transactionFilter(req)
+	if err != nil {
+		return nil, err
+	}
+	transactions, nextPageToken, err := h.service.GetUserHistory(ctx, req.GetUserId(), filter, int(req.GetPageSize()), req.GetPageToken())
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidPageToken) {
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
 		if errors.Is(err, domain.ErrInvalidInput) {
 			return nil, status.Error(codes.InvalidArgument, "invalid input")
 		}
@@ -86,7 +154,8 @@ func (h *TransactionHandler) History(ctx context.Context, req *pb.HistoryRequest
 	}
 
 	response := &pb.HistoryResponse{
-		Transactions: make([]*pb.TransactionResponse, len(transactions)),
+		Transactions:  make([]*pb.TransactionResponse, len(transactions)),
+		NextPageToken: nextPageToken,
 	}
 
 	for i, transaction := range transactions {
@@ -96,6 +165,433 @@ func (h *TransactionHandler) History(ctx context.Context, req *pb.HistoryRequest
 	return response, nil
 }
 
+// ListTransactions handles listing the transactions of every user
+func (h *TransactionHandler) ListTransactions(ctx context.Context, req *pb.ListTransactionsRequest) (*pb.ListTransactionsResponse, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := middleware.AuthorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	filter, err := transactionFilter(req)
+	if err != nil {
+		return nil, err
+	}
+	filter.UserID = req.GetUserId()
+	filter.BookID = req.GetBookId()
+
+	transactions, nextPageToken, err := h.service.ListTransactions(ctx, filter, int(req.GetPageSize()), req.GetPageToken())
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidPageToken) {
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
+		if errors.Is(err, domain.ErrInvalidInput) {
+			return nil, status.Error(codes.InvalidArgument, "invalid input")
+		}
+		return nil, status.Error(codes.Internal, "failed to list transactions")
+	}
+
+	response := &pb.ListTransactionsResponse{
+		Transactions:  make([]*pb.TransactionResponse, len(transactions)),
+		NextPageToken: nextPageToken,
+	}
+
+	for i, transaction := range transactions {
+		response.Transactions[i] = transaction.ToProto()
+	}
+
+	return response, nil
+}
+
+// ListOverdue handles listing overdue loans
+func (h *TransactionHandler) ListOverdue(ctx context.Context, _ *pb.ListOverdueRequest) (*pb.ListOverdueResponse, error) {
+	transactions, err := h.service.ListOverdue(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list overdue loans")
+	}
+
+	now := time.Now()
+	response := &pb.ListOverdueResponse{
+		Loans: make([]*pb.OverdueLoan, len(transactions)),
+	}
+
+	for i, transaction := range transactions {
+		response.Loans[i] = &pb.OverdueLoan{
+			Transaction: transaction.ToProto(),
+			DaysLate:    transaction.DaysLate(now),
+		}
+	}
+
+	return response, nil
+}
+
+// PlaceHold handles placing a hold on a book
+func (h *TransactionHandler) PlaceHold(ctx context.Context, req *pb.PlaceHoldRequest) (*pb.HoldResponse, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := middleware.AuthorizeOwner(ctx, req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	hold, err := h.service.PlaceHold(ctx, req.GetUserId(), req.GetBookId())
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidInput):
+			return nil, status.Error(codes.InvalidArgument, "invalid input")
+		case errors.Is(err, user.ErrUserNotFound):
+			return nil, status.Error(codes.NotFound, "user not found")
+		case errors.Is(err, book.ErrBookNotFound):
+			return nil, status.Error(codes.NotFound, "book not found")
+		case errors.Is(err, domain.ErrHoldExists):
+			return nil, status.Error(codes.AlreadyExists, "hold already exists")
+		case errors.Is(err, domain.ErrBookAvailable):
+			return nil, status.Error(codes.FailedPrecondition, "book is available to borrow")
+		default:
+			return nil, status.Error(codes.Internal, "failed to place hold")
+		}
+	}
+
+	return hold.ToProto(), nil
+}
+
+// CancelHold handles cancelling a hold
+func (h *TransactionHandler) CancelHold(ctx context.Context, req *pb.CancelHoldRequest) (*pb.HoldResponse, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := h.authorizeHold(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	hold, err := h.service.CancelHold(ctx, req.GetId())
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidInput):
+			return nil, status.Error(codes.InvalidArgument, "invalid input")
+		case errors.Is(err, domain.ErrHoldNotFound):
+			return nil, status.Error(codes.NotFound, "hold not found")
+		case errors.Is(err, domain.ErrHoldNotActive):
+			return nil, status.Error(codes.FailedPrecondition, "hold is no longer active")
+		default:
+			return nil, status.Error(codes.Internal, "failed to cancel hold")
+		}
+	}
+
+	return hold.ToProto(), nil
+}
+
+// ListHolds handles listing the holds of a user or a book
+func (h *TransactionHandler) ListHolds(ctx context.Context, req *pb.ListHoldsRequest) (*pb.ListHoldsResponse, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+
+	// Patrons only see their own holds, also when asking for a book's queue
+	userID := req.GetUserId()
+	if principal, ok := middleware.PrincipalFromContext(ctx); ok && !principal.IsAdmin() && userID == "" {
+		userID = principal.UserID
+	}
+	if err := middleware.AuthorizeOwner(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	holds, err := h.service.ListHolds(ctx, userID, req.GetBookId())
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			return nil, status.Error(codes.InvalidArgument, "invalid input")
+		}
+		return nil, status.Error(codes.Internal, "failed to list holds")
+	}
+
+	response := &pb.ListHoldsResponse{
+		Holds: make([]*pb.HoldResponse, len(holds)),
+	}
+
+	for i, hold := range holds {
+		response.Holds[i] = hold.ToProto()
+	}
+
+	return response, nil
+}
+
+// GetBalance handles retrieving what a user owes in fines
+func (h *TransactionHandler) GetBalance(ctx context.Context, req *pb.GetBalanceRequest) (*pb.BalanceResponse, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := middleware.AuthorizeOwner(ctx, req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	balance, err := h.service.GetBalance(ctx, req.GetUserId())
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			return nil, status.Error(codes.InvalidArgument, "invalid input")
+		}
+		return nil, status.Error(codes.Internal, "failed to get balance")
+	}
+
+	return &pb.BalanceResponse{
+		UserId:  req.GetUserId(),
+		Balance: balance,
+	}, nil
+}
+
+// ListLedger handles listing a user's fines ledger
+func (h *TransactionHandler) ListLedger(ctx context.Context, req *pb.ListLedgerRequest) (*pb.ListLedgerResponse, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := middleware.AuthorizeOwner(ctx, req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	entries, balance, err := h.service.ListLedger(ctx, req.GetUserId())
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			return nil, status.Error(codes.InvalidArgument, "invalid input")
+		}
+		return nil, status.Error(codes.Internal, "failed to list ledger")
+	}
+
+	response := &pb.ListLedgerResponse{
+		Entries: make([]*pb.LedgerEntryResponse, len(entries)),
+		Balance: balance,
+	}
+
+	for i, entry := range entries {
+		response.Entries[i] = entry.ToProto()
+	}
+
+	return response, nil
+}
+
+// RecordPayment handles recording a fine payment
+func (h *TransactionHandler) RecordPayment(ctx context.Context, req *pb.RecordPaymentRequest) (*pb.LedgerEntryResponse, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := middleware.AuthorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	entry, err := h.service.RecordPayment(ctx, req.GetUserId(), req.GetAmount(), req.GetNote())
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidInput):
+			return nil, status.Error(codes.InvalidArgument, "invalid input")
+		case errors.Is(err, domain.ErrExceedsBalance):
+			return nil, status.Error(codes.FailedPrecondition, "amount exceeds outstanding balance")
+		default:
+			return nil, status.Error(codes.Internal, "failed to record payment")
+		}
+	}
+
+	return entry.ToProto(), nil
+}
+
+// WaiveFine handles waiving part of a user's fines
+func (h *TransactionHandler) WaiveFine(ctx context.Context, req *pb.WaiveFineRequest) (*pb.LedgerEntryResponse, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := middleware.AuthorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	entry, err := h.service.WaiveFine(ctx, req.GetUserId(), req.GetAmount(), req.GetReason())
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidInput):
+			return nil, status.Error(codes.InvalidArgument, "invalid input")
+		case errors.Is(err, domain.ErrExceedsBalance):
+			return nil, status.Error(codes.FailedPrecondition, "amount exceeds outstanding balance")
+		default:
+			return nil, status.Error(codes.Internal, "failed to waive fine")
+		}
+	}
+
+	return entry.ToProto(), nil
+}
+
+// BlockBorrower handles blocking a user from borrowing
+func (h *TransactionHandler) BlockBorrower(ctx context.Context, req *pb.BlockBorrowerRequest) (*pb.BorrowerBlockResponse, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := middleware.AuthorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	var expiresAt *time.Time
+	if req.GetExpiresAt() != "" {
+		parsed, err := time.Parse(time.RFC3339, req.GetExpiresAt())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid expires_at")
+		}
+		expiresAt = &parsed
+	}
+
+	block, err := h.service.BlockBorrower(ctx, req.GetUserId(), req.GetReason(), expiresAt)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidInput):
+			return nil, status.Error(codes.InvalidArgument, "invalid input")
+		case errors.Is(err, user.ErrUserNotFound):
+			return nil, status.Error(codes.NotFound, "user not found")
+		default:
+			return nil, status.Error(codes.Internal, "failed to block borrower")
+		}
+	}
+
+	return block.ToProto(), nil
+}
+
+// UnblockBorrower handles lifting a user's borrowing block
+func (h *TransactionHandler) UnblockBorrower(ctx context.Context, req *pb.UnblockBorrowerRequest) (*pb.BorrowerBlockResponse, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := middleware.AuthorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	block, err := h.service.UnblockBorrower(ctx, req.GetUserId())
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidInput):
+			return nil, status.Error(codes.InvalidArgument, "invalid input")
+		case errors.Is(err, domain.ErrBlockNotFound):
+			return nil, status.Error(codes.NotFound, "borrower block not found")
+		default:
+			return nil, status.Error(codes.Internal, "failed to unblock borrower")
+		}
+	}
+
+	return block.ToProto(), nil
+}
+
+// authorizeTransaction lets the call through when the caller borrowed the
+// transaction or is an admin
+func (h *TransactionHandler) authorizeTransaction(ctx context.Context, transactionID string) error {
+	transaction, err := h.service.GetTransaction(ctx, transactionID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidInput):
+			return status.Error(codes.InvalidArgument, "invalid input")
+		case errors.Is(err, domain.ErrTransactionNotFound):
+			return status.Error(codes.NotFound, "transaction not found")
+		default:
+			return status.Error(codes.Internal, "failed to get transaction")
+		}
+	}
+	return middleware.AuthorizeOwner(ctx, transaction.UserID)
+}
+
+// openLoanError maps an error of looking up the open loan being returned to
+// its gRPC status, reporting a missing loan with notFound
+func openLoanError(err error, notFound string) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidInput):
+		return status.Error(codes.InvalidArgument, "invalid input")
+	case errors.Is(err, domain.ErrTransactionNotFound):
+		return status.Error(codes.NotFound, notFound)
+	default:
+		return status.Error(codes.Internal, "failed to find loan")
+	}
+}
+
+// borrowError maps an error of borrowing a book to its gRPC status
+func borrowError(err error) error {
+	var limitErr *domain.LimitError
+	switch {
+	case errors.As(err, &limitErr):
+		return validator.NewPreconditionGRPCError(limitErr.Reason, limitErr.Error(), limitErr.Metadata)
+	case errors.Is(err, domain.ErrInvalidInput):
+		return status.Error(codes.InvalidArgument, "invalid input")
+	case errors.Is(err, user.ErrUserNotFound):
+		return status.Error(codes.NotFound, "user not found")
+	case errors.Is(err, book.ErrBookNotFound):
+		return status.Error(codes.NotFound, "book not found")
+	case errors.Is(err, book.ErrCopyNotFound):
+		return status.Error(codes.NotFound, "copy not found")
+	case errors.Is(err, domain.ErrBookNotAvailable):
+		return status.Error(codes.FailedPrecondition, "book not available")
+	case errors.Is(err, domain.ErrBookOnHold):
+		return status.Error(codes.FailedPrecondition, "book is on hold for another patron")
+	default:
+		return status.Error(codes.Internal, "failed to borrow book")
+	}
+}
+
+// returnError maps an error of returning a book to its gRPC status
+func returnError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidInput):
+		return status.Error(codes.InvalidArgument, "invalid input")
+	case errors.Is(err, domain.ErrTransactionNotFound):
+		return status.Error(codes.NotFound, "transaction not found")
+	case errors.Is(err, domain.ErrAlreadyReturned):
+		return status.Error(codes.FailedPrecondition, "book already returned")
+	case errors.Is(err, domain.ErrNotBorrower):
+		return status.Error(codes.FailedPrecondition, "loan belongs to another user")
+	default:
+		return status.Error(codes.Internal, "failed to return book")
+	}
+}
+
+// authorizeHold lets the call through when the caller placed the hold or is
+// an admin
+func (h *TransactionHandler) authorizeHold(ctx context.Context, holdID string) error {
+	hold, err := h.service.GetHold(ctx, holdID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidInput):
+			return status.Error(codes.InvalidArgument, "invalid input")
+		case errors.Is(err, domain.ErrHoldNotFound):
+			return status.Error(codes.NotFound, "hold not found")
+		default:
+			return status.Error(codes.Internal, "failed to get hold")
+		}
+	}
+	return middleware.AuthorizeOwner(ctx, hold.UserID)
+}
+
 func (h *TransactionHandler) HealthCheck(ctx context.Context, _ *pb.HealthCheckRequest) (*pb.HealthCheckResponse, error) {
 	return h.service.Health(ctx)
 }
+
+// SubscribeEvents streams transaction events to admins
+func (h *TransactionHandler) SubscribeEvents(req *commonPb.SubscribeEventsRequest, stream pb.TransactionService_SubscribeEventsServer) error {
+	return streamEvents(h.feed, req, stream)
+}
+
+// transactionFilterRequest is a request carrying the filters of a
+// transaction listing
+type transactionFilterRequest interface {
+	GetStatus() string
+	GetBorrowedAfter() string
+	GetBorrowedBefore() string
+	GetSortOrder() string
+}
+
+// transactionFilter builds the listing filter of a validated request
+func transactionFilter(req transactionFilterRequest) (domain.ListFilter, error) {
+	borrowedAfter, err := optionalTime(req.GetBorrowedAfter(), "borrowed_after")
+	if err != nil {
+		return domain.ListFilter{}, err
+	}
+	borrowedBefore, err := optionalTime(req.GetBorrowedBefore(), "borrowed_before")
+	if err != nil {
+		return domain.ListFilter{}, err
+	}
+
+	return domain.ListFilter{
+		Status:         req.GetStatus(),
+		BorrowedAfter:  borrowedAfter,
+		BorrowedBefore: borrowedBefore,
+		Ascending:      req.GetSortOrder() == "asc",
+	}, nil
+}
@@ -0,0 +1,1363 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	commonPb "github.com/hinha/library-management-synapsis/gen/api/proto/common"
+	pb "github.com/hinha/library-management-synapsis/gen/api/proto/transaction"
+	"github.com/hinha/library-management-synapsis/internal/delivery/middleware"
+	"github.com/hinha/library-management-synapsis/internal/delivery/mocks"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"github.com/hinha/library-management-synapsis/internal/domain/book"
+	"github.com/hinha/library-management-synapsis/internal/domain/transaction"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+)
+
+var (
+	ownerCtx = middleware.WithPrincipal(context.Background(), middleware.Principal{UserID: "1", Role: domain.RoleOperation})
+	otherCtx = middleware.WithPrincipal(context.Background(), middleware.Principal{UserID: "2", Role: domain.RoleOperation})
+	adminCtx = middleware.WithPrincipal(context.Background(), middleware.Principal{UserID: "9", Role: domain.RoleAdmin})
+	// serviceCtx is the transaction service acting on behalf of user 1
+	serviceCtx = middleware.WithPrincipal(context.Background(), middleware.Principal{UserID: middleware.ServiceUserID, Service: true, OnBehalfOf: "1"})
+)
+
+var (
+	testLoan = &domain.Transaction{ID: "tx-1", UserID: "1", BookID: "book-1", BorrowedAt: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)}
+	testHold = &domain.Hold{ID: "h-1", UserID: "1", BookID: "book-1", Status: domain.HoldStatusWaiting, CreatedAt: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)}
+	testFine = &domain.LedgerEntry{ID: "l-1", UserID: "1", Type: domain.LedgerEntryPayment, Amount: 300, CreatedAt: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)}
+)
+
+// assertStatus checks err carries code, or that there is no error for codes.OK
+func assertStatus(t *testing.T, err error, code codes.Code) {
+	t.Helper()
+	if code == codes.OK {
+		assert.NoError(t, err)
+		return
+	}
+	st, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, code, st.Code())
+}
+
+func TestTransactionHandler_Borrow(t *testing.T) {
+	req := &pb.BorrowRequest{UserId: "1", BookId: "book-1"}
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		req        *pb.BorrowRequest
+		mockSetup  func(svc *mocks.TransactionService)
+		statusCode codes.Code
+	}{
+		{
+			name: "owner borrows",
+			ctx:  ownerCtx,
+			req:  req,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("BorrowBook", mock.Anything, "1", "book-1", "").Return(testLoan, nil)
+			},
+		},
+		{
+			name: "admin borrows on behalf of a user",
+			ctx:  adminCtx,
+			req:  req,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("BorrowBook", mock.Anything, "1", "book-1", "").Return(testLoan, nil)
+			},
+		},
+		{
+			name:       "other user",
+			ctx:        otherCtx,
+			req:        req,
+			statusCode: codes.PermissionDenied,
+		},
+		{
+			name:       "no caller identity",
+			ctx:        context.Background(),
+			req:        req,
+			statusCode: codes.Unauthenticated,
+		},
+		{
+			name:       "validation error",
+			ctx:        ownerCtx,
+			req:        &pb.BorrowRequest{UserId: "1"},
+			statusCode: codes.InvalidArgument,
+		},
+		{
+			name: "book on hold",
+			ctx:  ownerCtx,
+			req:  req,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("BorrowBook", mock.Anything, "1", "book-1", "").Return(nil, transaction.ErrBookOnHold)
+			},
+			statusCode: codes.FailedPrecondition,
+		},
+		{
+			name: "internal error",
+			ctx:  ownerCtx,
+			req:  req,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("BorrowBook", mock.Anything, "1", "book-1", "").Return(nil, errors.New("db error"))
+			},
+			statusCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.TransactionService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewTransactionHandler(mockSvc, nil)
+
+			got, err := h.Borrow(tt.ctx, tt.req)
+
+			assertStatus(t, err, tt.statusCode)
+			if tt.statusCode == codes.OK {
+				assert.Equal(t, testLoan.ToProto(), got)
+			}
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransactionHandler_Borrow_LimitDetails(t *testing.T) {
+	mockSvc := new(mocks.TransactionService)
+	mockSvc.On("BorrowBook", mock.Anything, "1", "book-1", "").Return(nil, &transaction.LimitError{
+		Err:      transaction.ErrActiveLoanLimit,
+		Reason:   transaction.ReasonActiveLoanLimit,
+		Metadata: map[string]string{"limit": "5"},
+	})
+	h := NewTransactionHandler(mockSvc, nil)
+
+	_, err := h.Borrow(ownerCtx, &pb.BorrowRequest{UserId: "1", BookId: "book-1"})
+
+	st, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.FailedPrecondition, st.Code())
+	if assert.Len(t, st.Details(), 1) {
+		detail, ok := st.Details()[0].(*commonPb.PreconditionFailure)
+		assert.True(t, ok)
+		assert.Equal(t, transaction.ReasonActiveLoanLimit, detail.GetReason())
+		assert.Equal(t, "5", detail.GetMetadata()["limit"])
+	}
+}
+
+func TestTransactionHandler_Return(t *testing.T) {
+	req := &pb.ReturnRequest{TransactionId: "tx-1"}
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		mockSetup  func(svc *mocks.TransactionService)
+		statusCode codes.Code
+	}{
+		{
+			name: "owner returns",
+			ctx:  ownerCtx,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("GetTransaction", mock.Anything, "tx-1").Return(testLoan, nil)
+				svc.On("ReturnBook", mock.Anything, "tx-1").Return(testLoan, nil)
+			},
+		},
+		{
+			name: "admin returns",
+			ctx:  adminCtx,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("GetTransaction", mock.Anything, "tx-1").Return(testLoan, nil)
+				svc.On("ReturnBook", mock.Anything, "tx-1").Return(testLoan, nil)
+			},
+		},
+		{
+			name: "other user",
+			ctx:  otherCtx,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("GetTransaction", mock.Anything, "tx-1").Return(testLoan, nil)
+			},
+			statusCode: codes.PermissionDenied,
+		},
+		{
+			name: "transaction not found",
+			ctx:  ownerCtx,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("GetTransaction", mock.Anything, "tx-1").Return(nil, transaction.ErrTransactionNotFound)
+			},
+			statusCode: codes.NotFound,
+		},
+		{
+			name: "already returned",
+			ctx:  ownerCtx,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("GetTransaction", mock.Anything, "tx-1").Return(testLoan, nil)
+				svc.On("ReturnBook", mock.Anything, "tx-1").Return(nil, transaction.ErrAlreadyReturned)
+			},
+			statusCode: codes.FailedPrecondition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.TransactionService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewTransactionHandler(mockSvc, nil)
+
+			_, err := h.Return(tt.ctx, req)
+
+			assertStatus(t, err, tt.statusCode)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransactionHandler_Return_ByBarcode(t *testing.T) {
+	req := &pb.ReturnRequest{Barcode: "LIB-0001"}
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		mockSetup  func(svc *mocks.TransactionService)
+		statusCode codes.Code
+	}{
+		{
+			name: "owner returns the copy",
+			ctx:  ownerCtx,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("GetOpenLoanByBarcode", mock.Anything, "LIB-0001").Return(testLoan, nil)
+				svc.On("ReturnBook", mock.Anything, "tx-1").Return(testLoan, nil)
+			},
+		},
+		{
+			name: "copy not on loan",
+			ctx:  ownerCtx,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("GetOpenLoanByBarcode", mock.Anything, "LIB-0001").Return(nil, transaction.ErrTransactionNotFound)
+			},
+			statusCode: codes.NotFound,
+		},
+		{
+			name: "admin returns the copy",
+			ctx:  adminCtx,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("GetOpenLoanByBarcode", mock.Anything, "LIB-0001").Return(testLoan, nil)
+				svc.On("ReturnBook", mock.Anything, "tx-1").Return(testLoan, nil)
+			},
+		},
+		{
+			name: "other user does not learn of the loan",
+			ctx:  otherCtx,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("GetOpenLoanByBarcode", mock.Anything, "LIB-0001").Return(testLoan, nil)
+			},
+			statusCode: codes.NotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.TransactionService)
+			tt.mockSetup(mockSvc)
+			h := NewTransactionHandler(mockSvc, nil)
+
+			_, err := h.Return(tt.ctx, req)
+
+			assertStatus(t, err, tt.statusCode)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransactionHandler_Return_ByBook(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		req        *pb.ReturnRequest
+		mockSetup  func(svc *mocks.TransactionService)
+		statusCode codes.Code
+	}{
+		{
+			name: "owner returns the book",
+			ctx:  ownerCtx,
+			req:  &pb.ReturnRequest{UserId: "1", BookId: "book-1"},
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("GetOpenLoanByBook", mock.Anything, "1", "book-1").Return(testLoan, nil)
+				svc.On("ReturnBook", mock.Anything, "tx-1").Return(testLoan, nil)
+			},
+		},
+		{
+			name: "book not on loan",
+			ctx:  ownerCtx,
+			req:  &pb.ReturnRequest{UserId: "1", BookId: "book-1"},
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("GetOpenLoanByBook", mock.Anything, "1", "book-1").Return(nil, transaction.ErrTransactionNotFound)
+			},
+			statusCode: codes.NotFound,
+		},
+		{
+			name: "admin returns the book for the user",
+			ctx:  adminCtx,
+			req:  &pb.ReturnRequest{UserId: "1", BookId: "book-1"},
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("GetOpenLoanByBook", mock.Anything, "1", "book-1").Return(testLoan, nil)
+				svc.On("ReturnBook", mock.Anything, "tx-1").Return(testLoan, nil)
+			},
+		},
+		{
+			name:       "other user is refused before the lookup",
+			ctx:        otherCtx,
+			req:        &pb.ReturnRequest{UserId: "1", BookId: "book-1"},
+			statusCode: codes.PermissionDenied,
+		},
+		{
+			name:       "book without user",
+			ctx:        ownerCtx,
+			req:        &pb.ReturnRequest{BookId: "book-1"},
+			statusCode: codes.InvalidArgument,
+		},
+		{
+			name:       "nothing to return",
+			ctx:        ownerCtx,
+			req:        &pb.ReturnRequest{},
+			statusCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.TransactionService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewTransactionHandler(mockSvc, nil)
+
+			_, err := h.Return(tt.ctx, tt.req)
+
+			assertStatus(t, err, tt.statusCode)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransactionHandler_BatchBorrow(t *testing.T) {
+	req := &pb.BatchBorrowRequest{UserId: "1", Items: []*pb.BorrowItem{{BookId: "book-1"}, {Barcode: "LIB-0002"}}}
+	items := []transaction.BatchItem{{BookID: "book-1"}, {Barcode: "LIB-0002"}}
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		req        *pb.BatchBorrowRequest
+		mockSetup  func(svc *mocks.TransactionService)
+		wantCodes  []string
+		statusCode codes.Code
+	}{
+		{
+			name: "per item outcomes",
+			ctx:  ownerCtx,
+			req:  req,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("BatchBorrow", mock.Anything, "1", items).Return([]*transaction.BatchOutcome{
+					{Item: items[0], Transaction: testLoan},
+					{Item: items[1], Err: transaction.ErrBookNotAvailable},
+				}, nil)
+			},
+			wantCodes: []string{"", codes.FailedPrecondition.String()},
+		},
+		{
+			name: "basket over the limit",
+			ctx:  adminCtx,
+			req:  req,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("BatchBorrow", mock.Anything, "1", items).Return(nil, &transaction.LimitError{
+					Err:    transaction.ErrActiveLoanLimit,
+					Reason: transaction.ReasonActiveLoanLimit,
+				})
+			},
+			statusCode: codes.FailedPrecondition,
+		},
+		{
+			name:       "other user",
+			ctx:        otherCtx,
+			req:        req,
+			statusCode: codes.PermissionDenied,
+		},
+		{
+			name:       "item without book",
+			ctx:        ownerCtx,
+			req:        &pb.BatchBorrowRequest{UserId: "1", Items: []*pb.BorrowItem{{}}},
+			statusCode: codes.InvalidArgument,
+		},
+		{
+			name:       "empty basket",
+			ctx:        ownerCtx,
+			req:        &pb.BatchBorrowRequest{UserId: "1"},
+			statusCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.TransactionService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewTransactionHandler(mockSvc, nil)
+
+			got, err := h.BatchBorrow(tt.ctx, tt.req)
+
+			assertStatus(t, err, tt.statusCode)
+			if tt.statusCode == codes.OK && assert.Len(t, got.GetResults(), len(tt.wantCodes)) {
+				assert.Equal(t, int32(1), got.GetSucceeded())
+				assert.Equal(t, int32(1), got.GetFailed())
+				for i, result := range got.GetResults() {
+					assert.Equal(t, int32(i), result.GetIndex())
+					assert.Equal(t, tt.wantCodes[i], result.GetCode())
+				}
+				assert.Equal(t, "tx-1", got.GetResults()[0].GetTransaction().GetTransactionId())
+				assert.Equal(t, "book not available", got.GetResults()[1].GetReason())
+			}
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransactionHandler_BatchReturn(t *testing.T) {
+	req := &pb.BatchReturnRequest{UserId: "1", Items: []*pb.ReturnItem{{BookId: "book-1"}, {Barcode: "LIB-0002"}}}
+	items := []transaction.BatchItem{{BookID: "book-1"}, {Barcode: "LIB-0002"}}
+	mockSvc := new(mocks.TransactionService)
+	mockSvc.On("BatchReturn", mock.Anything, "1", items).Return([]*transaction.BatchOutcome{
+		{Item: items[0], Transaction: testLoan},
+		{Item: items[1], Err: transaction.ErrNotBorrower},
+	}, nil)
+	h := NewTransactionHandler(mockSvc, nil)
+
+	got, err := h.BatchReturn(ownerCtx, req)
+
+	assert.NoError(t, err)
+	if assert.Len(t, got.GetResults(), 2) {
+		assert.Equal(t, "returned", got.GetResults()[0].GetStatus())
+		assert.Equal(t, "failed", got.GetResults()[1].GetStatus())
+		assert.Equal(t, codes.FailedPrecondition.String(), got.GetResults()[1].GetCode())
+	}
+	mockSvc.AssertExpectations(t)
+
+	_, err = h.BatchReturn(otherCtx, req)
+	assertStatus(t, err, codes.PermissionDenied)
+}
+
+func TestTransactionHandler_Renew(t *testing.T) {
+	req := &pb.RenewRequest{Id: "tx-1"}
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		mockSetup  func(svc *mocks.TransactionService)
+		statusCode codes.Code
+	}{
+		{
+			name: "owner renews",
+			ctx:  ownerCtx,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("GetTransaction", mock.Anything, "tx-1").Return(testLoan, nil)
+				svc.On("RenewLoan", mock.Anything, "tx-1").Return(testLoan, nil)
+			},
+		},
+		{
+			name: "other user",
+			ctx:  otherCtx,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("GetTransaction", mock.Anything, "tx-1").Return(testLoan, nil)
+			},
+			statusCode: codes.PermissionDenied,
+		},
+		{
+			name: "renewal limit reached",
+			ctx:  ownerCtx,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("GetTransaction", mock.Anything, "tx-1").Return(testLoan, nil)
+				svc.On("RenewLoan", mock.Anything, "tx-1").Return(nil, transaction.ErrRenewalLimitReached)
+			},
+			statusCode: codes.FailedPrecondition,
+		},
+		{
+			name: "concurrent update",
+			ctx:  adminCtx,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("GetTransaction", mock.Anything, "tx-1").Return(testLoan, nil)
+				svc.On("RenewLoan", mock.Anything, "tx-1").Return(nil, transaction.ErrConcurrentUpdate)
+			},
+			statusCode: codes.Aborted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.TransactionService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewTransactionHandler(mockSvc, nil)
+
+			_, err := h.Renew(tt.ctx, req)
+
+			assertStatus(t, err, tt.statusCode)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransactionHandler_DeclareLost(t *testing.T) {
+	closedAt := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	lost := &domain.Transaction{ID: "tx-1", UserID: "1", BookID: "book-1", BorrowedAt: testLoan.BorrowedAt, ReturnedAt: &closedAt, Status: domain.LoanStatusLost, ReplacementFee: 5000}
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		req        *pb.DeclareLostRequest
+		mockSetup  func(svc *mocks.TransactionService)
+		statusCode codes.Code
+	}{
+		{
+			name: "declares the book lost",
+			ctx:  adminCtx,
+			req:  &pb.DeclareLostRequest{Id: "tx-1", Note: "left on the bus"},
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("DeclareLost", mock.Anything, "tx-1", int64(0), "left on the bus").Return(lost, nil)
+			},
+		},
+		{
+			name: "loan already closed",
+			ctx:  adminCtx,
+			req:  &pb.DeclareLostRequest{Id: "tx-1", Fee: 2500},
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("DeclareLost", mock.Anything, "tx-1", int64(2500), "").Return(nil, transaction.ErrAlreadyReturned)
+			},
+			statusCode: codes.FailedPrecondition,
+		},
+		{
+			name: "copy not on loan",
+			ctx:  adminCtx,
+			req:  &pb.DeclareLostRequest{Id: "tx-1"},
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("DeclareLost", mock.Anything, "tx-1", int64(0), "").Return(nil, book.ErrCopyNotOnLoan)
+			},
+			statusCode: codes.FailedPrecondition,
+		},
+		{
+			name:       "negative fee",
+			ctx:        adminCtx,
+			req:        &pb.DeclareLostRequest{Id: "tx-1", Fee: -1},
+			statusCode: codes.InvalidArgument,
+		},
+		{
+			name:       "operation user",
+			ctx:        ownerCtx,
+			req:        &pb.DeclareLostRequest{Id: "tx-1"},
+			statusCode: codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.TransactionService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewTransactionHandler(mockSvc, nil)
+
+			got, err := h.DeclareLost(tt.ctx, tt.req)
+
+			assertStatus(t, err, tt.statusCode)
+			if tt.statusCode == codes.OK {
+				assert.Equal(t, "lost", got.GetStatus())
+				assert.Equal(t, int64(5000), got.GetReplacementFee())
+			}
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransactionHandler_ReportDamage(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		mockSetup  func(svc *mocks.TransactionService)
+		statusCode codes.Code
+	}{
+		{
+			name: "loan not found",
+			ctx:  adminCtx,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("ReportDamage", mock.Anything, "tx-9", int64(0), "").Return(nil, transaction.ErrTransactionNotFound)
+			},
+			statusCode: codes.NotFound,
+		},
+		{
+			name:       "operation user",
+			ctx:        ownerCtx,
+			statusCode: codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.TransactionService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewTransactionHandler(mockSvc, nil)
+
+			_, err := h.ReportDamage(tt.ctx, &pb.ReportDamageRequest{Id: "tx-9"})
+
+			assertStatus(t, err, tt.statusCode)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransactionHandler_MarkFound(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		mockSetup  func(svc *mocks.TransactionService)
+		statusCode codes.Code
+	}{
+		{
+			name: "marks the book found",
+			ctx:  adminCtx,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("MarkFound", mock.Anything, "tx-1", "").Return(&domain.Transaction{ID: "tx-1", Status: domain.LoanStatusFound}, nil)
+			},
+		},
+		{
+			name: "loan not lost",
+			ctx:  adminCtx,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("MarkFound", mock.Anything, "tx-1", "").Return(nil, transaction.ErrNotLost)
+			},
+			statusCode: codes.FailedPrecondition,
+		},
+		{
+			name: "copy not lost",
+			ctx:  adminCtx,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("MarkFound", mock.Anything, "tx-1", "").Return(nil, book.ErrCopyNotLost)
+			},
+			statusCode: codes.FailedPrecondition,
+		},
+		{
+			name:       "operation user",
+			ctx:        ownerCtx,
+			statusCode: codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.TransactionService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewTransactionHandler(mockSvc, nil)
+
+			_, err := h.MarkFound(tt.ctx, &pb.MarkFoundRequest{Id: "tx-1"})
+
+			assertStatus(t, err, tt.statusCode)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransactionHandler_History(t *testing.T) {
+	req := &pb.HistoryRequest{UserId: "1"}
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		mockSetup  func(svc *mocks.TransactionService)
+		wantLen    int
+		statusCode codes.Code
+	}{
+		{
+			name: "owner reads history",
+			ctx:  ownerCtx,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("GetUserHistory", mock.Anything, "1", transaction.ListFilter{}, 0, "").Return([]*domain.Transaction{testLoan}, "", nil)
+			},
+			wantLen: 1,
+		},
+		{
+			name: "admin reads history",
+			ctx:  adminCtx,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("GetUserHistory", mock.Anything, "1", transaction.ListFilter{}, 0, "").Return([]*domain.Transaction{testLoan}, "", nil)
+			},
+			wantLen: 1,
+		},
+		{
+			name:       "other user",
+			ctx:        otherCtx,
+			statusCode: codes.PermissionDenied,
+		},
+		{
+			name: "internal error",
+			ctx:  ownerCtx,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("GetUserHistory", mock.Anything, "1", transaction.ListFilter{}, 0, "").Return(nil, "", errors.New("db error"))
+			},
+			statusCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.TransactionService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewTransactionHandler(mockSvc, nil)
+
+			got, err := h.History(tt.ctx, req)
+
+			assertStatus(t, err, tt.statusCode)
+			assert.Len(t, got.GetTransactions(), tt.wantLen)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransactionHandler_History_Filtered(t *testing.T) {
+	borrowedAfter := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockSvc := new(mocks.TransactionService)
+	mockSvc.On("GetUserHistory", mock.Anything, "1", mock.MatchedBy(func(filter transaction.ListFilter) bool {
+		return filter.Status == domain.TransactionStatusReturned && filter.Ascending &&
+			filter.BorrowedAfter.Equal(borrowedAfter) && filter.BorrowedBefore == nil
+	}), 10, "token").Return([]*domain.Transaction{testLoan}, "next", nil)
+	h := NewTransactionHandler(mockSvc, nil)
+
+	got, err := h.History(ownerCtx, &pb.HistoryRequest{
+		UserId:        "1",
+		PageSize:      10,
+		PageToken:     "token",
+		Status:        "returned",
+		BorrowedAfter: "2026-01-01T00:00:00Z",
+		SortOrder:     "asc",
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, got.GetTransactions(), 1)
+	assert.Equal(t, "next", got.GetNextPageToken())
+	mockSvc.AssertExpectations(t)
+
+	_, err = h.History(ownerCtx, &pb.HistoryRequest{UserId: "1", Status: "missing"})
+	assertStatus(t, err, codes.InvalidArgument)
+}
+
+func TestTransactionHandler_ListTransactions(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		req        *pb.ListTransactionsRequest
+		mockSetup  func(svc *mocks.TransactionService)
+		statusCode codes.Code
+	}{
+		{
+			name: "admin lists the loans of a book",
+			ctx:  adminCtx,
+			req:  &pb.ListTransactionsRequest{BookId: "book-1", Status: "overdue"},
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("ListTransactions", mock.Anything, transaction.ListFilter{BookID: "book-1", Status: domain.TransactionStatusOverdue}, 0, "").
+					Return([]*domain.Transaction{testLoan}, "next", nil)
+			},
+		},
+		{
+			name:       "operation user",
+			ctx:        ownerCtx,
+			req:        &pb.ListTransactionsRequest{},
+			statusCode: codes.PermissionDenied,
+		},
+		{
+			name:       "invalid date",
+			ctx:        adminCtx,
+			req:        &pb.ListTransactionsRequest{BorrowedBefore: "yesterday"},
+			statusCode: codes.InvalidArgument,
+		},
+		{
+			name: "token of another filter",
+			ctx:  adminCtx,
+			req:  &pb.ListTransactionsRequest{UserId: "2", PageToken: "token"},
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("ListTransactions", mock.Anything, transaction.ListFilter{UserID: "2"}, 0, "token").
+					Return(nil, "", transaction.ErrInvalidPageToken)
+			},
+			statusCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.TransactionService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewTransactionHandler(mockSvc, nil)
+
+			got, err := h.ListTransactions(tt.ctx, tt.req)
+
+			assertStatus(t, err, tt.statusCode)
+			if tt.statusCode == codes.OK {
+				assert.Equal(t, []*pb.TransactionResponse{testLoan.ToProto()}, got.GetTransactions())
+				assert.Equal(t, "next", got.GetNextPageToken())
+			}
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransactionHandler_ListOverdue(t *testing.T) {
+	dueAt := time.Now().Add(-49 * time.Hour)
+	overdue := &domain.Transaction{ID: "tx-2", UserID: "1", BookID: "book-1", DueAt: &dueAt}
+	tests := []struct {
+		name         string
+		mockSetup    func(svc *mocks.TransactionService)
+		wantDaysLate []int32
+		statusCode   codes.Code
+	}{
+		{
+			name: "lists overdue loans",
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("ListOverdue", mock.Anything).Return([]*domain.Transaction{overdue}, nil)
+			},
+			wantDaysLate: []int32{3},
+		},
+		{
+			name: "internal error",
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("ListOverdue", mock.Anything).Return(nil, errors.New("db error"))
+			},
+			statusCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.TransactionService)
+			tt.mockSetup(mockSvc)
+			h := NewTransactionHandler(mockSvc, nil)
+
+			got, err := h.ListOverdue(adminCtx, &pb.ListOverdueRequest{})
+
+			assertStatus(t, err, tt.statusCode)
+			for i, loan := range got.GetLoans() {
+				assert.Equal(t, tt.wantDaysLate[i], loan.GetDaysLate())
+			}
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransactionHandler_PlaceHold(t *testing.T) {
+	req := &pb.PlaceHoldRequest{UserId: "1", BookId: "book-1"}
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		mockSetup  func(svc *mocks.TransactionService)
+		statusCode codes.Code
+	}{
+		{
+			name: "owner places hold",
+			ctx:  ownerCtx,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("PlaceHold", mock.Anything, "1", "book-1").Return(testHold, nil)
+			},
+		},
+		{
+			name:       "other user",
+			ctx:        otherCtx,
+			statusCode: codes.PermissionDenied,
+		},
+		{
+			name: "hold exists",
+			ctx:  ownerCtx,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("PlaceHold", mock.Anything, "1", "book-1").Return(nil, transaction.ErrHoldExists)
+			},
+			statusCode: codes.AlreadyExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.TransactionService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewTransactionHandler(mockSvc, nil)
+
+			_, err := h.PlaceHold(tt.ctx, req)
+
+			assertStatus(t, err, tt.statusCode)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransactionHandler_CancelHold(t *testing.T) {
+	req := &pb.CancelHoldRequest{Id: "h-1"}
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		mockSetup  func(svc *mocks.TransactionService)
+		statusCode codes.Code
+	}{
+		{
+			name: "owner cancels",
+			ctx:  ownerCtx,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("GetHold", mock.Anything, "h-1").Return(testHold, nil)
+				svc.On("CancelHold", mock.Anything, "h-1").Return(testHold, nil)
+			},
+		},
+		{
+			name: "admin cancels",
+			ctx:  adminCtx,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("GetHold", mock.Anything, "h-1").Return(testHold, nil)
+				svc.On("CancelHold", mock.Anything, "h-1").Return(testHold, nil)
+			},
+		},
+		{
+			name: "other user",
+			ctx:  otherCtx,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("GetHold", mock.Anything, "h-1").Return(testHold, nil)
+			},
+			statusCode: codes.PermissionDenied,
+		},
+		{
+			name: "hold not found",
+			ctx:  ownerCtx,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("GetHold", mock.Anything, "h-1").Return(nil, transaction.ErrHoldNotFound)
+			},
+			statusCode: codes.NotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.TransactionService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewTransactionHandler(mockSvc, nil)
+
+			_, err := h.CancelHold(tt.ctx, req)
+
+			assertStatus(t, err, tt.statusCode)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransactionHandler_ListHolds(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		req        *pb.ListHoldsRequest
+		mockSetup  func(svc *mocks.TransactionService)
+		statusCode codes.Code
+	}{
+		{
+			name: "owner lists own holds",
+			ctx:  ownerCtx,
+			req:  &pb.ListHoldsRequest{UserId: "1"},
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("ListHolds", mock.Anything, "1", "").Return([]*domain.Hold{testHold}, nil)
+			},
+		},
+		{
+			name: "patron asking for a book queue only sees their own hold",
+			ctx:  ownerCtx,
+			req:  &pb.ListHoldsRequest{BookId: "book-1"},
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("ListHolds", mock.Anything, "1", "book-1").Return([]*domain.Hold{testHold}, nil)
+			},
+		},
+		{
+			name: "admin lists a book queue",
+			ctx:  adminCtx,
+			req:  &pb.ListHoldsRequest{BookId: "book-1"},
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("ListHolds", mock.Anything, "", "book-1").Return([]*domain.Hold{testHold}, nil)
+			},
+		},
+		{
+			name:       "other user",
+			ctx:        otherCtx,
+			req:        &pb.ListHoldsRequest{UserId: "1"},
+			statusCode: codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.TransactionService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewTransactionHandler(mockSvc, nil)
+
+			_, err := h.ListHolds(tt.ctx, tt.req)
+
+			assertStatus(t, err, tt.statusCode)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransactionHandler_GetBalance(t *testing.T) {
+	req := &pb.GetBalanceRequest{UserId: "1"}
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		mockSetup   func(svc *mocks.TransactionService)
+		wantBalance int64
+		statusCode  codes.Code
+	}{
+		{
+			name: "owner reads balance",
+			ctx:  ownerCtx,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("GetBalance", mock.Anything, "1").Return(int64(700), nil)
+			},
+			wantBalance: 700,
+		},
+		{
+			name:       "other user",
+			ctx:        otherCtx,
+			statusCode: codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.TransactionService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewTransactionHandler(mockSvc, nil)
+
+			got, err := h.GetBalance(tt.ctx, req)
+
+			assertStatus(t, err, tt.statusCode)
+			assert.Equal(t, tt.wantBalance, got.GetBalance())
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransactionHandler_ListLedger(t *testing.T) {
+	req := &pb.ListLedgerRequest{UserId: "1"}
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		mockSetup  func(svc *mocks.TransactionService)
+		statusCode codes.Code
+	}{
+		{
+			name: "admin reads ledger",
+			ctx:  adminCtx,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("ListLedger", mock.Anything, "1").Return([]*domain.LedgerEntry{testFine}, int64(0), nil)
+			},
+		},
+		{
+			name:       "other user",
+			ctx:        otherCtx,
+			statusCode: codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.TransactionService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewTransactionHandler(mockSvc, nil)
+
+			_, err := h.ListLedger(tt.ctx, req)
+
+			assertStatus(t, err, tt.statusCode)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransactionHandler_RecordPayment(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		req        *pb.RecordPaymentRequest
+		mockSetup  func(svc *mocks.TransactionService)
+		statusCode codes.Code
+	}{
+		{
+			name: "records payment",
+			ctx:  adminCtx,
+			req:  &pb.RecordPaymentRequest{UserId: "1", Amount: 300},
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("RecordPayment", mock.Anything, "1", int64(300), "").Return(testFine, nil)
+			},
+		},
+		{
+			name: "exceeds balance",
+			ctx:  adminCtx,
+			req:  &pb.RecordPaymentRequest{UserId: "1", Amount: 300},
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("RecordPayment", mock.Anything, "1", int64(300), "").Return(nil, transaction.ErrExceedsBalance)
+			},
+			statusCode: codes.FailedPrecondition,
+		},
+		{
+			name:       "non-positive amount",
+			ctx:        adminCtx,
+			req:        &pb.RecordPaymentRequest{UserId: "1"},
+			statusCode: codes.InvalidArgument,
+		},
+		{
+			name:       "operation user",
+			ctx:        ownerCtx,
+			req:        &pb.RecordPaymentRequest{UserId: "1", Amount: 300},
+			statusCode: codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.TransactionService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewTransactionHandler(mockSvc, nil)
+
+			_, err := h.RecordPayment(tt.ctx, tt.req)
+
+			assertStatus(t, err, tt.statusCode)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransactionHandler_WaiveFine(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		req        *pb.WaiveFineRequest
+		mockSetup  func(svc *mocks.TransactionService)
+		statusCode codes.Code
+	}{
+		{
+			name: "waives fine",
+			ctx:  adminCtx,
+			req:  &pb.WaiveFineRequest{UserId: "1", Amount: 300, Reason: "first offence"},
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("WaiveFine", mock.Anything, "1", int64(300), "first offence").Return(testFine, nil)
+			},
+		},
+		{
+			name:       "missing reason",
+			ctx:        adminCtx,
+			req:        &pb.WaiveFineRequest{UserId: "1", Amount: 300},
+			statusCode: codes.InvalidArgument,
+		},
+		{
+			name:       "operation user",
+			ctx:        ownerCtx,
+			req:        &pb.WaiveFineRequest{UserId: "1", Amount: 300, Reason: "first offence"},
+			statusCode: codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.TransactionService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewTransactionHandler(mockSvc, nil)
+
+			_, err := h.WaiveFine(tt.ctx, tt.req)
+
+			assertStatus(t, err, tt.statusCode)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransactionHandler_BlockBorrower(t *testing.T) {
+	block := &domain.BorrowerBlock{UserID: "1", Reason: "lost library card"}
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		req        *pb.BlockBorrowerRequest
+		mockSetup  func(svc *mocks.TransactionService)
+		statusCode codes.Code
+	}{
+		{
+			name: "blocks until lifted",
+			ctx:  adminCtx,
+			req:  &pb.BlockBorrowerRequest{UserId: "1", Reason: "lost library card"},
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("BlockBorrower", mock.Anything, "1", "lost library card", (*time.Time)(nil)).Return(block, nil)
+			},
+		},
+		{
+			name: "blocks until a date",
+			ctx:  adminCtx,
+			req:  &pb.BlockBorrowerRequest{UserId: "1", Reason: "lost library card", ExpiresAt: "2030-01-02T15:04:05Z"},
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("BlockBorrower", mock.Anything, "1", "lost library card", mock.MatchedBy(func(at *time.Time) bool {
+					return at != nil && at.Equal(time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC))
+				})).Return(block, nil)
+			},
+		},
+		{
+			name:       "malformed expiry",
+			ctx:        adminCtx,
+			req:        &pb.BlockBorrowerRequest{UserId: "1", Reason: "lost library card", ExpiresAt: "next week"},
+			statusCode: codes.InvalidArgument,
+		},
+		{
+			name:       "operation user",
+			ctx:        ownerCtx,
+			req:        &pb.BlockBorrowerRequest{UserId: "1", Reason: "lost library card"},
+			statusCode: codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.TransactionService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewTransactionHandler(mockSvc, nil)
+
+			_, err := h.BlockBorrower(tt.ctx, tt.req)
+
+			assertStatus(t, err, tt.statusCode)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransactionHandler_UnblockBorrower(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		mockSetup  func(svc *mocks.TransactionService)
+		statusCode codes.Code
+	}{
+		{
+			name: "lifts block",
+			ctx:  adminCtx,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("UnblockBorrower", mock.Anything, "1").Return(&domain.BorrowerBlock{UserID: "1"}, nil)
+			},
+		},
+		{
+			name: "not blocked",
+			ctx:  adminCtx,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("UnblockBorrower", mock.Anything, "1").Return(nil, transaction.ErrBlockNotFound)
+			},
+			statusCode: codes.NotFound,
+		},
+		{
+			name:       "operation user",
+			ctx:        ownerCtx,
+			statusCode: codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.TransactionService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewTransactionHandler(mockSvc, nil)
+
+			_, err := h.UnblockBorrower(tt.ctx, &pb.UnblockBorrowerRequest{UserId: "1"})
+
+			assertStatus(t, err, tt.statusCode)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransactionHandler_RestoreTransaction(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		mockSetup  func(svc *mocks.TransactionService)
+		statusCode codes.Code
+	}{
+		{
+			name: "admin restores",
+			ctx:  adminCtx,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("RestoreTransaction", mock.Anything, "tx-1").Return(testLoan, nil)
+			},
+		},
+		{
+			name:       "operation user",
+			ctx:        ownerCtx,
+			statusCode: codes.PermissionDenied,
+		},
+		{
+			name: "not deleted",
+			ctx:  adminCtx,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("RestoreTransaction", mock.Anything, "tx-1").Return(nil, transaction.ErrTransactionNotFound)
+			},
+			statusCode: codes.NotFound,
+		},
+		{
+			name: "no copy left",
+			ctx:  adminCtx,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("RestoreTransaction", mock.Anything, "tx-1").Return(nil, transaction.ErrBookNotAvailable)
+			},
+			statusCode: codes.FailedPrecondition,
+		},
+		{
+			name: "borrower has overdue loans",
+			ctx:  adminCtx,
+			mockSetup: func(svc *mocks.TransactionService) {
+				svc.On("RestoreTransaction", mock.Anything, "tx-1").Return(nil, &transaction.LimitError{Err: transaction.ErrOverdueLoans, Reason: transaction.ReasonOverdueLoans})
+			},
+			statusCode: codes.FailedPrecondition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.TransactionService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewTransactionHandler(mockSvc, nil)
+
+			got, err := h.RestoreTransaction(tt.ctx, &pb.RestoreTransactionRequest{Id: "tx-1"})
+
+			assertStatus(t, err, tt.statusCode)
+			if tt.statusCode == codes.OK {
+				assert.Equal(t, testLoan.ToProto(), got)
+			}
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"github.com/hinha/library-management-synapsis/internal/delivery/middleware"
+	"github.com/hinha/library-management-synapsis/internal/domain/book"
+	"github.com/hinha/library-management-synapsis/pkg/validator"
+
+	pb "github.com/hinha/library-management-synapsis/gen/api/proto/transaction"
+	domain "github.com/hinha/library-management-synapsis/internal/domain/transaction"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DeclareLost handles closing a loan whose book the borrower lost
+func (h *TransactionHandler) DeclareLost(ctx context.Context, req *pb.DeclareLostRequest) (*pb.TransactionResponse, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := middleware.AuthorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	transaction, err := h.service.DeclareLost(ctx, req.GetId(), req.GetFee(), req.GetNote())
+	if err != nil {
+		return nil, writeOffError(err, "failed to declare book lost")
+	}
+
+	return transaction.ToProto(), nil
+}
+
+// ReportDamage handles closing a loan whose book came back damaged
+func (h *TransactionHandler) ReportDamage(ctx context.Context, req *pb.ReportDamageRequest) (*pb.TransactionResponse, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := middleware.AuthorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	transaction, err := h.service.ReportDamage(ctx, req.GetId(), req.GetFee(), req.GetNote())
+	if err != nil {
+		return nil, writeOffError(err, "failed to report damage")
+	}
+
+	return transaction.ToProto(), nil
+}
+
+// MarkFound handles reversing a lost loan once the book turns up
+func (h *TransactionHandler) MarkFound(ctx context.Context, req *pb.MarkFoundRequest) (*pb.TransactionResponse, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := middleware.AuthorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	transaction, err := h.service.MarkFound(ctx, req.GetId(), req.GetNote())
+	if err != nil {
+		return nil, writeOffError(err, "failed to mark book found")
+	}
+
+	return transaction.ToProto(), nil
+}
+
+// writeOffError maps an error of writing a loan off, or reversing that, to
+// its gRPC status
+func writeOffError(err error, msg string) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidInput):
+		return status.Error(codes.InvalidArgument, "invalid input")
+	case errors.Is(err, domain.ErrTransactionNotFound):
+		return status.Error(codes.NotFound, "transaction not found")
+	case errors.Is(err, domain.ErrAlreadyReturned):
+		return status.Error(codes.FailedPrecondition, "loan already closed")
+	case errors.Is(err, domain.ErrNotLost):
+		return status.Error(codes.FailedPrecondition, "loan is not lost")
+	case errors.Is(err, book.ErrBookNotFound):
+		return status.Error(codes.NotFound, "book not found")
+	case errors.Is(err, book.ErrCopyNotOnLoan):
+		return status.Error(codes.FailedPrecondition, "copy is not on loan")
+	case errors.Is(err, book.ErrCopyNotLost):
+		return status.Error(codes.FailedPrecondition, "copy is not lost")
+	case errors.Is(err, book.ErrInsufficientStock):
+		return status.Error(codes.FailedPrecondition, "copy is not in a state to be written off or restored")
+	default:
+		return status.Error(codes.Internal, msg)
+	}
+}
@@ -0,0 +1,91 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	commonPb "github.com/hinha/library-management-synapsis/gen/api/proto/common"
+	pb "github.com/hinha/library-management-synapsis/gen/api/proto/user"
+	"github.com/hinha/library-management-synapsis/internal/delivery/middleware"
+	"github.com/hinha/library-management-synapsis/internal/domain/user"
+	"github.com/hinha/library-management-synapsis/pkg/validator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListDeletedUsers handles listing one page of the soft-deleted users
+func (h *UserHandler) ListDeletedUsers(ctx context.Context, req *commonPb.ListDeletedRequest) (*pb.ListDeletedUsersResponse, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := middleware.AuthorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	users, nextPageToken, err := h.service.ListDeletedUsers(ctx, int(req.GetPageSize()), req.GetPageToken())
+	if err != nil {
+		if errors.Is(err, user.ErrInvalidPageToken) {
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
+		return nil, status.Error(codes.Internal, "failed to list deleted users")
+	}
+
+	response := &pb.ListDeletedUsersResponse{
+		Users:         make([]*pb.UserResponse, len(users)),
+		NextPageToken: nextPageToken,
+	}
+
+	for i, u := range users {
+		response.Users[i] = u.ToProto()
+	}
+
+	return response, nil
+}
+
+// RestoreUser handles bringing a soft-deleted user back
+func (h *UserHandler) RestoreUser(ctx context.Context, req *pb.RestoreUserRequest) (*pb.UserResponse, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := middleware.AuthorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	restored, err := h.service.RestoreUser(ctx, req.GetId())
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrUserNotFound):
+			return nil, status.Error(codes.NotFound, "deleted user not found")
+		case errors.Is(err, user.ErrEmailAlreadyExists):
+			return nil, status.Error(codes.AlreadyExists, "another user has the email")
+		case errors.Is(err, user.ErrInvalidInput):
+			return nil, status.Error(codes.InvalidArgument, "invalid input")
+		default:
+			return nil, status.Error(codes.Internal, "failed to restore user")
+		}
+	}
+
+	setETag(ctx, restored.Version)
+	return restored.ToProto(), nil
+}
+
+// PurgeDeletedUsers handles permanently removing users deleted longer ago
+// than the retention window
+func (h *UserHandler) PurgeDeletedUsers(ctx context.Context, req *commonPb.PurgeDeletedRequest) (*commonPb.PurgeDeletedResponse, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := middleware.AuthorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	purged, before, err := h.service.PurgeDeletedUsers(ctx, retentionWindow(req))
+	if err != nil {
+		if errors.Is(err, user.ErrInvalidInput) {
+			return nil, status.Error(codes.InvalidArgument, "invalid input")
+		}
+		return nil, status.Error(codes.Internal, "failed to purge deleted users")
+	}
+
+	return purgeResponse(purged, before), nil
+}
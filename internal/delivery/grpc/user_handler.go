@@ -3,9 +3,11 @@ package grpc
 import (
 	"context"
 	"errors"
+	commonPb "github.com/hinha/library-management-synapsis/gen/api/proto/common"
 	pb "github.com/hinha/library-management-synapsis/gen/api/proto/user"
 	"github.com/hinha/library-management-synapsis/internal/domain"
 	"github.com/hinha/library-management-synapsis/internal/domain/user"
+	"github.com/hinha/library-management-synapsis/internal/infrastructure/outbox"
 	"github.com/hinha/library-management-synapsis/pkg/validator"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc/codes"
@@ -16,12 +18,14 @@ import (
 type UserHandler struct {
 	pb.UserServiceServer
 	service user.IService
+	feed    *outbox.Feed
 }
 
 // NewUserHandler creates a new UserHandler
-func NewUserHandler(service user.IService) *UserHandler {
+func NewUserHandler(service user.IService, feed *outbox.Feed) *UserHandler {
 	return &UserHandler{
 		service: service,
+		feed:    feed,
 	}
 }
 
@@ -47,6 +51,7 @@ func (h *UserHandler) Register(ctx context.Context, req *pb.RegisterRequest) (*p
 		return nil, status.Error(codes.Internal, "failed to register user")
 	}
 
+	setETag(ctx, u.Version)
 	return u.ToProto(), nil
 }
 
@@ -78,12 +83,19 @@ func (h *UserHandler) Get(ctx context.Context, req *pb.GetUserRequest) (*pb.User
 		return nil, status.Error(codes.Internal, "failed to get user")
 	}
 
+	setETag(ctx, u.Version)
 	return u.ToProto(), nil
 }
 
-// Update updates a user's information
+// Update updates a user's information. The version it is based on comes
+// from the request or its If-Match header.
 func (h *UserHandler) Update(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UserResponse, error) {
-	u, err := h.service.UpdateUser(ctx, req.GetId(), req.GetName(), req.GetEmail())
+	version, err := requestVersion(ctx, req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := h.service.UpdateUser(ctx, req.GetId(), version, req.GetName(), req.GetEmail())
 	if err != nil {
 		if errors.Is(err, user.ErrUserNotFound) {
 			return nil, status.Error(codes.NotFound, "user not found")
@@ -91,9 +103,13 @@ func (h *UserHandler) Update(ctx context.Context, req *pb.UpdateUserRequest) (*p
 		if errors.Is(err, user.ErrEmailAlreadyExists) {
 			return nil, status.Error(codes.AlreadyExists, "email already exists")
 		}
+		if errors.Is(err, user.ErrVersionConflict) {
+			return nil, status.Error(codes.Aborted, "user was modified since it was read, reload it and retry")
+		}
 		return nil, status.Error(codes.Internal, "failed to update user")
 	}
 
+	setETag(ctx, u.Version)
 	return u.ToProto(), nil
 }
 
@@ -122,3 +138,8 @@ func (h *UserHandler) ValidateToken(ctx context.Context, req *pb.ValidateTokenRe
 func (h *UserHandler) HealthCheck(ctx context.Context, _ *pb.HealthCheckRequest) (*pb.HealthCheckResponse, error) {
 	return h.service.Health(ctx)
 }
+
+// SubscribeEvents streams user events to admins
+func (h *UserHandler) SubscribeEvents(req *commonPb.SubscribeEventsRequest, stream pb.UserService_SubscribeEventsServer) error {
+	return streamEvents(h.feed, req, stream)
+}
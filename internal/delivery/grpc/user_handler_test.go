@@ -3,6 +3,7 @@ package grpc
 import (
 	"context"
 	"errors"
+	commonPb "github.com/hinha/library-management-synapsis/gen/api/proto/common"
 	pb "github.com/hinha/library-management-synapsis/gen/api/proto/user"
 	"github.com/hinha/library-management-synapsis/internal/delivery/mocks"
 	"github.com/hinha/library-management-synapsis/internal/domain"
@@ -11,8 +12,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"testing"
+	"time"
 )
 
 func TestUserHandler_Register(t *testing.T) {
@@ -301,6 +304,7 @@ func TestUserHandler_Update(t *testing.T) {
 	}
 	tests := []struct {
 		name       string
+		ctx        context.Context
 		req        *pb.UpdateUserRequest
 		mockSetup  func(svc *mocks.IService)
 		want       *pb.UserResponse
@@ -310,12 +314,13 @@ func TestUserHandler_Update(t *testing.T) {
 		{
 			name: "success",
 			req: &pb.UpdateUserRequest{
-				Id:    "1",
-				Name:  "Updated User",
-				Email: "updated@example.com",
+				Id:      "1",
+				Name:    "Updated User",
+				Email:   "updated@example.com",
+				Version: 1,
 			},
 			mockSetup: func(svc *mocks.IService) {
-				svc.On("UpdateUser", mock.Anything, "1", "Updated User", "updated@example.com").
+				svc.On("UpdateUser", mock.Anything, "1", int64(1), "Updated User", "updated@example.com").
 					Return(validUser, nil)
 			},
 			want:    validUser.ToProto(),
@@ -324,12 +329,13 @@ func TestUserHandler_Update(t *testing.T) {
 		{
 			name: "user not found",
 			req: &pb.UpdateUserRequest{
-				Id:    "2",
-				Name:  "Any",
-				Email: "any@example.com",
+				Id:      "2",
+				Name:    "Any",
+				Email:   "any@example.com",
+				Version: 1,
 			},
 			mockSetup: func(svc *mocks.IService) {
-				svc.On("UpdateUser", mock.Anything, "2", "Any", "any@example.com").
+				svc.On("UpdateUser", mock.Anything, "2", int64(1), "Any", "any@example.com").
 					Return(nil, userEntity.ErrUserNotFound)
 			},
 			want:       nil,
@@ -339,27 +345,57 @@ func TestUserHandler_Update(t *testing.T) {
 		{
 			name: "email already exists",
 			req: &pb.UpdateUserRequest{
-				Id:    "1",
-				Name:  "Any",
-				Email: "exists@example.com",
+				Id:      "1",
+				Name:    "Any",
+				Email:   "exists@example.com",
+				Version: 1,
 			},
 			mockSetup: func(svc *mocks.IService) {
-				svc.On("UpdateUser", mock.Anything, "1", "Any", "exists@example.com").
+				svc.On("UpdateUser", mock.Anything, "1", int64(1), "Any", "exists@example.com").
 					Return(nil, userEntity.ErrEmailAlreadyExists)
 			},
 			want:       nil,
 			wantErr:    true,
 			statusCode: codes.AlreadyExists,
 		},
+		{
+			name: "version from if-match header",
+			ctx:  metadata.NewIncomingContext(context.Background(), metadata.Pairs("grpcgateway-if-match", `"3"`)),
+			req:  &pb.UpdateUserRequest{Id: "1", Name: "Updated User"},
+			mockSetup: func(svc *mocks.IService) {
+				svc.On("UpdateUser", mock.Anything, "1", int64(3), "Updated User", "").
+					Return(validUser, nil)
+			},
+			want:    validUser.ToProto(),
+			wantErr: false,
+		},
+		{
+			name:       "missing version",
+			req:        &pb.UpdateUserRequest{Id: "1", Name: "Any"},
+			wantErr:    true,
+			statusCode: codes.InvalidArgument,
+		},
+		{
+			name: "modified since read",
+			req:  &pb.UpdateUserRequest{Id: "1", Name: "Any", Version: 1},
+			mockSetup: func(svc *mocks.IService) {
+				svc.On("UpdateUser", mock.Anything, "1", int64(1), "Any", "").
+					Return(nil, userEntity.ErrVersionConflict)
+			},
+			want:       nil,
+			wantErr:    true,
+			statusCode: codes.Aborted,
+		},
 		{
 			name: "internal error",
 			req: &pb.UpdateUserRequest{
-				Id:    "1",
-				Name:  "Any",
-				Email: "any@example.com",
+				Id:      "1",
+				Name:    "Any",
+				Email:   "any@example.com",
+				Version: 1,
 			},
 			mockSetup: func(svc *mocks.IService) {
-				svc.On("UpdateUser", mock.Anything, "1", "Any", "any@example.com").
+				svc.On("UpdateUser", mock.Anything, "1", int64(1), "Any", "any@example.com").
 					Return(nil, errors.New("db error"))
 			},
 			want:       nil,
@@ -377,7 +413,11 @@ func TestUserHandler_Update(t *testing.T) {
 			h := &UserHandler{
 				service: mockSvc,
 			}
-			got, err := h.Update(context.Background(), tt.req)
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			got, err := h.Update(ctx, tt.req)
 			if tt.wantErr {
 				assert.Error(t, err)
 				if tt.statusCode != 0 {
@@ -451,7 +491,7 @@ func TestUserHandler_HealthCheck(t *testing.T) {
 
 func TestNewUserHandler(t *testing.T) {
 	mockSvc := new(mocks.IService)
-	handler := NewUserHandler(mockSvc)
+	handler := NewUserHandler(mockSvc, nil)
 	assert.NotNil(t, handler)
 	assert.Equal(t, mockSvc, handler.service)
 }
@@ -556,3 +596,75 @@ func TestUserHandler_ValidateToken(t *testing.T) {
 		})
 	}
 }
+
+func TestUserHandler_RestoreUser(t *testing.T) {
+	restored := &domain.User{ID: 5, Name: "Jane", Email: "jane@example.com", Role: userDomain.RoleOperation, Version: 3}
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		mockSetup  func(svc *mocks.IService)
+		statusCode codes.Code
+	}{
+		{
+			name: "admin restores",
+			ctx:  adminCtx,
+			mockSetup: func(svc *mocks.IService) {
+				svc.On("RestoreUser", mock.Anything, "5").Return(restored, nil)
+			},
+		},
+		{
+			name:       "operation user",
+			ctx:        ownerCtx,
+			statusCode: codes.PermissionDenied,
+		},
+		{
+			name: "not deleted",
+			ctx:  adminCtx,
+			mockSetup: func(svc *mocks.IService) {
+				svc.On("RestoreUser", mock.Anything, "5").Return(nil, userEntity.ErrUserNotFound)
+			},
+			statusCode: codes.NotFound,
+		},
+		{
+			name: "email taken since",
+			ctx:  adminCtx,
+			mockSetup: func(svc *mocks.IService) {
+				svc.On("RestoreUser", mock.Anything, "5").Return(nil, userEntity.ErrEmailAlreadyExists)
+			},
+			statusCode: codes.AlreadyExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.IService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockSvc)
+			}
+			h := NewUserHandler(mockSvc, nil)
+
+			got, err := h.RestoreUser(tt.ctx, &pb.RestoreUserRequest{Id: "5"})
+
+			assertStatus(t, err, tt.statusCode)
+			if tt.statusCode == codes.OK {
+				assert.Equal(t, restored.ToProto(), got)
+			}
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserHandler_PurgeDeletedUsers(t *testing.T) {
+	mockSvc := new(mocks.IService)
+	mockSvc.On("PurgeDeletedUsers", mock.Anything, 7*24*time.Hour).Return(int64(3), time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), nil)
+	h := NewUserHandler(mockSvc, nil)
+
+	got, err := h.PurgeDeletedUsers(adminCtx, &commonPb.PurgeDeletedRequest{RetentionDays: 7})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), got.GetPurged())
+	mockSvc.AssertExpectations(t)
+
+	_, err = h.PurgeDeletedUsers(ownerCtx, &commonPb.PurgeDeletedRequest{RetentionDays: 7})
+	assertStatus(t, err, codes.PermissionDenied)
+}
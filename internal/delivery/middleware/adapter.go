@@ -3,9 +3,20 @@ package middleware
 import (
 	"context"
 	bookPb "github.com/hinha/library-management-synapsis/gen/api/proto/book"
+	commonPb "github.com/hinha/library-management-synapsis/gen/api/proto/common"
+	transactionPb "github.com/hinha/library-management-synapsis/gen/api/proto/transaction"
+	userPb "github.com/hinha/library-management-synapsis/gen/api/proto/user"
 	"github.com/hinha/library-management-synapsis/internal/domain"
 	"github.com/hinha/library-management-synapsis/internal/domain/book"
+	"github.com/hinha/library-management-synapsis/internal/domain/user"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"io"
+	"strconv"
+	"sync"
+	"time"
 )
 
 // BookServiceClient is a client for the book service
@@ -20,29 +31,40 @@ func NewBookServiceClient(conn *grpc.ClientConn) *BookServiceClient {
 	}
 }
 
-// TransactionBookRepository defines a subset of book.IDbRepository needed by the transaction service
+// TransactionBookRepository defines a subset of book operations needed by the transaction service
 type TransactionBookRepository interface {
 	GetByID(ctx context.Context, id string) (*domain.Book, error)
-	UpdateStock(ctx context.Context, id string, change int32) error
+	GetCopy(ctx context.Context, barcode string) (*domain.BookCopy, error)
+	ReserveStock(ctx context.Context, id, barcode, transactionID string) (string, error)
+	ReopenStock(ctx context.Context, id, barcode, transactionID string) (string, error)
+	ReleaseStock(ctx context.Context, id, barcode, transactionID string) error
+	CancelStock(ctx context.Context, id, transactionID string) error
+	WriteOffStock(ctx context.Context, id, barcode, transactionID string, damaged bool) error
+	RestoreStock(ctx context.Context, id, barcode, transactionID string) error
 }
 
-// BookRepositoryAdapter adapts the book service client to the TransactionBookRepository interface
+// BookRepositoryAdapter adapts the book service client to the TransactionBookRepository interface.
+// It calls the book service with the service token, on behalf of the caller
+// of the transaction service, so stock changes do not depend on what the
+// caller's own token allows and also work from background workers.
 type BookRepositoryAdapter struct {
-	client *BookServiceClient
+	client       *BookServiceClient
+	serviceToken string
 }
 
-// NewBookRepositoryAdapter creates a new BookRepositoryAdapter
-func NewBookRepositoryAdapter(client *BookServiceClient) TransactionBookRepository {
+// NewBookRepositoryAdapter creates a new BookRepositoryAdapter authenticating with serviceToken
+func NewBookRepositoryAdapter(client *BookServiceClient, serviceToken string) TransactionBookRepository {
 	return &BookRepositoryAdapter{
-		client: client,
+		client:       client,
+		serviceToken: serviceToken,
 	}
 }
 
 // GetByID retrieves a book by ID
 func (a *BookRepositoryAdapter) GetByID(ctx context.Context, id string) (*domain.Book, error) {
-	resp, err := a.client.client.GetBook(ctx, &bookPb.GetBookRequest{Id: id})
+	resp, err := a.client.client.GetBook(a.asService(ctx), &bookPb.GetBookRequest{Id: id})
 	if err != nil {
-		return nil, book.ErrBookNotFound
+		return nil, bookError(err)
 	}
 
 	return &domain.Book{
@@ -54,26 +76,272 @@ func (a *BookRepositoryAdapter) GetByID(ctx context.Context, id string) (*domain
 	}, nil
 }
 
-// UpdateStock updates a book's stock
-func (a *BookRepositoryAdapter) UpdateStock(ctx context.Context, id string, change int32) error {
-	// Get current book
-	b, err := a.GetByID(ctx, id)
+// GetCopy retrieves a copy by barcode
+func (a *BookRepositoryAdapter) GetCopy(ctx context.Context, barcode string) (*domain.BookCopy, error) {
+	resp, err := a.client.client.GetCopy(a.asService(ctx), &bookPb.GetCopyRequest{Barcode: barcode})
 	if err != nil {
-		return err
+		if status.Code(err) == codes.NotFound {
+			return nil, book.ErrCopyNotFound
+		}
+		return nil, err
+	}
+
+	return &domain.BookCopy{
+		ID:            resp.Id,
+		BookID:        resp.BookId,
+		Barcode:       resp.Barcode,
+		ShelfLocation: resp.ShelfLocation,
+		Condition:     domain.CopyCondition(resp.Condition),
+		Status:        domain.CopyStatus(resp.Status),
+	}, nil
+}
+
+// ReserveStock takes one copy of a book out of stock for the loan
+// transactionID: the copy with the barcode, or any copy when it is empty. It
+// returns the barcode of the copy taken, empty for books without copies.
+// Retrying it returns the same copy without taking another.
+func (a *BookRepositoryAdapter) ReserveStock(ctx context.Context, id, barcode, transactionID string) (string, error) {
+	return a.reserve(ctx, &bookPb.ReserveStockRequest{Id: id, Barcode: barcode, TransactionId: transactionID})
+}
+
+// ReopenStock reserves stock like ReserveStock for a restored loan whose
+// borrow was cancelled
+func (a *BookRepositoryAdapter) ReopenStock(ctx context.Context, id, barcode, transactionID string) (string, error) {
+	return a.reserve(ctx, &bookPb.ReserveStockRequest{Id: id, Barcode: barcode, TransactionId: transactionID, Reopen: true})
+}
+
+func (a *BookRepositoryAdapter) reserve(ctx context.Context, req *bookPb.ReserveStockRequest) (string, error) {
+	resp, err := a.client.client.ReserveStock(a.asService(ctx), req)
+	if err != nil {
+		return "", bookError(err)
+	}
+	return resp.GetCopy().GetBarcode(), nil
+}
+
+// ReleaseStock puts the copy the loan transactionID reserved back into stock.
+// Retrying it changes nothing.
+func (a *BookRepositoryAdapter) ReleaseStock(ctx context.Context, id, barcode, transactionID string) error {
+	if _, err := a.client.client.ReleaseStock(a.asService(ctx), &bookPb.ReleaseStockRequest{Id: id, Barcode: barcode, TransactionId: transactionID}); err != nil {
+		return bookError(err)
+	}
+	return nil
+}
+
+// CancelStock undoes the reservation of the loan transactionID after its
+// borrow failed, whether or not the reservation arrived
+func (a *BookRepositoryAdapter) CancelStock(ctx context.Context, id, transactionID string) error {
+	if _, err := a.client.client.ReleaseStock(a.asService(ctx), &bookPb.ReleaseStockRequest{Id: id, TransactionId: transactionID, Cancel: true}); err != nil {
+		return bookError(err)
+	}
+	return nil
+}
+
+// WriteOffStock takes a lost or damaged copy of a book out of circulation
+func (a *BookRepositoryAdapter) WriteOffStock(ctx context.Context, id, barcode, transactionID string, damaged bool) error {
+	if _, err := a.client.client.WriteOffStock(a.asService(ctx), &bookPb.WriteOffStockRequest{Id: id, Barcode: barcode, TransactionId: transactionID, Damaged: damaged}); err != nil {
+		return bookError(err)
+	}
+	return nil
+}
+
+// RestoreStock puts a copy of a book that was lost back into stock
+func (a *BookRepositoryAdapter) RestoreStock(ctx context.Context, id, barcode, transactionID string) error {
+	if _, err := a.client.client.RestoreStock(a.asService(ctx), &bookPb.RestoreStockRequest{Id: id, Barcode: barcode, TransactionId: transactionID}); err != nil {
+		return bookError(err)
+	}
+	return nil
+}
+
+// asService authenticates a call to the book service as this service, on
+// behalf of the caller in ctx, if any
+func (a *BookRepositoryAdapter) asService(ctx context.Context) context.Context {
+	outgoing := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+a.serviceToken)
+	if principal, ok := PrincipalFromContext(ctx); ok {
+		outgoing = metadata.AppendToOutgoingContext(outgoing, onBehalfOfKey, principal.ActorID())
 	}
+	return outgoing
+}
 
-	// Check if there's enough stock when decreasing
-	if change < 0 && b.Stock < -change {
+// bookError maps a book service status back to the book domain errors. A
+// refused stock or copy change carries its reason in a precondition detail;
+// one without a known reason is taken for a lack of stock.
+func bookError(err error) error {
+	st := status.Convert(err)
+	switch st.Code() {
+	case codes.NotFound:
+		return book.ErrBookNotFound
+	case codes.FailedPrecondition:
+		for _, detail := range st.Details() {
+			if failure, ok := detail.(*commonPb.PreconditionFailure); ok {
+				if reasonErr := book.PreconditionError(failure.GetReason()); reasonErr != nil {
+					return reasonErr
+				}
+			}
+		}
 		return book.ErrInsufficientStock
+	default:
+		return err
+	}
+}
+
+// UserServiceClient is a client for the user service
+type UserServiceClient struct {
+	client userPb.UserServiceClient
+}
+
+// NewUserServiceClient creates a new UserServiceClient
+func NewUserServiceClient(conn *grpc.ClientConn) *UserServiceClient {
+	return &UserServiceClient{
+		client: userPb.NewUserServiceClient(conn),
+	}
+}
+
+// TransactionUserRepository defines a subset of user operations needed by the transaction service
+type TransactionUserRepository interface {
+	GetByID(ctx context.Context, id string) (*domain.User, error)
+}
+
+// UserRepositoryAdapter adapts the user service client to the TransactionUserRepository interface
+type UserRepositoryAdapter struct {
+	client *UserServiceClient
+}
+
+// NewUserRepositoryAdapter creates a new UserRepositoryAdapter
+func NewUserRepositoryAdapter(client *UserServiceClient) TransactionUserRepository {
+	return &UserRepositoryAdapter{
+		client: client,
+	}
+}
+
+// GetByID retrieves a user by ID
+func (a *UserRepositoryAdapter) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	resp, err := a.client.client.Get(forwardAuth(ctx), &userPb.GetUserRequest{Id: id})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, user.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	userID, err := strconv.ParseUint(resp.Id, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.User{
+		ID:    uint(userID),
+		Name:  resp.Name,
+		Email: resp.Email,
+		Role:  roleFromProto(resp.Role),
+	}, nil
+}
+
+// roleFromProto converts a protobuf user role to a domain role
+func roleFromProto(role userPb.UserRole) domain.Role {
+	if role == userPb.UserRole_USER_ROLE_ADMIN {
+		return domain.RoleAdmin
+	}
+	return domain.RoleOperation
+}
+
+// TransactionServiceClient is a client for the transaction service
+type TransactionServiceClient struct {
+	client transactionPb.TransactionServiceClient
+}
+
+// NewTransactionServiceClient creates a new TransactionServiceClient
+func NewTransactionServiceClient(conn *grpc.ClientConn) *TransactionServiceClient {
+	return &TransactionServiceClient{
+		client: transactionPb.NewTransactionServiceClient(conn),
+	}
+}
+
+// LoanFeedAdapter adapts the transaction service event stream to the book
+// service's LoanFeed. The stream is limited to admins and services, so it
+// authenticates with the service token rather than a caller's.
+type LoanFeedAdapter struct {
+	client       *TransactionServiceClient
+	serviceToken string
+
+	mu     sync.Mutex
+	denied error
+}
+
+// NewLoanFeedAdapter creates a new LoanFeedAdapter authenticating with serviceToken
+func NewLoanFeedAdapter(client *TransactionServiceClient, serviceToken string) *LoanFeedAdapter {
+	return &LoanFeedAdapter{
+		client:       client,
+		serviceToken: serviceToken,
+	}
+}
+
+// Check reports the error the transaction service last refused the stream
+// with, until a later subscription is let in
+func (a *LoanFeedAdapter) Check() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.denied
+}
+
+// Subscribe streams the transaction service's events after cursor
+func (a *LoanFeedAdapter) Subscribe(ctx context.Context, cursor uint64, types []string, handle func(*domain.OutboxEvent) error) error {
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+a.serviceToken)
+	stream, err := a.client.client.SubscribeEvents(ctx, &commonPb.SubscribeEventsRequest{After: cursor, Types: types})
+	if err != nil {
+		return a.refused(err)
+	}
+	// The headers arrive once the stream is let in
+	if _, err := stream.Header(); err != nil {
+		return a.refused(err)
 	}
+	a.refused(nil)
+
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return a.refused(err)
+		}
 
-	// Update book with new stock
-	_, err = a.client.client.Create(ctx, &bookPb.CreateBookRequest{
-		Title:    b.Title,
-		Author:   b.Author,
-		Category: b.Category,
-		Stock:    b.Stock + change,
-	})
+		createdAt, _ := time.Parse(time.RFC3339, event.GetCreatedAt())
+		if err := handle(&domain.OutboxEvent{
+			ID:            event.GetCursor(),
+			EventID:       event.GetEventId(),
+			AggregateType: event.GetAggregateType(),
+			AggregateID:   event.GetAggregateId(),
+			Type:          event.GetType(),
+			Payload:       event.GetPayload(),
+			CreatedAt:     createdAt,
+		}); err != nil {
+			return err
+		}
+	}
+}
 
+// refused records err as the reason the stream is refused when the
+// transaction service did not accept the token, or clears the reason when err
+// is nil. It returns err.
+func (a *LoanFeedAdapter) refused(err error) error {
+	switch status.Code(err) {
+	case codes.OK, codes.Unauthenticated, codes.PermissionDenied:
+		a.mu.Lock()
+		a.denied = err
+		a.mu.Unlock()
+	}
 	return err
 }
+
+// forwardAuth passes the caller's authorization header on to another service
+func forwardAuth(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	authHeader := md.Get("authorization")
+	if len(authHeader) == 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, "authorization", authHeader[0])
+}
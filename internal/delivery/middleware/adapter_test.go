@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	bookPb "github.com/hinha/library-management-synapsis/gen/api/proto/book"
+	"github.com/hinha/library-management-synapsis/internal/domain/book"
+	"github.com/hinha/library-management-synapsis/pkg/validator"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+// stubBookClient answers every stock change with err
+type stubBookClient struct {
+	bookPb.BookServiceClient
+	err error
+}
+
+func (c *stubBookClient) ReserveStock(context.Context, *bookPb.ReserveStockRequest, ...grpc.CallOption) (*bookPb.ReserveStockResponse, error) {
+	return nil, c.err
+}
+
+func (c *stubBookClient) WriteOffStock(context.Context, *bookPb.WriteOffStockRequest, ...grpc.CallOption) (*bookPb.BookResponse, error) {
+	return nil, c.err
+}
+
+func TestBookRepositoryAdapter_StockErrors(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "book service down")
+
+	tests := []struct {
+		name    string
+		err     error
+		wantErr error
+	}{
+		{name: "book not found", err: status.Error(codes.NotFound, "book not found"), wantErr: book.ErrBookNotFound},
+		{name: "insufficient stock", err: validator.NewPreconditionGRPCError(book.ReasonInsufficientStock, "insufficient stock", nil), wantErr: book.ErrInsufficientStock},
+		{name: "copy not available", err: validator.NewPreconditionGRPCError(book.ReasonCopyNotAvailable, "copy not available", nil), wantErr: book.ErrCopyNotAvailable},
+		{name: "copy not on loan", err: validator.NewPreconditionGRPCError(book.ReasonCopyNotOnLoan, "copy is not on loan", nil), wantErr: book.ErrCopyNotOnLoan},
+		{name: "copy on loan", err: validator.NewPreconditionGRPCError(book.ReasonCopyOnLoan, "copy is on loan", nil), wantErr: book.ErrCopyOnLoan},
+		{name: "copy withdrawn", err: validator.NewPreconditionGRPCError(book.ReasonCopyWithdrawn, "copy is withdrawn", nil), wantErr: book.ErrCopyWithdrawn},
+		{name: "copy not lost", err: validator.NewPreconditionGRPCError(book.ReasonCopyNotLost, "copy is not lost", nil), wantErr: book.ErrCopyNotLost},
+		{name: "counter loans open", err: validator.NewPreconditionGRPCError(book.ReasonCounterLoansOpen, "book is on loan off its stock counter", nil), wantErr: book.ErrCounterLoansOpen},
+		{name: "unknown reason", err: validator.NewPreconditionGRPCError("SOMETHING_NEW", "refused", nil), wantErr: book.ErrInsufficientStock},
+		{name: "no reason", err: status.Error(codes.FailedPrecondition, "insufficient stock"), wantErr: book.ErrInsufficientStock},
+		{name: "other status", err: unavailable, wantErr: unavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter := NewBookRepositoryAdapter(&BookServiceClient{client: &stubBookClient{err: tt.err}}, "service-token")
+
+			_, err := adapter.ReserveStock(context.Background(), "book-1", "LIB-0001", "tx-1")
+			assert.True(t, errors.Is(err, tt.wantErr), "reserve: got %v, want %v", err, tt.wantErr)
+
+			err = adapter.WriteOffStock(context.Background(), "book-1", "LIB-0001", "tx-1", false)
+			assert.True(t, errors.Is(err, tt.wantErr), "write off: got %v, want %v", err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"context"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ServiceUserID is the user ID of another service of the deployment calling
+// with the service token
+const ServiceUserID = "service"
+
+// onBehalfOfKey is the metadata key a service passes the user it acts for in
+const onBehalfOfKey = "on-behalf-of"
+
+// Principal is the caller a validated token belongs to
+type Principal struct {
+	UserID string
+	Role   domain.Role
+	// Service is set for other services of the deployment, which act on
+	// behalf of the user in OnBehalfOf, if any
+	Service    bool
+	OnBehalfOf string
+}
+
+// IsAdmin checks if the caller is an admin
+func (p Principal) IsAdmin() bool {
+	return p.Role == domain.RoleAdmin
+}
+
+// ActorID returns the user the call is made for: the user a service acts on
+// behalf of, or else the caller
+func (p Principal) ActorID() string {
+	if p.Service && p.OnBehalfOf != "" {
+		return p.OnBehalfOf
+	}
+	return p.UserID
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying the caller
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the caller set by the token interceptors
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
+
+// AuthorizeOwner lets the call through when the caller is ownerID or an admin
+func AuthorizeOwner(ctx context.Context, ownerID string) error {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "missing caller identity")
+	}
+	return checkPermission(principal.UserID, ownerID, string(principal.Role))
+}
+
+// AuthorizeAdmin only lets admins through
+func AuthorizeAdmin(ctx context.Context) error {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "missing caller identity")
+	}
+	if !principal.IsAdmin() {
+		return status.Error(codes.PermissionDenied, "permission denied")
+	}
+	return nil
+}
+
+// AuthorizeService only lets other services of the deployment and admins through
+func AuthorizeService(ctx context.Context) error {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "missing caller identity")
+	}
+	if !principal.Service && !principal.IsAdmin() {
+		return status.Error(codes.PermissionDenied, "permission denied")
+	}
+	return nil
+}
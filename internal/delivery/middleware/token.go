@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"context"
+	"crypto/subtle"
 	pb "github.com/hinha/library-management-synapsis/gen/api/proto/user"
 	"github.com/hinha/library-management-synapsis/internal/domain"
 	"github.com/hinha/library-management-synapsis/internal/domain/user"
@@ -17,12 +18,16 @@ import (
 type Middleware struct {
 	service    user.IService
 	authClient pb.UserServiceClient
+	// serviceToken authenticates the other services of the deployment, none
+	// when empty
+	serviceToken string
 }
 
-func NewMiddleware(service user.IService, grpcClient *grpc.ClientConn) *Middleware {
+func NewMiddleware(service user.IService, grpcClient *grpc.ClientConn, serviceToken string) *Middleware {
 	return &Middleware{
-		service:    service,
-		authClient: pb.NewUserServiceClient(grpcClient),
+		service:      service,
+		authClient:   pb.NewUserServiceClient(grpcClient),
+		serviceToken: serviceToken,
 	}
 }
 
@@ -44,6 +49,7 @@ func (m *Middleware) AuthValidateToken() grpc.UnaryServerInterceptor {
 			"/user.UserService/Register":      true,
 			"/user.UserService/ValidateToken": true,
 			"/user.UserService/HealthCheck":   true,
+			"/grpc.health.v1.Health/Check":    true,
 		}
 
 		if whitelist[info.FullMethod] {
@@ -60,6 +66,8 @@ func (m *Middleware) AuthValidateToken() grpc.UnaryServerInterceptor {
 			return nil, status.Error(codes.Unauthenticated, "invalid token")
 		}
 
+		ctx = WithPrincipal(ctx, Principal{UserID: claims.UserID, Role: domain.Role(claims.Role)})
+
 		// Check if user is requesting their own data or is an admin
 		switch request := req.(type) {
 		case *pb.GetUserRequest:
@@ -92,40 +100,50 @@ func (m *Middleware) CrossValidateToken() grpc.UnaryServerInterceptor {
 		whitelist := map[string]bool{
 			"/book.BookService/HealthCheck":               true,
 			"/transaction.TransactionService/HealthCheck": true,
+			"/grpc.health.v1.Health/Check":                true,
 		}
 		if whitelist[info.FullMethod] {
 			return handler(ctx, req)
 		}
 
+		adminOnly := map[string]bool{
+			"/transaction.TransactionService/ListOverdue":      true,
+			"/transaction.TransactionService/ListTransactions": true,
+			"/transaction.TransactionService/RecordPayment":    true,
+			"/transaction.TransactionService/WaiveFine":        true,
+			"/transaction.TransactionService/BlockBorrower":    true,
+			"/transaction.TransactionService/UnblockBorrower":  true,
+			"/transaction.TransactionService/DeclareLost":      true,
+			"/transaction.TransactionService/ReportDamage":     true,
+			"/transaction.TransactionService/MarkFound":        true,
+		}
+
 		authHeader := md.Get("authorization")
 		if len(authHeader) == 0 {
 			return nil, status.Error(codes.Unauthenticated, "missing authorization header")
 		}
 		token := strings.TrimPrefix(authHeader[0], "Bearer ")
 
-		response, err := m.authClient.ValidateToken(ctx, &pb.ValidateTokenRequest{Token: token})
-		if err != nil {
-			st, ok := status.FromError(err)
-			if !ok {
-				log.Error().Err(err).Msg("Failed to validate token")
-				return nil, status.Error(codes.Internal, "Internal server error")
-			}
-			if st.Code() == codes.Unavailable {
-				log.Error().Err(err).Msg("Auth service unavailable")
-				return nil, status.Error(codes.Unavailable, "error connecting another service")
-			} else if st.Code() == codes.Unauthenticated {
-				log.Error().Err(err).Msg("Invalid token")
-				return nil, status.Error(codes.Unauthenticated, "invalid token")
-			} else if st.Code() == codes.PermissionDenied {
-				log.Error().Err(err).Msg("Permission denied")
+		// Other services are not admins, handlers decide what they may call
+		if principal, ok := m.servicePrincipal(md, token); ok {
+			if adminOnly[info.FullMethod] {
 				return nil, status.Error(codes.PermissionDenied, "permission denied")
-			} else {
-				log.Error().Err(err).Msg("Unknown error during token validation")
 			}
+			return handler(WithPrincipal(ctx, principal), req)
+		}
 
-			return nil, err
+		response, err := m.authClient.ValidateToken(ctx, &pb.ValidateTokenRequest{Token: token})
+		if err != nil {
+			return nil, validateTokenError(err)
+		}
+
+		if adminOnly[info.FullMethod] && response.GetRole() != pb.UserRole_USER_ROLE_ADMIN {
+			return nil, status.Error(codes.PermissionDenied, "permission denied")
 		}
 
+		// Handlers check the caller owns what they ask for
+		ctx = WithPrincipal(ctx, Principal{UserID: response.GetUserId(), Role: roleFromProto(response.GetRole())})
+
 		log.Info().Str("path", info.FullMethod).
 			Dur("duration", time.Since(start)).
 			Interface("request", req).
@@ -137,6 +155,136 @@ func (m *Middleware) CrossValidateToken() grpc.UnaryServerInterceptor {
 	}
 }
 
+// AuthStreamAdmin only lets admins open streams on the user service
+func (m *Middleware) AuthStreamAdmin() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		token, err := bearerToken(ss.Context())
+		if err != nil {
+			return err
+		}
+
+		claims, err := m.service.ValidateToken(ss.Context(), token)
+		if err != nil {
+			return status.Error(codes.Unauthenticated, "invalid token")
+		}
+		if claims.Role != string(domain.RoleAdmin) {
+			return status.Error(codes.PermissionDenied, "permission denied")
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+// CrossStreamAdmin only lets admins open streams on the book and transaction
+// services, validating the token against the user service. Other services
+// may follow the event feeds with the service token.
+func (m *Middleware) CrossStreamAdmin() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		token, err := bearerToken(ss.Context())
+		if err != nil {
+			return err
+		}
+
+		serviceStreams := map[string]bool{
+			"/book.BookService/SubscribeEvents":               true,
+			"/transaction.TransactionService/SubscribeEvents": true,
+		}
+		md, _ := metadata.FromIncomingContext(ss.Context())
+		if principal, ok := m.servicePrincipal(md, token); ok && serviceStreams[info.FullMethod] {
+			log.Info().Str("path", info.FullMethod).Msg("Stream opened by a service")
+			return handler(srv, &principalStream{ServerStream: ss, ctx: WithPrincipal(ss.Context(), principal)})
+		}
+
+		response, err := m.authClient.ValidateToken(ss.Context(), &pb.ValidateTokenRequest{Token: token})
+		if err != nil {
+			return validateTokenError(err)
+		}
+		if response.GetRole() != pb.UserRole_USER_ROLE_ADMIN {
+			return status.Error(codes.PermissionDenied, "permission denied")
+		}
+
+		log.Info().Str("path", info.FullMethod).
+			Str("user_id", response.GetUserId()).
+			Msg("Stream opened")
+
+		ctx := WithPrincipal(ss.Context(), Principal{UserID: response.GetUserId(), Role: roleFromProto(response.GetRole())})
+		return handler(srv, &principalStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// servicePrincipal returns the identity of another service of the deployment
+// when token is the service token, acting on behalf of the user in its
+// on-behalf-of metadata, if any
+func (m *Middleware) servicePrincipal(md metadata.MD, token string) (Principal, bool) {
+	if m.serviceToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(m.serviceToken)) != 1 {
+		return Principal{}, false
+	}
+
+	principal := Principal{UserID: ServiceUserID, Service: true}
+	if onBehalfOf := md.Get(onBehalfOfKey); len(onBehalfOf) > 0 {
+		principal.OnBehalfOf = onBehalfOf[0]
+	}
+	return principal, true
+}
+
+// principalStream is a server stream whose context carries the caller
+type principalStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the stream context carrying the caller
+func (s *principalStream) Context() context.Context {
+	return s.ctx
+}
+
+// bearerToken extracts the bearer token from the incoming metadata
+func bearerToken(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, "missing metadata")
+	}
+
+	authHeader := md.Get("authorization")
+	if len(authHeader) == 0 {
+		return "", status.Error(codes.Unauthenticated, "missing authorization header")
+	}
+	return strings.TrimPrefix(authHeader[0], "Bearer "), nil
+}
+
+// validateTokenError maps an error from the user service ValidateToken call
+func validateTokenError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		log.Error().Err(err).Msg("Failed to validate token")
+		return status.Error(codes.Internal, "Internal server error")
+	}
+	if st.Code() == codes.Unavailable {
+		log.Error().Err(err).Msg("Auth service unavailable")
+		return status.Error(codes.Unavailable, "error connecting another service")
+	} else if st.Code() == codes.Unauthenticated {
+		log.Error().Err(err).Msg("Invalid token")
+		return status.Error(codes.Unauthenticated, "invalid token")
+	} else if st.Code() == codes.PermissionDenied {
+		log.Error().Err(err).Msg("Permission denied")
+		return status.Error(codes.PermissionDenied, "permission denied")
+	} else {
+		log.Error().Err(err).Msg("Unknown error during token validation")
+	}
+
+	return err
+}
+
 func checkPermission(srcId, dstId, role string) error {
 	if srcId != dstId && role != string(domain.RoleAdmin) {
 		return status.Error(codes.PermissionDenied, "permission denied")
@@ -11,6 +11,8 @@ import (
 	protouser "github.com/hinha/library-management-synapsis/gen/api/proto/user"
 
 	user "github.com/hinha/library-management-synapsis/internal/domain/user"
+
+	time "time"
 )
 
 // IService is an autogenerated mock type for the IService type
@@ -78,6 +80,43 @@ func (_m *IService) Health(ctx context.Context) (*protouser.HealthCheckResponse,
 	return r0, r1
 }
 
+// ListDeletedUsers provides a mock function with given fields: ctx, pageSize, pageToken
+func (_m *IService) ListDeletedUsers(ctx context.Context, pageSize int, pageToken string) ([]*domain.User, string, error) {
+	ret := _m.Called(ctx, pageSize, pageToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeletedUsers")
+	}
+
+	var r0 []*domain.User
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) ([]*domain.User, string, error)); ok {
+		return rf(ctx, pageSize, pageToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) []*domain.User); ok {
+		r0 = rf(ctx, pageSize, pageToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) string); ok {
+		r1 = rf(ctx, pageSize, pageToken)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int, string) error); ok {
+		r2 = rf(ctx, pageSize, pageToken)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Login provides a mock function with given fields: ctx, email, password
 func (_m *IService) Login(ctx context.Context, email string, password string) (string, string, error) {
 	ret := _m.Called(ctx, email, password)
@@ -113,6 +152,41 @@ func (_m *IService) Login(ctx context.Context, email string, password string) (s
 	return r0, r1, r2
 }
 
+// PurgeDeletedUsers provides a mock function with given fields: ctx, retention
+func (_m *IService) PurgeDeletedUsers(ctx context.Context, retention time.Duration) (int64, time.Time, error) {
+	ret := _m.Called(ctx, retention)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDeletedUsers")
+	}
+
+	var r0 int64
+	var r1 time.Time
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) (int64, time.Time, error)); ok {
+		return rf(ctx, retention)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) int64); ok {
+		r0 = rf(ctx, retention)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Duration) time.Time); ok {
+		r1 = rf(ctx, retention)
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, time.Duration) error); ok {
+		r2 = rf(ctx, retention)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Register provides a mock function with given fields: ctx, name, email, password, isAdmin
 func (_m *IService) Register(ctx context.Context, name string, email string, password string, isAdmin bool) (*domain.User, error) {
 	ret := _m.Called(ctx, name, email, password, isAdmin)
@@ -143,9 +217,39 @@ func (_m *IService) Register(ctx context.Context, name string, email string, pas
 	return r0, r1
 }
 
-// UpdateUser provides a mock function with given fields: ctx, id, name, email
-func (_m *IService) UpdateUser(ctx context.Context, id string, name string, email string) (*domain.User, error) {
-	ret := _m.Called(ctx, id, name, email)
+// RestoreUser provides a mock function with given fields: ctx, id
+func (_m *IService) RestoreUser(ctx context.Context, id string) (*domain.User, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreUser")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.User, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateUser provides a mock function with given fields: ctx, id, version, name, email
+func (_m *IService) UpdateUser(ctx context.Context, id string, version int64, name string, email string) (*domain.User, error) {
+	ret := _m.Called(ctx, id, version, name, email)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateUser")
@@ -153,19 +257,19 @@ func (_m *IService) UpdateUser(ctx context.Context, id string, name string, emai
 
 	var r0 *domain.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*domain.User, error)); ok {
-		return rf(ctx, id, name, email)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, string, string) (*domain.User, error)); ok {
+		return rf(ctx, id, version, name, email)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *domain.User); ok {
-		r0 = rf(ctx, id, name, email)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, string, string) *domain.User); ok {
+		r0 = rf(ctx, id, version, name, email)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
-		r1 = rf(ctx, id, name, email)
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, string, string) error); ok {
+		r1 = rf(ctx, id, version, name, email)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -0,0 +1,145 @@
+package book
+
+import (
+	"context"
+	"errors"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+)
+
+// ErrStockTracked is returned when setting the stock of a book whose stock is
+// counted from its copies
+var ErrStockTracked = errors.New("stock is derived from copies")
+
+// AddCopy registers a new available copy of a book
+func (s *DefaultService) AddCopy(ctx context.Context, bookID, barcode, shelfLocation string, condition domain.CopyCondition) (*domain.BookCopy, error) {
+	if bookID == "" || barcode == "" {
+		return nil, ErrInvalidInput
+	}
+
+	bookCopy := domain.NewBookCopy(bookID, barcode, shelfLocation, condition)
+	if err := s.copyRepo.Create(ctx, bookCopy); err != nil {
+		return nil, err
+	}
+
+	return bookCopy, nil
+}
+
+// ListCopies retrieves every copy of a book
+func (s *DefaultService) ListCopies(ctx context.Context, bookID string) ([]*domain.BookCopy, error) {
+	if bookID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	if _, err := s.repoDb.GetByID(ctx, bookID); err != nil {
+		return nil, err
+	}
+	return s.copyRepo.ListByBook(ctx, bookID)
+}
+
+// GetCopy retrieves a copy by its barcode
+func (s *DefaultService) GetCopy(ctx context.Context, barcode string) (*domain.BookCopy, error) {
+	if barcode == "" {
+		return nil, ErrInvalidInput
+	}
+
+	return s.copyRepo.GetByBarcode(ctx, barcode)
+}
+
+// UpdateCopy updates a copy the same way UpdateBook updates a book. Copies on
+// loan or withdrawn keep their status: checkouts, returns and WithdrawCopy
+// own those.
+func (s *DefaultService) UpdateCopy(ctx context.Context, barcode, shelfLocation string, condition domain.CopyCondition, status domain.CopyStatus, paths []string) (*domain.BookCopy, error) {
+	if barcode == "" {
+		return nil, ErrInvalidInput
+	}
+
+	if len(paths) == 0 {
+		if shelfLocation != "" {
+			paths = append(paths, "shelf_location")
+		}
+		if condition != "" {
+			paths = append(paths, "condition")
+		}
+		if status != "" {
+			paths = append(paths, "status")
+		}
+	}
+
+	bookCopy, err := s.copyRepo.GetByBarcode(ctx, barcode)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range paths {
+		switch path {
+		case "shelf_location":
+			bookCopy.ShelfLocation = shelfLocation
+		case "condition":
+			if condition == "" {
+				return nil, ErrInvalidInput
+			}
+			bookCopy.Condition = condition
+		case "status":
+			switch status {
+			case domain.CopyStatusAvailable, domain.CopyStatusInRepair, domain.CopyStatusLost:
+			default:
+				return nil, ErrInvalidInput
+			}
+			if err := checkStatusChange(bookCopy); err != nil {
+				return nil, err
+			}
+			bookCopy.SetStatus(status)
+		default:
+			return nil, ErrInvalidInput
+		}
+	}
+
+	if err := s.copyRepo.Update(ctx, bookCopy); err != nil {
+		return nil, err
+	}
+
+	return bookCopy, nil
+}
+
+// WithdrawCopy takes a copy out of circulation. The copy is kept so loans
+// that reference it still resolve.
+func (s *DefaultService) WithdrawCopy(ctx context.Context, barcode string) (*domain.BookCopy, error) {
+	if barcode == "" {
+		return nil, ErrInvalidInput
+	}
+
+	bookCopy, err := s.copyRepo.GetByBarcode(ctx, barcode)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkStatusChange(bookCopy); err != nil {
+		return nil, err
+	}
+
+	bookCopy.SetStatus(domain.CopyStatusWithdrawn)
+	if err := s.copyRepo.Update(ctx, bookCopy); err != nil {
+		return nil, err
+	}
+
+	return bookCopy, nil
+}
+
+// checkStatusChange refuses to change the status of a copy on loan or withdrawn
+func checkStatusChange(bookCopy *domain.BookCopy) error {
+	switch bookCopy.Status {
+	case domain.CopyStatusOnLoan:
+		return ErrCopyOnLoan
+	case domain.CopyStatusWithdrawn:
+		return ErrCopyWithdrawn
+	}
+	return nil
+}
+
+// tracksCopies checks if a book's stock is counted from its copies
+func (s *DefaultService) tracksCopies(ctx context.Context, bookID string) (bool, error) {
+	count, err := s.copyRepo.CountByBook(ctx, bookID)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
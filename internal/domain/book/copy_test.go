@@ -0,0 +1,192 @@
+package book
+
+import (
+	"context"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"github.com/hinha/library-management-synapsis/internal/domain/book/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestDefaultService_AddCopy(t *testing.T) {
+	tests := []struct {
+		name      string
+		bookID    string
+		barcode   string
+		condition domain.CopyCondition
+		mockFn    func(copyRepo *mocks.ICopyRepository)
+		wantErr   error
+	}{
+		{
+			name:    "adds an available copy in good condition",
+			bookID:  "book-id-1",
+			barcode: "LIB-0001",
+			mockFn: func(copyRepo *mocks.ICopyRepository) {
+				copyRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *domain.BookCopy) bool {
+					return c.BookID == "book-id-1" && c.Barcode == "LIB-0001" && c.ShelfLocation == "A3" &&
+						c.Condition == domain.CopyConditionGood && c.Status == domain.CopyStatusAvailable
+				})).Return(nil)
+			},
+		},
+		{
+			name:    "duplicate barcode",
+			bookID:  "book-id-1",
+			barcode: "LIB-0001",
+			mockFn: func(copyRepo *mocks.ICopyRepository) {
+				copyRepo.On("Create", mock.Anything, mock.Anything).Return(ErrDuplicateBarcode)
+			},
+			wantErr: ErrDuplicateBarcode,
+		},
+		{
+			name:    "missing barcode",
+			bookID:  "book-id-1",
+			wantErr: ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			copyRepo := new(mocks.ICopyRepository)
+			if tt.mockFn != nil {
+				tt.mockFn(copyRepo)
+			}
+			s := &DefaultService{copyRepo: copyRepo}
+
+			got, err := s.AddCopy(context.Background(), tt.bookID, tt.barcode, "A3", tt.condition)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.Equal(t, tt.barcode, got.Barcode)
+			}
+			copyRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDefaultService_UpdateCopy(t *testing.T) {
+	type args struct {
+		shelfLocation string
+		condition     domain.CopyCondition
+		status        domain.CopyStatus
+		paths         []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		current *domain.BookCopy
+		want    *domain.BookCopy
+		wantErr error
+	}{
+		{
+			name:    "sends a copy to repair",
+			args:    args{condition: domain.CopyConditionDamaged, status: domain.CopyStatusInRepair},
+			current: &domain.BookCopy{Barcode: "LIB-0001", ShelfLocation: "A3", Condition: domain.CopyConditionGood, Status: domain.CopyStatusAvailable},
+			want:    &domain.BookCopy{Barcode: "LIB-0001", ShelfLocation: "A3", Condition: domain.CopyConditionDamaged, Status: domain.CopyStatusInRepair},
+		},
+		{
+			name:    "field mask clears the shelf location",
+			args:    args{paths: []string{"shelf_location"}},
+			current: &domain.BookCopy{Barcode: "LIB-0001", ShelfLocation: "A3", Condition: domain.CopyConditionGood, Status: domain.CopyStatusAvailable},
+			want:    &domain.BookCopy{Barcode: "LIB-0001", Condition: domain.CopyConditionGood, Status: domain.CopyStatusAvailable},
+		},
+		{
+			name:    "copy on loan keeps its status",
+			args:    args{status: domain.CopyStatusAvailable},
+			current: &domain.BookCopy{Barcode: "LIB-0001", Status: domain.CopyStatusOnLoan},
+			wantErr: ErrCopyOnLoan,
+		},
+		{
+			name:    "withdrawn copy keeps its status",
+			args:    args{status: domain.CopyStatusAvailable},
+			current: &domain.BookCopy{Barcode: "LIB-0001", Status: domain.CopyStatusWithdrawn},
+			wantErr: ErrCopyWithdrawn,
+		},
+		{
+			name:    "status owned by checkouts",
+			args:    args{status: domain.CopyStatusOnLoan},
+			current: &domain.BookCopy{Barcode: "LIB-0001", Status: domain.CopyStatusAvailable},
+			wantErr: ErrInvalidInput,
+		},
+		{
+			name:    "unknown path",
+			args:    args{paths: []string{"book_id"}},
+			current: &domain.BookCopy{Barcode: "LIB-0001", Status: domain.CopyStatusAvailable},
+			wantErr: ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			copyRepo := new(mocks.ICopyRepository)
+			copyRepo.On("GetByBarcode", mock.Anything, "LIB-0001").Return(tt.current, nil)
+			if tt.wantErr == nil {
+				copyRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+			}
+			s := &DefaultService{copyRepo: copyRepo}
+
+			got, err := s.UpdateCopy(context.Background(), "LIB-0001", tt.args.shelfLocation, tt.args.condition, tt.args.status, tt.args.paths)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.want != nil {
+				assert.Equal(t, tt.want.ShelfLocation, got.ShelfLocation)
+				assert.Equal(t, tt.want.Condition, got.Condition)
+				assert.Equal(t, tt.want.Status, got.Status)
+			}
+			copyRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDefaultService_WithdrawCopy(t *testing.T) {
+	tests := []struct {
+		name    string
+		current *domain.BookCopy
+		wantErr error
+	}{
+		{
+			name:    "withdraws a copy on the shelf",
+			current: &domain.BookCopy{BookID: "book-id-1", Barcode: "LIB-0001", Status: domain.CopyStatusAvailable},
+		},
+		{
+			name:    "copy on loan",
+			current: &domain.BookCopy{BookID: "book-id-1", Barcode: "LIB-0001", Status: domain.CopyStatusOnLoan},
+			wantErr: ErrCopyOnLoan,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			copyRepo := new(mocks.ICopyRepository)
+			copyRepo.On("GetByBarcode", mock.Anything, "LIB-0001").Return(tt.current, nil)
+			if tt.wantErr == nil {
+				copyRepo.On("Update", mock.Anything, mock.MatchedBy(func(c *domain.BookCopy) bool {
+					return c.Status == domain.CopyStatusWithdrawn
+				})).Return(nil)
+			}
+			s := &DefaultService{copyRepo: copyRepo}
+
+			_, err := s.WithdrawCopy(context.Background(), "LIB-0001")
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			copyRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDefaultService_ListCopies(t *testing.T) {
+	repo := new(mocks.IDbRepository)
+	copyRepo := new(mocks.ICopyRepository)
+	repo.On("GetByID", mock.Anything, "book-id-1").Return(&domain.Book{ID: "book-id-1"}, nil)
+	repo.On("GetByID", mock.Anything, "book-id-2").Return(nil, ErrBookNotFound)
+	copies := []*domain.BookCopy{{Barcode: "LIB-0001"}, {Barcode: "LIB-0002"}}
+	copyRepo.On("ListByBook", mock.Anything, "book-id-1").Return(copies, nil)
+	s := &DefaultService{repoDb: repo, copyRepo: copyRepo}
+
+	got, err := s.ListCopies(context.Background(), "book-id-1")
+	assert.NoError(t, err)
+	assert.Equal(t, copies, got)
+
+	_, err = s.ListCopies(context.Background(), "book-id-2")
+	assert.ErrorIs(t, err, ErrBookNotFound)
+}
@@ -0,0 +1,91 @@
+package book
+
+import (
+	"context"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ListDeletedBooks retrieves one page of the soft-deleted books, most
+// recently deleted first. It returns the token for the next page, empty on
+// the last page.
+func (s *DefaultService) ListDeletedBooks(ctx context.Context, pageSize int, pageToken string) ([]*domain.Book, string, error) {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	var after *domain.DeletedCursor
+	if pageToken != "" {
+		cursor, ok := domain.DecodeDeletedCursor(pageToken)
+		if !ok {
+			return nil, "", ErrInvalidPageToken
+		}
+		after = cursor
+	}
+
+	// One book past the page tells whether another page follows
+	books, err := s.repoDb.ListDeleted(ctx, after, pageSize+1)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(books) <= pageSize {
+		return books, "", nil
+	}
+
+	books = books[:pageSize]
+	last := books[pageSize-1]
+	nextPageToken, err := domain.DeletedCursor{DeletedAt: last.DeletedAt.Time, ID: last.ID}.Encode()
+	if err != nil {
+		return nil, "", err
+	}
+	return books, nextPageToken, nil
+}
+
+// RestoreBook brings a soft-deleted book back. It returns ErrDuplicateISBN
+// when another book has taken its ISBN since it was deleted.
+func (s *DefaultService) RestoreBook(ctx context.Context, id string) (*domain.Book, error) {
+	if id == "" {
+		return nil, ErrInvalidInput
+	}
+	return s.repoDb.Restore(ctx, id)
+}
+
+// PurgeDeletedBooks permanently removes the books soft-deleted longer ago
+// than retention. It returns how many books were removed and the cutoff.
+func (s *DefaultService) PurgeDeletedBooks(ctx context.Context, retention time.Duration) (int64, time.Time, error) {
+	if retention <= 0 {
+		return 0, time.Time{}, ErrInvalidInput
+	}
+
+	before := time.Now().Add(-retention)
+	purged, err := s.repoDb.Purge(ctx, before)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	return purged, before, nil
+}
+
+// RunRetention calls PurgeDeletedBooks every interval until ctx is cancelled
+func (s *DefaultService) RunRetention(ctx context.Context, interval, retention time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, _, err := s.PurgeDeletedBooks(ctx, retention)
+			if err != nil {
+				log.Error().Err(err).Msg("Failed to purge deleted books")
+			} else if purged > 0 {
+				log.Info().Int64("books", purged).Msg("Purged deleted books")
+			}
+		}
+	}
+}
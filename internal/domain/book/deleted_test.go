@@ -0,0 +1,138 @@
+package book
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"github.com/hinha/library-management-synapsis/internal/domain/book/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestDefaultService_ListDeletedBooks(t *testing.T) {
+	deletedAt := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	books := []*domain.Book{
+		{ID: "book-3", DeletedAt: gorm.DeletedAt{Time: deletedAt.Add(time.Hour), Valid: true}},
+		{ID: "book-2", DeletedAt: gorm.DeletedAt{Time: deletedAt, Valid: true}},
+		{ID: "book-1", DeletedAt: gorm.DeletedAt{Time: deletedAt, Valid: true}},
+	}
+	nextPage, _ := domain.DeletedCursor{DeletedAt: deletedAt, ID: "book-2"}.Encode()
+
+	tests := []struct {
+		name          string
+		pageSize      int
+		pageToken     string
+		mockFn        func(repo *mocks.IDbRepository)
+		wantIDs       []string
+		wantPageToken string
+		wantErr       error
+	}{
+		{
+			name:     "first page with more to come",
+			pageSize: 2,
+			mockFn: func(repo *mocks.IDbRepository) {
+				repo.On("ListDeleted", mock.Anything, (*domain.DeletedCursor)(nil), 3).Return(books, nil)
+			},
+			wantIDs:       []string{"book-3", "book-2"},
+			wantPageToken: nextPage,
+		},
+		{
+			name:      "last page",
+			pageToken: nextPage,
+			mockFn: func(repo *mocks.IDbRepository) {
+				repo.On("ListDeleted", mock.Anything, mock.MatchedBy(func(after *domain.DeletedCursor) bool {
+					return after.ID == "book-2" && after.DeletedAt.Equal(deletedAt)
+				}), DefaultPageSize+1).Return(books[2:], nil)
+			},
+			wantIDs: []string{"book-1"},
+		},
+		{
+			name:      "malformed token",
+			pageToken: "not-a-token",
+			wantErr:   ErrInvalidPageToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.IDbRepository)
+			if tt.mockFn != nil {
+				tt.mockFn(repo)
+			}
+			s := &DefaultService{repoDb: repo}
+
+			got, pageToken, err := s.ListDeletedBooks(context.Background(), tt.pageSize, tt.pageToken)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil && assert.Len(t, got, len(tt.wantIDs)) {
+				for i, book := range got {
+					assert.Equal(t, tt.wantIDs[i], book.ID)
+				}
+				assert.Equal(t, tt.wantPageToken, pageToken)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDefaultService_RestoreBook(t *testing.T) {
+	t.Run("restored", func(t *testing.T) {
+		repo := new(mocks.IDbRepository)
+		repo.On("Restore", mock.Anything, "book-1").Return(&domain.Book{ID: "book-1", Version: 4}, nil)
+		s := &DefaultService{repoDb: repo}
+
+		book, err := s.RestoreBook(context.Background(), "book-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), book.Version)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("isbn taken since", func(t *testing.T) {
+		repo := new(mocks.IDbRepository)
+		repo.On("Restore", mock.Anything, "book-1").Return(nil, ErrDuplicateISBN)
+		s := &DefaultService{repoDb: repo}
+
+		_, err := s.RestoreBook(context.Background(), "book-1")
+
+		assert.ErrorIs(t, err, ErrDuplicateISBN)
+	})
+
+	t.Run("missing id", func(t *testing.T) {
+		s := &DefaultService{repoDb: new(mocks.IDbRepository)}
+
+		_, err := s.RestoreBook(context.Background(), "")
+
+		assert.ErrorIs(t, err, ErrInvalidInput)
+	})
+}
+
+func TestDefaultService_PurgeDeletedBooks(t *testing.T) {
+	t.Run("purged", func(t *testing.T) {
+		repo := new(mocks.IDbRepository)
+		var cutoff time.Time
+		repo.On("Purge", mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+			cutoff = before
+			return time.Since(before) >= 30*24*time.Hour
+		})).Return(int64(2), nil)
+		s := &DefaultService{repoDb: repo}
+
+		purged, before, err := s.PurgeDeletedBooks(context.Background(), 30*24*time.Hour)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), purged)
+		assert.Equal(t, cutoff, before)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("no retention", func(t *testing.T) {
+		s := &DefaultService{repoDb: new(mocks.IDbRepository)}
+
+		_, _, err := s.PurgeDeletedBooks(context.Background(), 0)
+
+		assert.ErrorIs(t, err, ErrInvalidInput)
+	})
+}
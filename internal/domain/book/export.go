@@ -0,0 +1,45 @@
+package book
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"io"
+)
+
+// ExportBooks writes every book matching filter to w, in the order a listing
+// with the same filter returns them. Books are read through a database
+// cursor, so memory use does not grow with the size of the catalogue.
+func (s *DefaultService) ExportBooks(ctx context.Context, filter ListFilter, w domain.ExportWriter) error {
+	if filter.SortBy == "" {
+		filter.SortBy = SortByCreatedAt
+	}
+	if err := s.repoDb.Each(ctx, filter, w.Write); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// jsonExportWriter writes books as JSON Lines, one object per book
+type jsonExportWriter struct {
+	buf     *bufio.Writer
+	encoder *json.Encoder
+}
+
+// NewJSONExportWriter writes books to w as newline delimited JSON objects
+// with the same fields as the book responses
+func NewJSONExportWriter(w io.Writer) domain.ExportWriter {
+	buf := bufio.NewWriter(w)
+	return &jsonExportWriter{buf: buf, encoder: json.NewEncoder(buf)}
+}
+
+// Write implements domain.ExportWriter
+func (j *jsonExportWriter) Write(book *domain.Book) error {
+	return j.encoder.Encode(book)
+}
+
+// Flush implements domain.ExportWriter
+func (j *jsonExportWriter) Flush() error {
+	return j.buf.Flush()
+}
@@ -0,0 +1,75 @@
+package book
+
+import (
+	"encoding/csv"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"io"
+	"strconv"
+	"time"
+)
+
+// exportColumns is the header of a CSV export. Its book fields carry the
+// names an import reads, so an export can be imported again.
+var exportColumns = append(append([]string{"id"}, importFields...), "created_at", "updated_at")
+
+// csvExportWriter writes books as the rows of a CSV file
+type csvExportWriter struct {
+	writer *csv.Writer
+	header bool
+}
+
+// NewCSVExportWriter writes books to w as CSV rows under a header row. Zero
+// publication years and page counts are left empty.
+func NewCSVExportWriter(w io.Writer) domain.ExportWriter {
+	return &csvExportWriter{writer: csv.NewWriter(w)}
+}
+
+// Write implements domain.ExportWriter
+func (c *csvExportWriter) Write(book *domain.Book) error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+	return c.writer.Write([]string{
+		book.ID,
+		book.Title,
+		book.Author,
+		book.Category,
+		strconv.Itoa(int(book.Stock)),
+		book.ISBN,
+		book.Publisher,
+		optionalInt(book.PublicationYear),
+		book.Language,
+		optionalInt(book.PageCount),
+		book.Edition,
+		book.Description,
+		book.CreatedAt.UTC().Format(time.RFC3339),
+		book.UpdatedAt.UTC().Format(time.RFC3339),
+	})
+}
+
+// Flush implements domain.ExportWriter. An export without books still gets
+// its header row.
+func (c *csvExportWriter) Flush() error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+// writeHeader writes the header row before the first row
+func (c *csvExportWriter) writeHeader() error {
+	if c.header {
+		return nil
+	}
+	c.header = true
+	return c.writer.Write(exportColumns)
+}
+
+// optionalInt formats n, or returns "" for zero
+func optionalInt(n int32) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(int(n))
+}
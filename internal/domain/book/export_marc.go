@@ -0,0 +1,161 @@
+package book
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/language"
+)
+
+const (
+	// marcMaxFieldLength is the longest field a directory entry can describe
+	marcMaxFieldLength = 9999
+	// marcMaxRecordLength is the longest record a leader can describe
+	marcMaxRecordLength = 99999
+)
+
+// marcBibliographicCodes are the MARC language codes that differ from the
+// ISO 639-2/T codes the language package knows
+var marcBibliographicCodes = map[string]string{
+	"bod": "tib", "ces": "cze", "cym": "wel", "deu": "ger", "ell": "gre",
+	"eus": "baq", "fas": "per", "fra": "fre", "hye": "arm", "isl": "ice",
+	"kat": "geo", "mkd": "mac", "mri": "mao", "msa": "may", "mya": "bur",
+	"nld": "dut", "ron": "rum", "slk": "slo", "sqi": "alb", "zho": "chi",
+}
+
+// marcExportWriter writes books as binary MARC21 (ISO 2709) bibliographic
+// records
+type marcExportWriter struct {
+	buf *bufio.Writer
+}
+
+// NewMARCExportWriter writes books to w as UTF-8 MARC21 records, the layout
+// an import reads back. MARC21 bibliographic records have no stock, so it is
+// left out.
+func NewMARCExportWriter(w io.Writer) domain.ExportWriter {
+	return &marcExportWriter{buf: bufio.NewWriter(w)}
+}
+
+// Write implements domain.ExportWriter
+func (m *marcExportWriter) Write(book *domain.Book) error {
+	record, err := marcRecordOf(book)
+	if err != nil {
+		return err
+	}
+	_, err = m.buf.Write(record)
+	return err
+}
+
+// Flush implements domain.ExportWriter
+func (m *marcExportWriter) Flush() error {
+	return m.buf.Flush()
+}
+
+// marcRecordOf encodes a book as one MARC21 record
+func marcRecordOf(book *domain.Book) ([]byte, error) {
+	var directory, data bytes.Buffer
+	addField := func(tag, value string) {
+		value = truncateUTF8(value, marcMaxFieldLength-1) + string(rune(marcFieldTerminator))
+		fmt.Fprintf(&directory, "%s%04d%05d", tag, len(value), data.Len())
+		data.WriteString(value)
+	}
+	// addDataField adds a field with the given indicators and subfields, given
+	// as code and value pairs. Subfields without a value are left out.
+	addDataField := func(tag, indicators string, subfields ...string) {
+		var field strings.Builder
+		for i := 0; i+1 < len(subfields); i += 2 {
+			if value := marcValue(subfields[i+1]); value != "" {
+				field.WriteByte(marcSubfieldDelimiter)
+				field.WriteString(subfields[i] + value)
+			}
+		}
+		if field.Len() > 0 {
+			addField(tag, indicators+field.String())
+		}
+	}
+
+	addField("001", marcValue(book.ID))
+	addField("008", marcFixedData(book))
+	addDataField("020", "  ", "a", book.ISBN)
+	addDataField("100", "0 ", "a", book.Author)
+	addDataField("245", "00", "a", book.Title)
+	addDataField("250", "  ", "a", book.Edition)
+	addDataField("264", " 1", "b", book.Publisher, "c", optionalInt(book.PublicationYear))
+	if book.PageCount > 0 {
+		addDataField("300", "  ", "a", fmt.Sprintf("%d pages", book.PageCount))
+	}
+	addDataField("520", "  ", "a", book.Description)
+	addDataField("650", " 4", "a", book.Category)
+	directory.WriteByte(marcFieldTerminator)
+
+	base := marcLeaderLength + directory.Len()
+	length := base + data.Len() + 1
+	if length > marcMaxRecordLength {
+		return nil, fmt.Errorf("book %s is too long for a MARC21 record", book.ID)
+	}
+
+	record := make([]byte, 0, length)
+	record = fmt.Appendf(record, "%05dnam a22%05d   4500", length, base)
+	record = append(record, directory.Bytes()...)
+	record = append(record, data.Bytes()...)
+	return append(record, marcRecordTerminator), nil
+}
+
+// marcFixedData builds the 008 field: the date the book was added, its
+// publication year and its language
+func marcFixedData(book *domain.Book) string {
+	dateType, year := "n", "uuuu"
+	if book.PublicationYear > 0 && book.PublicationYear <= 9999 {
+		dateType, year = "s", fmt.Sprintf("%04d", book.PublicationYear)
+	}
+	return book.CreatedAt.UTC().Format("060102") + dateType + year +
+		strings.Repeat(" ", 24) + marcLanguageCode(book.Language) + " d"
+}
+
+// marcLanguageCode converts a BCP 47 tag such as "en" to its MARC language
+// code, or "und" when there is none
+func marcLanguageCode(tag string) string {
+	parsed, err := language.Parse(tag)
+	if err != nil {
+		return "und"
+	}
+	base, confidence := parsed.Base()
+	if confidence == language.No {
+		return "und"
+	}
+	code := base.ISO3()
+	if bibliographic, ok := marcBibliographicCodes[code]; ok {
+		return bibliographic
+	}
+	if len(code) != 3 {
+		return "und"
+	}
+	return code
+}
+
+// marcValue drops the characters that delimit MARC21 fields and subfields
+func marcValue(value string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case marcFieldTerminator, marcRecordTerminator, marcSubfieldDelimiter:
+			return -1
+		}
+		return r
+	}, value)
+}
+
+// truncateUTF8 cuts s to at most n bytes without splitting a character
+func truncateUTF8(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,57 @@
+package book
+
+import (
+	"bytes"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewMARCExportWriter(t *testing.T) {
+	tests := []struct {
+		name string
+		book *domain.Book
+		want *domain.ImportRecord
+	}{
+		{
+			name: "full record imports back",
+			book: exportBook,
+			want: &domain.ImportRecord{
+				Row: 1, Title: exportBook.Title, Author: exportBook.Author, Category: exportBook.Category, Stock: 1,
+				BookMetadata: exportBook.BookMetadata,
+			},
+		},
+		{
+			name: "delimiters in values are dropped",
+			book: &domain.Book{ID: "book-2", Title: "Em\x1fma\x1e", Author: "Jane Austen", Category: "Fiction", BookMetadata: domain.BookMetadata{Language: "de"}},
+			want: &domain.ImportRecord{
+				Row: 1, Title: "Emma", Author: "Jane Austen", Category: "Fiction", Stock: 1,
+				BookMetadata: domain.BookMetadata{Language: "de"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			w := NewMARCExportWriter(&out)
+			assert.NoError(t, w.Write(tt.book))
+			assert.NoError(t, w.Flush())
+
+			assert.Equal(t, []*domain.ImportRecord{tt.want}, readAll(t, NewMARCImportReader(&out, 1)))
+		})
+	}
+}
+
+func TestMARCLanguageCode(t *testing.T) {
+	tests := map[string]string{
+		"en":      "eng",
+		"de":      "ger",
+		"zh-Hant": "chi",
+		"":        "und",
+		"klingon": "und",
+	}
+	for tag, want := range tests {
+		assert.Equal(t, want, marcLanguageCode(tag), tag)
+	}
+}
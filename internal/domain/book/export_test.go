@@ -0,0 +1,184 @@
+package book
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"github.com/hinha/library-management-synapsis/internal/domain/book/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+// exportBook is a book with every field set
+var exportBook = &domain.Book{
+	ID: "book-1", Title: "Dune", Author: "Frank Herbert", Category: "Science fiction", Stock: 3,
+	BookMetadata: domain.BookMetadata{
+		ISBN: "9780441172719", Publisher: "Ace Books", PublicationYear: 1965, Language: "en",
+		PageCount: 535, Edition: "First edition", Description: "Set on the desert planet Arrakis.",
+	},
+	Version:   4,
+	CreatedAt: time.Date(2025, 6, 22, 9, 0, 0, 0, time.UTC),
+	UpdatedAt: time.Date(2025, 6, 23, 9, 0, 0, 0, time.UTC),
+}
+
+// eachOf calls fn with every book of books
+func eachOf(books ...*domain.Book) func(context.Context, ListFilter, func(*domain.Book) error) error {
+	return func(_ context.Context, _ ListFilter, fn func(*domain.Book) error) error {
+		for _, book := range books {
+			if err := fn(book); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// bookRecorder is an export writer keeping the books written to it
+type bookRecorder struct {
+	books   []*domain.Book
+	flushed bool
+}
+
+func (r *bookRecorder) Write(book *domain.Book) error {
+	r.books = append(r.books, book)
+	return nil
+}
+
+func (r *bookRecorder) Flush() error {
+	r.flushed = true
+	return nil
+}
+
+func TestDefaultService_ExportBooks(t *testing.T) {
+	emma := &domain.Book{ID: "book-2", Title: "Emma"}
+	tests := []struct {
+		name    string
+		filter  ListFilter
+		mockFn  func(repo *mocks.IDbRepository)
+		want    []*domain.Book
+		wantErr error
+	}{
+		{
+			name:   "defaults to newest first",
+			filter: ListFilter{Category: "Science fiction", Descending: true},
+			mockFn: func(repo *mocks.IDbRepository) {
+				repo.On("Each", mock.Anything, ListFilter{Category: "Science fiction", SortBy: SortByCreatedAt, Descending: true}, mock.Anything).
+					Return(eachOf(exportBook, emma))
+			},
+			want: []*domain.Book{exportBook, emma},
+		},
+		{
+			name:   "keeps the sort field",
+			filter: ListFilter{SortBy: SortByTitle},
+			mockFn: func(repo *mocks.IDbRepository) {
+				repo.On("Each", mock.Anything, ListFilter{SortBy: SortByTitle}, mock.Anything).Return(eachOf())
+			},
+		},
+		{
+			name: "repository error",
+			mockFn: func(repo *mocks.IDbRepository) {
+				repo.On("Each", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("db error"))
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.IDbRepository)
+			tt.mockFn(repo)
+			s := &DefaultService{repoDb: repo}
+			recorder := &bookRecorder{}
+
+			err := s.ExportBooks(context.Background(), tt.filter, recorder)
+
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				assert.False(t, recorder.flushed)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, recorder.books)
+				assert.True(t, recorder.flushed)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNewJSONExportWriter(t *testing.T) {
+	var out bytes.Buffer
+	w := NewJSONExportWriter(&out)
+
+	assert.NoError(t, w.Write(exportBook))
+	assert.NoError(t, w.Write(&domain.Book{ID: "book-2", Title: "Emma", Version: 1, CreatedAt: exportBook.CreatedAt, UpdatedAt: exportBook.UpdatedAt}))
+	assert.Empty(t, out.String(), "nothing is written before Flush")
+	assert.NoError(t, w.Flush())
+
+	assert.Equal(t,
+		`{"id":"book-1","title":"Dune","author":"Frank Herbert","category":"Science fiction","stock":3,`+
+			`"isbn":"9780441172719","publisher":"Ace Books","publication_year":1965,"language":"en","page_count":535,`+
+			`"edition":"First edition","description":"Set on the desert planet Arrakis.","version":4,`+
+			`"created_at":"2025-06-22T09:00:00Z","updated_at":"2025-06-23T09:00:00Z"}`+"\n"+
+			`{"id":"book-2","title":"Emma","author":"","category":"","stock":0,"version":1,`+
+			`"created_at":"2025-06-22T09:00:00Z","updated_at":"2025-06-23T09:00:00Z"}`+"\n",
+		out.String())
+}
+
+func TestNewCSVExportWriter(t *testing.T) {
+	header := "id,title,author,category,stock,isbn,publisher,publication_year,language,page_count,edition,description,created_at,updated_at\n"
+	tests := []struct {
+		name  string
+		books []*domain.Book
+		want  string
+	}{
+		{
+			name: "books",
+			books: []*domain.Book{
+				exportBook,
+				{ID: "book-2", Title: "Emma, a novel", Author: "Jane Austen", Category: "Fiction",
+					BookMetadata: domain.BookMetadata{Description: "Three \"quoted\"\nlines"},
+					CreatedAt:    exportBook.CreatedAt, UpdatedAt: exportBook.UpdatedAt},
+			},
+			want: header +
+				"book-1,Dune,Frank Herbert,Science fiction,3,9780441172719,Ace Books,1965,en,535,First edition,Set on the desert planet Arrakis.,2025-06-22T09:00:00Z,2025-06-23T09:00:00Z\n" +
+				"book-2,\"Emma, a novel\",Jane Austen,Fiction,0,,,,,,,\"Three \"\"quoted\"\"\nlines\",2025-06-22T09:00:00Z,2025-06-23T09:00:00Z\n",
+		},
+		{
+			name: "no books still has a header",
+			want: header,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			w := NewCSVExportWriter(&out)
+			for _, book := range tt.books {
+				assert.NoError(t, w.Write(book))
+			}
+			assert.NoError(t, w.Flush())
+
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+}
+
+func TestNewCSVExportWriter_ImportsBack(t *testing.T) {
+	var out bytes.Buffer
+	w := NewCSVExportWriter(&out)
+	assert.NoError(t, w.Write(exportBook))
+	assert.NoError(t, w.Flush())
+
+	reader, err := NewCSVImportReader(&out, nil, 1)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, []*domain.ImportRecord{{
+		Row: 2, Title: exportBook.Title, Author: exportBook.Author, Category: exportBook.Category, Stock: exportBook.Stock,
+		BookMetadata: exportBook.BookMetadata,
+	}}, readAll(t, reader))
+}
@@ -0,0 +1,79 @@
+package book
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"io"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ImportBooks saves the records of a catalogue import in batches of
+// batchSize records, each batch in one transaction. Records that failed to
+// read, have a malformed ISBN or repeat an ISBN seen earlier in the file are
+// rejected; the others update the book with their ISBN or create one. Fields
+// a record leaves empty keep their current values. With dryRun nothing is
+// saved, but the report says what the import would have done.
+func (s *DefaultService) ImportBooks(ctx context.Context, records domain.ImportReader, batchSize int, dryRun bool) (*domain.ImportReport, error) {
+	if batchSize <= 0 {
+		batchSize = domain.DefaultImportBatchSize
+	}
+
+	report := &domain.ImportReport{DryRun: dryRun, Results: []domain.ImportResult{}}
+	isbnRows := make(map[string]int)
+	batch := make([]*domain.ImportRecord, 0, batchSize)
+	for {
+		record, err := records.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if record.Err != nil {
+			report.Reject(record, record.Err.Error())
+			continue
+		}
+		if err := record.Normalize(); err != nil {
+			report.Reject(record, err.Error())
+			continue
+		}
+		if record.ISBN != "" {
+			if row, ok := isbnRows[record.ISBN]; ok {
+				report.Reject(record, fmt.Sprintf("isbn already imported from row %d", row))
+				continue
+			}
+			isbnRows[record.ISBN] = record.Row
+		}
+
+		batch = append(batch, record)
+		if len(batch) == batchSize {
+			s.importBatch(ctx, report, batch, dryRun)
+			batch = batch[:0]
+		}
+	}
+	if len(batch) > 0 {
+		s.importBatch(ctx, report, batch, dryRun)
+	}
+
+	return report, nil
+}
+
+// importBatch saves one batch of records, rejecting all of them when the
+// transaction fails
+func (s *DefaultService) importBatch(ctx context.Context, report *domain.ImportReport, batch []*domain.ImportRecord, dryRun bool) {
+	results, err := s.repoDb.ImportBatch(ctx, batch, dryRun)
+	if err != nil {
+		log.Error().Err(err).Int("records", len(batch)).Msg("Failed to import batch")
+		for _, record := range batch {
+			report.Reject(record, "batch could not be saved")
+		}
+		return
+	}
+	for _, result := range results {
+		report.Add(result)
+	}
+}
@@ -0,0 +1,163 @@
+package book
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidImport is returned when an import file cannot be read at all,
+// such as a CSV file whose header lacks a mapped column
+var ErrInvalidImport = errors.New("invalid import file")
+
+// importFields are the book fields an import record can set
+var importFields = []string{
+	"title", "author", "category", "stock", "isbn", "publisher",
+	"publication_year", "language", "page_count", "edition", "description",
+}
+
+// requiredImportColumns are the fields a CSV import needs a column for
+var requiredImportColumns = []string{"title", "author", "category"}
+
+// csvImportReader reads import records from the rows of a CSV file
+type csvImportReader struct {
+	reader       *csv.Reader
+	columns      map[string]int
+	defaultStock int32
+}
+
+// NewCSVImportReader reads books from a CSV file with a header row. columns
+// maps book field names to column headers; fields it leaves out are read
+// from the column named after them, when there is one. Headers are compared
+// case-insensitively. Rows with an empty stock get defaultStock.
+func NewCSVImportReader(r io.Reader, columns map[string]string, defaultStock int32) (domain.ImportReader, error) {
+	for field := range columns {
+		if !isImportField(field) {
+			return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidImport, field)
+		}
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: missing header row", ErrInvalidImport)
+		} else if errors.As(err, &parseErr) {
+			return nil, fmt.Errorf("%w: header: %v", ErrInvalidImport, parseErr.Err)
+		}
+		return nil, err
+	}
+
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		index[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	resolved := make(map[string]int, len(importFields))
+	for _, field := range importFields {
+		name, mapped := columns[field]
+		if !mapped {
+			name = field
+		}
+		i, ok := index[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			if mapped {
+				return nil, fmt.Errorf("%w: no column %q for %s", ErrInvalidImport, name, field)
+			}
+			continue
+		}
+		resolved[field] = i
+	}
+	for _, field := range requiredImportColumns {
+		if _, ok := resolved[field]; !ok {
+			return nil, fmt.Errorf("%w: no column for %s", ErrInvalidImport, field)
+		}
+	}
+
+	return &csvImportReader{
+		reader:       reader,
+		columns:      resolved,
+		defaultStock: defaultStock,
+	}, nil
+}
+
+// Next reads the next row. Rows the CSV parser chokes on come back with Err
+// set, so one stray quote does not stop the import.
+func (c *csvImportReader) Next() (*domain.ImportRecord, error) {
+	row, err := c.reader.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return &domain.ImportRecord{Row: parseErr.StartLine, Err: parseErr.Err}, nil
+		}
+		return nil, err
+	}
+
+	line, _ := c.reader.FieldPos(0)
+	record := &domain.ImportRecord{Row: line, Stock: c.defaultStock}
+	value := func(field string) string {
+		i, ok := c.columns[field]
+		if !ok || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	record.Title = value("title")
+	record.Author = value("author")
+	record.Category = value("category")
+	record.ISBN = value("isbn")
+	record.Publisher = value("publisher")
+	record.Language = value("language")
+	record.Edition = value("edition")
+	record.Description = value("description")
+	for _, column := range []struct {
+		field string
+		dst   *int32
+	}{
+		{"stock", &record.Stock},
+		{"publication_year", &record.PublicationYear},
+		{"page_count", &record.PageCount},
+	} {
+		if err := parseImportInt(column.field, value(column.field), column.dst); err != nil && record.Err == nil {
+			record.Err = err
+		}
+	}
+
+	return record, nil
+}
+
+// parseImportInt parses a whole number column, leaving dst as is when the
+// column is empty
+func parseImportInt(field, value string, dst *int32) error {
+	if value == "" {
+		return nil
+	}
+	n, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return fmt.Errorf("%s: %q is not a whole number", field, value)
+	}
+	*dst = int32(n)
+	return nil
+}
+
+// isImportField checks if field is a book field an import can set
+func isImportField(field string) bool {
+	for _, f := range importFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+package book
+
+import (
+	"errors"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"strings"
+	"testing"
+)
+
+// readAll drains an import reader
+func readAll(t *testing.T, reader domain.ImportReader) []*domain.ImportRecord {
+	t.Helper()
+	var records []*domain.ImportRecord
+	for {
+		record, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return records
+		}
+		if !assert.NoError(t, err) {
+			return records
+		}
+		records = append(records, record)
+	}
+}
+
+func TestNewCSVImportReader(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		columns map[string]string
+		want    []*domain.ImportRecord
+		wantErr string
+	}{
+		{
+			name: "columns named after fields",
+			input: "Title,Author,Category,Stock,ISBN,Publication_Year\n" +
+				"Dune,Frank Herbert,Fiction,3,978-0-441-17271-9,1965\n",
+			want: []*domain.ImportRecord{{
+				Row: 2, Title: "Dune", Author: "Frank Herbert", Category: "Fiction", Stock: 3,
+				BookMetadata: domain.BookMetadata{ISBN: "978-0-441-17271-9", PublicationYear: 1965},
+			}},
+		},
+		{
+			name:    "header mapping",
+			input:   "\ufeffBook Title,Writer,Genre,Copies\nDune,Frank Herbert,Fiction,\n",
+			columns: map[string]string{"title": "book title", "author": "Writer", "category": "Genre", "stock": "Copies"},
+			want: []*domain.ImportRecord{{
+				Row: 2, Title: "Dune", Author: "Frank Herbert", Category: "Fiction", Stock: 5,
+			}},
+		},
+		{
+			name:  "quoted field spanning lines",
+			input: "title,author,category,description\n\"Dune\",Frank Herbert,Fiction,\"Spice\nand sand\"\nEmma,Jane Austen,Fiction,\n",
+			want: []*domain.ImportRecord{
+				{Row: 2, Title: "Dune", Author: "Frank Herbert", Category: "Fiction", Stock: 5, BookMetadata: domain.BookMetadata{Description: "Spice\nand sand"}},
+				{Row: 4, Title: "Emma", Author: "Jane Austen", Category: "Fiction", Stock: 5},
+			},
+		},
+		{
+			name:  "short row leaves missing fields empty",
+			input: "title,author,category,stock\nDune,Frank Herbert\n",
+			want:  []*domain.ImportRecord{{Row: 2, Title: "Dune", Author: "Frank Herbert", Stock: 5}},
+		},
+		{
+			name:  "number that is not a number",
+			input: "title,author,category,stock\nDune,Frank Herbert,Fiction,three\n",
+			want: []*domain.ImportRecord{{
+				Row: 2, Title: "Dune", Author: "Frank Herbert", Category: "Fiction", Stock: 5,
+				Err: errors.New(`stock: "three" is not a whole number`),
+			}},
+		},
+		{
+			name:    "unknown field in mapping",
+			input:   "title,author,category\n",
+			columns: map[string]string{"rating": "Stars"},
+			wantErr: `invalid import file: unknown field "rating"`,
+		},
+		{
+			name:    "mapped column missing",
+			input:   "title,author,category\n",
+			columns: map[string]string{"isbn": "ISBN13"},
+			wantErr: `invalid import file: no column "ISBN13" for isbn`,
+		},
+		{
+			name:    "required column missing",
+			input:   "title,author\n",
+			wantErr: "invalid import file: no column for category",
+		},
+		{
+			name:    "empty file",
+			wantErr: "invalid import file: missing header row",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := NewCSVImportReader(strings.NewReader(tt.input), tt.columns, 5)
+			if tt.wantErr != "" {
+				assert.ErrorIs(t, err, ErrInvalidImport)
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, readAll(t, reader))
+			}
+		})
+	}
+}
+
+func TestCSVImportReader_ParseError(t *testing.T) {
+	input := "title,author,category\nDu\"ne,Frank Herbert,Fiction\nEmma,Jane Austen,Fiction\n"
+	reader, err := NewCSVImportReader(strings.NewReader(input), nil, 1)
+	assert.NoError(t, err)
+
+	records := readAll(t, reader)
+
+	if !assert.Len(t, records, 2) {
+		return
+	}
+	assert.Equal(t, 2, records[0].Row)
+	assert.Error(t, records[0].Err)
+	assert.Equal(t, "Emma", records[1].Title)
+	assert.NoError(t, records[1].Err)
+}
@@ -0,0 +1,310 @@
+package book
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/language"
+)
+
+const (
+	marcLeaderLength      = 24
+	marcEntryLength       = 12
+	marcFieldTerminator   = 0x1E
+	marcRecordTerminator  = 0x1D
+	marcSubfieldDelimiter = 0x1F
+)
+
+// errMalformedMARC is returned for a record that does not follow the ISO 2709
+// layout
+var errMalformedMARC = errors.New("malformed MARC21 record")
+
+// marcPages finds the page count in a physical description such as
+// "xii, 320 p. : ill." or "535 pages"
+var marcPages = regexp.MustCompile(`(\d+)\s*p(?:ages?)?\b`)
+
+// marcYear finds a year in a publication date such as "c1965."
+var marcYear = regexp.MustCompile(`\d{4}`)
+
+// marcImportReader reads import records from a file of binary MARC21
+// (ISO 2709) bibliographic records
+type marcImportReader struct {
+	reader       *bufio.Reader
+	record       int
+	defaultStock int32
+	stopped      bool
+}
+
+// NewMARCImportReader reads books from binary MARC21 records. MARC21 has no
+// stock, so every record gets defaultStock.
+func NewMARCImportReader(r io.Reader, defaultStock int32) domain.ImportReader {
+	return &marcImportReader{
+		reader:       bufio.NewReader(r),
+		defaultStock: defaultStock,
+	}
+}
+
+// Next reads the next record. A record whose length or terminator is wrong
+// comes back with Err set and ends the file, as the start of the next record
+// cannot be found.
+func (m *marcImportReader) Next() (*domain.ImportRecord, error) {
+	if m.stopped {
+		return nil, io.EOF
+	}
+
+	// Some tools put a line break between records
+	for {
+		b, err := m.reader.Peek(1)
+		if err != nil {
+			return nil, err
+		}
+		if b[0] != '\n' && b[0] != '\r' {
+			break
+		}
+		_, _ = m.reader.Discard(1)
+	}
+
+	m.record++
+	record := &domain.ImportRecord{Row: m.record, Stock: m.defaultStock}
+	data, err := m.readRecord()
+	if err != nil {
+		if !errors.Is(err, errMalformedMARC) {
+			return nil, err
+		}
+		// Without a record's length the next one cannot be found
+		m.stopped = true
+		record.Err = fmt.Errorf("%w; the rest of the file was skipped", err)
+		return record, nil
+	}
+
+	fields, err := marcFields(data)
+	if err != nil {
+		record.Err = err
+		return record, nil
+	}
+	fields.describe(record)
+	return record, nil
+}
+
+// readRecord reads one record using the length at the start of its leader
+func (m *marcImportReader) readRecord() ([]byte, error) {
+	digits := make([]byte, 5)
+	if _, err := io.ReadFull(m.reader, digits); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("%w: truncated record", errMalformedMARC)
+		}
+		return nil, err
+	}
+	length, err := strconv.Atoi(string(digits))
+	if err != nil || length <= marcLeaderLength {
+		return nil, fmt.Errorf("%w: invalid record length %q", errMalformedMARC, digits)
+	}
+
+	data := make([]byte, length)
+	copy(data, digits)
+	if _, err := io.ReadFull(m.reader, data[len(digits):]); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("%w: truncated record", errMalformedMARC)
+		}
+		return nil, err
+	}
+	if data[length-1] != marcRecordTerminator {
+		return nil, fmt.Errorf("%w: record does not end with a record terminator", errMalformedMARC)
+	}
+	return data, nil
+}
+
+// marcField is a control or data field of a record, without its terminator
+type marcField struct {
+	tag   string
+	value []byte
+}
+
+// marcRecord holds the fields of a record in directory order
+type marcRecord []marcField
+
+// marcFields splits a record into its fields using the directory
+func marcFields(data []byte) (marcRecord, error) {
+	leader := data[:marcLeaderLength]
+	base, err := strconv.Atoi(string(leader[12:17]))
+	if err != nil || base <= marcLeaderLength || base > len(data) || data[base-1] != marcFieldTerminator {
+		return nil, fmt.Errorf("%w: invalid base address of data", errMalformedMARC)
+	}
+
+	content := data[base : len(data)-1]
+	if leader[9] == 'a' {
+		if !utf8.Valid(content) {
+			return nil, errors.New("record is not valid UTF-8")
+		}
+	} else if bytes.IndexFunc(content, func(r rune) bool { return r >= utf8.RuneSelf }) >= 0 {
+		return nil, errors.New("MARC-8 encoded characters are not supported, convert the file to UTF-8")
+	}
+
+	directory := data[marcLeaderLength : base-1]
+	if len(directory)%marcEntryLength != 0 {
+		return nil, fmt.Errorf("%w: invalid directory", errMalformedMARC)
+	}
+
+	fields := make(marcRecord, 0, len(directory)/marcEntryLength)
+	for entry := directory; len(entry) > 0; entry = entry[marcEntryLength:] {
+		tag := string(entry[:3])
+		length, lengthErr := strconv.Atoi(string(entry[3:7]))
+		start, startErr := strconv.Atoi(string(entry[7:12]))
+		if lengthErr != nil || startErr != nil || length < 1 || start+length > len(content) {
+			return nil, fmt.Errorf("%w: invalid directory entry for field %s", errMalformedMARC, tag)
+		}
+		value := content[start : start+length]
+		fields = append(fields, marcField{tag: tag, value: bytes.TrimSuffix(value, []byte{marcFieldTerminator})})
+	}
+	return fields, nil
+}
+
+// control returns the value of the first control field with tag
+func (r marcRecord) control(tag string) string {
+	for _, field := range r {
+		if field.tag == tag {
+			return string(field.value)
+		}
+	}
+	return ""
+}
+
+// subfield returns the first code subfield of the first field with one of
+// tags, trying the tags in order
+func (r marcRecord) subfield(code byte, tags ...string) string {
+	for _, tag := range tags {
+		for _, field := range r {
+			if field.tag != tag {
+				continue
+			}
+			if value, ok := field.subfield(code); ok {
+				return value
+			}
+		}
+	}
+	return ""
+}
+
+// field returns the first data field with tag
+func (r marcRecord) field(tag string) (marcField, bool) {
+	for _, field := range r {
+		if field.tag == tag {
+			return field, true
+		}
+	}
+	return marcField{}, false
+}
+
+// indicator returns the first or second indicator of a data field
+func (f marcField) indicator(n int) byte {
+	if len(f.value) < 2 {
+		return ' '
+	}
+	return f.value[n-1]
+}
+
+// subfield returns the first code subfield of a data field
+func (f marcField) subfield(code byte) (string, bool) {
+	if len(f.value) < 2 {
+		return "", false
+	}
+	for _, part := range bytes.Split(f.value[2:], []byte{marcSubfieldDelimiter}) {
+		if len(part) > 0 && part[0] == code {
+			return strings.TrimSpace(string(part[1:])), true
+		}
+	}
+	return "", false
+}
+
+// describe fills in the book fields of an import record from a bibliographic
+// record
+func (r marcRecord) describe(record *domain.ImportRecord) {
+	// 020 $a may carry a qualifier after the number, e.g. "0441172717 (pbk.)"
+	if isbn := strings.Fields(r.subfield('a', "020")); len(isbn) > 0 {
+		record.ISBN = isbn[0]
+	}
+
+	record.Title = trimISBD(r.subfield('a', "245"))
+	if subtitle := trimISBD(r.subfield('b', "245")); subtitle != "" {
+		record.Title += ": " + subtitle
+	}
+
+	for _, tag := range []string{"100", "110", "111", "700", "710"} {
+		if field, ok := r.field(tag); ok {
+			if name, ok := field.subfield('a'); ok {
+				record.Author = trimISBD(name)
+				// Personal names are entered surname first
+				if (tag == "100" || tag == "700") && field.indicator(1) == '1' {
+					record.Author = invertName(record.Author)
+				}
+				break
+			}
+		}
+	}
+
+	record.Category = trimISBD(r.subfield('a', "655", "650"))
+	record.Edition = trimISBD(r.subfield('a', "250"))
+	record.Description = r.subfield('a', "520")
+	record.Publisher = trimISBD(r.subfield('b', "264", "260"))
+
+	// 008 holds the date of publication at 07-10 and the language at 35-37
+	fixed := r.control("008")
+	if len(fixed) >= 11 {
+		if year, err := strconv.Atoi(fixed[7:11]); err == nil {
+			record.PublicationYear = int32(year)
+		}
+	}
+	if record.PublicationYear == 0 {
+		if year := marcYear.FindString(r.subfield('c', "264", "260")); year != "" {
+			year, _ := strconv.Atoi(year)
+			record.PublicationYear = int32(year)
+		}
+	}
+	code := r.subfield('a', "041")
+	if len(fixed) >= 38 && strings.TrimSpace(fixed[35:38]) != "" {
+		code = fixed[35:38]
+	}
+	record.Language = marcLanguage(code)
+
+	if pages := marcPages.FindAllStringSubmatch(r.subfield('a', "300"), -1); len(pages) > 0 {
+		count, _ := strconv.Atoi(pages[len(pages)-1][1])
+		record.PageCount = int32(count)
+	}
+}
+
+// trimISBD removes the ISBD punctuation cataloguers end subfields with, such
+// as the " /" before a statement of responsibility
+func trimISBD(value string) string {
+	return strings.TrimSpace(strings.TrimRight(strings.TrimSpace(value), " /:;,.="))
+}
+
+// invertName turns "Herbert, Frank" into "Frank Herbert"
+func invertName(name string) string {
+	parts := strings.Split(name, ", ")
+	if len(parts) != 2 {
+		return name
+	}
+	return parts[1] + " " + parts[0]
+}
+
+// marcLanguage converts a MARC language code such as "eng" to its BCP 47
+// tag, or "" when the code is undetermined or unknown
+func marcLanguage(code string) string {
+	switch code {
+	case "", "und", "zxx", "mul", "|||":
+		return ""
+	}
+	tag, err := language.Parse(code)
+	if err != nil {
+		return ""
+	}
+	return tag.String()
+}
@@ -0,0 +1,148 @@
+package book
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+// buildMARC builds a binary MARC21 record from tag and value pairs, with
+// leader9 as the character coding scheme
+func buildMARC(leader9 byte, fields ...[2]string) []byte {
+	var directory, content bytes.Buffer
+	for _, field := range fields {
+		value := field[1] + "\x1e"
+		fmt.Fprintf(&directory, "%s%04d%05d", field[0], len(value), content.Len())
+		content.WriteString(value)
+	}
+	directory.WriteByte(0x1e)
+	base := 24 + directory.Len()
+	leader := fmt.Sprintf("%05dnam %c22%05d   4500", base+content.Len()+1, leader9, base)
+	return []byte(leader + directory.String() + content.String() + "\x1d")
+}
+
+// duneMARC is a full bibliographic record for Dune
+var duneMARC = buildMARC('a',
+	[2]string{"001", "ocm00123456"},
+	[2]string{"008", "650101s1965" + strings.Repeat(" ", 24) + "eng d"},
+	[2]string{"020", "  \x1fa0441172717 (pbk.)"},
+	[2]string{"100", "1 \x1faHerbert, Frank."},
+	[2]string{"245", "10\x1faDune :\x1fbthe novel /\x1fcFrank Herbert."},
+	[2]string{"250", "  \x1faFirst edition."},
+	[2]string{"264", " 1\x1faNew York :\x1fbAce Books,\x1fc1990."},
+	[2]string{"300", "  \x1faxii, 535 pages ;\x1fc18 cm"},
+	[2]string{"520", "  \x1faSet on the desert planet Arrakis."},
+	[2]string{"650", " 0\x1faDesert people\x1fvFiction."},
+	[2]string{"655", " 7\x1faScience fiction.\x1f2lcgft"},
+)
+
+func TestMARCImportReader_Next(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+		want  []*domain.ImportRecord
+	}{
+		{
+			name:  "full record",
+			input: duneMARC,
+			want: []*domain.ImportRecord{{
+				Row: 1, Title: "Dune: the novel", Author: "Frank Herbert", Category: "Science fiction", Stock: 2,
+				BookMetadata: domain.BookMetadata{
+					ISBN: "0441172717", Publisher: "Ace Books", PublicationYear: 1965, Language: "en",
+					PageCount: 535, Edition: "First edition", Description: "Set on the desert planet Arrakis.",
+				},
+			}},
+		},
+		{
+			name: "sparse record falls back to other fields",
+			input: buildMARC(' ',
+				[2]string{"041", "0 \x1fafre"},
+				[2]string{"110", "2 \x1faAcademie francaise."},
+				[2]string{"245", "00\x1faDictionnaire."},
+				[2]string{"260", "  \x1faParis :\x1fbFirmin-Didot,\x1fcc1932."},
+				[2]string{"300", "  \x1fa2 v. (xxii, 740 p.)"},
+				[2]string{"650", " 0\x1faFrench language\x1fvDictionaries."},
+			),
+			want: []*domain.ImportRecord{{
+				Row: 1, Title: "Dictionnaire", Author: "Academie francaise", Category: "French language", Stock: 2,
+				BookMetadata: domain.BookMetadata{Publisher: "Firmin-Didot", PublicationYear: 1932, Language: "fr", PageCount: 740},
+			}},
+		},
+		{
+			name:  "records separated by line breaks",
+			input: append(append(append([]byte{}, duneMARC...), '\r', '\n'), buildMARC('a', [2]string{"245", "00\x1faEmma."})...),
+			want: []*domain.ImportRecord{
+				{Row: 1, Title: "Dune: the novel", Author: "Frank Herbert", Category: "Science fiction", Stock: 2,
+					BookMetadata: domain.BookMetadata{
+						ISBN: "0441172717", Publisher: "Ace Books", PublicationYear: 1965, Language: "en",
+						PageCount: 535, Edition: "First edition", Description: "Set on the desert planet Arrakis.",
+					}},
+				{Row: 2, Title: "Emma", Stock: 2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := NewMARCImportReader(bytes.NewReader(tt.input), 2)
+
+			assert.Equal(t, tt.want, readAll(t, reader))
+		})
+	}
+}
+
+func TestMARCImportReader_Malformed(t *testing.T) {
+	emma := buildMARC('a', [2]string{"245", "00\x1faEmma."})
+	tests := []struct {
+		name    string
+		input   []byte
+		wantErr []string
+	}{
+		{
+			name:    "MARC-8 characters",
+			input:   append(buildMARC(' ', [2]string{"245", "00\x1faM\xe2ecanique."}), emma...),
+			wantErr: []string{"MARC-8 encoded characters are not supported, convert the file to UTF-8", ""},
+		},
+		{
+			name:    "invalid UTF-8",
+			input:   append(buildMARC('a', [2]string{"245", "00\x1fa\xff."}), emma...),
+			wantErr: []string{"record is not valid UTF-8", ""},
+		},
+		{
+			name:    "directory pointing past the data",
+			input:   append(bytes.Replace(buildMARC('a', [2]string{"245", "00\x1faDune."}), []byte("245001000000"), []byte("245009900000"), 1), emma...),
+			wantErr: []string{"malformed MARC21 record: invalid directory entry for field 245", ""},
+		},
+		{
+			name:    "invalid length skips the rest",
+			input:   append([]byte("abcde"), emma...),
+			wantErr: []string{`malformed MARC21 record: invalid record length "abcde"; the rest of the file was skipped`},
+		},
+		{
+			name:    "truncated record",
+			input:   append(append([]byte{}, emma...), emma[:30]...),
+			wantErr: []string{"", "malformed MARC21 record: truncated record; the rest of the file was skipped"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records := readAll(t, NewMARCImportReader(bytes.NewReader(tt.input), 1))
+
+			if !assert.Len(t, records, len(tt.wantErr)) {
+				return
+			}
+			for i, record := range records {
+				assert.Equal(t, i+1, record.Row)
+				if tt.wantErr[i] == "" {
+					assert.NoError(t, record.Err)
+				} else {
+					assert.EqualError(t, record.Err, tt.wantErr[i])
+				}
+			}
+		})
+	}
+}
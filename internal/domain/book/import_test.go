@@ -0,0 +1,139 @@
+package book
+
+import (
+	"context"
+	"errors"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"github.com/hinha/library-management-synapsis/internal/domain/book/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"io"
+	"testing"
+)
+
+// recordList is an import reader over a fixed list of records
+type recordList struct {
+	records []*domain.ImportRecord
+	err     error
+}
+
+func (l *recordList) Next() (*domain.ImportRecord, error) {
+	if len(l.records) == 0 {
+		if l.err != nil {
+			return nil, l.err
+		}
+		return nil, io.EOF
+	}
+	record := l.records[0]
+	l.records = l.records[1:]
+	return record, nil
+}
+
+// created reports every record of a batch as created
+func created(_ context.Context, records []*domain.ImportRecord, _ bool) []domain.ImportResult {
+	results := make([]domain.ImportResult, len(records))
+	for i, record := range records {
+		results[i] = domain.ImportResult{Row: record.Row, Status: domain.ImportStatusCreated, ISBN: record.ISBN, Title: record.Title}
+	}
+	return results
+}
+
+func TestDefaultService_ImportBooks(t *testing.T) {
+	record := func(row int, isbn string) *domain.ImportRecord {
+		return &domain.ImportRecord{Row: row, Title: "Dune", Author: "Frank Herbert", Category: "Fiction", Stock: 1,
+			BookMetadata: domain.BookMetadata{ISBN: isbn}}
+	}
+	batchOf := func(rows ...int) interface{} {
+		return mock.MatchedBy(func(records []*domain.ImportRecord) bool {
+			if len(records) != len(rows) {
+				return false
+			}
+			for i, record := range records {
+				if record.Row != rows[i] {
+					return false
+				}
+			}
+			return true
+		})
+	}
+
+	tests := []struct {
+		name      string
+		records   *recordList
+		batchSize int
+		dryRun    bool
+		mockFn    func(repo *mocks.IDbRepository)
+		want      []domain.ImportResult
+		wantErr   error
+	}{
+		{
+			name:      "saves records in batches",
+			records:   &recordList{records: []*domain.ImportRecord{record(2, ""), record(3, ""), record(4, "")}},
+			batchSize: 2,
+			mockFn: func(repo *mocks.IDbRepository) {
+				repo.On("ImportBatch", mock.Anything, batchOf(2, 3), false).Return(created, nil).Once()
+				repo.On("ImportBatch", mock.Anything, batchOf(4), false).Return(created, nil).Once()
+			},
+			want: []domain.ImportResult{
+				{Row: 2, Status: domain.ImportStatusCreated, Title: "Dune"},
+				{Row: 3, Status: domain.ImportStatusCreated, Title: "Dune"},
+				{Row: 4, Status: domain.ImportStatusCreated, Title: "Dune"},
+			},
+		},
+		{
+			name: "rejects unreadable records, bad isbns and isbns seen before",
+			records: &recordList{records: []*domain.ImportRecord{
+				{Row: 2, Err: errors.New("bare quote in non-quoted field")},
+				record(3, "0-441-17271-7"),
+				record(4, "9780441172719"),
+				record(5, "9780441172710"),
+			}},
+			dryRun: true,
+			mockFn: func(repo *mocks.IDbRepository) {
+				repo.On("ImportBatch", mock.Anything, batchOf(3), true).Return(created, nil).Once()
+			},
+			want: []domain.ImportResult{
+				{Row: 2, Status: domain.ImportStatusRejected, Reason: "bare quote in non-quoted field"},
+				{Row: 4, Status: domain.ImportStatusRejected, ISBN: "9780441172719", Title: "Dune", Reason: "isbn already imported from row 3"},
+				{Row: 5, Status: domain.ImportStatusRejected, ISBN: "9780441172710", Title: "Dune", Reason: "invalid isbn"},
+				{Row: 3, Status: domain.ImportStatusCreated, ISBN: "9780441172719", Title: "Dune"},
+			},
+		},
+		{
+			name:    "failed batch rejects its records",
+			records: &recordList{records: []*domain.ImportRecord{record(2, "")}},
+			mockFn: func(repo *mocks.IDbRepository) {
+				repo.On("ImportBatch", mock.Anything, batchOf(2), false).Return(nil, errors.New("db error"))
+			},
+			want: []domain.ImportResult{
+				{Row: 2, Status: domain.ImportStatusRejected, Title: "Dune", Reason: "batch could not be saved"},
+			},
+		},
+		{
+			name:    "stream broken",
+			records: &recordList{records: []*domain.ImportRecord{record(2, "")}, err: errors.New("stream reset")},
+			wantErr: errors.New("stream reset"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.IDbRepository)
+			if tt.mockFn != nil {
+				tt.mockFn(repo)
+			}
+			s := &DefaultService{repoDb: repo}
+
+			got, err := s.ImportBooks(context.Background(), tt.records, tt.batchSize, tt.dryRun)
+
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else if assert.NoError(t, err) {
+				assert.Equal(t, tt.dryRun, got.DryRun)
+				assert.Equal(t, tt.want, got.Results)
+				assert.Equal(t, len(tt.want), got.Created+got.Updated+got.Rejected)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
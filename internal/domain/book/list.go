@@ -0,0 +1,115 @@
+package book
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"strconv"
+	"time"
+)
+
+// Fields a book listing can be sorted by
+const (
+	SortByTitle     = "title"
+	SortByAuthor    = "author"
+	SortByCreatedAt = "created_at"
+	SortByStock     = "stock"
+)
+
+const (
+	// DefaultPageSize is the page size used when the client does not ask for one
+	DefaultPageSize = 20
+	// MaxPageSize is the largest page a client can ask for
+	MaxPageSize = 100
+)
+
+// ErrInvalidPageToken is returned when a page token is malformed or was
+// issued for a different filter
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// The listing types live in the domain package so repository mocks can use them
+type (
+	ListFilter = domain.BookFilter
+	Cursor     = domain.BookCursor
+	ListQuery  = domain.BookQuery
+)
+
+// pageToken is what an opaque page token decodes to. The filter is kept so a
+// token cannot be replayed against a different listing.
+type pageToken struct {
+	Filter ListFilter `json:"f"`
+	Cursor Cursor     `json:"c"`
+}
+
+// encodePageToken returns the page token continuing filter after cursor
+func encodePageToken(filter ListFilter, cursor Cursor) (string, error) {
+	raw, err := json.Marshal(pageToken{Filter: filter, Cursor: cursor})
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// decodePageToken returns the cursor in token, checking it was issued for filter
+func decodePageToken(token string, filter ListFilter) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	var decoded pageToken
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	// Compare the encoded forms, times lose their location in JSON
+	want, err := json.Marshal(filter)
+	if err != nil {
+		return nil, err
+	}
+	got, err := json.Marshal(decoded.Filter)
+	if err != nil {
+		return nil, err
+	}
+	if string(want) != string(got) {
+		return nil, ErrInvalidPageToken
+	}
+
+	return &decoded.Cursor, nil
+}
+
+// cursorAt returns the cursor positioned on book for a listing sorted by sortBy
+func cursorAt(book *domain.Book, sortBy string) Cursor {
+	cursor := Cursor{ID: book.ID}
+	switch sortBy {
+	case SortByTitle:
+		cursor.Value = book.Title
+	case SortByAuthor:
+		cursor.Value = book.Author
+	case SortByStock:
+		cursor.Value = strconv.Itoa(int(book.Stock))
+	default:
+		cursor.Value = book.CreatedAt.Format(time.RFC3339Nano)
+	}
+	return cursor
+}
+
+// cursorValue converts a cursor value back to the type of the sortBy column
+func cursorValue(cursor *Cursor, sortBy string) (interface{}, error) {
+	switch sortBy {
+	case SortByTitle, SortByAuthor:
+		return cursor.Value, nil
+	case SortByStock:
+		stock, err := strconv.Atoi(cursor.Value)
+		if err != nil {
+			return nil, ErrInvalidPageToken
+		}
+		return stock, nil
+	default:
+		createdAt, err := time.Parse(time.RFC3339Nano, cursor.Value)
+		if err != nil {
+			return nil, ErrInvalidPageToken
+		}
+		return createdAt, nil
+	}
+}
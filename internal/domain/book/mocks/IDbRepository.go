@@ -7,6 +7,8 @@ import (
 
 	domain "github.com/hinha/library-management-synapsis/internal/domain"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // IDbRepository is an autogenerated mock type for the IDbRepository type
@@ -50,6 +52,52 @@ func (_m *IDbRepository) Delete(ctx context.Context, id string) error {
 	return r0
 }
 
+// Each provides a mock function with given fields: ctx, filter, fn
+func (_m *IDbRepository) Each(ctx context.Context, filter domain.BookFilter, fn func(*domain.Book) error) error {
+	ret := _m.Called(ctx, filter, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Each")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BookFilter, func(*domain.Book) error) error); ok {
+		r0 = rf(ctx, filter, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DetectFullTextSearch provides a mock function with given fields: ctx
+func (_m *IDbRepository) DetectFullTextSearch(ctx context.Context) (bool, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DetectFullTextSearch")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (bool, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) bool); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByCategory provides a mock function with given fields: ctx, category
 func (_m *IDbRepository) GetByCategory(ctx context.Context, category string) ([]*domain.Book, error) {
 	ret := _m.Called(ctx, category)
@@ -110,6 +158,66 @@ func (_m *IDbRepository) GetByID(ctx context.Context, id string) (*domain.Book,
 	return r0, r1
 }
 
+// GetByISBN provides a mock function with given fields: ctx, isbn
+func (_m *IDbRepository) GetByISBN(ctx context.Context, isbn string) (*domain.Book, error) {
+	ret := _m.Called(ctx, isbn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByISBN")
+	}
+
+	var r0 *domain.Book
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Book, error)); ok {
+		return rf(ctx, isbn)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Book); ok {
+		r0 = rf(ctx, isbn)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Book)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, isbn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportBatch provides a mock function with given fields: ctx, records, dryRun
+func (_m *IDbRepository) ImportBatch(ctx context.Context, records []*domain.ImportRecord, dryRun bool) ([]domain.ImportResult, error) {
+	ret := _m.Called(ctx, records, dryRun)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportBatch")
+	}
+
+	var r0 []domain.ImportResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.ImportRecord, bool) ([]domain.ImportResult, error)); ok {
+		return rf(ctx, records, dryRun)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.ImportRecord, bool) []domain.ImportResult); ok {
+		r0 = rf(ctx, records, dryRun)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ImportResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*domain.ImportRecord, bool) error); ok {
+		r1 = rf(ctx, records, dryRun)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // List provides a mock function with given fields: ctx
 func (_m *IDbRepository) List(ctx context.Context) ([]*domain.Book, error) {
 	ret := _m.Called(ctx)
@@ -140,6 +248,73 @@ func (_m *IDbRepository) List(ctx context.Context) ([]*domain.Book, error) {
 	return r0, r1
 }
 
+// ListDeleted provides a mock function with given fields: ctx, after, limit
+func (_m *IDbRepository) ListDeleted(ctx context.Context, after *domain.DeletedCursor, limit int) ([]*domain.Book, error) {
+	ret := _m.Called(ctx, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeleted")
+	}
+
+	var r0 []*domain.Book
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.DeletedCursor, int) ([]*domain.Book, error)); ok {
+		return rf(ctx, after, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.DeletedCursor, int) []*domain.Book); ok {
+		r0 = rf(ctx, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Book)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.DeletedCursor, int) error); ok {
+		r1 = rf(ctx, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPage provides a mock function with given fields: ctx, query
+func (_m *IDbRepository) ListPage(ctx context.Context, query domain.BookQuery) ([]*domain.Book, int64, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPage")
+	}
+
+	var r0 []*domain.Book
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BookQuery) ([]*domain.Book, int64, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BookQuery) []*domain.Book); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Book)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.BookQuery) int64); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, domain.BookQuery) error); ok {
+		r2 = rf(ctx, query)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Ping provides a mock function with given fields: ctx
 func (_m *IDbRepository) Ping(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -158,6 +333,94 @@ func (_m *IDbRepository) Ping(ctx context.Context) error {
 	return r0
 }
 
+// Purge provides a mock function with given fields: ctx, before
+func (_m *IDbRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Purge")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Restore provides a mock function with given fields: ctx, id
+func (_m *IDbRepository) Restore(ctx context.Context, id string) (*domain.Book, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 *domain.Book
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Book, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Book); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Book)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Search provides a mock function with given fields: ctx, search
+func (_m *IDbRepository) Search(ctx context.Context, search domain.BookSearch) ([]*domain.BookMatch, error) {
+	ret := _m.Called(ctx, search)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []*domain.BookMatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BookSearch) ([]*domain.BookMatch, error)); ok {
+		return rf(ctx, search)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BookSearch) []*domain.BookMatch); ok {
+		r0 = rf(ctx, search)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.BookMatch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.BookSearch) error); ok {
+		r1 = rf(ctx, search)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, _a1
 func (_m *IDbRepository) Update(ctx context.Context, _a1 *domain.Book) error {
 	ret := _m.Called(ctx, _a1)
@@ -176,17 +439,17 @@ func (_m *IDbRepository) Update(ctx context.Context, _a1 *domain.Book) error {
 	return r0
 }
 
-// UpdateStock provides a mock function with given fields: ctx, id, change
-func (_m *IDbRepository) UpdateStock(ctx context.Context, id string, change int32) error {
-	ret := _m.Called(ctx, id, change)
+// UpdateStock provides a mock function with given fields: ctx, id, change, reason
+func (_m *IDbRepository) UpdateStock(ctx context.Context, id string, change int32, reason domain.StockReason) error {
+	ret := _m.Called(ctx, id, change, reason)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateStock")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, int32) error); ok {
-		r0 = rf(ctx, id, change)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int32, domain.StockReason) error); ok {
+		r0 = rf(ctx, id, change, reason)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -0,0 +1,50 @@
+package book
+
+import "errors"
+
+// Reasons a stock or copy change is refused, carried on the wire so that
+// callers of the book service can tell the refusals apart
+const (
+	ReasonInsufficientStock = "INSUFFICIENT_STOCK"
+	ReasonCopyNotAvailable  = "COPY_NOT_AVAILABLE"
+	ReasonCopyNotOnLoan     = "COPY_NOT_ON_LOAN"
+	ReasonCopyOnLoan        = "COPY_ON_LOAN"
+	ReasonCopyWithdrawn     = "COPY_WITHDRAWN"
+	ReasonCopyNotLost       = "COPY_NOT_LOST"
+	ReasonCounterLoansOpen  = "COUNTER_LOANS_OPEN"
+)
+
+var preconditions = []struct {
+	reason string
+	err    error
+}{
+	{ReasonInsufficientStock, ErrInsufficientStock},
+	{ReasonCopyNotAvailable, ErrCopyNotAvailable},
+	{ReasonCopyNotOnLoan, ErrCopyNotOnLoan},
+	{ReasonCopyOnLoan, ErrCopyOnLoan},
+	{ReasonCopyWithdrawn, ErrCopyWithdrawn},
+	{ReasonCopyNotLost, ErrCopyNotLost},
+	{ReasonCounterLoansOpen, ErrCounterLoansOpen},
+}
+
+// PreconditionReason returns the reason for err, or false when err is not a
+// refused stock or copy change
+func PreconditionReason(err error) (string, bool) {
+	for _, p := range preconditions {
+		if errors.Is(err, p.err) {
+			return p.reason, true
+		}
+	}
+	return "", false
+}
+
+// PreconditionError returns the error for reason, or nil when the reason is
+// unknown
+func PreconditionError(reason string) error {
+	for _, p := range preconditions {
+		if p.reason == reason {
+			return p.err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package book
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"github.com/rs/zerolog/log"
+	"time"
+)
+
+// DefaultRecommendLimit is how many books are recommended when the client
+// does not ask for a number
+const DefaultRecommendLimit = 10
+
+// RecommendPolicy tunes how recommendations are computed
+type RecommendPolicy struct {
+	// PopularWindow is how far back borrows count towards a book's popularity
+	PopularWindow time.Duration
+	// TopCategories is how many of a user's most borrowed categories to
+	// recommend popular books from
+	TopCategories int
+}
+
+// LoanFeed streams loan events from the transaction service
+type LoanFeed interface {
+	// Subscribe calls handle for every event after cursor whose type is in
+	// types until ctx is cancelled, the stream ends or handle fails
+	Subscribe(ctx context.Context, cursor uint64, types []string, handle func(*domain.OutboxEvent) error) error
+}
+
+// RecommendBooks recommends up to limit in-stock books the user has not
+// borrowed yet. Books borrowed by people who borrowed the same books as the
+// user come first, then popular books in the user's favourite categories,
+// then popular books overall. New arrivals fill up whatever is left, so new
+// users and an empty catalogue history still get recommendations.
+func (s *DefaultService) RecommendBooks(ctx context.Context, userID string, limit int) ([]*domain.Book, error) {
+	if limit <= 0 {
+		limit = DefaultRecommendLimit
+	}
+	if limit > MaxPageSize {
+		limit = MaxPageSize
+	}
+
+	picks := make([]*domain.Book, 0, limit)
+	seen := make(map[string]bool)
+	add := func(books []*domain.Book) {
+		for _, book := range books {
+			if len(picks) < limit && !seen[book.ID] && book.Stock > 0 {
+				seen[book.ID] = true
+				picks = append(picks, book)
+			}
+		}
+	}
+
+	since := time.Now().Add(-s.recommend.PopularWindow)
+	if userID != "" {
+		coBorrowed, err := s.loanRepo.CoBorrowedBooks(ctx, userID, limit)
+		if err != nil {
+			return nil, err
+		}
+		add(coBorrowed)
+
+		categories, err := s.loanRepo.TopCategories(ctx, userID, s.recommend.TopCategories)
+		if err != nil {
+			return nil, err
+		}
+		if len(categories) > 0 && len(picks) < limit {
+			inCategories, err := s.loanRepo.PopularBooks(ctx, since, categories, userID, limit)
+			if err != nil {
+				return nil, err
+			}
+			add(inCategories)
+		}
+	}
+
+	if len(picks) < limit {
+		popular, err := s.loanRepo.PopularBooks(ctx, since, nil, userID, limit)
+		if err != nil {
+			return nil, err
+		}
+		add(popular)
+	}
+
+	if len(picks) < limit {
+		if err := s.addNewArrivals(ctx, userID, limit, seen, add); err != nil {
+			return nil, err
+		}
+	}
+
+	return picks, nil
+}
+
+// addNewArrivals tops up the recommendations with the newest in-stock books
+// the user has not borrowed
+func (s *DefaultService) addNewArrivals(ctx context.Context, userID string, limit int, seen map[string]bool, add func([]*domain.Book)) error {
+	if userID != "" {
+		borrowed, err := s.loanRepo.BorrowedBookIDs(ctx, userID)
+		if err != nil {
+			return err
+		}
+		for _, id := range borrowed {
+			seen[id] = true
+		}
+	}
+
+	query := ListQuery{
+		Filter: ListFilter{InStock: true, SortBy: SortByCreatedAt, Descending: true},
+		Limit:  limit + len(seen),
+	}
+	books, _, err := s.repoDb.ListPage(ctx, query)
+	if err != nil {
+		return err
+	}
+	add(books)
+	return nil
+}
+
+// RunLoanFeed keeps the borrowing data behind recommendations up to date
+// from the transaction service's loan events, resubscribing after
+// retryInterval whenever the stream breaks, until ctx is cancelled
+func (s *DefaultService) RunLoanFeed(ctx context.Context, feed LoanFeed, retryInterval time.Duration) {
+	types := []string{domain.EventLoanOpened, domain.EventLoanVoided, domain.EventLoanRestored}
+	for {
+		cursor, err := s.loanRepo.Cursor(ctx)
+		if err == nil {
+			err = feed.Subscribe(ctx, cursor, types, func(event *domain.OutboxEvent) error {
+				return s.applyLoanEvent(ctx, event)
+			})
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		log.Error().Err(err).Msg("Loan feed interrupted, resubscribing")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// applyLoanEvent records an opened or restored loan or forgets a voided one
+func (s *DefaultService) applyLoanEvent(ctx context.Context, event *domain.OutboxEvent) error {
+	switch event.Type {
+	case domain.EventLoanOpened, domain.EventLoanRestored:
+		var transaction domain.Transaction
+		if err := json.Unmarshal([]byte(event.Payload), &transaction); err != nil {
+			return err
+		}
+		return s.loanRepo.Record(ctx, domain.NewLoanRecord(&transaction), event.ID)
+	case domain.EventLoanVoided:
+		return s.loanRepo.Void(ctx, event.AggregateID, event.ID)
+	default:
+		return nil
+	}
+}
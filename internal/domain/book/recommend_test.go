@@ -0,0 +1,231 @@
+package book
+
+import (
+	"context"
+	"errors"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"github.com/hinha/library-management-synapsis/internal/domain/book/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+var recommendPolicy = RecommendPolicy{PopularWindow: 30 * 24 * time.Hour, TopCategories: 2}
+
+// inStock returns an in-stock book with the given ID
+func inStock(id string) *domain.Book {
+	return &domain.Book{ID: id, Title: "Book " + id, Stock: 1}
+}
+
+func TestDefaultService_RecommendBooks(t *testing.T) {
+	newest := ListQuery{Filter: ListFilter{InStock: true, SortBy: SortByCreatedAt, Descending: true}}
+
+	tests := []struct {
+		name    string
+		userID  string
+		limit   int
+		mockFn  func(repo *mocks.IDbRepository, loanRepo *mocks.ILoanRepository)
+		wantIDs []string
+		wantErr error
+	}{
+		{
+			name:   "co-borrowed, then favourite categories, then popular",
+			userID: "1",
+			limit:  4,
+			mockFn: func(repo *mocks.IDbRepository, loanRepo *mocks.ILoanRepository) {
+				loanRepo.On("CoBorrowedBooks", mock.Anything, "1", 4).Return([]*domain.Book{inStock("a")}, nil)
+				loanRepo.On("TopCategories", mock.Anything, "1", 2).Return([]string{"Fiction"}, nil)
+				loanRepo.On("PopularBooks", mock.Anything, mock.Anything, []string{"Fiction"}, "1", 4).Return([]*domain.Book{inStock("a"), inStock("b")}, nil)
+				loanRepo.On("PopularBooks", mock.Anything, mock.Anything, []string(nil), "1", 4).Return([]*domain.Book{inStock("c"), inStock("d"), inStock("e")}, nil)
+			},
+			wantIDs: []string{"a", "b", "c", "d"},
+		},
+		{
+			name:   "out of stock books are skipped",
+			userID: "1",
+			limit:  2,
+			mockFn: func(repo *mocks.IDbRepository, loanRepo *mocks.ILoanRepository) {
+				loanRepo.On("CoBorrowedBooks", mock.Anything, "1", 2).Return([]*domain.Book{{ID: "a"}, inStock("b")}, nil)
+				loanRepo.On("TopCategories", mock.Anything, "1", 2).Return(nil, nil)
+				loanRepo.On("PopularBooks", mock.Anything, mock.Anything, []string(nil), "1", 2).Return([]*domain.Book{inStock("c")}, nil)
+			},
+			wantIDs: []string{"b", "c"},
+		},
+		{
+			name:   "new arrivals top up, leaving out borrowed books",
+			userID: "1",
+			limit:  3,
+			mockFn: func(repo *mocks.IDbRepository, loanRepo *mocks.ILoanRepository) {
+				loanRepo.On("CoBorrowedBooks", mock.Anything, "1", 3).Return(nil, nil)
+				loanRepo.On("TopCategories", mock.Anything, "1", 2).Return(nil, nil)
+				loanRepo.On("PopularBooks", mock.Anything, mock.Anything, []string(nil), "1", 3).Return([]*domain.Book{inStock("a")}, nil)
+				loanRepo.On("BorrowedBookIDs", mock.Anything, "1").Return([]string{"x"}, nil)
+				query := newest
+				query.Limit = 5
+				repo.On("ListPage", mock.Anything, query).Return([]*domain.Book{inStock("x"), inStock("a"), inStock("n1"), inStock("n2"), inStock("n3")}, int64(5), nil)
+			},
+			wantIDs: []string{"a", "n1", "n2"},
+		},
+		{
+			name: "no user gets popular books and new arrivals",
+			mockFn: func(repo *mocks.IDbRepository, loanRepo *mocks.ILoanRepository) {
+				loanRepo.On("PopularBooks", mock.Anything, mock.Anything, []string(nil), "", DefaultRecommendLimit).Return([]*domain.Book{inStock("a")}, nil)
+				query := newest
+				query.Limit = DefaultRecommendLimit + 1
+				repo.On("ListPage", mock.Anything, query).Return([]*domain.Book{inStock("n1")}, int64(1), nil)
+			},
+			wantIDs: []string{"a", "n1"},
+		},
+		{
+			name:   "repo returns error",
+			userID: "1",
+			limit:  2,
+			mockFn: func(repo *mocks.IDbRepository, loanRepo *mocks.ILoanRepository) {
+				loanRepo.On("CoBorrowedBooks", mock.Anything, "1", 2).Return(nil, errors.New("db error"))
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.IDbRepository)
+			loanRepo := new(mocks.ILoanRepository)
+			tt.mockFn(repo, loanRepo)
+			s := NewService(repo, nil, loanRepo, nil, nil, recommendPolicy)
+
+			got, err := s.RecommendBooks(context.Background(), tt.userID, tt.limit)
+
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				ids := make([]string, len(got))
+				for i, book := range got {
+					ids[i] = book.ID
+				}
+				assert.Equal(t, tt.wantIDs, ids)
+			}
+			repo.AssertExpectations(t)
+			loanRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDefaultService_applyLoanEvent(t *testing.T) {
+	borrowedAt := time.Date(2025, 6, 22, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		event   *domain.OutboxEvent
+		mockFn  func(loanRepo *mocks.ILoanRepository)
+		wantErr bool
+	}{
+		{
+			name: "loan opened",
+			event: &domain.OutboxEvent{
+				ID:          7,
+				Type:        domain.EventLoanOpened,
+				AggregateID: "tx-1",
+				Payload:     `{"id":"tx-1","user_id":"1","book_id":"book-1","borrowed_at":"2025-06-22T09:00:00Z"}`,
+			},
+			mockFn: func(loanRepo *mocks.ILoanRepository) {
+				loanRepo.On("Record", mock.Anything, mock.MatchedBy(func(loan *domain.LoanRecord) bool {
+					return loan.TransactionID == "tx-1" && loan.UserID == "1" && loan.BookID == "book-1" && loan.BorrowedAt.Equal(borrowedAt)
+				}), uint64(7)).Return(nil)
+			},
+		},
+		{
+			name:  "loan voided",
+			event: &domain.OutboxEvent{ID: 8, Type: domain.EventLoanVoided, AggregateID: "tx-1", Payload: `{"id":"tx-1"}`},
+			mockFn: func(loanRepo *mocks.ILoanRepository) {
+				loanRepo.On("Void", mock.Anything, "tx-1", uint64(8)).Return(nil)
+			},
+		},
+		{
+			name: "loan restored",
+			event: &domain.OutboxEvent{
+				ID:          9,
+				Type:        domain.EventLoanRestored,
+				AggregateID: "tx-1",
+				Payload:     `{"id":"tx-1","user_id":"1","book_id":"book-1","borrowed_at":"2025-06-22T09:00:00Z"}`,
+			},
+			mockFn: func(loanRepo *mocks.ILoanRepository) {
+				loanRepo.On("Record", mock.Anything, mock.MatchedBy(func(loan *domain.LoanRecord) bool {
+					return loan.TransactionID == "tx-1" && loan.BookID == "book-1"
+				}), uint64(9)).Return(nil)
+			},
+		},
+		{
+			name:  "other events are ignored",
+			event: &domain.OutboxEvent{ID: 9, Type: domain.EventLoanReturned, AggregateID: "tx-1"},
+		},
+		{
+			name:    "malformed payload",
+			event:   &domain.OutboxEvent{ID: 10, Type: domain.EventLoanOpened, AggregateID: "tx-1", Payload: `{`},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loanRepo := new(mocks.ILoanRepository)
+			if tt.mockFn != nil {
+				tt.mockFn(loanRepo)
+			}
+			s := NewService(nil, nil, loanRepo, nil, nil, recommendPolicy)
+
+			err := s.applyLoanEvent(context.Background(), tt.event)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			loanRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// stubLoanFeed replays events once, then reports the stream broken
+type stubLoanFeed struct {
+	events  []*domain.OutboxEvent
+	cursors []uint64
+	cancel  context.CancelFunc
+}
+
+func (f *stubLoanFeed) Subscribe(ctx context.Context, cursor uint64, types []string, handle func(*domain.OutboxEvent) error) error {
+	f.cursors = append(f.cursors, cursor)
+	if len(f.cursors) > 1 {
+		f.cancel()
+		return ctx.Err()
+	}
+	for _, event := range f.events {
+		if err := handle(event); err != nil {
+			return err
+		}
+	}
+	return errors.New("stream broken")
+}
+
+func TestDefaultService_RunLoanFeed(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	loanRepo := new(mocks.ILoanRepository)
+	loanRepo.On("Cursor", mock.Anything).Return(uint64(3), nil).Once()
+	loanRepo.On("Void", mock.Anything, "tx-1", uint64(4)).Return(nil)
+	loanRepo.On("Cursor", mock.Anything).Return(uint64(4), nil).Once()
+	feed := &stubLoanFeed{
+		events: []*domain.OutboxEvent{{ID: 4, Type: domain.EventLoanVoided, AggregateID: "tx-1"}},
+		cancel: cancel,
+	}
+	s := NewService(nil, nil, loanRepo, nil, nil, recommendPolicy)
+
+	s.RunLoanFeed(ctx, feed, time.Millisecond)
+
+	assert.Equal(t, []uint64{3, 4}, feed.cursors)
+	loanRepo.AssertExpectations(t)
+}
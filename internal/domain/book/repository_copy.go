@@ -0,0 +1,325 @@
+package book
+
+import (
+	"context"
+	"errors"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrCopyNotFound is returned when no copy has the barcode
+	ErrCopyNotFound = errors.New("copy not found")
+	// ErrDuplicateBarcode is returned when another copy already has the barcode
+	ErrDuplicateBarcode = errors.New("barcode already exists")
+	// ErrCopyNotAvailable is returned when checking out a copy that is not on the shelf
+	ErrCopyNotAvailable = errors.New("copy not available")
+	// ErrCopyNotOnLoan is returned when checking in a copy that is not on loan
+	ErrCopyNotOnLoan = errors.New("copy is not on loan")
+	// ErrCopyOnLoan is returned when changing the status of a copy a patron has
+	ErrCopyOnLoan = errors.New("copy is on loan")
+	// ErrCopyWithdrawn is returned when changing the status of a withdrawn copy
+	ErrCopyWithdrawn = errors.New("copy is withdrawn")
+	// ErrCopyNotLost is returned when a copy reported found is not lost
+	ErrCopyNotLost = errors.New("copy is not lost")
+	// ErrCounterLoansOpen is returned when adding the first copy of a book
+	// that is on loan off its stock counter
+	ErrCounterLoansOpen = errors.New("book is on loan off its stock counter")
+)
+
+// ICopyRepository defines the interface for book copy data access.
+// Every change to a copy recounts the stock of its book in the same database
+// transaction.
+//
+//go:generate mockery --name=ICopyRepository --output=mocks --outpkg=mocks
+type ICopyRepository interface {
+	Create(ctx context.Context, bookCopy *domain.BookCopy) error
+	GetByBarcode(ctx context.Context, barcode string) (*domain.BookCopy, error)
+	ListByBook(ctx context.Context, bookID string) ([]*domain.BookCopy, error)
+	CountByBook(ctx context.Context, bookID string) (int64, error)
+	Update(ctx context.Context, bookCopy *domain.BookCopy) error
+	Checkout(ctx context.Context, bookID, barcode string) (*domain.BookCopy, error)
+	Checkin(ctx context.Context, bookID, barcode string) (*domain.BookCopy, error)
+	WriteOff(ctx context.Context, bookID, barcode string, damaged bool) (*domain.BookCopy, error)
+	Found(ctx context.Context, bookID, barcode string) (*domain.BookCopy, error)
+}
+
+// CopyRepository implements ICopyRepository using GORM
+type CopyRepository struct {
+	db *gorm.DB
+}
+
+// NewCopyRepository creates a new CopyRepository
+func NewCopyRepository(db *gorm.DB) ICopyRepository {
+	return &CopyRepository{db: db}
+}
+
+// Create stores a new copy. It returns ErrBookNotFound when the book does not
+// exist, ErrDuplicateBarcode when the barcode is taken and
+// ErrCounterLoansOpen for the first copy of a book lent off its counter.
+func (r *CopyRepository) Create(ctx context.Context, bookCopy *domain.BookCopy) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := lockStock(tx, bookCopy.BookID); err != nil {
+			return err
+		}
+		if err := checkCounterLoans(tx, bookCopy.BookID); err != nil {
+			return err
+		}
+		if err := tx.Create(bookCopy).Error; err != nil {
+			if isUniqueViolation(err) {
+				return ErrDuplicateBarcode
+			}
+			return err
+		}
+		if err := syncStock(tx, bookCopy.BookID, domain.StockReasonAcquisition, bookCopy.Barcode); err != nil {
+			return err
+		}
+		return writeEvent(tx, domain.EventCopyAdded, bookCopy.BookID, bookCopy)
+	})
+}
+
+// GetByBarcode retrieves a copy by its barcode
+func (r *CopyRepository) GetByBarcode(ctx context.Context, barcode string) (*domain.BookCopy, error) {
+	var bookCopy domain.BookCopy
+	if err := r.db.WithContext(ctx).Where("barcode = ?", barcode).First(&bookCopy).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCopyNotFound
+		}
+		return nil, err
+	}
+	return &bookCopy, nil
+}
+
+// ListByBook retrieves every copy of a book ordered by barcode
+func (r *CopyRepository) ListByBook(ctx context.Context, bookID string) ([]*domain.BookCopy, error) {
+	var copies []*domain.BookCopy
+	if err := r.db.WithContext(ctx).Where("book_id = ?", bookID).Order("barcode").Find(&copies).Error; err != nil {
+		return nil, err
+	}
+	return copies, nil
+}
+
+// CountByBook counts the copies of a book, withdrawn ones included
+func (r *CopyRepository) CountByBook(ctx context.Context, bookID string) (int64, error) {
+	return countCopies(r.db.WithContext(ctx), bookID)
+}
+
+// countCopies counts the copies of a book, withdrawn ones included
+func countCopies(db *gorm.DB, bookID string) (int64, error) {
+	var count int64
+	if err := db.Model(&domain.BookCopy{}).Where("book_id = ?", bookID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// Update saves a copy's shelf location, condition and status. A status
+// change that moves the copy in or out of stock is recorded in the stock
+// ledger as a loss, a withdrawal or an adjustment.
+func (r *CopyRepository) Update(ctx context.Context, bookCopy *domain.BookCopy) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.BookCopy{}).
+			Where("id = ?", bookCopy.ID).
+			Updates(map[string]interface{}{
+				"shelf_location": bookCopy.ShelfLocation,
+				"condition":      bookCopy.Condition,
+				"status":         bookCopy.Status,
+				"updated_at":     bookCopy.UpdatedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrCopyNotFound
+		}
+		if err := syncStock(tx, bookCopy.BookID, statusReason(bookCopy.Status), bookCopy.Barcode); err != nil {
+			return err
+		}
+		return writeEvent(tx, domain.EventCopyUpdated, bookCopy.BookID, bookCopy)
+	})
+}
+
+// Checkout marks an available copy of a book as on loan: the copy with the
+// barcode, or the first available one when barcode is empty. Candidate rows
+// are locked, so concurrent checkouts never take the same copy.
+func (r *CopyRepository) Checkout(ctx context.Context, bookID, barcode string) (*domain.BookCopy, error) {
+	return r.move(ctx, bookID, barcode, domain.CopyStatusAvailable, domain.CopyStatusOnLoan, "", -1, domain.StockReasonLoan)
+}
+
+// Checkin marks a copy of a book that is on loan as available again: the
+// copy with the barcode, or the first one on loan when barcode is empty
+func (r *CopyRepository) Checkin(ctx context.Context, bookID, barcode string) (*domain.BookCopy, error) {
+	return r.move(ctx, bookID, barcode, domain.CopyStatusOnLoan, domain.CopyStatusAvailable, "", 1, domain.StockReasonReturn)
+}
+
+// WriteOff takes a copy of a book that is on loan out of circulation: the
+// copy with the barcode, or the first one on loan when barcode is empty. The
+// copy is marked lost, or damaged and withdrawn. Its stock went down when it
+// was lent, so the stock stays as it is.
+func (r *CopyRepository) WriteOff(ctx context.Context, bookID, barcode string, damaged bool) (*domain.BookCopy, error) {
+	if damaged {
+		return r.move(ctx, bookID, barcode, domain.CopyStatusOnLoan, domain.CopyStatusWithdrawn, domain.CopyConditionDamaged, 0, domain.StockReasonWithdrawal)
+	}
+	return r.move(ctx, bookID, barcode, domain.CopyStatusOnLoan, domain.CopyStatusLost, "", 0, domain.StockReasonLoss)
+}
+
+// Found puts a lost copy of a book back on the shelf: the copy with the
+// barcode, or the first lost one when barcode is empty
+func (r *CopyRepository) Found(ctx context.Context, bookID, barcode string) (*domain.BookCopy, error) {
+	return r.move(ctx, bookID, barcode, domain.CopyStatusLost, domain.CopyStatusAvailable, "", 1, domain.StockReasonFound)
+}
+
+// move switches a copy of bookID from one status to another in its own
+// database transaction, see moveCopy
+func (r *CopyRepository) move(ctx context.Context, bookID, barcode string, from, to domain.CopyStatus, condition domain.CopyCondition, delta int32, reason domain.StockReason) (*domain.BookCopy, error) {
+	var bookCopy *domain.BookCopy
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		bookCopy, err = moveCopy(tx, bookID, barcode, from, to, condition, delta, reason)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bookCopy, nil
+}
+
+// moveCopy switches a copy of bookID from one status to another, and to
+// condition unless it is empty, and records the stock change for reason:
+// the copy with the barcode, or the first one in status from when barcode is
+// empty. Candidate rows are locked, so concurrent moves never take the same copy.
+func moveCopy(tx *gorm.DB, bookID, barcode string, from, to domain.CopyStatus, condition domain.CopyCondition, delta int32, reason domain.StockReason) (*domain.BookCopy, error) {
+	var bookCopy domain.BookCopy
+	query := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("book_id = ? AND status = ?", bookID, from)
+	if barcode != "" {
+		query = query.Where("barcode = ?", barcode)
+	}
+	if err := query.Order("barcode").First(&bookCopy).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, missingCopy(tx, bookID, barcode, from)
+		}
+		return nil, err
+	}
+
+	bookCopy.SetStatus(to)
+	updates := map[string]interface{}{
+		"status":     bookCopy.Status,
+		"updated_at": bookCopy.UpdatedAt,
+	}
+	if condition != "" {
+		bookCopy.Condition = condition
+		updates["condition"] = condition
+	}
+	if err := tx.Model(&bookCopy).Updates(updates).Error; err != nil {
+		return nil, err
+	}
+	if err := syncStock(tx, bookID, reason, bookCopy.Barcode); err != nil {
+		return nil, err
+	}
+	if err := writeEvent(tx, domain.EventStockChanged, bookID, map[string]interface{}{"book_id": bookID, "barcode": bookCopy.Barcode, "delta": delta}); err != nil {
+		return nil, err
+	}
+	return &bookCopy, nil
+}
+
+// missingCopy explains why no copy of bookID in status from was found
+func missingCopy(tx *gorm.DB, bookID, barcode string, from domain.CopyStatus) error {
+	if barcode == "" {
+		switch from {
+		case domain.CopyStatusAvailable:
+			return ErrInsufficientStock
+		case domain.CopyStatusLost:
+			return ErrCopyNotLost
+		}
+		return ErrCopyNotOnLoan
+	}
+
+	var count int64
+	if err := tx.Model(&domain.BookCopy{}).Where("book_id = ? AND barcode = ?", bookID, barcode).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrCopyNotFound
+	}
+	switch from {
+	case domain.CopyStatusAvailable:
+		return ErrCopyNotAvailable
+	case domain.CopyStatusLost:
+		return ErrCopyNotLost
+	}
+	return ErrCopyNotOnLoan
+}
+
+// syncStock sets a book's stock to the number of its available copies and
+// records the difference in the stock ledger for reason. The book row is
+// locked first, so concurrent copy changes are counted one after the other.
+func syncStock(tx *gorm.DB, bookID string, reason domain.StockReason, barcode string) error {
+	stock, err := lockStock(tx, bookID)
+	if err != nil {
+		return err
+	}
+
+	var available int64
+	if err := tx.Model(&domain.BookCopy{}).Where("book_id = ? AND status = ?", bookID, domain.CopyStatusAvailable).Count(&available).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(&domain.Book{}).
+		Where("id = ?", bookID).
+		Updates(map[string]interface{}{
+			"stock":      available,
+			"version":    gorm.Expr("version + 1"),
+			"updated_at": time.Now(),
+		}).Error; err != nil {
+		return err
+	}
+	return writeMovement(tx, bookID, int32(available)-stock, reason, barcode)
+}
+
+// lockStock reads and locks the stock of a book, so changes to its copies
+// and stock counter are made one after the other
+func lockStock(tx *gorm.DB, bookID string) (int32, error) {
+	var book domain.Book
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("stock").Where("id = ?", bookID).First(&book).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, ErrBookNotFound
+		}
+		return 0, err
+	}
+	return book.Stock, nil
+}
+
+// checkCounterLoans refuses the first copy of a book while loans taken off
+// its stock counter are open. Their books have no copy to check in, and the
+// counter they go back to is replaced by the count of copies.
+func checkCounterLoans(tx *gorm.DB, bookID string) error {
+	copies, err := countCopies(tx, bookID)
+	if err != nil || copies > 0 {
+		return err
+	}
+
+	var loans int64
+	if err := tx.Model(&domain.StockReservation{}).
+		Where("book_id = ? AND barcode = ? AND status = ?", bookID, "", domain.ReservationReserved).
+		Count(&loans).Error; err != nil {
+		return err
+	}
+	if loans > 0 {
+		return ErrCounterLoansOpen
+	}
+	return nil
+}
+
+// statusReason is the stock ledger reason of a copy moving to status
+func statusReason(status domain.CopyStatus) domain.StockReason {
+	switch status {
+	case domain.CopyStatusLost:
+		return domain.StockReasonLoss
+	case domain.CopyStatusWithdrawn:
+		return domain.StockReasonWithdrawal
+	}
+	return domain.StockReasonAdjustment
+}
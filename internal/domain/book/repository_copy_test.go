@@ -0,0 +1,546 @@
+package book
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// newCopyRepository returns a CopyRepository backed by sqlmock
+func newCopyRepository(t *testing.T) (*CopyRepository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	gdb, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+	return &CopyRepository{db: gdb}, mock
+}
+
+// expectSyncStock expects the book's stock to be recounted from its copies
+// and the difference to be recorded in the stock ledger for reason
+func expectSyncStock(mock sqlmock.Sqlmock, bookID string, stock, available int32, reason domain.StockReason) {
+	expectStockLock(mock, bookID, &stock)
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "book_copies" WHERE book_id = \$1 AND status = \$2`).
+		WithArgs(bookID, domain.CopyStatusAvailable).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(available))
+	mock.ExpectExec(`UPDATE "books" SET "stock"=\$1,"updated_at"=\$2,"version"=version \+ 1 WHERE id = \$3 AND "books"\."deleted_at" IS NULL`).
+		WithArgs(available, sqlmock.AnyArg(), bookID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	if available != stock {
+		expectStockMovement(mock, bookID, available-stock, reason)
+	}
+}
+
+// expectCounterLoans expects the loans of bookID off its stock counter to be
+// counted
+func expectCounterLoans(mock sqlmock.Sqlmock, bookID string, loans int) {
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "stock_reservations" WHERE book_id = \$1 AND barcode = \$2 AND status = \$3`).
+		WithArgs(bookID, "", domain.ReservationReserved).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(loans))
+}
+
+func TestCopyRepository_Create(t *testing.T) {
+	createdAt := time.Date(2025, 6, 22, 9, 0, 0, 0, time.UTC)
+	bookCopy := &domain.BookCopy{
+		ID:            "copy-1",
+		BookID:        "book-1",
+		Barcode:       "LIB-0001",
+		ShelfLocation: "A3",
+		Condition:     domain.CopyConditionGood,
+		Status:        domain.CopyStatusAvailable,
+		CreatedAt:     createdAt,
+		UpdatedAt:     createdAt,
+	}
+
+	stock := int32(1)
+
+	testCases := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError error
+	}{
+		{
+			name: "Success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectStockLock(mock, "book-1", &stock)
+				expectCopyCount(mock, "book-1", 1)
+				mock.ExpectExec(`INSERT INTO "book_copies"`).
+					WithArgs("copy-1", "book-1", "LIB-0001", "A3", domain.CopyConditionGood, domain.CopyStatusAvailable, createdAt, createdAt).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectSyncStock(mock, "book-1", 1, 2, domain.StockReasonAcquisition)
+				expectOutboxEvent(mock, domain.EventCopyAdded)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "First copy",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectStockLock(mock, "book-1", &stock)
+				expectCopyCount(mock, "book-1", 0)
+				expectCounterLoans(mock, "book-1", 0)
+				mock.ExpectExec(`INSERT INTO "book_copies"`).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectSyncStock(mock, "book-1", 1, 1, domain.StockReasonAcquisition)
+				expectOutboxEvent(mock, domain.EventCopyAdded)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Book on loan off its counter",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectStockLock(mock, "book-1", &stock)
+				expectCopyCount(mock, "book-1", 0)
+				expectCounterLoans(mock, "book-1", 2)
+				mock.ExpectRollback()
+			},
+			expectedError: ErrCounterLoansOpen,
+		},
+		{
+			name: "Duplicate barcode",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectStockLock(mock, "book-1", &stock)
+				expectCopyCount(mock, "book-1", 1)
+				mock.ExpectExec(`INSERT INTO "book_copies"`).WillReturnError(&pgconn.PgError{Code: "23505"})
+				mock.ExpectRollback()
+			},
+			expectedError: ErrDuplicateBarcode,
+		},
+		{
+			name: "Book not found",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectStockLock(mock, "book-1", nil)
+				mock.ExpectRollback()
+			},
+			expectedError: ErrBookNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo, mock := newCopyRepository(t)
+			tc.setupMock(mock)
+
+			err := repo.Create(context.Background(), bookCopy)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestCopyRepository_GetByBarcode(t *testing.T) {
+	testCases := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedCopy  *domain.BookCopy
+		expectedError error
+	}{
+		{
+			name: "Success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "book_copies" WHERE barcode = \$1 ORDER BY "book_copies"\."id" LIMIT \$2`).
+					WithArgs("LIB-0001", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "book_id", "barcode", "status"}).
+						AddRow("copy-1", "book-1", "LIB-0001", "on_loan"))
+			},
+			expectedCopy: &domain.BookCopy{ID: "copy-1", BookID: "book-1", Barcode: "LIB-0001", Status: domain.CopyStatusOnLoan},
+		},
+		{
+			name: "Not Found",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "book_copies"`).WillReturnError(gorm.ErrRecordNotFound)
+			},
+			expectedError: ErrCopyNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo, mock := newCopyRepository(t)
+			tc.setupMock(mock)
+
+			got, err := repo.GetByBarcode(context.Background(), "LIB-0001")
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedCopy, got)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestCopyRepository_Update(t *testing.T) {
+	updatedAt := time.Date(2025, 6, 22, 9, 0, 0, 0, time.UTC)
+	bookCopy := &domain.BookCopy{
+		ID:            "copy-1",
+		BookID:        "book-1",
+		Barcode:       "LIB-0001",
+		ShelfLocation: "B1",
+		Condition:     domain.CopyConditionPoor,
+		Status:        domain.CopyStatusInRepair,
+		UpdatedAt:     updatedAt,
+	}
+
+	testCases := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError error
+	}{
+		{
+			name: "Success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "book_copies" SET "condition"=\$1,"shelf_location"=\$2,"status"=\$3,"updated_at"=\$4 WHERE id = \$5`).
+					WithArgs(domain.CopyConditionPoor, "B1", domain.CopyStatusInRepair, updatedAt, "copy-1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectSyncStock(mock, "book-1", 1, 0, domain.StockReasonAdjustment)
+				expectOutboxEvent(mock, domain.EventCopyUpdated)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Not Found",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "book_copies"`).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			expectedError: ErrCopyNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo, mock := newCopyRepository(t)
+			tc.setupMock(mock)
+
+			err := repo.Update(context.Background(), bookCopy)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestCopyRepository_Checkout(t *testing.T) {
+	copyColumns := []string{"id", "book_id", "barcode", "status"}
+
+	testCases := []struct {
+		name          string
+		barcode       string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedCopy  *domain.BookCopy
+		expectedError error
+	}{
+		{
+			name: "Any available copy",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "book_copies" WHERE book_id = \$1 AND status = \$2 ORDER BY barcode,"book_copies"\."id" LIMIT \$3 FOR UPDATE SKIP LOCKED`).
+					WithArgs("book-1", domain.CopyStatusAvailable, 1).
+					WillReturnRows(sqlmock.NewRows(copyColumns).AddRow("copy-1", "book-1", "LIB-0001", "available"))
+				mock.ExpectExec(`UPDATE "book_copies" SET "status"=\$1,"updated_at"=\$2 WHERE "id" = \$3`).
+					WithArgs(domain.CopyStatusOnLoan, sqlmock.AnyArg(), "copy-1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectSyncStock(mock, "book-1", 1, 0, domain.StockReasonLoan)
+				expectOutboxEvent(mock, domain.EventStockChanged)
+				mock.ExpectCommit()
+			},
+			expectedCopy: &domain.BookCopy{ID: "copy-1", BookID: "book-1", Barcode: "LIB-0001", Status: domain.CopyStatusOnLoan},
+		},
+		{
+			name: "No copy left",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "book_copies"`).WillReturnRows(sqlmock.NewRows(copyColumns))
+				mock.ExpectRollback()
+			},
+			expectedError: ErrInsufficientStock,
+		},
+		{
+			name:    "Scanned copy on loan",
+			barcode: "LIB-0002",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "book_copies" WHERE \(book_id = \$1 AND status = \$2\) AND barcode = \$3`).
+					WithArgs("book-1", domain.CopyStatusAvailable, "LIB-0002", 1).
+					WillReturnRows(sqlmock.NewRows(copyColumns))
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "book_copies" WHERE book_id = \$1 AND barcode = \$2`).
+					WithArgs("book-1", "LIB-0002").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectRollback()
+			},
+			expectedError: ErrCopyNotAvailable,
+		},
+		{
+			name:    "Scanned copy of another book",
+			barcode: "LIB-0009",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "book_copies"`).WillReturnRows(sqlmock.NewRows(copyColumns))
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "book_copies"`).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectRollback()
+			},
+			expectedError: ErrCopyNotFound,
+		},
+		{
+			name: "DB Error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "book_copies"`).WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
+			},
+			expectedError: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo, mock := newCopyRepository(t)
+			tc.setupMock(mock)
+
+			got, err := repo.Checkout(context.Background(), "book-1", tc.barcode)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedCopy.Barcode, got.Barcode)
+				assert.Equal(t, tc.expectedCopy.Status, got.Status)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestCopyRepository_Checkin(t *testing.T) {
+	copyColumns := []string{"id", "book_id", "barcode", "status"}
+
+	testCases := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError error
+	}{
+		{
+			name: "Success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "book_copies" WHERE \(book_id = \$1 AND status = \$2\) AND barcode = \$3`).
+					WithArgs("book-1", domain.CopyStatusOnLoan, "LIB-0001", 1).
+					WillReturnRows(sqlmock.NewRows(copyColumns).AddRow("copy-1", "book-1", "LIB-0001", "on_loan"))
+				mock.ExpectExec(`UPDATE "book_copies" SET "status"=\$1`).
+					WithArgs(domain.CopyStatusAvailable, sqlmock.AnyArg(), "copy-1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectSyncStock(mock, "book-1", 0, 1, domain.StockReasonReturn)
+				expectOutboxEvent(mock, domain.EventStockChanged)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Copy already on the shelf",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "book_copies"`).WillReturnRows(sqlmock.NewRows(copyColumns))
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "book_copies"`).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectRollback()
+			},
+			expectedError: ErrCopyNotOnLoan,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo, mock := newCopyRepository(t)
+			tc.setupMock(mock)
+
+			got, err := repo.Checkin(context.Background(), "book-1", "LIB-0001")
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, domain.CopyStatusAvailable, got.Status)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestCopyRepository_WriteOff(t *testing.T) {
+	copyColumns := []string{"id", "book_id", "barcode", "status"}
+
+	testCases := []struct {
+		name          string
+		damaged       bool
+		setupMock     func(sqlmock.Sqlmock)
+		wantStatus    domain.CopyStatus
+		expectedError error
+	}{
+		{
+			name: "Lost",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "book_copies" WHERE \(book_id = \$1 AND status = \$2\) AND barcode = \$3`).
+					WithArgs("book-1", domain.CopyStatusOnLoan, "LIB-0001", 1).
+					WillReturnRows(sqlmock.NewRows(copyColumns).AddRow("copy-1", "book-1", "LIB-0001", "on_loan"))
+				mock.ExpectExec(`UPDATE "book_copies" SET "status"=\$1`).
+					WithArgs(domain.CopyStatusLost, sqlmock.AnyArg(), "copy-1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectSyncStock(mock, "book-1", 2, 2, domain.StockReasonLoss)
+				expectOutboxEvent(mock, domain.EventStockChanged)
+				mock.ExpectCommit()
+			},
+			wantStatus: domain.CopyStatusLost,
+		},
+		{
+			name:    "Damaged",
+			damaged: true,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "book_copies"`).
+					WillReturnRows(sqlmock.NewRows(copyColumns).AddRow("copy-1", "book-1", "LIB-0001", "on_loan"))
+				mock.ExpectExec(`UPDATE "book_copies" SET "condition"=\$1,"status"=\$2`).
+					WithArgs(domain.CopyConditionDamaged, domain.CopyStatusWithdrawn, sqlmock.AnyArg(), "copy-1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectSyncStock(mock, "book-1", 2, 2, domain.StockReasonWithdrawal)
+				expectOutboxEvent(mock, domain.EventStockChanged)
+				mock.ExpectCommit()
+			},
+			wantStatus: domain.CopyStatusWithdrawn,
+		},
+		{
+			name: "Copy not on loan",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "book_copies"`).WillReturnRows(sqlmock.NewRows(copyColumns))
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "book_copies"`).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectRollback()
+			},
+			expectedError: ErrCopyNotOnLoan,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo, mock := newCopyRepository(t)
+			tc.setupMock(mock)
+
+			got, err := repo.WriteOff(context.Background(), "book-1", "LIB-0001", tc.damaged)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.wantStatus, got.Status)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestCopyRepository_Found(t *testing.T) {
+	copyColumns := []string{"id", "book_id", "barcode", "status"}
+
+	testCases := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError error
+	}{
+		{
+			name: "Success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "book_copies" WHERE \(book_id = \$1 AND status = \$2\) AND barcode = \$3`).
+					WithArgs("book-1", domain.CopyStatusLost, "LIB-0001", 1).
+					WillReturnRows(sqlmock.NewRows(copyColumns).AddRow("copy-1", "book-1", "LIB-0001", "lost"))
+				mock.ExpectExec(`UPDATE "book_copies" SET "status"=\$1`).
+					WithArgs(domain.CopyStatusAvailable, sqlmock.AnyArg(), "copy-1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectSyncStock(mock, "book-1", 0, 1, domain.StockReasonFound)
+				expectOutboxEvent(mock, domain.EventStockChanged)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Copy not lost",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "book_copies"`).WillReturnRows(sqlmock.NewRows(copyColumns))
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "book_copies"`).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectRollback()
+			},
+			expectedError: ErrCopyNotLost,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo, mock := newCopyRepository(t)
+			tc.setupMock(mock)
+
+			got, err := repo.Found(context.Background(), "book-1", "LIB-0001")
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, domain.CopyStatusAvailable, got.Status)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -3,9 +3,13 @@ package book
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/hinha/library-management-synapsis/internal/domain"
+	"time"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var (
@@ -13,6 +17,16 @@ var (
 	ErrBookNotFound = errors.New("book not found")
 	// ErrInsufficientStock is returned when a book has insufficient stock
 	ErrInsufficientStock = errors.New("insufficient stock")
+	// ErrDuplicateISBN is returned when another book already has the ISBN
+	ErrDuplicateISBN = errors.New("isbn already exists")
+	// ErrVersionConflict is returned when a book changed since it was read
+	ErrVersionConflict = errors.New("book was modified concurrently")
+
+	// errDryRun rolls back the transaction of a dry run import batch
+	errDryRun = errors.New("dry run")
+	// errStockUnchanged rolls back a stock change that found no book with
+	// enough stock
+	errStockUnchanged = errors.New("stock unchanged")
 )
 
 // IDbRepository defines the interface for book data access
@@ -21,17 +35,29 @@ var (
 type IDbRepository interface {
 	Create(ctx context.Context, book *domain.Book) error
 	GetByID(ctx context.Context, id string) (*domain.Book, error)
+	GetByISBN(ctx context.Context, isbn string) (*domain.Book, error)
 	List(ctx context.Context) ([]*domain.Book, error)
+	ListPage(ctx context.Context, query ListQuery) ([]*domain.Book, int64, error)
+	Each(ctx context.Context, filter ListFilter, fn func(book *domain.Book) error) error
 	Update(ctx context.Context, book *domain.Book) error
 	Delete(ctx context.Context, id string) error
-	UpdateStock(ctx context.Context, id string, change int32) error
+	ListDeleted(ctx context.Context, after *domain.DeletedCursor, limit int) ([]*domain.Book, error)
+	Restore(ctx context.Context, id string) (*domain.Book, error)
+	Purge(ctx context.Context, before time.Time) (int64, error)
+	UpdateStock(ctx context.Context, id string, change int32, reason domain.StockReason) error
 	GetByCategory(ctx context.Context, category string) ([]*domain.Book, error)
+	Search(ctx context.Context, search domain.BookSearch) ([]*domain.BookMatch, error)
+	ImportBatch(ctx context.Context, records []*domain.ImportRecord, dryRun bool) ([]domain.ImportResult, error)
+	DetectFullTextSearch(ctx context.Context) (bool, error)
 	Ping(ctx context.Context) (err error)
 }
 
 // DBRepository implements IDbRepository using GORM
 type DBRepository struct {
 	db *gorm.DB
+	// fullText is set once the search_vector column is found, until then
+	// searches fall back to ILIKE matching
+	fullText bool
 }
 
 // NewDbRepository creates a new DBRepository
@@ -39,9 +65,20 @@ func NewDbRepository(db *gorm.DB) IDbRepository {
 	return &DBRepository{db: db}
 }
 
-// Create creates a new book
+// Create creates a new book, recording its initial stock as an acquisition
 func (r *DBRepository) Create(ctx context.Context, book *domain.Book) error {
-	return r.db.WithContext(ctx).Create(book).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(book).Error; err != nil {
+			if isUniqueViolation(err) {
+				return ErrDuplicateISBN
+			}
+			return err
+		}
+		if err := writeMovement(tx, book.ID, book.Stock, domain.StockReasonAcquisition, ""); err != nil {
+			return err
+		}
+		return writeEvent(tx, domain.EventBookCreated, book.ID, book)
+	})
 }
 
 // GetByID retrieves a book by ID
@@ -56,6 +93,18 @@ func (r *DBRepository) GetByID(ctx context.Context, id string) (*domain.Book, er
 	return &book, nil
 }
 
+// GetByISBN retrieves a book by its normalized ISBN
+func (r *DBRepository) GetByISBN(ctx context.Context, isbn string) (*domain.Book, error) {
+	var book domain.Book
+	if err := r.db.WithContext(ctx).Where("isbn = ?", isbn).First(&book).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBookNotFound
+		}
+		return nil, err
+	}
+	return &book, nil
+}
+
 // List retrieves all books
 func (r *DBRepository) List(ctx context.Context) ([]*domain.Book, error) {
 	var books []*domain.Book
@@ -65,48 +114,275 @@ func (r *DBRepository) List(ctx context.Context) ([]*domain.Book, error) {
 	return books, nil
 }
 
-// Update updates a book
-func (r *DBRepository) Update(ctx context.Context, book *domain.Book) error {
-	result := r.db.WithContext(ctx).Save(book)
-	if result.Error != nil {
-		return result.Error
+// sortColumns maps the sort fields of a listing onto their columns
+var sortColumns = map[string]string{
+	SortByTitle:     "title",
+	SortByAuthor:    "author",
+	SortByCreatedAt: "created_at",
+	SortByStock:     "stock",
+}
+
+// ListPage retrieves up to query.Limit books matching the filter, sorted by
+// the filter's field with the ID breaking ties, starting after query.After.
+// It also returns how many books match the filter in total.
+func (r *DBRepository) ListPage(ctx context.Context, query ListQuery) ([]*domain.Book, int64, error) {
+	column, ok := sortColumns[query.Filter.SortBy]
+	if !ok {
+		return nil, 0, ErrInvalidInput
 	}
-	if result.RowsAffected == 0 {
-		return ErrBookNotFound
+
+	var total int64
+	if err := filterBooks(r.db.WithContext(ctx), query.Filter).Count(&total).Error; err != nil {
+		return nil, 0, err
 	}
-	return nil
+
+	direction, comparison := "ASC", ">"
+	if query.Filter.Descending {
+		direction, comparison = "DESC", "<"
+	}
+
+	page := filterBooks(r.db.WithContext(ctx), query.Filter)
+	if query.After != nil {
+		value, err := cursorValue(query.After, query.Filter.SortBy)
+		if err != nil {
+			return nil, 0, err
+		}
+		// Row comparison keeps the keyset stable when sort values repeat
+		page = page.Where(fmt.Sprintf("(%s, id) %s (?, ?)", column, comparison), value, query.After.ID)
+	}
+
+	var books []*domain.Book
+	if err := page.
+		Order(fmt.Sprintf("%s %s, id %s", column, direction, direction)).
+		Limit(query.Limit).
+		Find(&books).Error; err != nil {
+		return nil, 0, err
+	}
+	return books, total, nil
+}
+
+// Each calls fn with every book matching the filter, in the order ListPage
+// returns them. Rows are scanned one at a time from an open database cursor
+// rather than loaded up front, and an error from fn stops the iteration.
+func (r *DBRepository) Each(ctx context.Context, filter ListFilter, fn func(book *domain.Book) error) error {
+	column, ok := sortColumns[filter.SortBy]
+	if !ok {
+		return ErrInvalidInput
+	}
+	direction := "ASC"
+	if filter.Descending {
+		direction = "DESC"
+	}
+
+	rows, err := filterBooks(r.db.WithContext(ctx), filter).
+		Order(fmt.Sprintf("%s %s, id %s", column, direction, direction)).
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var book domain.Book
+		if err := r.db.ScanRows(rows, &book); err != nil {
+			return err
+		}
+		if err := fn(&book); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// filterBooks narrows db down to the books matching filter
+func filterBooks(db *gorm.DB, filter ListFilter) *gorm.DB {
+	db = db.Model(&domain.Book{})
+	if filter.Category != "" {
+		db = db.Where("category = ?", filter.Category)
+	}
+	if filter.Author != "" {
+		db = db.Where("author = ?", filter.Author)
+	}
+	if filter.InStock {
+		db = db.Where("stock > 0")
+	}
+	if filter.CreatedAfter != nil {
+		db = db.Where("created_at > ?", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		db = db.Where("created_at < ?", *filter.CreatedBefore)
+	}
+	return db
+}
+
+// Update updates a book if it still has the version it was read at and
+// bumps its version. It returns ErrVersionConflict when the book has been
+// written since. A change to its stock is recorded as an adjustment.
+func (r *DBRepository) Update(ctx context.Context, book *domain.Book) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the row so the stock the adjustment starts from cannot change
+		var current domain.Book
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("stock", "version").Where("id = ?", book.ID).First(&current).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrBookNotFound
+			}
+			return err
+		}
+		if current.Version != book.Version {
+			return ErrVersionConflict
+		}
+
+		if err := updateBook(tx, book); err != nil {
+			if isUniqueViolation(err) {
+				return ErrDuplicateISBN
+			}
+			return err
+		}
+		if err := writeMovement(tx, book.ID, book.Stock-current.Stock, domain.StockReasonAdjustment, ""); err != nil {
+			return err
+		}
+		return writeEvent(tx, domain.EventBookUpdated, book.ID, book)
+	})
 }
 
 // Delete deletes a book
 func (r *DBRepository) Delete(ctx context.Context, id string) error {
-	result := r.db.WithContext(ctx).Delete(&domain.Book{}, "id = ?", id)
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&domain.Book{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrBookNotFound
+		}
+		return writeEvent(tx, domain.EventBookDeleted, id, map[string]string{"id": id})
+	})
+}
+
+// UpdateStock atomically applies change to a book's stock and records it in
+// the stock ledger for reason. Decreases are guarded in the UPDATE itself, so
+// concurrent callers can never push the stock below zero.
+func (r *DBRepository) UpdateStock(ctx context.Context, id string, change int32, reason domain.StockReason) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return changeStock(tx, id, change, reason)
+	})
+	if errors.Is(err, errStockUnchanged) {
+		return stockShortage(r.db.WithContext(ctx), id)
+	}
+	return err
+}
+
+// changeStock adds change to the stock counter of a book, refusing to take
+// it below zero, and records the change for reason. It returns
+// errStockUnchanged when no book was updated.
+func changeStock(tx *gorm.DB, id string, change int32, reason domain.StockReason) error {
+	query := tx.Model(&domain.Book{}).Where("id = ?", id)
+	if change < 0 {
+		query = query.Where("stock >= ?", -change)
+	}
+
+	result := query.Updates(map[string]interface{}{
+		"stock":      gorm.Expr("stock + ?", change),
+		"version":    gorm.Expr("version + 1"),
+		"updated_at": time.Now(),
+	})
 	if result.Error != nil {
 		return result.Error
 	}
 	if result.RowsAffected == 0 {
-		return ErrBookNotFound
+		return errStockUnchanged
 	}
-	return nil
+	if err := writeMovement(tx, id, change, reason, ""); err != nil {
+		return err
+	}
+	return writeEvent(tx, domain.EventStockChanged, id, map[string]interface{}{"book_id": id, "delta": change})
 }
 
-// UpdateStock updates a book's stock
-func (r *DBRepository) UpdateStock(ctx context.Context, id string, change int32) error {
+// stockShortage tells a missing book apart from one without enough copies
+// left once changeStock updated none, after its transaction rolled back
+func stockShortage(db *gorm.DB, id string) error {
 	var book domain.Book
-	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&book).Error; err != nil {
+	if err := db.Where("id = ?", id).First(&book).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return ErrBookNotFound
 		}
 		return err
 	}
+	return ErrInsufficientStock
+}
 
-	// Check if there's enough stock when decreasing
-	if change < 0 && book.Stock < -change {
-		return ErrInsufficientStock
+// ImportBatch saves a batch of import records in one transaction: a record
+// with the ISBN of an existing book updates it, any other creates a book.
+// The stock of a book with copies is left to its copies. Stock changes are
+// recorded like those of Create and Update. A dry run batch is
+// rolled back once every record has been tried.
+func (r *DBRepository) ImportBatch(ctx context.Context, records []*domain.ImportRecord, dryRun bool) ([]domain.ImportResult, error) {
+	var results []domain.ImportResult
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		results = make([]domain.ImportResult, 0, len(records))
+		for _, record := range records {
+			result, err := importRecord(tx, record)
+			if err != nil {
+				return fmt.Errorf("row %d: %w", record.Row, err)
+			}
+			results = append(results, result)
+		}
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
 	}
+	return results, nil
+}
 
-	book.Stock += change
-	book.UpdatedAt = book.UpdatedAt
-	return r.db.WithContext(ctx).Save(&book).Error
+// importRecord upserts one import record by ISBN inside tx
+func importRecord(tx *gorm.DB, record *domain.ImportRecord) (domain.ImportResult, error) {
+	result := domain.ImportResult{Row: record.Row, ISBN: record.ISBN, Title: record.Title}
+
+	var existing domain.Book
+	found := false
+	if record.ISBN != "" {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("isbn = ?", record.ISBN).First(&existing).Error
+		if err == nil {
+			found = true
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return result, err
+		}
+	}
+
+	if !found {
+		book := domain.NewBook(record.Title, record.Author, record.Category, record.Stock)
+		book.BookMetadata = record.BookMetadata
+		if err := tx.Create(book).Error; err != nil {
+			return result, err
+		}
+		if err := writeMovement(tx, book.ID, book.Stock, domain.StockReasonAcquisition, ""); err != nil {
+			return result, err
+		}
+		result.Status = domain.ImportStatusCreated
+		result.BookID = book.ID
+		return result, writeEvent(tx, domain.EventBookCreated, book.ID, book)
+	}
+
+	var copies int64
+	if err := tx.Model(&domain.BookCopy{}).Where("book_id = ?", existing.ID).Count(&copies).Error; err != nil {
+		return result, err
+	}
+	stock := existing.Stock
+	record.ApplyTo(&existing, copies == 0)
+	if err := updateBook(tx, &existing); err != nil {
+		return result, err
+	}
+	if err := writeMovement(tx, existing.ID, existing.Stock-stock, domain.StockReasonAdjustment, ""); err != nil {
+		return result, err
+	}
+	result.Status = domain.ImportStatusUpdated
+	result.BookID = existing.ID
+	result.Title = existing.Title
+	return result, writeEvent(tx, domain.EventBookUpdated, existing.ID, &existing)
 }
 
 // GetByCategory retrieves books by category
@@ -118,6 +394,37 @@ func (r *DBRepository) GetByCategory(ctx context.Context, category string) ([]*d
 	return books, nil
 }
 
+// updateBook writes every column of book inside tx and bumps its version,
+// provided the row still has the version book was read at. The caller holds
+// the row lock, so a conflict means the book is gone.
+func updateBook(tx *gorm.DB, book *domain.Book) error {
+	version := book.Version
+	book.Version++
+	result := tx.Model(book).
+		Where("version = ?", version).
+		Select("*").
+		Omit("id", "created_at", "deleted_at").
+		Updates(book)
+	if result.Error != nil {
+		book.Version = version
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		book.Version = version
+		return ErrBookNotFound
+	}
+	return nil
+}
+
+// writeEvent appends a book event to the outbox inside tx
+func writeEvent(tx *gorm.DB, eventType, bookID string, payload interface{}) error {
+	event, err := domain.NewOutboxEvent(eventType, domain.AggregateBook, bookID, payload)
+	if err != nil {
+		return err
+	}
+	return tx.Create(event).Error
+}
+
 func (r *DBRepository) Ping(ctx context.Context) (err error) {
 	sql, err := r.db.DB()
 	if err != nil {
@@ -130,3 +437,78 @@ func (r *DBRepository) Ping(ctx context.Context) (err error) {
 
 	return nil
 }
+
+// isUniqueViolation checks if err is a PostgreSQL unique constraint violation.
+// Besides the primary keys the only unique constraints are the ISBN index on
+// books and the barcode index on book copies.
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+// searchDocumentSQL mirrors searchDocument
+const searchDocumentSQL = "title || ' — ' || author || ' — ' || category"
+
+// DetectFullTextSearch switches searches over to full-text search when the
+// books table has the search_vector column added by the full_text_search
+// migration, and reports whether it does
+func (r *DBRepository) DetectFullTextSearch(ctx context.Context) (bool, error) {
+	var exists bool
+	if err := r.db.WithContext(ctx).
+		Raw("SELECT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = 'books' AND column_name = 'search_vector')").
+		Scan(&exists).Error; err != nil {
+		return false, err
+	}
+
+	r.fullText = exists
+	return exists, nil
+}
+
+// Search retrieves up to search.Limit books matching every search term.
+// With full-text search the matches are ranked by ts_rank, weighting title
+// over author over category. The ILIKE fallback does not rank and orders the
+// matches by title.
+func (r *DBRepository) Search(ctx context.Context, search domain.BookSearch) ([]*domain.BookMatch, error) {
+	if len(search.Terms) == 0 {
+		return nil, ErrInvalidInput
+	}
+	if !r.fullText {
+		return r.searchLike(ctx, search)
+	}
+
+	query := tsQuery(search.Terms)
+	var matches []*domain.BookMatch
+	if err := r.db.WithContext(ctx).
+		Model(&domain.Book{}).
+		Select(
+			"books.*, ts_rank(search_vector, to_tsquery('simple', ?)) AS rank, ts_headline('simple', "+searchDocumentSQL+", to_tsquery('simple', ?), ?) AS snippet",
+			query, query, "StartSel="+highlightStart+", StopSel="+highlightStop+", HighlightAll=true",
+		).
+		Where("search_vector @@ to_tsquery('simple', ?)", query).
+		Order("rank DESC, id").
+		Limit(search.Limit).
+		Scan(&matches).Error; err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+// searchLike matches every term anywhere in the title, author or category
+func (r *DBRepository) searchLike(ctx context.Context, search domain.BookSearch) ([]*domain.BookMatch, error) {
+	db := r.db.WithContext(ctx)
+	for _, term := range search.Terms {
+		pattern := "%" + searchPhrase(term) + "%"
+		db = db.Where("title ILIKE ? OR author ILIKE ? OR category ILIKE ?", pattern, pattern, pattern)
+	}
+
+	var books []*domain.Book
+	if err := db.Order("title, id").Limit(search.Limit).Find(&books).Error; err != nil {
+		return nil, err
+	}
+
+	matches := make([]*domain.BookMatch, len(books))
+	for i, book := range books {
+		matches[i] = &domain.BookMatch{Book: *book, Snippet: highlight(searchDocument(book), search.Terms)}
+	}
+	return matches, nil
+}
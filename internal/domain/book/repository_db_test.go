@@ -10,10 +10,45 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/hinha/library-management-synapsis/internal/domain"
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
+// expectOutboxEvent expects the outbox insert written alongside a book change
+func expectOutboxEvent(mock sqlmock.Sqlmock, eventType string) {
+	mock.ExpectQuery(`INSERT INTO "outbox_events"`).
+		WithArgs(sqlmock.AnyArg(), domain.AggregateBook, sqlmock.AnyArg(), eventType, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+}
+
+// expectStockMovement expects a movement to be appended to the stock ledger
+func expectStockMovement(mock sqlmock.Sqlmock, bookID string, delta int32, reason domain.StockReason) {
+	mock.ExpectQuery(`INSERT INTO "stock_movements"`).
+		WithArgs(bookID, delta, reason, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+}
+
+// expectStockLock expects the book's stock to be read and locked, finding
+// no book when stock is nil
+func expectStockLock(mock sqlmock.Sqlmock, bookID string, stock *int32) {
+	rows := sqlmock.NewRows([]string{"stock"})
+	if stock != nil {
+		rows.AddRow(*stock)
+	}
+	mock.ExpectQuery(`SELECT "stock" FROM "books" WHERE id = \$1 AND "books"\."deleted_at" IS NULL ORDER BY "books"\."id" LIMIT \$2 FOR UPDATE`).
+		WithArgs(bookID, 1).
+		WillReturnRows(rows)
+}
+
+// expectVersionLock expects the book's stock and version to be read and
+// locked before an update
+func expectVersionLock(mock sqlmock.Sqlmock, bookID string, stock int32, version int64) {
+	mock.ExpectQuery(`SELECT "stock","version" FROM "books" WHERE id = \$1 AND "books"\."deleted_at" IS NULL ORDER BY "books"\."id" LIMIT \$2 FOR UPDATE`).
+		WithArgs(bookID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"stock", "version"}).AddRow(stock, version))
+}
+
 func TestNewDbRepository(t *testing.T) {
 	db := &gorm.DB{}
 	repo := NewDbRepository(db)
@@ -53,11 +88,21 @@ func TestDBRepository_Create(t *testing.T) {
 							book.Author,
 							book.Category,
 							book.Stock,
+							book.ISBN,
+							book.Publisher,
+							book.PublicationYear,
+							book.Language,
+							book.PageCount,
+							book.Edition,
+							book.Description,
+							sqlmock.AnyArg(), // Version
 							sqlmock.AnyArg(), // CreatedAt
 							sqlmock.AnyArg(), // UpdatedAt
 							sqlmock.AnyArg(), // DeletedAt
 						).
 						WillReturnResult(sqlmock.NewResult(1, 1))
+					expectStockMovement(mock, book.ID, book.Stock, domain.StockReasonAcquisition)
+					expectOutboxEvent(mock, domain.EventBookCreated)
 					mock.ExpectCommit()
 				},
 			},
@@ -83,6 +128,14 @@ func TestDBRepository_Create(t *testing.T) {
 							book.Author,
 							book.Category,
 							book.Stock,
+							book.ISBN,
+							book.Publisher,
+							book.PublicationYear,
+							book.Language,
+							book.PageCount,
+							book.Edition,
+							book.Description,
+							sqlmock.AnyArg(),
 							sqlmock.AnyArg(),
 							sqlmock.AnyArg(),
 							sqlmock.AnyArg(),
@@ -93,6 +146,25 @@ func TestDBRepository_Create(t *testing.T) {
 			},
 			expectedError: errors.New("database error"),
 		},
+		{
+			name: "Duplicate ISBN",
+			book: &domain.Book{
+				Title:        "Copy Book",
+				Author:       "Author",
+				Category:     "Fiction",
+				Stock:        1,
+				BookMetadata: domain.BookMetadata{ISBN: "9780306406157"},
+			},
+			fields: fields{
+				setupMock: func(mock sqlmock.Sqlmock, book *domain.Book) {
+					mock.ExpectBegin()
+					mock.ExpectExec(`INSERT INTO "books"`).
+						WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: "idx_books_isbn"})
+					mock.ExpectRollback()
+				},
+			},
+			expectedError: ErrDuplicateISBN,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -255,6 +327,88 @@ func TestDBRepository_GetByID(t *testing.T) {
 	}
 }
 
+func TestDBRepository_GetByISBN(t *testing.T) {
+	fixedTime := time.Date(2025, 6, 22, 9, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		isbn          string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedBook  *domain.Book
+		expectedError error
+	}{
+		{
+			name: "Success",
+			isbn: "9780306406157",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "books" WHERE isbn = \$1 AND "books"\."deleted_at" IS NULL ORDER BY "books"\."id" LIMIT \$2`).
+					WithArgs("9780306406157", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "title", "author", "category", "stock", "isbn", "publisher", "created_at", "updated_at", "deleted_at"}).
+						AddRow("1", "Test Book", "Author", "Fiction", 10, "9780306406157", "Plenum", fixedTime, fixedTime, nil))
+			},
+			expectedBook: &domain.Book{
+				ID:           "1",
+				Title:        "Test Book",
+				Author:       "Author",
+				Category:     "Fiction",
+				Stock:        10,
+				BookMetadata: domain.BookMetadata{ISBN: "9780306406157", Publisher: "Plenum"},
+				CreatedAt:    fixedTime,
+				UpdatedAt:    fixedTime,
+			},
+		},
+		{
+			name: "Not Found",
+			isbn: "9780306406157",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "books" WHERE isbn = \$1`).WillReturnError(gorm.ErrRecordNotFound)
+			},
+			expectedError: ErrBookNotFound,
+		},
+		{
+			name: "DB Error",
+			isbn: "9780306406157",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "books" WHERE isbn = \$1`).WillReturnError(errors.New("db error"))
+			},
+			expectedError: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to open sqlmock database: %v", err)
+			}
+			defer db.Close()
+
+			gdb, err := gorm.Open(postgres.New(postgres.Config{
+				Conn: db,
+			}), &gorm.Config{})
+			if err != nil {
+				t.Fatalf("failed to open gorm db: %v", err)
+			}
+
+			tc.setupMock(mock)
+
+			repo := &DBRepository{db: gdb}
+			got, err := repo.GetByISBN(context.Background(), tc.isbn)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedBook, got)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
 func TestDBRepository_List(t *testing.T) {
 	fixedTime := time.Date(2025, 6, 22, 9, 0, 0, 0, time.UTC)
 
@@ -350,8 +504,210 @@ func TestDBRepository_List(t *testing.T) {
 	}
 }
 
+func TestDBRepository_ListPage(t *testing.T) {
+	fixedTime := time.Date(2025, 6, 22, 9, 0, 0, 0, time.UTC)
+	columns := []string{"id", "title", "author", "category", "stock", "created_at", "updated_at", "deleted_at"}
+
+	testCases := []struct {
+		name          string
+		query         ListQuery
+		setupMock     func(sqlmock.Sqlmock)
+		expectedBooks []*domain.Book
+		expectedTotal int64
+		expectedError error
+	}{
+		{
+			name:  "First page newest first",
+			query: ListQuery{Filter: ListFilter{SortBy: SortByCreatedAt, Descending: true}, Limit: 2},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "books" WHERE "books"\."deleted_at" IS NULL`).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+				mock.ExpectQuery(`SELECT \* FROM "books" WHERE "books"\."deleted_at" IS NULL ORDER BY created_at DESC, id DESC LIMIT \$1`).
+					WithArgs(2).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow("1", "Book1", "Author1", "Cat1", 5, fixedTime, fixedTime, nil))
+			},
+			expectedBooks: []*domain.Book{
+				{ID: "1", Title: "Book1", Author: "Author1", Category: "Cat1", Stock: 5, CreatedAt: fixedTime, UpdatedAt: fixedTime},
+			},
+			expectedTotal: 3,
+		},
+		{
+			name: "Filtered page after cursor",
+			query: ListQuery{
+				Filter: ListFilter{Category: "Cat1", Author: "Author1", InStock: true, SortBy: SortByTitle},
+				Limit:  2,
+				After:  &Cursor{Value: "Book1", ID: "1"},
+			},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "books" WHERE category = \$1 AND author = \$2 AND stock > 0 AND "books"\."deleted_at" IS NULL`).
+					WithArgs("Cat1", "Author1").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+				mock.ExpectQuery(`SELECT \* FROM "books" WHERE category = \$1 AND author = \$2 AND stock > 0 AND \(title, id\) > \(\$3, \$4\) AND "books"\."deleted_at" IS NULL ORDER BY title ASC, id ASC LIMIT \$5`).
+					WithArgs("Cat1", "Author1", "Book1", "1", 2).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow("2", "Book2", "Author1", "Cat1", 1, fixedTime, fixedTime, nil))
+			},
+			expectedBooks: []*domain.Book{
+				{ID: "2", Title: "Book2", Author: "Author1", Category: "Cat1", Stock: 1, CreatedAt: fixedTime, UpdatedAt: fixedTime},
+			},
+			expectedTotal: 2,
+		},
+		{
+			name:          "Unknown sort field",
+			query:         ListQuery{Filter: ListFilter{SortBy: "isbn"}, Limit: 2},
+			expectedError: ErrInvalidInput,
+		},
+		{
+			name:  "Malformed cursor",
+			query: ListQuery{Filter: ListFilter{SortBy: SortByStock}, Limit: 2, After: &Cursor{Value: "many", ID: "1"}},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "books"`).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+			},
+			expectedError: ErrInvalidPageToken,
+		},
+		{
+			name:  "DB Error",
+			query: ListQuery{Filter: ListFilter{SortBy: SortByCreatedAt}, Limit: 2},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "books"`).WillReturnError(errors.New("db error"))
+			},
+			expectedError: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to open sqlmock database: %v", err)
+			}
+			defer db.Close()
+
+			gdb, err := gorm.Open(postgres.New(postgres.Config{
+				Conn: db,
+			}), &gorm.Config{})
+			if err != nil {
+				t.Fatalf("failed to open gorm db: %v", err)
+			}
+
+			if tc.setupMock != nil {
+				tc.setupMock(mock)
+			}
+
+			repo := &DBRepository{db: gdb}
+			got, total, err := repo.ListPage(context.Background(), tc.query)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedBooks, got)
+				assert.Equal(t, tc.expectedTotal, total)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestDBRepository_Each(t *testing.T) {
+	fixedTime := time.Date(2025, 6, 22, 9, 0, 0, 0, time.UTC)
+	columns := []string{"id", "title", "author", "category", "stock", "created_at", "updated_at", "deleted_at"}
+
+	testCases := []struct {
+		name          string
+		filter        ListFilter
+		fnErr         error
+		setupMock     func(sqlmock.Sqlmock)
+		expectedIDs   []string
+		expectedError error
+	}{
+		{
+			name:   "Filtered books in order",
+			filter: ListFilter{Category: "Cat1", InStock: true, SortBy: SortByTitle},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "books" WHERE category = \$1 AND stock > 0 AND "books"\."deleted_at" IS NULL ORDER BY title ASC, id ASC$`).
+					WithArgs("Cat1").
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow("1", "Book1", "Author1", "Cat1", 5, fixedTime, fixedTime, nil).
+						AddRow("2", "Book2", "Author2", "Cat1", 1, fixedTime, fixedTime, nil))
+			},
+			expectedIDs: []string{"1", "2"},
+		},
+		{
+			name:   "Callback error stops the iteration",
+			filter: ListFilter{SortBy: SortByCreatedAt, Descending: true},
+			fnErr:  errors.New("write error"),
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "books" WHERE "books"\."deleted_at" IS NULL ORDER BY created_at DESC, id DESC`).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow("1", "Book1", "Author1", "Cat1", 5, fixedTime, fixedTime, nil).
+						AddRow("2", "Book2", "Author2", "Cat1", 1, fixedTime, fixedTime, nil))
+			},
+			expectedIDs:   []string{"1"},
+			expectedError: errors.New("write error"),
+		},
+		{
+			name:          "Unknown sort field",
+			filter:        ListFilter{SortBy: "isbn"},
+			expectedError: ErrInvalidInput,
+		},
+		{
+			name:   "DB Error",
+			filter: ListFilter{SortBy: SortByCreatedAt},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "books"`).WillReturnError(errors.New("db error"))
+			},
+			expectedError: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to open sqlmock database: %v", err)
+			}
+			defer db.Close()
+
+			gdb, err := gorm.Open(postgres.New(postgres.Config{
+				Conn: db,
+			}), &gorm.Config{})
+			if err != nil {
+				t.Fatalf("failed to open gorm db: %v", err)
+			}
+
+			if tc.setupMock != nil {
+				tc.setupMock(mock)
+			}
+
+			repo := &DBRepository{db: gdb}
+			var ids []string
+			err = repo.Each(context.Background(), tc.filter, func(book *domain.Book) error {
+				ids = append(ids, book.ID)
+				return tc.fnErr
+			})
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedIDs, ids)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
 func TestDBRepository_Update(t *testing.T) {
 	fixedTime := time.Date(2025, 6, 22, 9, 0, 0, 0, time.UTC)
+	stock := int32(8)
 
 	testCases := []struct {
 		name          string
@@ -367,27 +723,55 @@ func TestDBRepository_Update(t *testing.T) {
 				Author:    "Author",
 				Category:  "Fiction",
 				Stock:     10,
+				Version:   1,
 				CreatedAt: fixedTime,
 				UpdatedAt: fixedTime,
 			},
 			setupMock: func(mock sqlmock.Sqlmock, book *domain.Book) {
 				mock.ExpectBegin()
-				mock.ExpectExec(`UPDATE "books" SET (.+) WHERE "books"\."deleted_at" IS NULL AND "id" = \$8`).
+				expectVersionLock(mock, book.ID, stock, 1)
+				mock.ExpectExec(`UPDATE "books" SET (.+) WHERE version = \$14 AND "books"\."deleted_at" IS NULL AND "id" = \$15`).
 					WithArgs(
 						book.Title,
 						book.Author,
 						book.Category,
 						book.Stock,
-						book.CreatedAt,
+						book.ISBN,
+						book.Publisher,
+						book.PublicationYear,
+						book.Language,
+						book.PageCount,
+						book.Edition,
+						book.Description,
+						int64(2),         // Version
 						sqlmock.AnyArg(), // UpdatedAt (will be set to time.Now())
-						sqlmock.AnyArg(), // DeletedAt
+						int64(1),         // Version read
 						book.ID,
 					).
 					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectStockMovement(mock, book.ID, book.Stock-stock, domain.StockReasonAdjustment)
+				expectOutboxEvent(mock, domain.EventBookUpdated)
 				mock.ExpectCommit()
 			},
 			expectedError: nil,
 		},
+		{
+			name: "Version Conflict",
+			book: &domain.Book{
+				ID:        "1",
+				Title:     "Stale Book",
+				Stock:     10,
+				Version:   1,
+				CreatedAt: fixedTime,
+				UpdatedAt: fixedTime,
+			},
+			setupMock: func(mock sqlmock.Sqlmock, book *domain.Book) {
+				mock.ExpectBegin()
+				expectVersionLock(mock, book.ID, stock, 2)
+				mock.ExpectRollback()
+			},
+			expectedError: ErrVersionConflict,
+		},
 		//{
 		//	name: "Book Not Found",
 		//	book: &domain.Book{
@@ -411,20 +795,29 @@ func TestDBRepository_Update(t *testing.T) {
 				Author:    "Author",
 				Category:  "Fiction",
 				Stock:     7,
+				Version:   1,
 				CreatedAt: fixedTime,
 				UpdatedAt: fixedTime,
 			},
 			setupMock: func(mock sqlmock.Sqlmock, book *domain.Book) {
 				mock.ExpectBegin()
-				mock.ExpectExec(`UPDATE "books" SET (.+) WHERE "books"\."deleted_at" IS NULL AND "id" = \$8`).
+				expectVersionLock(mock, book.ID, stock, 1)
+				mock.ExpectExec(`UPDATE "books" SET (.+) WHERE version = \$14 AND "books"\."deleted_at" IS NULL AND "id" = \$15`).
 					WithArgs(
 						book.Title,
 						book.Author,
 						book.Category,
 						book.Stock,
-						book.CreatedAt,
-						sqlmock.AnyArg(),
-						sqlmock.AnyArg(),
+						book.ISBN,
+						book.Publisher,
+						book.PublicationYear,
+						book.Language,
+						book.PageCount,
+						book.Edition,
+						book.Description,
+						int64(2),         // Version
+						sqlmock.AnyArg(), // UpdatedAt (will be set to time.Now())
+						int64(1),         // Version read
 						book.ID,
 					).
 					WillReturnError(errors.New("update error"))
@@ -496,6 +889,7 @@ func TestDBRepository_Delete(t *testing.T) {
 					mock.ExpectExec(`UPDATE "books" SET "deleted_at"=\$1 WHERE id = \$2 AND "books"\."deleted_at" IS NULL`).
 						WithArgs(sqlmock.AnyArg(), id).
 						WillReturnResult(sqlmock.NewResult(1, 1))
+					expectOutboxEvent(mock, domain.EventBookDeleted)
 					mock.ExpectCommit()
 				},
 			},
@@ -510,7 +904,7 @@ func TestDBRepository_Delete(t *testing.T) {
 					mock.ExpectExec(`UPDATE "books" SET "deleted_at"=\$1 WHERE id = \$2 AND "books"\."deleted_at" IS NULL`).
 						WithArgs(sqlmock.AnyArg(), id).
 						WillReturnResult(sqlmock.NewResult(0, 0))
-					mock.ExpectCommit()
+					mock.ExpectRollback()
 				},
 			},
 			expectedError: ErrBookNotFound,
@@ -586,17 +980,12 @@ func TestDBRepository_UpdateStock(t *testing.T) {
 			id:     "1",
 			change: 5,
 			setupMock: func(mock sqlmock.Sqlmock, id string, change int32) {
-				rows := sqlmock.NewRows([]string{"id", "title", "author", "category", "stock", "created_at", "updated_at", "deleted_at"}).
-					AddRow(id, "Book", "Author", "Cat", 10, fixedTime, fixedTime, nil)
-				mock.ExpectQuery(`SELECT \* FROM "books" WHERE id = \$1 AND "books"\."deleted_at" IS NULL ORDER BY "books"\."id" LIMIT \$2`).
-					WithArgs(id, 1).
-					WillReturnRows(rows)
 				mock.ExpectBegin()
-				mock.ExpectExec(`UPDATE "books" SET (.+) WHERE "books"\."deleted_at" IS NULL AND "id" = \$8`).
-					WithArgs(
-						"Book", "Author", "Cat", int32(15), fixedTime, sqlmock.AnyArg(), sqlmock.AnyArg(), id,
-					).
-					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`UPDATE "books" SET "stock"=stock \+ \$1,"updated_at"=\$2,"version"=version \+ 1 WHERE id = \$3 AND "books"\."deleted_at" IS NULL`).
+					WithArgs(change, sqlmock.AnyArg(), id).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectStockMovement(mock, id, change, domain.StockReasonAdjustment)
+				expectOutboxEvent(mock, domain.EventStockChanged)
 				mock.ExpectCommit()
 			},
 			expectedError: nil,
@@ -606,17 +995,12 @@ func TestDBRepository_UpdateStock(t *testing.T) {
 			id:     "1",
 			change: -3,
 			setupMock: func(mock sqlmock.Sqlmock, id string, change int32) {
-				rows := sqlmock.NewRows([]string{"id", "title", "author", "category", "stock", "created_at", "updated_at", "deleted_at"}).
-					AddRow(id, "Book", "Author", "Cat", 10, fixedTime, fixedTime, nil)
-				mock.ExpectQuery(`SELECT \* FROM "books" WHERE id = \$1 AND "books"\."deleted_at" IS NULL ORDER BY "books"\."id" LIMIT \$2`).
-					WithArgs(id, 1).
-					WillReturnRows(rows)
 				mock.ExpectBegin()
-				mock.ExpectExec(`UPDATE "books" SET (.+) WHERE "books"\."deleted_at" IS NULL AND "id" = \$8`).
-					WithArgs(
-						"Book", "Author", "Cat", int32(7), fixedTime, sqlmock.AnyArg(), sqlmock.AnyArg(), id,
-					).
-					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`UPDATE "books" SET "stock"=stock \+ \$1,"updated_at"=\$2,"version"=version \+ 1 WHERE id = \$3 AND stock >= \$4 AND "books"\."deleted_at" IS NULL`).
+					WithArgs(change, sqlmock.AnyArg(), id, -change).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectStockMovement(mock, id, change, domain.StockReasonAdjustment)
+				expectOutboxEvent(mock, domain.EventStockChanged)
 				mock.ExpectCommit()
 			},
 			expectedError: nil,
@@ -626,6 +1010,11 @@ func TestDBRepository_UpdateStock(t *testing.T) {
 			id:     "1",
 			change: -15,
 			setupMock: func(mock sqlmock.Sqlmock, id string, change int32) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "books" SET "stock"=stock \+ \$1,"updated_at"=\$2,"version"=version \+ 1 WHERE id = \$3 AND stock >= \$4 AND "books"\."deleted_at" IS NULL`).
+					WithArgs(change, sqlmock.AnyArg(), id, -change).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
 				rows := sqlmock.NewRows([]string{"id", "title", "author", "category", "stock", "created_at", "updated_at", "deleted_at"}).
 					AddRow(id, "Book", "Author", "Cat", 10, fixedTime, fixedTime, nil)
 				mock.ExpectQuery(`SELECT \* FROM "books" WHERE id = \$1 AND "books"\."deleted_at" IS NULL ORDER BY "books"\."id" LIMIT \$2`).
@@ -639,38 +1028,25 @@ func TestDBRepository_UpdateStock(t *testing.T) {
 			id:     "2",
 			change: 1,
 			setupMock: func(mock sqlmock.Sqlmock, id string, change int32) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "books" SET "stock"=stock \+ \$1,"updated_at"=\$2,"version"=version \+ 1 WHERE id = \$3 AND "books"\."deleted_at" IS NULL`).
+					WithArgs(change, sqlmock.AnyArg(), id).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
 				mock.ExpectQuery(`SELECT \* FROM "books" WHERE id = \$1 AND "books"\."deleted_at" IS NULL ORDER BY "books"\."id" LIMIT \$2`).
 					WithArgs(id, 1).
 					WillReturnError(gorm.ErrRecordNotFound)
 			},
 			expectedError: ErrBookNotFound,
 		},
-		{
-			name:   "DB error on select",
-			id:     "3",
-			change: 1,
-			setupMock: func(mock sqlmock.Sqlmock, id string, change int32) {
-				mock.ExpectQuery(`SELECT \* FROM "books" WHERE id = \$1 AND "books"\."deleted_at" IS NULL ORDER BY "books"\."id" LIMIT \$2`).
-					WithArgs(id, 1).
-					WillReturnError(errors.New("db error"))
-			},
-			expectedError: errors.New("db error"),
-		},
 		{
 			name:   "DB error on update",
 			id:     "1",
 			change: 2,
 			setupMock: func(mock sqlmock.Sqlmock, id string, change int32) {
-				rows := sqlmock.NewRows([]string{"id", "title", "author", "category", "stock", "created_at", "updated_at", "deleted_at"}).
-					AddRow(id, "Book", "Author", "Cat", 10, fixedTime, fixedTime, nil)
-				mock.ExpectQuery(`SELECT \* FROM "books" WHERE id = \$1 AND "books"\."deleted_at" IS NULL ORDER BY "books"\."id" LIMIT \$2`).
-					WithArgs(id, 1).
-					WillReturnRows(rows)
 				mock.ExpectBegin()
-				mock.ExpectExec(`UPDATE "books" SET (.+) WHERE "books"\."deleted_at" IS NULL AND "id" = \$8`).
-					WithArgs(
-						"Book", "Author", "Cat", int32(12), fixedTime, sqlmock.AnyArg(), sqlmock.AnyArg(), id,
-					).
+				mock.ExpectExec(`UPDATE "books" SET "stock"=stock \+ \$1,"updated_at"=\$2,"version"=version \+ 1 WHERE id = \$3 AND "books"\."deleted_at" IS NULL`).
+					WithArgs(change, sqlmock.AnyArg(), id).
 					WillReturnError(errors.New("update error"))
 				mock.ExpectRollback()
 			},
@@ -698,7 +1074,7 @@ func TestDBRepository_UpdateStock(t *testing.T) {
 			}
 
 			repo := &DBRepository{db: gdb}
-			err = repo.UpdateStock(context.Background(), tc.id, tc.change)
+			err = repo.UpdateStock(context.Background(), tc.id, tc.change, domain.StockReasonAdjustment)
 
 			if tc.expectedError != nil {
 				if err == nil {
@@ -872,3 +1248,301 @@ func TestDBRepository_Ping(t *testing.T) {
 		})
 	}
 }
+
+func TestDBRepository_Search(t *testing.T) {
+	fixedTime := time.Date(2025, 6, 22, 9, 0, 0, 0, time.UTC)
+	search := domain.BookSearch{Terms: parseSearch(`"frank herbert" dun*`), Limit: 10}
+
+	testCases := []struct {
+		name            string
+		fullText        bool
+		search          domain.BookSearch
+		setupMock       func(sqlmock.Sqlmock)
+		expectedMatches []*domain.BookMatch
+		expectedError   error
+	}{
+		{
+			name:     "Full-text search",
+			fullText: true,
+			search:   search,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT books\.\*, ts_rank\(search_vector, to_tsquery\('simple', \$1\)\) AS rank, ts_headline\('simple', title \|\| ' — ' \|\| author \|\| ' — ' \|\| category, to_tsquery\('simple', \$2\), \$3\) AS snippet FROM "books" WHERE search_vector @@ to_tsquery\('simple', \$4\) AND "books"\."deleted_at" IS NULL ORDER BY rank DESC, id LIMIT \$5`).
+					WithArgs("frank <-> herbert & dun:*", "frank <-> herbert & dun:*", "StartSel=<mark>, StopSel=</mark>, HighlightAll=true", "frank <-> herbert & dun:*", 10).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "title", "author", "category", "stock", "created_at", "updated_at", "deleted_at", "rank", "snippet"}).
+						AddRow("1", "Dune", "Frank Herbert", "Fiction", 2, fixedTime, fixedTime, nil, 0.5, "<mark>Dune</mark> — <mark>Frank</mark> <mark>Herbert</mark> — Fiction"))
+			},
+			expectedMatches: []*domain.BookMatch{
+				{
+					Book:    domain.Book{ID: "1", Title: "Dune", Author: "Frank Herbert", Category: "Fiction", Stock: 2, CreatedAt: fixedTime, UpdatedAt: fixedTime},
+					Rank:    0.5,
+					Snippet: "<mark>Dune</mark> — <mark>Frank</mark> <mark>Herbert</mark> — Fiction",
+				},
+			},
+		},
+		{
+			name:   "ILIKE fallback",
+			search: search,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "books" WHERE \(title ILIKE \$1 OR author ILIKE \$2 OR category ILIKE \$3\) AND \(title ILIKE \$4 OR author ILIKE \$5 OR category ILIKE \$6\) AND "books"\."deleted_at" IS NULL ORDER BY title, id LIMIT \$7`).
+					WithArgs("%frank herbert%", "%frank herbert%", "%frank herbert%", "%dun%", "%dun%", "%dun%", 10).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "title", "author", "category", "stock", "created_at", "updated_at", "deleted_at"}).
+						AddRow("1", "Dune", "Frank Herbert", "Fiction", 2, fixedTime, fixedTime, nil))
+			},
+			expectedMatches: []*domain.BookMatch{
+				{
+					Book:    domain.Book{ID: "1", Title: "Dune", Author: "Frank Herbert", Category: "Fiction", Stock: 2, CreatedAt: fixedTime, UpdatedAt: fixedTime},
+					Snippet: "<mark>Dun</mark>e — <mark>Frank Herbert</mark> — Fiction",
+				},
+			},
+		},
+		{
+			name:          "No terms",
+			fullText:      true,
+			search:        domain.BookSearch{Limit: 10},
+			expectedError: ErrInvalidInput,
+		},
+		{
+			name:     "DB Error",
+			fullText: true,
+			search:   search,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT books\.\*`).WillReturnError(errors.New("db error"))
+			},
+			expectedError: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to open sqlmock database: %v", err)
+			}
+			defer db.Close()
+
+			gdb, err := gorm.Open(postgres.New(postgres.Config{
+				Conn: db,
+			}), &gorm.Config{})
+			if err != nil {
+				t.Fatalf("failed to open gorm db: %v", err)
+			}
+
+			if tc.setupMock != nil {
+				tc.setupMock(mock)
+			}
+
+			repo := &DBRepository{db: gdb, fullText: tc.fullText}
+			got, err := repo.Search(context.Background(), tc.search)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedMatches, got)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestDBRepository_DetectFullTextSearch(t *testing.T) {
+	testCases := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedFull  bool
+		expectedError error
+	}{
+		{
+			name: "Migrated",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT EXISTS \(SELECT 1 FROM information_schema\.columns WHERE table_schema = current_schema\(\) AND table_name = 'books' AND column_name = 'search_vector'\)`).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+			},
+			expectedFull: true,
+		},
+		{
+			name: "No search column",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT EXISTS`).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+			},
+		},
+		{
+			name: "Database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT EXISTS`).WillReturnError(errors.New("connection refused"))
+			},
+			expectedError: errors.New("connection refused"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to open sqlmock database: %v", err)
+			}
+			defer db.Close()
+
+			gdb, err := gorm.Open(postgres.New(postgres.Config{
+				Conn: db,
+			}), &gorm.Config{})
+			if err != nil {
+				t.Fatalf("failed to open gorm db: %v", err)
+			}
+
+			tc.setupMock(mock)
+
+			repo := &DBRepository{db: gdb}
+			full, err := repo.DetectFullTextSearch(context.Background())
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedFull, full)
+			assert.Equal(t, tc.expectedFull, repo.fullText)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestDBRepository_ImportBatch(t *testing.T) {
+	bookColumns := []string{"id", "title", "author", "category", "stock", "isbn", "publisher", "version", "created_at", "updated_at"}
+	fixedTime := time.Date(2025, 6, 22, 9, 0, 0, 0, time.UTC)
+	dune := &domain.ImportRecord{Row: 2, Title: "Dune", Author: "Frank Herbert", Category: "Fiction", Stock: 4,
+		BookMetadata: domain.BookMetadata{ISBN: "9780441172719", Publisher: "Ace"}}
+	emma := &domain.ImportRecord{Row: 3, Title: "Emma", Author: "Jane Austen", Category: "Fiction", Stock: 1}
+
+	expectISBNLookup := func(mock sqlmock.Sqlmock, rows *sqlmock.Rows) {
+		mock.ExpectQuery(`SELECT \* FROM "books" WHERE isbn = \$1 AND "books"\."deleted_at" IS NULL ORDER BY "books"\."id" LIMIT \$2 FOR UPDATE`).
+			WithArgs("9780441172719", 1).
+			WillReturnRows(rows)
+	}
+
+	testCases := []struct {
+		name      string
+		records   []*domain.ImportRecord
+		dryRun    bool
+		setupMock func(sqlmock.Sqlmock)
+		want      []domain.ImportResult
+		wantErr   string
+	}{
+		{
+			name:    "creates new books and updates the one with the isbn",
+			records: []*domain.ImportRecord{dune, emma},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectISBNLookup(mock, sqlmock.NewRows(bookColumns).
+					AddRow("book-1", "Dune (old)", "Frank Herbert", "Fiction", 2, "9780441172719", "", 1, fixedTime, fixedTime))
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "book_copies" WHERE book_id = \$1`).
+					WithArgs("book-1").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectExec(`UPDATE "books" SET (.+) WHERE version = \$14 AND "books"\."deleted_at" IS NULL AND "id" = \$15`).
+					WithArgs("Dune", "Frank Herbert", "Fiction", int32(4), "9780441172719", "Ace", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+						sqlmock.AnyArg(), sqlmock.AnyArg(), int64(2), sqlmock.AnyArg(), int64(1), "book-1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectStockMovement(mock, "book-1", 2, domain.StockReasonAdjustment)
+				expectOutboxEvent(mock, domain.EventBookUpdated)
+				mock.ExpectExec(`INSERT INTO "books"`).
+					WithArgs(sqlmock.AnyArg(), "Emma", "Jane Austen", "Fiction", int32(1), "", "", int32(0), "", int32(0), "", "",
+						int64(1), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery(`INSERT INTO "stock_movements"`).
+					WithArgs(sqlmock.AnyArg(), int32(1), domain.StockReasonAcquisition, "", "", "", sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				expectOutboxEvent(mock, domain.EventBookCreated)
+				mock.ExpectCommit()
+			},
+			want: []domain.ImportResult{
+				{Row: 2, Status: domain.ImportStatusUpdated, BookID: "book-1", ISBN: "9780441172719", Title: "Dune"},
+				{Row: 3, Status: domain.ImportStatusCreated, Title: "Emma"},
+			},
+		},
+		{
+			name:    "stock of a book with copies is kept",
+			records: []*domain.ImportRecord{dune},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectISBNLookup(mock, sqlmock.NewRows(bookColumns).
+					AddRow("book-1", "Dune", "Frank Herbert", "Fiction", 2, "9780441172719", "", 1, fixedTime, fixedTime))
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "book_copies" WHERE book_id = \$1`).
+					WithArgs("book-1").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+				mock.ExpectExec(`UPDATE "books" SET (.+) WHERE version = \$14 AND "books"\."deleted_at" IS NULL AND "id" = \$15`).
+					WithArgs("Dune", "Frank Herbert", "Fiction", int32(2), "9780441172719", "Ace", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+						sqlmock.AnyArg(), sqlmock.AnyArg(), int64(2), sqlmock.AnyArg(), int64(1), "book-1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectOutboxEvent(mock, domain.EventBookUpdated)
+				mock.ExpectCommit()
+			},
+			want: []domain.ImportResult{
+				{Row: 2, Status: domain.ImportStatusUpdated, BookID: "book-1", ISBN: "9780441172719", Title: "Dune"},
+			},
+		},
+		{
+			name:    "dry run is rolled back",
+			records: []*domain.ImportRecord{dune},
+			dryRun:  true,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectISBNLookup(mock, sqlmock.NewRows(bookColumns))
+				mock.ExpectExec(`INSERT INTO "books"`).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery(`INSERT INTO "stock_movements"`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				expectOutboxEvent(mock, domain.EventBookCreated)
+				mock.ExpectRollback()
+			},
+			want: []domain.ImportResult{
+				{Row: 2, Status: domain.ImportStatusCreated, ISBN: "9780441172719", Title: "Dune"},
+			},
+		},
+		{
+			name:    "DB Error",
+			records: []*domain.ImportRecord{emma},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`INSERT INTO "books"`).WillReturnError(errors.New("insert error"))
+				mock.ExpectRollback()
+			},
+			wantErr: "row 3: insert error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to open sqlmock database: %v", err)
+			}
+			defer db.Close()
+
+			gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+			if err != nil {
+				t.Fatalf("failed to open gorm db: %v", err)
+			}
+			tc.setupMock(mock)
+
+			repo := &DBRepository{db: gdb}
+			got, err := repo.ImportBatch(context.Background(), tc.records, tc.dryRun)
+
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+			} else if assert.NoError(t, err) && assert.Len(t, got, len(tc.want)) {
+				for i := range got {
+					if tc.want[i].Status == domain.ImportStatusCreated {
+						assert.NotEmpty(t, got[i].BookID)
+						tc.want[i].BookID = got[i].BookID
+					}
+				}
+				assert.Equal(t, tc.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package book
+
+import (
+	"context"
+	"errors"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ListDeleted retrieves up to limit soft-deleted books, most recently
+// deleted first, continuing after the cursor when it is set
+func (r *DBRepository) ListDeleted(ctx context.Context, after *domain.DeletedCursor, limit int) ([]*domain.Book, error) {
+	db := r.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL")
+	if after != nil {
+		db = db.Where("(deleted_at, id) < (?, ?)", after.DeletedAt, after.ID)
+	}
+
+	var books []*domain.Book
+	if err := db.Order("deleted_at DESC, id DESC").Limit(limit).Find(&books).Error; err != nil {
+		return nil, err
+	}
+	return books, nil
+}
+
+// Restore brings a soft-deleted book back and bumps its version. It returns
+// ErrBookNotFound when no deleted book has the id, and ErrDuplicateISBN when
+// another book has taken its ISBN since it was deleted.
+func (r *DBRepository) Restore(ctx context.Context, id string) (*domain.Book, error) {
+	var book domain.Book
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND deleted_at IS NOT NULL", id).First(&book).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrBookNotFound
+			}
+			return err
+		}
+
+		if book.ISBN != "" {
+			var count int64
+			if err := tx.Model(&domain.Book{}).Where("isbn = ?", book.ISBN).Count(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				return ErrDuplicateISBN
+			}
+		}
+
+		book.DeletedAt = gorm.DeletedAt{}
+		book.Version++
+		book.UpdatedAt = time.Now()
+		// The unique index still catches a book created with the ISBN meanwhile
+		if err := tx.Unscoped().Model(&book).Updates(map[string]interface{}{
+			"deleted_at": nil,
+			"version":    book.Version,
+			"updated_at": book.UpdatedAt,
+		}).Error; err != nil {
+			if isUniqueViolation(err) {
+				return ErrDuplicateISBN
+			}
+			return err
+		}
+		return writeEvent(tx, domain.EventBookRestored, book.ID, &book)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &book, nil
+}
+
+// Purge permanently removes the books soft-deleted before the given time
+// along with their copies. Their stock movements are kept as the audit trail
+// of the stock. It returns how many books were removed.
+func (r *DBRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		expired := tx.Unscoped().Model(&domain.Book{}).Select("id").Where("deleted_at < ?", before)
+		if err := tx.Where("book_id IN (?)", expired).Delete(&domain.BookCopy{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Where("deleted_at < ?", before).Delete(&domain.Book{})
+		if result.Error != nil {
+			return result.Error
+		}
+		purged = result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return purged, nil
+}
@@ -0,0 +1,156 @@
+package book
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// newDBRepository returns a DBRepository backed by sqlmock
+func newDBRepository(t *testing.T) (*DBRepository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	gdb, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+	return &DBRepository{db: gdb}, mock
+}
+
+// expectDeletedBookLock expects a soft-deleted book to be read and locked
+// for a restore, finding none when isbn is empty
+func expectDeletedBookLock(mock sqlmock.Sqlmock, id, isbn string) {
+	rows := sqlmock.NewRows([]string{"id", "title", "isbn", "version", "deleted_at"})
+	if isbn != "" {
+		rows.AddRow(id, "Dune", isbn, 3, time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC))
+	}
+	mock.ExpectQuery(`SELECT \* FROM "books" WHERE id = \$1 AND deleted_at IS NOT NULL ORDER BY "books"\."id" LIMIT \$2 FOR UPDATE`).
+		WithArgs(id, 1).
+		WillReturnRows(rows)
+}
+
+func TestDBRepository_ListDeleted(t *testing.T) {
+	deletedAt := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("first page", func(t *testing.T) {
+		repo, mock := newDBRepository(t)
+		mock.ExpectQuery(`SELECT \* FROM "books" WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC, id DESC LIMIT \$1`).
+			WithArgs(3).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "deleted_at"}).AddRow("book-2", "Dune", deletedAt))
+
+		books, err := repo.ListDeleted(context.Background(), nil, 3)
+
+		assert.NoError(t, err)
+		if assert.Len(t, books, 1) {
+			assert.Equal(t, "book-2", books[0].ID)
+			assert.True(t, books[0].DeletedAt.Valid)
+		}
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("after a cursor", func(t *testing.T) {
+		repo, mock := newDBRepository(t)
+		mock.ExpectQuery(`SELECT \* FROM "books" WHERE deleted_at IS NOT NULL AND \(deleted_at, id\) < \(\$1, \$2\) ORDER BY deleted_at DESC, id DESC LIMIT \$3`).
+			WithArgs(deletedAt, "book-2", 3).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "deleted_at"}))
+
+		books, err := repo.ListDeleted(context.Background(), &domain.DeletedCursor{DeletedAt: deletedAt, ID: "book-2"}, 3)
+
+		assert.NoError(t, err)
+		assert.Empty(t, books)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDBRepository_Restore(t *testing.T) {
+	tests := []struct {
+		name      string
+		setupMock func(mock sqlmock.Sqlmock)
+		wantErr   error
+	}{
+		{
+			name: "restored",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectDeletedBookLock(mock, "book-1", "978-0441013593")
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "books" WHERE isbn = \$1 AND "books"\."deleted_at" IS NULL`).
+					WithArgs("978-0441013593").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectExec(`UPDATE "books" SET "deleted_at"=\$1,"updated_at"=\$2,"version"=\$3 WHERE "id" = \$4`).
+					WithArgs(nil, sqlmock.AnyArg(), int64(4), "book-1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectOutboxEvent(mock, domain.EventBookRestored)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "isbn taken since",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectDeletedBookLock(mock, "book-1", "978-0441013593")
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "books" WHERE isbn = \$1 AND "books"\."deleted_at" IS NULL`).
+					WithArgs("978-0441013593").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectRollback()
+			},
+			wantErr: ErrDuplicateISBN,
+		},
+		{
+			name: "not deleted",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectDeletedBookLock(mock, "book-1", "")
+				mock.ExpectRollback()
+			},
+			wantErr: ErrBookNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock := newDBRepository(t)
+			tt.setupMock(mock)
+
+			book, err := repo.Restore(context.Background(), "book-1")
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.False(t, book.DeletedAt.Valid)
+				assert.Equal(t, int64(4), book.Version)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDBRepository_Purge(t *testing.T) {
+	before := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	repo, mock := newDBRepository(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "book_copies" WHERE book_id IN \(SELECT "id" FROM "books" WHERE deleted_at < \$1\)`).
+		WithArgs(before).
+		WillReturnResult(sqlmock.NewResult(0, 4))
+	mock.ExpectExec(`DELETE FROM "books" WHERE deleted_at < \$1`).
+		WithArgs(before).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	purged, err := repo.Purge(context.Background(), before)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), purged)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
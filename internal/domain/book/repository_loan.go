@@ -0,0 +1,159 @@
+package book
+
+import (
+	"context"
+	"errors"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// loanFeed names the cursor of the transaction service's loan events
+const loanFeed = "transaction-loans"
+
+// ILoanRepository defines the interface for the borrowing data behind
+// recommendations
+//
+//go:generate mockery --name=ILoanRepository --output=mocks --outpkg=mocks
+type ILoanRepository interface {
+	Record(ctx context.Context, loan *domain.LoanRecord, cursor uint64) error
+	Void(ctx context.Context, transactionID string, cursor uint64) error
+	Cursor(ctx context.Context) (uint64, error)
+	PopularBooks(ctx context.Context, since time.Time, categories []string, excludeUserID string, limit int) ([]*domain.Book, error)
+	CoBorrowedBooks(ctx context.Context, userID string, limit int) ([]*domain.Book, error)
+	TopCategories(ctx context.Context, userID string, limit int) ([]string, error)
+	BorrowedBookIDs(ctx context.Context, userID string) ([]string, error)
+}
+
+// LoanRepository implements ILoanRepository using GORM
+type LoanRepository struct {
+	db *gorm.DB
+}
+
+// NewLoanRepository creates a new LoanRepository
+func NewLoanRepository(db *gorm.DB) ILoanRepository {
+	return &LoanRepository{db: db}
+}
+
+// Record stores a loan and moves the feed cursor past its event. A loan
+// delivered twice is only stored once.
+func (r *LoanRepository) Record(ctx context.Context, loan *domain.LoanRecord, cursor uint64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(loan).Error; err != nil {
+			return err
+		}
+		return saveCursor(tx, cursor)
+	})
+}
+
+// Void forgets a loan the transaction service rolled back and moves the feed
+// cursor past its event
+func (r *LoanRepository) Void(ctx context.Context, transactionID string, cursor uint64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("transaction_id = ?", transactionID).Delete(&domain.LoanRecord{}).Error; err != nil {
+			return err
+		}
+		return saveCursor(tx, cursor)
+	})
+}
+
+// Cursor returns the cursor of the last loan event handled, 0 before the first
+func (r *LoanRepository) Cursor(ctx context.Context) (uint64, error) {
+	var cursor domain.FeedCursor
+	if err := r.db.WithContext(ctx).Where("name = ?", loanFeed).First(&cursor).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return cursor.Cursor, nil
+}
+
+// PopularBooks retrieves the in-stock books borrowed most since the given
+// time, limited to categories when any are given. Books excludeUserID has
+// borrowed before are left out.
+func (r *LoanRepository) PopularBooks(ctx context.Context, since time.Time, categories []string, excludeUserID string, limit int) ([]*domain.Book, error) {
+	query := r.db.WithContext(ctx).
+		Model(&domain.Book{}).
+		Select("books.*").
+		Joins("JOIN loan_records ON loan_records.book_id = books.id").
+		Where("books.stock > 0").
+		Where("loan_records.borrowed_at >= ?", since)
+	if len(categories) > 0 {
+		query = query.Where("books.category IN ?", categories)
+	}
+	if excludeUserID != "" {
+		query = query.Where("books.id NOT IN (?)", r.borrowedBy(excludeUserID))
+	}
+
+	var books []*domain.Book
+	if err := query.
+		Group("books.id").
+		Order("COUNT(*) DESC, books.id").
+		Limit(limit).
+		Find(&books).Error; err != nil {
+		return nil, err
+	}
+	return books, nil
+}
+
+// CoBorrowedBooks retrieves the in-stock books most often borrowed by people
+// who also borrowed one of userID's books, leaving out userID's own books
+func (r *LoanRepository) CoBorrowedBooks(ctx context.Context, userID string, limit int) ([]*domain.Book, error) {
+	var books []*domain.Book
+	if err := r.db.WithContext(ctx).
+		Model(&domain.Book{}).
+		Select("books.*").
+		Joins("JOIN loan_records other ON other.book_id = books.id").
+		Joins("JOIN loan_records peer ON peer.user_id = other.user_id AND peer.book_id <> other.book_id").
+		Joins("JOIN loan_records mine ON mine.book_id = peer.book_id AND mine.user_id <> peer.user_id").
+		Where("mine.user_id = ?", userID).
+		Where("books.stock > 0").
+		Where("books.id NOT IN (?)", r.borrowedBy(userID)).
+		Group("books.id").
+		Order("COUNT(DISTINCT other.user_id) DESC, books.id").
+		Limit(limit).
+		Find(&books).Error; err != nil {
+		return nil, err
+	}
+	return books, nil
+}
+
+// TopCategories retrieves the categories userID borrows from most, most
+// borrowed first
+func (r *LoanRepository) TopCategories(ctx context.Context, userID string, limit int) ([]string, error) {
+	var categories []string
+	if err := r.db.WithContext(ctx).
+		Model(&domain.LoanRecord{}).
+		Joins("JOIN books ON books.id = loan_records.book_id").
+		Where("loan_records.user_id = ?", userID).
+		Group("books.category").
+		Order("COUNT(*) DESC, books.category").
+		Limit(limit).
+		Pluck("books.category", &categories).Error; err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+// BorrowedBookIDs retrieves the IDs of every book userID has borrowed
+func (r *LoanRepository) BorrowedBookIDs(ctx context.Context, userID string) ([]string, error) {
+	var ids []string
+	if err := r.borrowedBy(userID).WithContext(ctx).Pluck("book_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// borrowedBy selects the distinct books userID has borrowed
+func (r *LoanRepository) borrowedBy(userID string) *gorm.DB {
+	return r.db.Model(&domain.LoanRecord{}).Distinct("book_id").Where("user_id = ?", userID)
+}
+
+// saveCursor stores the loan feed cursor
+func saveCursor(tx *gorm.DB, cursor uint64) error {
+	return tx.Clauses(clause.OnConflict{UpdateAll: true}).
+		Create(&domain.FeedCursor{Name: loanFeed, Cursor: cursor, UpdatedAt: time.Now()}).Error
+}
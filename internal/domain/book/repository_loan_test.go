@@ -0,0 +1,199 @@
+package book
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// newLoanRepository returns a LoanRepository backed by sqlmock
+func newLoanRepository(t *testing.T) (*LoanRepository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	gdb, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+	return &LoanRepository{db: gdb}, mock
+}
+
+func TestLoanRepository_Record(t *testing.T) {
+	borrowedAt := time.Date(2025, 6, 22, 9, 0, 0, 0, time.UTC)
+	loan := &domain.LoanRecord{TransactionID: "tx-1", UserID: "1", BookID: "book-1", BorrowedAt: borrowedAt, CreatedAt: borrowedAt}
+
+	testCases := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError error
+	}{
+		{
+			name: "Success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`INSERT INTO "loan_records" .* ON CONFLICT DO NOTHING`).
+					WithArgs("tx-1", "1", "book-1", borrowedAt, borrowedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`INSERT INTO "feed_cursors" .* ON CONFLICT \("name"\) DO UPDATE SET "updated_at"=\$4,"cursor"="excluded"."cursor"`).
+					WithArgs(loanFeed, uint64(7), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "DB Error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`INSERT INTO "loan_records"`).WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
+			},
+			expectedError: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo, mock := newLoanRepository(t)
+			tc.setupMock(mock)
+
+			err := repo.Record(context.Background(), loan, 7)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestLoanRepository_Void(t *testing.T) {
+	repo, mock := newLoanRepository(t)
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "loan_records" WHERE transaction_id = \$1`).
+		WithArgs("tx-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO "feed_cursors"`).
+		WithArgs(loanFeed, uint64(8), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := repo.Void(context.Background(), "tx-1", 8)
+
+	assert.NoError(t, err)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestLoanRepository_Cursor(t *testing.T) {
+	testCases := []struct {
+		name           string
+		setupMock      func(sqlmock.Sqlmock)
+		expectedCursor uint64
+		expectedError  error
+	}{
+		{
+			name: "Stored cursor",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "feed_cursors" WHERE name = \$1 ORDER BY "feed_cursors"."name" LIMIT \$2`).
+					WithArgs(loanFeed, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"name", "cursor", "updated_at"}).AddRow(loanFeed, 42, time.Now()))
+			},
+			expectedCursor: 42,
+		},
+		{
+			name: "No cursor yet",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "feed_cursors"`).WillReturnError(gorm.ErrRecordNotFound)
+			},
+		},
+		{
+			name: "DB Error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "feed_cursors"`).WillReturnError(errors.New("db error"))
+			},
+			expectedError: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo, mock := newLoanRepository(t)
+			tc.setupMock(mock)
+
+			got, err := repo.Cursor(context.Background())
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedCursor, got)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestLoanRepository_PopularBooks(t *testing.T) {
+	since := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	repo, mock := newLoanRepository(t)
+	mock.ExpectQuery(`SELECT books\.\* FROM "books" JOIN loan_records ON loan_records\.book_id = books\.id WHERE books\.stock > 0 AND loan_records\.borrowed_at >= \$1 AND books\.category IN \(\$2,\$3\) AND books\.id NOT IN \(SELECT DISTINCT "book_id" FROM "loan_records" WHERE user_id = \$4\) AND "books"\."deleted_at" IS NULL GROUP BY "books"\."id" ORDER BY COUNT\(\*\) DESC, books\.id LIMIT \$5`).
+		WithArgs(since, "Fiction", "Poetry", "1", 5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "stock"}).AddRow("book-1", "Dune", 2))
+
+	got, err := repo.PopularBooks(context.Background(), since, []string{"Fiction", "Poetry"}, "1", 5)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*domain.Book{{ID: "book-1", Title: "Dune", Stock: 2}}, got)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestLoanRepository_CoBorrowedBooks(t *testing.T) {
+	repo, mock := newLoanRepository(t)
+	mock.ExpectQuery(`SELECT books\.\* FROM "books" JOIN loan_records other ON other\.book_id = books\.id JOIN loan_records peer ON peer\.user_id = other\.user_id AND peer\.book_id <> other\.book_id JOIN loan_records mine ON mine\.book_id = peer\.book_id AND mine\.user_id <> peer\.user_id WHERE mine\.user_id = \$1 AND books\.stock > 0 AND books\.id NOT IN \(SELECT DISTINCT "book_id" FROM "loan_records" WHERE user_id = \$2\) AND "books"\."deleted_at" IS NULL GROUP BY "books"\."id" ORDER BY COUNT\(DISTINCT other\.user_id\) DESC, books\.id LIMIT \$3`).
+		WithArgs("1", "1", 5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "stock"}).AddRow("book-2", "Dune Messiah", 1))
+
+	got, err := repo.CoBorrowedBooks(context.Background(), "1", 5)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*domain.Book{{ID: "book-2", Title: "Dune Messiah", Stock: 1}}, got)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestLoanRepository_TopCategories(t *testing.T) {
+	repo, mock := newLoanRepository(t)
+	mock.ExpectQuery(`SELECT "books"\."category" FROM "loan_records" JOIN books ON books\.id = loan_records\.book_id WHERE loan_records\.user_id = \$1 GROUP BY "books"\."category" ORDER BY COUNT\(\*\) DESC, books\.category LIMIT \$2`).
+		WithArgs("1", 2).
+		WillReturnRows(sqlmock.NewRows([]string{"category"}).AddRow("Fiction").AddRow("Poetry"))
+
+	got, err := repo.TopCategories(context.Background(), "1", 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Fiction", "Poetry"}, got)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
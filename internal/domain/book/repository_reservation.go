@@ -0,0 +1,308 @@
+package book
+
+import (
+	"context"
+	"errors"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrReservationCancelled is returned when reserving stock for a loan
+	// whose borrow was cancelled
+	ErrReservationCancelled = errors.New("reservation was cancelled")
+	// ErrReservationReleased is returned when reserving stock for a loan that
+	// gave its copy back
+	ErrReservationReleased = errors.New("reservation was released")
+)
+
+// IReservationRepository defines the interface for the stock reservations of
+// loans. Every operation is keyed by the loan's transaction ID and changes
+// the stock at most once, so the transaction service can retry it.
+//
+//go:generate mockery --name=IReservationRepository --output=mocks --outpkg=mocks
+type IReservationRepository interface {
+	Reserve(ctx context.Context, bookID, barcode, transactionID string, reopen bool) (*domain.BookCopy, error)
+	Release(ctx context.Context, bookID, barcode, transactionID string) error
+	Cancel(ctx context.Context, bookID, transactionID string) error
+	WriteOff(ctx context.Context, bookID, barcode, transactionID string, damaged bool) error
+	Restore(ctx context.Context, bookID, barcode, transactionID string) error
+}
+
+// ReservationRepository implements IReservationRepository using GORM
+type ReservationRepository struct {
+	db *gorm.DB
+}
+
+// NewReservationRepository creates a new ReservationRepository
+func NewReservationRepository(db *gorm.DB) IReservationRepository {
+	return &ReservationRepository{db: db}
+}
+
+// Reserve takes one copy of a book out of stock for the loan transactionID:
+// the copy with the barcode, or the first available one when barcode is
+// empty, for books with copies, otherwise one off the stock counter. It
+// returns the copy, nil for books without copies. A loan that already has
+// its stock gets the same copy back. It returns ErrReservationCancelled once
+// the borrow was cancelled, unless reopen takes the stock again for a
+// restored loan, and ErrReservationReleased once the copy was returned.
+func (r *ReservationRepository) Reserve(ctx context.Context, bookID, barcode, transactionID string, reopen bool) (*domain.BookCopy, error) {
+	var bookCopy *domain.BookCopy
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		reservation, err := lockReservation(tx, transactionID)
+		if err != nil {
+			return err
+		}
+		if reservation != nil {
+			switch {
+			case reservation.Status == domain.ReservationReserved:
+				bookCopy, err = reservedCopy(tx, reservation)
+				return err
+			case reservation.Status == domain.ReservationReleased:
+				return ErrReservationReleased
+			case !reopen:
+				return ErrReservationCancelled
+			}
+		}
+
+		if bookCopy, err = takeStock(tx, bookID, barcode); err != nil {
+			return err
+		}
+		if bookCopy != nil {
+			barcode = bookCopy.Barcode
+		}
+		if reservation == nil {
+			// A concurrent reservation of the same loan fails on the primary key
+			return tx.Create(domain.NewStockReservation(transactionID, bookID, barcode, domain.ReservationReserved)).Error
+		}
+		reservation.Barcode = barcode
+		reservation.SetStatus(domain.ReservationReserved)
+		return tx.Save(reservation).Error
+	})
+	if errors.Is(err, errStockUnchanged) {
+		return nil, stockShortage(r.db.WithContext(ctx), bookID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return bookCopy, nil
+}
+
+// Release puts the copy the loan transactionID reserved back into stock.
+// Releasing a loan that no longer has its copy changes nothing. Loans
+// reserved before reservations were recorded give back the copy with the
+// barcode, or one off the stock counter.
+func (r *ReservationRepository) Release(ctx context.Context, bookID, barcode, transactionID string) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		reservation, err := lockReservation(tx, transactionID)
+		if err != nil {
+			return err
+		}
+		if reservation == nil {
+			if err := putBackStock(tx, bookID, barcode); err != nil {
+				return err
+			}
+			return tx.Create(domain.NewStockReservation(transactionID, bookID, barcode, domain.ReservationReleased)).Error
+		}
+		if reservation.Status != domain.ReservationReserved {
+			return nil
+		}
+
+		if err := putBackStock(tx, reservation.BookID, reservation.Barcode); err != nil {
+			return err
+		}
+		reservation.SetStatus(domain.ReservationReleased)
+		return tx.Save(reservation).Error
+	})
+	if errors.Is(err, errStockUnchanged) {
+		return stockShortage(r.db.WithContext(ctx), bookID)
+	}
+	return err
+}
+
+// Cancel undoes the reservation of a borrow that failed, putting the copy
+// back into stock if the loan got one. A cancelled loan that was not
+// reserved yet is recorded as such, so a reservation still on its way is
+// refused when it arrives.
+func (r *ReservationRepository) Cancel(ctx context.Context, bookID, transactionID string) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		reservation, err := lockReservation(tx, transactionID)
+		if err != nil {
+			return err
+		}
+		if reservation == nil {
+			// A concurrent reservation of the same loan fails on the primary key
+			return tx.Create(domain.NewStockReservation(transactionID, bookID, "", domain.ReservationCancelled)).Error
+		}
+		if reservation.Status != domain.ReservationReserved {
+			return nil
+		}
+
+		if err := putBackStock(tx, reservation.BookID, reservation.Barcode); err != nil {
+			return err
+		}
+		reservation.SetStatus(domain.ReservationCancelled)
+		return tx.Save(reservation).Error
+	})
+	if errors.Is(err, errStockUnchanged) {
+		return stockShortage(r.db.WithContext(ctx), bookID)
+	}
+	return err
+}
+
+// WriteOff takes the copy the loan transactionID reserved out of circulation
+// after the patron lost or damaged it: the copy is marked lost, or damaged
+// and withdrawn. Its stock went down when it was lent, so the stock stays as
+// it is, and a loan off the stock counter changes nothing but its
+// reservation. Writing off a loan that was already written off changes
+// nothing. It returns ErrReservationReleased once the copy was returned and
+// ErrReservationCancelled when the borrow was cancelled. Loans reserved
+// before reservations were recorded write off the copy with the barcode.
+func (r *ReservationRepository) WriteOff(ctx context.Context, bookID, barcode, transactionID string, damaged bool) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		reservation, err := lockReservation(tx, transactionID)
+		if err != nil {
+			return err
+		}
+		if reservation == nil {
+			if err := writeOffCopy(tx, bookID, barcode, damaged); err != nil {
+				return err
+			}
+			return tx.Create(domain.NewStockReservation(transactionID, bookID, barcode, domain.ReservationWrittenOff)).Error
+		}
+		switch reservation.Status {
+		case domain.ReservationWrittenOff, domain.ReservationRestored:
+			return nil
+		case domain.ReservationReleased:
+			return ErrReservationReleased
+		case domain.ReservationCancelled:
+			return ErrReservationCancelled
+		}
+
+		if err := writeOffCopy(tx, reservation.BookID, reservation.Barcode, damaged); err != nil {
+			return err
+		}
+		reservation.SetStatus(domain.ReservationWrittenOff)
+		return tx.Save(reservation).Error
+	})
+}
+
+// Restore puts the copy the loan transactionID lost back into stock once it
+// turns up, or one back on the stock counter for a loan off the counter.
+// Restoring a loan that was already restored changes nothing. It returns
+// ErrCopyNotLost when the loan's copy was not written off. Loans reserved
+// before reservations were recorded restore the copy with the barcode.
+func (r *ReservationRepository) Restore(ctx context.Context, bookID, barcode, transactionID string) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		reservation, err := lockReservation(tx, transactionID)
+		if err != nil {
+			return err
+		}
+		if reservation == nil {
+			if err := restoreStock(tx, bookID, barcode); err != nil {
+				return err
+			}
+			return tx.Create(domain.NewStockReservation(transactionID, bookID, barcode, domain.ReservationRestored)).Error
+		}
+		if reservation.Status == domain.ReservationRestored {
+			return nil
+		}
+		if reservation.Status != domain.ReservationWrittenOff {
+			return ErrCopyNotLost
+		}
+
+		if err := restoreStock(tx, reservation.BookID, reservation.Barcode); err != nil {
+			return err
+		}
+		reservation.SetStatus(domain.ReservationRestored)
+		return tx.Save(reservation).Error
+	})
+	if errors.Is(err, errStockUnchanged) {
+		return stockShortage(r.db.WithContext(ctx), bookID)
+	}
+	return err
+}
+
+// lockReservation locks the reservation of the loan transactionID, nil when
+// the loan has none
+func lockReservation(tx *gorm.DB, transactionID string) (*domain.StockReservation, error) {
+	var reservation domain.StockReservation
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("transaction_id = ?", transactionID).First(&reservation).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+// reservedCopy retrieves the copy a reservation holds, nil for a reservation
+// off the stock counter
+func reservedCopy(tx *gorm.DB, reservation *domain.StockReservation) (*domain.BookCopy, error) {
+	if reservation.Barcode == "" {
+		return nil, nil
+	}
+	var bookCopy domain.BookCopy
+	if err := tx.Where("barcode = ?", reservation.Barcode).First(&bookCopy).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCopyNotFound
+		}
+		return nil, err
+	}
+	return &bookCopy, nil
+}
+
+// takeStock checks out a copy of a book that has copies, or the one with the
+// barcode, and otherwise takes one off the stock counter. The book is locked
+// first, so its first copy is not added in between.
+func takeStock(tx *gorm.DB, bookID, barcode string) (*domain.BookCopy, error) {
+	if _, err := lockStock(tx, bookID); err != nil {
+		return nil, err
+	}
+	copies, err := countCopies(tx, bookID)
+	if err != nil {
+		return nil, err
+	}
+	if copies > 0 || barcode != "" {
+		return moveCopy(tx, bookID, barcode, domain.CopyStatusAvailable, domain.CopyStatusOnLoan, "", -1, domain.StockReasonLoan)
+	}
+	return nil, changeStock(tx, bookID, -1, domain.StockReasonLoan)
+}
+
+// putBackStock checks in the copy of a book on loan with the barcode, and
+// otherwise adds one to the stock counter. A loan off the counter never
+// checks in a copy another loan has.
+func putBackStock(tx *gorm.DB, bookID, barcode string) error {
+	if barcode == "" {
+		return changeStock(tx, bookID, 1, domain.StockReasonReturn)
+	}
+	_, err := moveCopy(tx, bookID, barcode, domain.CopyStatusOnLoan, domain.CopyStatusAvailable, "", 1, domain.StockReasonReturn)
+	return err
+}
+
+// writeOffCopy marks the copy on loan with the barcode lost, or damaged and
+// withdrawn. A loan off the stock counter has no copy to write off.
+func writeOffCopy(tx *gorm.DB, bookID, barcode string, damaged bool) error {
+	if barcode == "" {
+		return nil
+	}
+	var err error
+	if damaged {
+		_, err = moveCopy(tx, bookID, barcode, domain.CopyStatusOnLoan, domain.CopyStatusWithdrawn, domain.CopyConditionDamaged, 0, domain.StockReasonWithdrawal)
+	} else {
+		_, err = moveCopy(tx, bookID, barcode, domain.CopyStatusOnLoan, domain.CopyStatusLost, "", 0, domain.StockReasonLoss)
+	}
+	return err
+}
+
+// restoreStock puts the lost copy with the barcode back on the shelf, and
+// otherwise adds one to the stock counter
+func restoreStock(tx *gorm.DB, bookID, barcode string) error {
+	if barcode == "" {
+		return changeStock(tx, bookID, 1, domain.StockReasonFound)
+	}
+	_, err := moveCopy(tx, bookID, barcode, domain.CopyStatusLost, domain.CopyStatusAvailable, "", 1, domain.StockReasonFound)
+	return err
+}
@@ -0,0 +1,457 @@
+package book
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+var reservationColumns = []string{"transaction_id", "book_id", "barcode", "status"}
+
+// newReservationRepository returns a ReservationRepository backed by sqlmock
+func newReservationRepository(t *testing.T) (*ReservationRepository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	gdb, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+	return &ReservationRepository{db: gdb}, mock
+}
+
+// expectReservationLock expects the reservation of transactionID to be
+// locked, returning rows
+func expectReservationLock(mock sqlmock.Sqlmock, transactionID string, rows *sqlmock.Rows) {
+	mock.ExpectQuery(`SELECT \* FROM "stock_reservations" WHERE transaction_id = \$1 ORDER BY "stock_reservations"\."transaction_id" LIMIT \$2 FOR UPDATE`).
+		WithArgs(transactionID, 1).
+		WillReturnRows(rows)
+}
+
+// expectCopyCount expects the copies of bookID to be counted
+func expectCopyCount(mock sqlmock.Sqlmock, bookID string, copies int) {
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "book_copies" WHERE book_id = \$1`).
+		WithArgs(bookID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(copies))
+}
+
+// expectCounterChange expects the stock counter of bookID to change
+func expectCounterChange(mock sqlmock.Sqlmock, bookID string, change int32, reason domain.StockReason) {
+	mock.ExpectExec(`UPDATE "books" SET "stock"=stock \+ \$1`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectStockMovement(mock, bookID, change, reason)
+	expectOutboxEvent(mock, domain.EventStockChanged)
+}
+
+func TestReservationRepository_Reserve(t *testing.T) {
+	copyColumns := []string{"id", "book_id", "barcode", "status"}
+	stock := int32(2)
+
+	testCases := []struct {
+		name          string
+		barcode       string
+		reopen        bool
+		setupMock     func(sqlmock.Sqlmock)
+		expectedCopy  *domain.BookCopy
+		expectedError error
+	}{
+		{
+			name: "Takes one off the counter",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectReservationLock(mock, "tx-1", sqlmock.NewRows(reservationColumns))
+				expectStockLock(mock, "book-1", &stock)
+				expectCopyCount(mock, "book-1", 0)
+				expectCounterChange(mock, "book-1", -1, domain.StockReasonLoan)
+				mock.ExpectExec(`INSERT INTO "stock_reservations"`).
+					WithArgs("tx-1", "book-1", "", domain.ReservationReserved, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Checks out a copy",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectReservationLock(mock, "tx-1", sqlmock.NewRows(reservationColumns))
+				expectStockLock(mock, "book-1", &stock)
+				expectCopyCount(mock, "book-1", 2)
+				mock.ExpectQuery(`SELECT \* FROM "book_copies" WHERE book_id = \$1 AND status = \$2 ORDER BY barcode,"book_copies"\."id" LIMIT \$3 FOR UPDATE SKIP LOCKED`).
+					WithArgs("book-1", domain.CopyStatusAvailable, 1).
+					WillReturnRows(sqlmock.NewRows(copyColumns).AddRow("copy-1", "book-1", "LIB-0001", "available"))
+				mock.ExpectExec(`UPDATE "book_copies" SET "status"=\$1,"updated_at"=\$2 WHERE "id" = \$3`).
+					WithArgs(domain.CopyStatusOnLoan, sqlmock.AnyArg(), "copy-1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectSyncStock(mock, "book-1", 2, 1, domain.StockReasonLoan)
+				expectOutboxEvent(mock, domain.EventStockChanged)
+				mock.ExpectExec(`INSERT INTO "stock_reservations"`).
+					WithArgs("tx-1", "book-1", "LIB-0001", domain.ReservationReserved, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectedCopy: &domain.BookCopy{Barcode: "LIB-0001", Status: domain.CopyStatusOnLoan},
+		},
+		{
+			name: "Retry returns the reserved copy",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectReservationLock(mock, "tx-1", sqlmock.NewRows(reservationColumns).AddRow("tx-1", "book-1", "LIB-0001", "reserved"))
+				mock.ExpectQuery(`SELECT \* FROM "book_copies" WHERE barcode = \$1`).
+					WithArgs("LIB-0001", 1).
+					WillReturnRows(sqlmock.NewRows(copyColumns).AddRow("copy-1", "book-1", "LIB-0001", "on_loan"))
+				mock.ExpectCommit()
+			},
+			expectedCopy: &domain.BookCopy{Barcode: "LIB-0001", Status: domain.CopyStatusOnLoan},
+		},
+		{
+			name: "Cancelled borrow",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectReservationLock(mock, "tx-1", sqlmock.NewRows(reservationColumns).AddRow("tx-1", "book-1", "", "cancelled"))
+				mock.ExpectRollback()
+			},
+			expectedError: ErrReservationCancelled,
+		},
+		{
+			name:   "Reopens a cancelled borrow",
+			reopen: true,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectReservationLock(mock, "tx-1", sqlmock.NewRows(reservationColumns).AddRow("tx-1", "book-1", "", "cancelled"))
+				expectStockLock(mock, "book-1", &stock)
+				expectCopyCount(mock, "book-1", 0)
+				expectCounterChange(mock, "book-1", -1, domain.StockReasonLoan)
+				mock.ExpectExec(`UPDATE "stock_reservations" SET "book_id"=\$1,"barcode"=\$2,"status"=\$3,"created_at"=\$4,"updated_at"=\$5 WHERE "transaction_id" = \$6`).
+					WithArgs("book-1", "", domain.ReservationReserved, sqlmock.AnyArg(), sqlmock.AnyArg(), "tx-1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Returned loan",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectReservationLock(mock, "tx-1", sqlmock.NewRows(reservationColumns).AddRow("tx-1", "book-1", "", "released"))
+				mock.ExpectRollback()
+			},
+			expectedError: ErrReservationReleased,
+		},
+		{
+			name: "No stock left",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectReservationLock(mock, "tx-1", sqlmock.NewRows(reservationColumns))
+				expectStockLock(mock, "book-1", &stock)
+				expectCopyCount(mock, "book-1", 0)
+				mock.ExpectExec(`UPDATE "books" SET "stock"=stock \+ \$1`).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+				mock.ExpectQuery(`SELECT \* FROM "books" WHERE id = \$1`).
+					WithArgs("book-1", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "stock"}).AddRow("book-1", 0))
+			},
+			expectedError: ErrInsufficientStock,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo, mock := newReservationRepository(t)
+			tc.setupMock(mock)
+
+			got, err := repo.Reserve(context.Background(), "book-1", tc.barcode, "tx-1", tc.reopen)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, got)
+			} else if tc.expectedCopy == nil {
+				assert.NoError(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedCopy.Barcode, got.Barcode)
+				assert.Equal(t, tc.expectedCopy.Status, got.Status)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestReservationRepository_Release(t *testing.T) {
+	testCases := []struct {
+		name          string
+		barcode       string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError error
+	}{
+		{
+			name: "Puts the reserved stock back",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectReservationLock(mock, "tx-1", sqlmock.NewRows(reservationColumns).AddRow("tx-1", "book-1", "", "reserved"))
+				expectCounterChange(mock, "book-1", 1, domain.StockReasonReturn)
+				mock.ExpectExec(`UPDATE "stock_reservations"`).
+					WithArgs("book-1", "", domain.ReservationReleased, sqlmock.AnyArg(), sqlmock.AnyArg(), "tx-1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Retry changes nothing",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectReservationLock(mock, "tx-1", sqlmock.NewRows(reservationColumns).AddRow("tx-1", "book-1", "", "released"))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Loan reserved before reservations were recorded",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectReservationLock(mock, "tx-1", sqlmock.NewRows(reservationColumns))
+				expectCounterChange(mock, "book-1", 1, domain.StockReasonReturn)
+				mock.ExpectExec(`INSERT INTO "stock_reservations"`).
+					WithArgs("tx-1", "book-1", "", domain.ReservationReleased, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo, mock := newReservationRepository(t)
+			tc.setupMock(mock)
+
+			err := repo.Release(context.Background(), "book-1", tc.barcode, "tx-1")
+
+			assert.ErrorIs(t, err, tc.expectedError)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestReservationRepository_Cancel(t *testing.T) {
+	testCases := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError error
+	}{
+		{
+			name: "Puts the reserved stock back",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectReservationLock(mock, "tx-1", sqlmock.NewRows(reservationColumns).AddRow("tx-1", "book-1", "", "reserved"))
+				expectCounterChange(mock, "book-1", 1, domain.StockReasonReturn)
+				mock.ExpectExec(`UPDATE "stock_reservations"`).
+					WithArgs("book-1", "", domain.ReservationCancelled, sqlmock.AnyArg(), sqlmock.AnyArg(), "tx-1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Refuses a reservation still on its way",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectReservationLock(mock, "tx-1", sqlmock.NewRows(reservationColumns))
+				mock.ExpectExec(`INSERT INTO "stock_reservations"`).
+					WithArgs("tx-1", "book-1", "", domain.ReservationCancelled, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Retry changes nothing",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectReservationLock(mock, "tx-1", sqlmock.NewRows(reservationColumns).AddRow("tx-1", "book-1", "", "cancelled"))
+				mock.ExpectCommit()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo, mock := newReservationRepository(t)
+			tc.setupMock(mock)
+
+			err := repo.Cancel(context.Background(), "book-1", "tx-1")
+
+			assert.ErrorIs(t, err, tc.expectedError)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestReservationRepository_WriteOff(t *testing.T) {
+	copyColumns := []string{"id", "book_id", "barcode", "status"}
+
+	testCases := []struct {
+		name          string
+		barcode       string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError error
+	}{
+		{
+			name: "Marks the reserved copy lost",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectReservationLock(mock, "tx-1", sqlmock.NewRows(reservationColumns).AddRow("tx-1", "book-1", "LIB-0002", "reserved"))
+				mock.ExpectQuery(`SELECT \* FROM "book_copies" WHERE \(book_id = \$1 AND status = \$2\) AND barcode = \$3`).
+					WithArgs("book-1", domain.CopyStatusOnLoan, "LIB-0002", 1).
+					WillReturnRows(sqlmock.NewRows(copyColumns).AddRow("copy-2", "book-1", "LIB-0002", "on_loan"))
+				mock.ExpectExec(`UPDATE "book_copies" SET "status"=\$1,"updated_at"=\$2 WHERE "id" = \$3`).
+					WithArgs(domain.CopyStatusLost, sqlmock.AnyArg(), "copy-2").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectSyncStock(mock, "book-1", 1, 1, domain.StockReasonLoss)
+				expectOutboxEvent(mock, domain.EventStockChanged)
+				mock.ExpectExec(`UPDATE "stock_reservations"`).
+					WithArgs("book-1", "LIB-0002", domain.ReservationWrittenOff, sqlmock.AnyArg(), sqlmock.AnyArg(), "tx-1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Loan off the counter keeps the stock",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectReservationLock(mock, "tx-1", sqlmock.NewRows(reservationColumns).AddRow("tx-1", "book-1", "", "reserved"))
+				mock.ExpectExec(`UPDATE "stock_reservations"`).
+					WithArgs("book-1", "", domain.ReservationWrittenOff, sqlmock.AnyArg(), sqlmock.AnyArg(), "tx-1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Retry changes nothing",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectReservationLock(mock, "tx-1", sqlmock.NewRows(reservationColumns).AddRow("tx-1", "book-1", "LIB-0002", "written_off"))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Returned loan",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectReservationLock(mock, "tx-1", sqlmock.NewRows(reservationColumns).AddRow("tx-1", "book-1", "LIB-0002", "released"))
+				mock.ExpectRollback()
+			},
+			expectedError: ErrReservationReleased,
+		},
+		{
+			name: "Loan reserved before reservations were recorded",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectReservationLock(mock, "tx-1", sqlmock.NewRows(reservationColumns))
+				mock.ExpectExec(`INSERT INTO "stock_reservations"`).
+					WithArgs("tx-1", "book-1", "", domain.ReservationWrittenOff, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo, mock := newReservationRepository(t)
+			tc.setupMock(mock)
+
+			err := repo.WriteOff(context.Background(), "book-1", tc.barcode, "tx-1", false)
+
+			assert.ErrorIs(t, err, tc.expectedError)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestReservationRepository_Restore(t *testing.T) {
+	copyColumns := []string{"id", "book_id", "barcode", "status"}
+
+	testCases := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError error
+	}{
+		{
+			name: "Puts the lost copy back",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectReservationLock(mock, "tx-1", sqlmock.NewRows(reservationColumns).AddRow("tx-1", "book-1", "LIB-0002", "written_off"))
+				mock.ExpectQuery(`SELECT \* FROM "book_copies" WHERE \(book_id = \$1 AND status = \$2\) AND barcode = \$3`).
+					WithArgs("book-1", domain.CopyStatusLost, "LIB-0002", 1).
+					WillReturnRows(sqlmock.NewRows(copyColumns).AddRow("copy-2", "book-1", "LIB-0002", "lost"))
+				mock.ExpectExec(`UPDATE "book_copies" SET "status"=\$1,"updated_at"=\$2 WHERE "id" = \$3`).
+					WithArgs(domain.CopyStatusAvailable, sqlmock.AnyArg(), "copy-2").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectSyncStock(mock, "book-1", 1, 2, domain.StockReasonFound)
+				expectOutboxEvent(mock, domain.EventStockChanged)
+				mock.ExpectExec(`UPDATE "stock_reservations"`).
+					WithArgs("book-1", "LIB-0002", domain.ReservationRestored, sqlmock.AnyArg(), sqlmock.AnyArg(), "tx-1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Loan off the counter adds one back",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectReservationLock(mock, "tx-1", sqlmock.NewRows(reservationColumns).AddRow("tx-1", "book-1", "", "written_off"))
+				expectCounterChange(mock, "book-1", 1, domain.StockReasonFound)
+				mock.ExpectExec(`UPDATE "stock_reservations"`).
+					WithArgs("book-1", "", domain.ReservationRestored, sqlmock.AnyArg(), sqlmock.AnyArg(), "tx-1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Retry changes nothing",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectReservationLock(mock, "tx-1", sqlmock.NewRows(reservationColumns).AddRow("tx-1", "book-1", "", "restored"))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Copy was not written off",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectReservationLock(mock, "tx-1", sqlmock.NewRows(reservationColumns).AddRow("tx-1", "book-1", "", "reserved"))
+				mock.ExpectRollback()
+			},
+			expectedError: ErrCopyNotLost,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo, mock := newReservationRepository(t)
+			tc.setupMock(mock)
+
+			err := repo.Restore(context.Background(), "book-1", "", "tx-1")
+
+			assert.ErrorIs(t, err, tc.expectedError)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
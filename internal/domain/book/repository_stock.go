@@ -0,0 +1,88 @@
+package book
+
+import (
+	"context"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// IStockRepository defines the interface for reading the stock ledger. The
+// movements themselves are written by the book and copy repositories, in the
+// same database transaction as the stock change they record.
+//
+//go:generate mockery --name=IStockRepository --output=mocks --outpkg=mocks
+type IStockRepository interface {
+	ListMovements(ctx context.Context, bookID string, before uint64, limit int) ([]*domain.StockMovement, error)
+	Reconcile(ctx context.Context, bookID string) ([]*domain.StockDrift, int64, error)
+}
+
+// StockRepository implements IStockRepository using GORM
+type StockRepository struct {
+	db *gorm.DB
+}
+
+// NewStockRepository creates a new StockRepository
+func NewStockRepository(db *gorm.DB) IStockRepository {
+	return &StockRepository{db: db}
+}
+
+// ListMovements retrieves up to limit movements of a book, newest first,
+// starting before the movement with ID before. A zero before starts with the
+// newest movement.
+func (r *StockRepository) ListMovements(ctx context.Context, bookID string, before uint64, limit int) ([]*domain.StockMovement, error) {
+	query := r.db.WithContext(ctx).Where("book_id = ?", bookID)
+	if before > 0 {
+		query = query.Where("id < ?", before)
+	}
+
+	var movements []*domain.StockMovement
+	if err := query.Order("id DESC").Limit(limit).Find(&movements).Error; err != nil {
+		return nil, err
+	}
+	return movements, nil
+}
+
+// ledgerStockSQL sums the movements joined onto a book
+const ledgerStockSQL = "COALESCE(SUM(stock_movements.delta), 0)"
+
+// Reconcile recomputes the stock of every book, or only of bookID when it is
+// not empty, from the stock ledger. It returns the books whose stock differs
+// from their ledger, ordered by title, and how many books were checked.
+func (r *StockRepository) Reconcile(ctx context.Context, bookID string) ([]*domain.StockDrift, int64, error) {
+	books := func() *gorm.DB {
+		query := r.db.WithContext(ctx).Model(&domain.Book{})
+		if bookID != "" {
+			query = query.Where("books.id = ?", bookID)
+		}
+		return query
+	}
+
+	var checked int64
+	if err := books().Count(&checked).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var drifts []*domain.StockDrift
+	if err := books().
+		Select("books.id AS book_id, books.title, books.stock, " + ledgerStockSQL + " AS ledger_stock").
+		Joins("LEFT JOIN stock_movements ON stock_movements.book_id = books.id").
+		Group("books.id").
+		Having("books.stock <> " + ledgerStockSQL).
+		Order("books.title, books.id").
+		Scan(&drifts).Error; err != nil {
+		return nil, 0, err
+	}
+	return drifts, checked, nil
+}
+
+// writeMovement appends a stock movement to the ledger inside tx, made on
+// behalf of the stock origin in tx's context. Nothing is written when the
+// stock did not change.
+func writeMovement(tx *gorm.DB, bookID string, delta int32, reason domain.StockReason, barcode string) error {
+	if delta == 0 {
+		return nil
+	}
+	origin := domain.StockOriginFromContext(tx.Statement.Context)
+	return tx.Create(domain.NewStockMovement(bookID, delta, reason, origin, barcode)).Error
+}
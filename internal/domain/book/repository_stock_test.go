@@ -0,0 +1,180 @@
+package book
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// newStockRepository returns a StockRepository backed by sqlmock
+func newStockRepository(t *testing.T) (*StockRepository, *gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	gdb, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+	return &StockRepository{db: gdb}, gdb, mock
+}
+
+var movementColumns = []string{"id", "book_id", "delta", "reason", "actor_id", "correlation_id", "barcode", "created_at"}
+
+func TestStockRepository_ListMovements(t *testing.T) {
+	createdAt := time.Date(2025, 6, 22, 9, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		before        uint64
+		setupMock     func(sqlmock.Sqlmock)
+		expectedIDs   []uint64
+		expectedError error
+	}{
+		{
+			name: "First page",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "stock_movements" WHERE book_id = \$1 ORDER BY id DESC LIMIT \$2`).
+					WithArgs("book-1", 2).
+					WillReturnRows(sqlmock.NewRows(movementColumns).
+						AddRow(9, "book-1", -1, "loan", "user-1", "tx-1", "LIB-0001", createdAt).
+						AddRow(4, "book-1", 3, "acquisition", "admin-1", "", "", createdAt))
+			},
+			expectedIDs: []uint64{9, 4},
+		},
+		{
+			name:   "Next page",
+			before: 4,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "stock_movements" WHERE book_id = \$1 AND id < \$2 ORDER BY id DESC LIMIT \$3`).
+					WithArgs("book-1", uint64(4), 2).
+					WillReturnRows(sqlmock.NewRows(movementColumns).
+						AddRow(1, "book-1", 2, "opening", "", "", "", createdAt))
+			},
+			expectedIDs: []uint64{1},
+		},
+		{
+			name: "DB Error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "stock_movements"`).WillReturnError(errors.New("db error"))
+			},
+			expectedError: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo, _, mock := newStockRepository(t)
+			tc.setupMock(mock)
+
+			movements, err := repo.ListMovements(context.Background(), "book-1", tc.before, 2)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+			} else if assert.NoError(t, err) && assert.Len(t, movements, len(tc.expectedIDs)) {
+				for i, movement := range movements {
+					assert.Equal(t, tc.expectedIDs[i], movement.ID)
+				}
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestStockRepository_Reconcile(t *testing.T) {
+	testCases := []struct {
+		name            string
+		bookID          string
+		setupMock       func(sqlmock.Sqlmock)
+		expectedDrifts  []*domain.StockDrift
+		expectedChecked int64
+		expectedError   error
+	}{
+		{
+			name: "All books",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "books" WHERE "books"\."deleted_at" IS NULL`).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+				mock.ExpectQuery(`SELECT books\.id AS book_id, books\.title, books\.stock, COALESCE\(SUM\(stock_movements\.delta\), 0\) AS ledger_stock FROM "books" LEFT JOIN stock_movements ON stock_movements\.book_id = books\.id WHERE "books"\."deleted_at" IS NULL GROUP BY "books"\."id" HAVING books\.stock <> COALESCE\(SUM\(stock_movements\.delta\), 0\) ORDER BY books\.title, books\.id`).
+					WillReturnRows(sqlmock.NewRows([]string{"book_id", "title", "stock", "ledger_stock"}).
+						AddRow("book-2", "Dune", 5, 3))
+			},
+			expectedDrifts:  []*domain.StockDrift{{BookID: "book-2", Title: "Dune", Stock: 5, LedgerStock: 3}},
+			expectedChecked: 3,
+		},
+		{
+			name:   "One book without drift",
+			bookID: "book-1",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "books" WHERE books\.id = \$1`).
+					WithArgs("book-1").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectQuery(`FROM "books" LEFT JOIN stock_movements ON stock_movements\.book_id = books\.id WHERE books\.id = \$1`).
+					WithArgs("book-1").
+					WillReturnRows(sqlmock.NewRows([]string{"book_id", "title", "stock", "ledger_stock"}))
+			},
+			expectedChecked: 1,
+		},
+		{
+			name: "DB Error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "books"`).WillReturnError(errors.New("db error"))
+			},
+			expectedError: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo, _, mock := newStockRepository(t)
+			tc.setupMock(mock)
+
+			drifts, checked, err := repo.Reconcile(context.Background(), tc.bookID)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+			} else if assert.NoError(t, err) {
+				assert.Equal(t, tc.expectedChecked, checked)
+				assert.Equal(t, len(tc.expectedDrifts), len(drifts))
+				for i, drift := range drifts {
+					assert.Equal(t, tc.expectedDrifts[i], drift)
+				}
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestWriteMovement(t *testing.T) {
+	_, gdb, mock := newStockRepository(t)
+	ctx := domain.WithStockOrigin(context.Background(), domain.StockOrigin{ActorID: "user-1", CorrelationID: "tx-1"})
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "stock_movements" \("book_id","delta","reason","actor_id","correlation_id","barcode","created_at"\)`).
+		WithArgs("book-1", int32(-1), domain.StockReasonLoan, "user-1", "tx-1", "LIB-0001", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	assert.NoError(t, writeMovement(gdb.WithContext(ctx), "book-1", -1, domain.StockReasonLoan, "LIB-0001"))
+	// An unchanged stock leaves no movement
+	assert.NoError(t, writeMovement(gdb.WithContext(ctx), "book-1", 0, domain.StockReasonAdjustment, ""))
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
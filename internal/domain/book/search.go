@@ -0,0 +1,77 @@
+package book
+
+import (
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const (
+	// highlightStart and highlightStop wrap the matches in a search snippet
+	highlightStart = "<mark>"
+	highlightStop  = "</mark>"
+)
+
+// parseSearch splits a search query into terms. Double quotes group words
+// into a phrase and a trailing * turns a word into a prefix. Anything but
+// letters and digits separates words, so the terms are safe to use in a
+// tsquery or LIKE pattern.
+func parseSearch(q string) []domain.BookSearchTerm {
+	var terms []domain.BookSearchTerm
+	for i, part := range strings.Split(q, `"`) {
+		// Odd parts sit between a pair of quotes
+		if i%2 == 1 {
+			if words := searchWords(part); len(words) > 0 {
+				terms = append(terms, domain.BookSearchTerm{Words: words, Prefix: strings.HasSuffix(strings.TrimSpace(part), "*")})
+			}
+			continue
+		}
+		for _, field := range strings.Fields(part) {
+			if words := searchWords(field); len(words) > 0 {
+				terms = append(terms, domain.BookSearchTerm{Words: words, Prefix: strings.HasSuffix(field, "*")})
+			}
+		}
+	}
+	return terms
+}
+
+// searchWords returns the lower-cased words of s
+func searchWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// tsQuery renders terms as a PostgreSQL tsquery
+func tsQuery(terms []domain.BookSearchTerm) string {
+	parts := make([]string, len(terms))
+	for i, term := range terms {
+		part := strings.Join(term.Words, " <-> ")
+		if term.Prefix {
+			part += ":*"
+		}
+		parts[i] = part
+	}
+	return strings.Join(parts, " & ")
+}
+
+// searchPhrase is the text a term matches when full-text search is unavailable
+func searchPhrase(term domain.BookSearchTerm) string {
+	return strings.Join(term.Words, " ")
+}
+
+// searchDocument is the text a snippet highlights matches in
+func searchDocument(book *domain.Book) string {
+	return book.Title + " — " + book.Author + " — " + book.Category
+}
+
+// highlight wraps every case-insensitive occurrence of the terms in document
+func highlight(document string, terms []domain.BookSearchTerm) string {
+	patterns := make([]string, len(terms))
+	for i, term := range terms {
+		patterns[i] = regexp.QuoteMeta(searchPhrase(term))
+	}
+	re := regexp.MustCompile(`(?i)` + strings.Join(patterns, "|"))
+	return re.ReplaceAllString(document, highlightStart+"$0"+highlightStop)
+}
@@ -0,0 +1,80 @@
+package book
+
+import (
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseSearch(t *testing.T) {
+	tests := []struct {
+		name      string
+		q         string
+		want      []domain.BookSearchTerm
+		wantQuery string
+	}{
+		{
+			name:      "words",
+			q:         "Dune  Herbert",
+			want:      []domain.BookSearchTerm{{Words: []string{"dune"}}, {Words: []string{"herbert"}}},
+			wantQuery: "dune & herbert",
+		},
+		{
+			name:      "prefix",
+			q:         "herb*",
+			want:      []domain.BookSearchTerm{{Words: []string{"herb"}, Prefix: true}},
+			wantQuery: "herb:*",
+		},
+		{
+			name: "phrase",
+			q:    `"frank herbert" dune`,
+			want: []domain.BookSearchTerm{
+				{Words: []string{"frank", "herbert"}},
+				{Words: []string{"dune"}},
+			},
+			wantQuery: "frank <-> herbert & dune",
+		},
+		{
+			name:      "phrase with prefix",
+			q:         `"science fic*"`,
+			want:      []domain.BookSearchTerm{{Words: []string{"science", "fic"}, Prefix: true}},
+			wantQuery: "science <-> fic:*",
+		},
+		{
+			name:      "tsquery operators are dropped",
+			q:         "dune & !(messiah | children)",
+			want:      []domain.BookSearchTerm{{Words: []string{"dune"}}, {Words: []string{"messiah"}}, {Words: []string{"children"}}},
+			wantQuery: "dune & messiah & children",
+		},
+		{
+			name:      "hyphenated word is a phrase",
+			q:         "sci-fi",
+			want:      []domain.BookSearchTerm{{Words: []string{"sci", "fi"}}},
+			wantQuery: "sci <-> fi",
+		},
+		{
+			name: "nothing to search for",
+			q:    `"" % *`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseSearch(tt.q)
+
+			assert.Equal(t, tt.want, got)
+			if len(got) > 0 {
+				assert.Equal(t, tt.wantQuery, tsQuery(got))
+			}
+		})
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	book := &domain.Book{Title: "Dune Messiah", Author: "Frank Herbert", Category: "Fiction"}
+	terms := parseSearch(`dune "frank herbert"`)
+
+	got := highlight(searchDocument(book), terms)
+
+	assert.Equal(t, "<mark>Dune</mark> Messiah — <mark>Frank Herbert</mark> — Fiction", got)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	pb "github.com/hinha/library-management-synapsis/gen/api/proto/book"
 	"github.com/hinha/library-management-synapsis/internal/domain"
+	"time"
 )
 
 var (
@@ -14,32 +15,70 @@ var (
 
 // Service defines the interface for book business logic
 //
-//go:generate mockery --name=IService --output=../../delivery/mocks --outpkg=mocks
+//go:generate mockery --name=Service --structname=BookService --filename=BookService.go --output=../../delivery/mocks --outpkg=mocks
 type Service interface {
-	CreateBook(ctx context.Context, title, author, category string, stock int32) (*domain.Book, error)
+	CreateBook(ctx context.Context, title, author, category string, stock int32, metadata domain.BookMetadata) (*domain.Book, error)
 	GetBook(ctx context.Context, id string) (*domain.Book, error)
-	ListBooks(ctx context.Context) ([]*domain.Book, error)
-	UpdateBook(ctx context.Context, id, title, author, category string, stock int32) (*domain.Book, error)
+	GetBookByISBN(ctx context.Context, isbn string) (*domain.Book, error)
+	ListBooks(ctx context.Context, filter ListFilter, pageSize int, pageToken string) ([]*domain.Book, string, int64, error)
+	UpdateBook(ctx context.Context, id string, version int64, title, author, category string, stock int32, metadata domain.BookMetadata, paths []string) (*domain.Book, error)
 	DeleteBook(ctx context.Context, id string) error
-	RecommendBooks(ctx context.Context) ([]*domain.Book, error)
+	ReserveStock(ctx context.Context, id, barcode, transactionID string, reopen bool) (*domain.Book, *domain.BookCopy, error)
+	ReleaseStock(ctx context.Context, id, barcode, transactionID string) (*domain.Book, error)
+	CancelStock(ctx context.Context, id, transactionID string) (*domain.Book, error)
+	WriteOffStock(ctx context.Context, id, barcode, transactionID string, damaged bool) (*domain.Book, error)
+	RestoreStock(ctx context.Context, id, barcode, transactionID string) (*domain.Book, error)
+	AddCopy(ctx context.Context, bookID, barcode, shelfLocation string, condition domain.CopyCondition) (*domain.BookCopy, error)
+	ListCopies(ctx context.Context, bookID string) ([]*domain.BookCopy, error)
+	GetCopy(ctx context.Context, barcode string) (*domain.BookCopy, error)
+	UpdateCopy(ctx context.Context, barcode, shelfLocation string, condition domain.CopyCondition, status domain.CopyStatus, paths []string) (*domain.BookCopy, error)
+	WithdrawCopy(ctx context.Context, barcode string) (*domain.BookCopy, error)
+	RecommendBooks(ctx context.Context, userID string, limit int) ([]*domain.Book, error)
+	SearchBooks(ctx context.Context, q string, limit int) ([]*domain.BookMatch, error)
+	ImportBooks(ctx context.Context, records domain.ImportReader, batchSize int, dryRun bool) (*domain.ImportReport, error)
+	ExportBooks(ctx context.Context, filter ListFilter, w domain.ExportWriter) error
+	ListStockMovements(ctx context.Context, bookID string, pageSize int, pageToken string) ([]*domain.StockMovement, string, error)
+	ReconcileStock(ctx context.Context, bookID string) ([]*domain.StockDrift, int64, error)
+	ListDeletedBooks(ctx context.Context, pageSize int, pageToken string) ([]*domain.Book, string, error)
+	RestoreBook(ctx context.Context, id string) (*domain.Book, error)
+	PurgeDeletedBooks(ctx context.Context, retention time.Duration) (int64, time.Time, error)
 	Health(ctx context.Context) (*pb.HealthCheckResponse, error)
 }
 
 // DefaultService implements Service
 type DefaultService struct {
-	repoDb IDbRepository
+	repoDb          IDbRepository
+	copyRepo        ICopyRepository
+	loanRepo        ILoanRepository
+	stockRepo       IStockRepository
+	reservationRepo IReservationRepository
+	recommend       RecommendPolicy
 }
 
 // NewService creates a new DefaultService
-func NewService(repo IDbRepository) *DefaultService {
+func NewService(repo IDbRepository, copyRepo ICopyRepository, loanRepo ILoanRepository, stockRepo IStockRepository, reservationRepo IReservationRepository, recommend RecommendPolicy) *DefaultService {
 	return &DefaultService{
-		repoDb: repo,
+		repoDb:          repo,
+		copyRepo:        copyRepo,
+		loanRepo:        loanRepo,
+		stockRepo:       stockRepo,
+		reservationRepo: reservationRepo,
+		recommend:       recommend,
 	}
 }
 
-// CreateBook creates a new book
-func (s *DefaultService) CreateBook(ctx context.Context, title, author, category string, stock int32) (*domain.Book, error) {
+// CreateBook creates a new book. It returns ErrDuplicateISBN when another
+// book already has the ISBN.
+func (s *DefaultService) CreateBook(ctx context.Context, title, author, category string, stock int32, metadata domain.BookMetadata) (*domain.Book, error) {
+	if err := metadata.Normalize(); err != nil {
+		return nil, err
+	}
+	if err := s.checkISBNFree(ctx, metadata.ISBN, ""); err != nil {
+		return nil, err
+	}
+
 	book := domain.NewBook(title, author, category, stock)
+	book.BookMetadata = metadata
 	if err := s.repoDb.Create(ctx, book); err != nil {
 		return nil, err
 	}
@@ -56,37 +95,183 @@ func (s *DefaultService) GetBook(ctx context.Context, id string) (*domain.Book,
 	return s.repoDb.GetByID(ctx, id)
 }
 
-// ListBooks retrieves all books
-func (s *DefaultService) ListBooks(ctx context.Context) ([]*domain.Book, error) {
-	return s.repoDb.List(ctx)
+// GetBookByISBN retrieves a book by its ISBN-10 or ISBN-13
+func (s *DefaultService) GetBookByISBN(ctx context.Context, isbn string) (*domain.Book, error) {
+	normalized, err := domain.NormalizeISBN(isbn)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repoDb.GetByISBN(ctx, normalized)
 }
 
-// UpdateBook updates a book
-func (s *DefaultService) UpdateBook(ctx context.Context, id, title, author, category string, stock int32) (*domain.Book, error) {
-	if id == "" {
-		return nil, ErrInvalidInput
+// checkISBNFree returns ErrDuplicateISBN when a book other than exceptID has
+// the ISBN
+func (s *DefaultService) checkISBNFree(ctx context.Context, isbn, exceptID string) error {
+	if isbn == "" {
+		return nil
 	}
+	existing, err := s.repoDb.GetByISBN(ctx, isbn)
+	if err == nil && existing.ID != exceptID {
+		return ErrDuplicateISBN
+	} else if err != nil && !errors.Is(err, ErrBookNotFound) {
+		return err
+	}
+	return nil
+}
 
-	book, err := s.repoDb.GetByID(ctx, id)
+// ListBooks retrieves one page of the books matching filter, sorted by
+// created_at when filter names no sort field. pageToken continues a previous
+// listing with the same filter. It returns the token for the next page, empty
+// on the last page, and how many books match the filter in total.
+func (s *DefaultService) ListBooks(ctx context.Context, filter ListFilter, pageSize int, pageToken string) ([]*domain.Book, string, int64, error) {
+	if filter.SortBy == "" {
+		filter.SortBy = SortByCreatedAt
+	}
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	query := ListQuery{Filter: filter, Limit: pageSize + 1}
+	if pageToken != "" {
+		cursor, err := decodePageToken(pageToken, filter)
+		if err != nil {
+			return nil, "", 0, err
+		}
+		query.After = cursor
+	}
+
+	// One book past the page tells whether another page follows
+	books, total, err := s.repoDb.ListPage(ctx, query)
 	if err != nil {
-		return nil, err
+		return nil, "", 0, err
+	}
+	if len(books) <= pageSize {
+		return books, "", total, nil
 	}
 
-	if title != "" {
-		book.Title = title
+	books = books[:pageSize]
+	nextToken, err := encodePageToken(filter, cursorAt(books[pageSize-1], filter.SortBy))
+	if err != nil {
+		return nil, "", 0, err
+	}
+	return books, nextToken, total, nil
+}
+
+// UpdateBook updates the book at version, returning ErrVersionConflict when
+// it has been changed since.
+// When paths is empty only the non-zero values are applied, otherwise exactly
+// the fields named in paths are written, so a stock of 0 can be set explicitly.
+func (s *DefaultService) UpdateBook(ctx context.Context, id string, version int64, title, author, category string, stock int32, metadata domain.BookMetadata, paths []string) (*domain.Book, error) {
+	if id == "" || version <= 0 {
+		return nil, ErrInvalidInput
 	}
 
-	if author != "" {
-		book.Author = author
+	if len(paths) == 0 {
+		if title != "" {
+			paths = append(paths, "title")
+		}
+		if author != "" {
+			paths = append(paths, "author")
+		}
+		if category != "" {
+			paths = append(paths, "category")
+		}
+		if stock > 0 {
+			paths = append(paths, "stock")
+		}
+		if metadata.ISBN != "" {
+			paths = append(paths, "isbn")
+		}
+		if metadata.Publisher != "" {
+			paths = append(paths, "publisher")
+		}
+		if metadata.PublicationYear > 0 {
+			paths = append(paths, "publication_year")
+		}
+		if metadata.Language != "" {
+			paths = append(paths, "language")
+		}
+		if metadata.PageCount > 0 {
+			paths = append(paths, "page_count")
+		}
+		if metadata.Edition != "" {
+			paths = append(paths, "edition")
+		}
+		if metadata.Description != "" {
+			paths = append(paths, "description")
+		}
 	}
 
-	if category != "" {
-		book.Category = category
+	if err := metadata.Normalize(); err != nil {
+		return nil, err
+	}
+
+	book, err := s.repoDb.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if book.Version != version {
+		return nil, ErrVersionConflict
 	}
 
-	if stock >= 0 {
-		book.Stock = stock
+	for _, path := range paths {
+		switch path {
+		case "title":
+			if title == "" {
+				return nil, ErrInvalidInput
+			}
+			book.Title = title
+		case "author":
+			if author == "" {
+				return nil, ErrInvalidInput
+			}
+			book.Author = author
+		case "category":
+			if category == "" {
+				return nil, ErrInvalidInput
+			}
+			book.Category = category
+		case "stock":
+			if stock < 0 {
+				return nil, ErrInvalidInput
+			}
+			tracked, err := s.tracksCopies(ctx, book.ID)
+			if err != nil {
+				return nil, err
+			}
+			if tracked {
+				return nil, ErrStockTracked
+			}
+			book.Stock = stock
+		case "isbn":
+			if err := s.checkISBNFree(ctx, metadata.ISBN, book.ID); err != nil {
+				return nil, err
+			}
+			book.ISBN = metadata.ISBN
+		case "publisher":
+			book.Publisher = metadata.Publisher
+		case "publication_year":
+			book.PublicationYear = metadata.PublicationYear
+		case "language":
+			book.Language = metadata.Language
+		case "page_count":
+			if metadata.PageCount < 0 {
+				return nil, ErrInvalidInput
+			}
+			book.PageCount = metadata.PageCount
+		case "edition":
+			book.Edition = metadata.Edition
+		case "description":
+			book.Description = metadata.Description
+		default:
+			return nil, ErrInvalidInput
+		}
 	}
+	book.UpdatedAt = time.Now()
 
 	if err := s.repoDb.Update(ctx, book); err != nil {
 		return nil, err
@@ -97,16 +282,114 @@ func (s *DefaultService) UpdateBook(ctx context.Context, id, title, author, cate
 
 // DeleteBook deletes a book
 func (s *DefaultService) DeleteBook(ctx context.Context, id string) error {
+	if id == "" {
+		return ErrInvalidInput
+	}
+
 	return s.repoDb.Delete(ctx, id)
 }
 
-// RecommendBooks recommends books based on popularity or other criteria
-// This is a simple implementation that just returns all books
-// In a real application, this would use more sophisticated logic
-func (s *DefaultService) RecommendBooks(ctx context.Context) ([]*domain.Book, error) {
-	// For now, just return all books
-	// In a real application, this would use more sophisticated logic
-	return s.repoDb.List(ctx)
+// ReserveStock takes one copy of a book out of stock for the loan
+// transactionID. Books with copies check out the copy with the barcode, or
+// any available copy when barcode is empty, and return it. Books without
+// copies only have their stock counter decreased. It returns
+// ErrInsufficientStock when no copies are left. A retry returns the copy the
+// loan already has; a loan whose borrow was cancelled gets
+// ErrReservationCancelled unless reopen restores it.
+func (s *DefaultService) ReserveStock(ctx context.Context, id, barcode, transactionID string, reopen bool) (*domain.Book, *domain.BookCopy, error) {
+	if id == "" || transactionID == "" {
+		return nil, nil, ErrInvalidInput
+	}
+
+	bookCopy, err := s.reservationRepo.Reserve(ctx, id, barcode, transactionID, reopen)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	book, err := s.repoDb.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return book, bookCopy, nil
+}
+
+// ReleaseStock puts the copy the loan transactionID reserved back into
+// stock. A retry changes nothing. Loans reserved before reservations were
+// recorded check in the copy with the barcode, or add one back to the stock
+// counter when barcode is empty.
+func (s *DefaultService) ReleaseStock(ctx context.Context, id, barcode, transactionID string) (*domain.Book, error) {
+	if id == "" || transactionID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	if err := s.reservationRepo.Release(ctx, id, barcode, transactionID); err != nil {
+		return nil, err
+	}
+
+	return s.repoDb.GetByID(ctx, id)
+}
+
+// CancelStock undoes the reservation of the loan transactionID after its
+// borrow failed, putting the copy back if the loan got one. A reservation
+// that arrives after the cancellation is refused.
+func (s *DefaultService) CancelStock(ctx context.Context, id, transactionID string) (*domain.Book, error) {
+	if id == "" || transactionID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	if err := s.reservationRepo.Cancel(ctx, id, transactionID); err != nil {
+		return nil, err
+	}
+
+	return s.repoDb.GetByID(ctx, id)
+}
+
+// WriteOffStock takes the copy the loan transactionID reserved out of
+// circulation for good after the patron lost or damaged it. The copy is
+// marked lost, or damaged and withdrawn. The stock went down when the copy
+// was lent, so it is left as it is. A retry changes nothing.
+func (s *DefaultService) WriteOffStock(ctx context.Context, id, barcode, transactionID string, damaged bool) (*domain.Book, error) {
+	if id == "" || transactionID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	if err := s.reservationRepo.WriteOff(ctx, id, barcode, transactionID, damaged); err != nil {
+		return nil, err
+	}
+
+	return s.repoDb.GetByID(ctx, id)
+}
+
+// RestoreStock puts the copy the loan transactionID lost back into stock
+// once it turns up, or one back on the stock counter for books without
+// copies. A retry changes nothing.
+func (s *DefaultService) RestoreStock(ctx context.Context, id, barcode, transactionID string) (*domain.Book, error) {
+	if id == "" || transactionID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	if err := s.reservationRepo.Restore(ctx, id, barcode, transactionID); err != nil {
+		return nil, err
+	}
+
+	return s.repoDb.GetByID(ctx, id)
+}
+
+// SearchBooks searches the catalogue for the words, "quoted phrases" and
+// prefix* terms in q. It returns ErrInvalidInput when q has nothing to search for.
+func (s *DefaultService) SearchBooks(ctx context.Context, q string, limit int) ([]*domain.BookMatch, error) {
+	terms := parseSearch(q)
+	if len(terms) == 0 {
+		return nil, ErrInvalidInput
+	}
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+	if limit > MaxPageSize {
+		limit = MaxPageSize
+	}
+
+	return s.repoDb.Search(ctx, domain.BookSearch{Terms: terms, Limit: limit})
 }
 
 func (s *DefaultService) Health(ctx context.Context) (*pb.HealthCheckResponse, error) {
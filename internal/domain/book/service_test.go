@@ -19,6 +19,7 @@ func TestDefaultService_CreateBook(t *testing.T) {
 		author   string
 		category string
 		stock    int32
+		metadata domain.BookMetadata
 	}
 	tests := []struct {
 		name    string
@@ -49,6 +50,60 @@ func TestDefaultService_CreateBook(t *testing.T) {
 			}(),
 			wantErr: assert.NoError,
 		},
+		{
+			name: "normalizes the isbn",
+			args: args{
+				ctx:      context.Background(),
+				title:    "Book Title",
+				author:   "Author",
+				category: "Fiction",
+				stock:    10,
+				metadata: domain.BookMetadata{ISBN: "0-306-40615-2", Publisher: "Publisher", PublicationYear: 1999},
+			},
+			mockFn: func(repo *mocks.IDbRepository) {
+				repo.On("GetByISBN", mock.Anything, "9780306406157").Return(nil, ErrBookNotFound)
+				repo.On("Create", mock.Anything, mock.MatchedBy(func(b *domain.Book) bool {
+					return b.ISBN == "9780306406157" && b.Publisher == "Publisher" && b.PublicationYear == 1999
+				})).Return(nil)
+			},
+			want: func() *domain.Book {
+				book := domain.NewBook("Book Title", "Author", "Fiction", 10)
+				book.BookMetadata = domain.BookMetadata{ISBN: "9780306406157", Publisher: "Publisher", PublicationYear: 1999}
+				return book
+			}(),
+			wantErr: assert.NoError,
+		},
+		{
+			name: "duplicate isbn",
+			args: args{
+				ctx:      context.Background(),
+				title:    "Book Title",
+				author:   "Author",
+				category: "Fiction",
+				stock:    10,
+				metadata: domain.BookMetadata{ISBN: "978-0-306-40615-7"},
+			},
+			mockFn: func(repo *mocks.IDbRepository) {
+				repo.On("GetByISBN", mock.Anything, "9780306406157").Return(&domain.Book{ID: "other"}, nil)
+			},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrDuplicateISBN, i...)
+			},
+		},
+		{
+			name: "invalid isbn",
+			args: args{
+				ctx:      context.Background(),
+				title:    "Book Title",
+				author:   "Author",
+				category: "Fiction",
+				stock:    10,
+				metadata: domain.BookMetadata{ISBN: "978-0-306-40615-8"},
+			},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.ErrorIs(t, err, domain.ErrInvalidISBN, i...)
+			},
+		},
 		{
 			name: "repo returns error",
 			args: args{
@@ -80,7 +135,7 @@ func TestDefaultService_CreateBook(t *testing.T) {
 			s := &DefaultService{
 				repoDb: repo,
 			}
-			got, err := s.CreateBook(tt.args.ctx, tt.args.title, tt.args.author, tt.args.category, tt.args.stock)
+			got, err := s.CreateBook(tt.args.ctx, tt.args.title, tt.args.author, tt.args.category, tt.args.stock, tt.args.metadata)
 			if !tt.wantErr(t, err, fmt.Sprintf("CreateBook(%v, %v, %v, %v, %v)", tt.args.ctx, tt.args.title, tt.args.author, tt.args.category, tt.args.stock)) {
 				return
 			}
@@ -89,6 +144,7 @@ func TestDefaultService_CreateBook(t *testing.T) {
 				assert.Equal(t, tt.want.Author, got.Author)
 				assert.Equal(t, tt.want.Category, got.Category)
 				assert.Equal(t, tt.want.Stock, got.Stock)
+				assert.Equal(t, tt.want.BookMetadata, got.BookMetadata)
 			} else {
 				assert.Equal(t, tt.want, got)
 			}
@@ -185,42 +241,162 @@ func TestDefaultService_GetBook(t *testing.T) {
 	}
 }
 
+func TestDefaultService_GetBookByISBN(t *testing.T) {
+	tests := []struct {
+		name    string
+		isbn    string
+		mockFn  func(repo *mocks.IDbRepository)
+		want    *domain.Book
+		wantErr error
+	}{
+		{
+			name: "looks up the normalized isbn",
+			isbn: "0-306-40615-2",
+			mockFn: func(repo *mocks.IDbRepository) {
+				repo.On("GetByISBN", mock.Anything, "9780306406157").
+					Return(&domain.Book{ID: "book-id-1", BookMetadata: domain.BookMetadata{ISBN: "9780306406157"}}, nil)
+			},
+			want: &domain.Book{ID: "book-id-1", BookMetadata: domain.BookMetadata{ISBN: "9780306406157"}},
+		},
+		{
+			name:    "invalid isbn",
+			isbn:    "0306406153",
+			wantErr: domain.ErrInvalidISBN,
+		},
+		{
+			name: "not found",
+			isbn: "9780306406157",
+			mockFn: func(repo *mocks.IDbRepository) {
+				repo.On("GetByISBN", mock.Anything, "9780306406157").Return(nil, ErrBookNotFound)
+			},
+			wantErr: ErrBookNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.IDbRepository)
+			if tt.mockFn != nil {
+				tt.mockFn(repo)
+			}
+			s := &DefaultService{
+				repoDb: repo,
+			}
+			got, err := s.GetBookByISBN(context.Background(), tt.isbn)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
 func TestNewService(t *testing.T) {
 	repo := new(mocks.IDbRepository)
-	svc := NewService(repo)
+	copyRepo := new(mocks.ICopyRepository)
+	loanRepo := new(mocks.ILoanRepository)
+	stockRepo := new(mocks.IStockRepository)
+	reservationRepo := new(mocks.IReservationRepository)
+	svc := NewService(repo, copyRepo, loanRepo, stockRepo, reservationRepo, RecommendPolicy{})
 	assert.NotNil(t, svc)
 	assert.Equal(t, repo, svc.repoDb)
+	assert.Equal(t, copyRepo, svc.copyRepo)
+	assert.Equal(t, loanRepo, svc.loanRepo)
+	assert.Equal(t, stockRepo, svc.stockRepo)
+	assert.Equal(t, reservationRepo, svc.reservationRepo)
 }
 
 func TestDefaultService_ListBooks(t *testing.T) {
+	books := []*domain.Book{
+		{ID: "1", Title: "Book1", Author: "Author1", Category: "Fiction", Stock: 5},
+		{ID: "2", Title: "Book2", Author: "Author2", Category: "NonFiction", Stock: 3},
+		{ID: "3", Title: "Book3", Author: "Author3", Category: "Fiction", Stock: 1},
+	}
+	byTitle := ListFilter{SortBy: SortByTitle}
+	titleToken, _ := encodePageToken(byTitle, Cursor{Value: "Book2", ID: "2"})
+
 	tests := []struct {
-		name    string
-		mockFn  func(repo *mocks.IDbRepository)
-		want    []*domain.Book
-		wantErr assert.ErrorAssertionFunc
+		name          string
+		filter        ListFilter
+		pageSize      int
+		pageToken     string
+		mockFn        func(repo *mocks.IDbRepository)
+		want          []*domain.Book
+		wantNextToken string
+		wantTotal     int64
+		wantErr       error
 	}{
 		{
-			name: "success",
+			name:     "last page",
+			filter:   byTitle,
+			pageSize: 5,
 			mockFn: func(repo *mocks.IDbRepository) {
-				books := []*domain.Book{
-					{ID: "1", Title: "Book1", Author: "Author1", Category: "Fiction", Stock: 5},
-					{ID: "2", Title: "Book2", Author: "Author2", Category: "NonFiction", Stock: 3},
-				}
-				repo.On("List", mock.Anything).Return(books, nil)
+				repo.On("ListPage", mock.Anything, ListQuery{Filter: byTitle, Limit: 6}).Return(books, int64(3), nil)
+			},
+			want:      books,
+			wantTotal: 3,
+		},
+		{
+			name:     "more pages follow",
+			filter:   byTitle,
+			pageSize: 2,
+			mockFn: func(repo *mocks.IDbRepository) {
+				repo.On("ListPage", mock.Anything, ListQuery{Filter: byTitle, Limit: 3}).Return(books, int64(3), nil)
 			},
-			want: []*domain.Book{
-				{ID: "1", Title: "Book1", Author: "Author1", Category: "Fiction", Stock: 5},
-				{ID: "2", Title: "Book2", Author: "Author2", Category: "NonFiction", Stock: 3},
+			want:          books[:2],
+			wantNextToken: titleToken,
+			wantTotal:     3,
+		},
+		{
+			name:      "continues from page token",
+			filter:    byTitle,
+			pageSize:  2,
+			pageToken: titleToken,
+			mockFn: func(repo *mocks.IDbRepository) {
+				query := ListQuery{Filter: byTitle, Limit: 3, After: &Cursor{Value: "Book2", ID: "2"}}
+				repo.On("ListPage", mock.Anything, query).Return(books[2:], int64(3), nil)
 			},
-			wantErr: assert.NoError,
+			want:      books[2:],
+			wantTotal: 3,
 		},
 		{
-			name: "repo returns error",
+			name: "defaults sort and page size",
 			mockFn: func(repo *mocks.IDbRepository) {
-				repo.On("List", mock.Anything).Return(nil, errors.New("db error"))
+				query := ListQuery{Filter: ListFilter{SortBy: SortByCreatedAt}, Limit: DefaultPageSize + 1}
+				repo.On("ListPage", mock.Anything, query).Return(books, int64(3), nil)
 			},
-			want:    nil,
-			wantErr: assert.Error,
+			want:      books,
+			wantTotal: 3,
+		},
+		{
+			name:     "caps page size",
+			filter:   byTitle,
+			pageSize: 500,
+			mockFn: func(repo *mocks.IDbRepository) {
+				repo.On("ListPage", mock.Anything, ListQuery{Filter: byTitle, Limit: MaxPageSize + 1}).Return(books, int64(3), nil)
+			},
+			want:      books,
+			wantTotal: 3,
+		},
+		{
+			name:      "token from another filter",
+			filter:    ListFilter{SortBy: SortByTitle, Category: "Fiction"},
+			pageToken: titleToken,
+			wantErr:   ErrInvalidPageToken,
+		},
+		{
+			name:      "malformed token",
+			filter:    byTitle,
+			pageToken: "not a token",
+			wantErr:   ErrInvalidPageToken,
+		},
+		{
+			name:   "repo returns error",
+			filter: byTitle,
+			mockFn: func(repo *mocks.IDbRepository) {
+				repo.On("ListPage", mock.Anything, mock.Anything).Return(nil, int64(0), errors.New("db error"))
+			},
+			wantErr: errors.New("db error"),
 		},
 	}
 
@@ -233,11 +409,16 @@ func TestDefaultService_ListBooks(t *testing.T) {
 			s := &DefaultService{
 				repoDb: repo,
 			}
-			got, err := s.ListBooks(context.Background())
-			if !tt.wantErr(t, err, fmt.Sprintf("ListBooks(%v)", context.Background())) {
+			got, nextToken, total, err := s.ListBooks(context.Background(), tt.filter, tt.pageSize, tt.pageToken)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				assert.Nil(t, got)
 				return
 			}
-			assert.Equalf(t, tt.want, got, "ListBooks(%v)", context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantNextToken, nextToken)
+			assert.Equal(t, tt.wantTotal, total)
 			repo.AssertExpectations(t)
 		})
 	}
@@ -247,14 +428,18 @@ func TestDefaultService_UpdateBook(t *testing.T) {
 	type args struct {
 		ctx      context.Context
 		id       string
+		version  int64
 		title    string
 		author   string
 		category string
 		stock    int32
+		metadata domain.BookMetadata
+		paths    []string
 	}
 	tests := []struct {
 		name    string
 		args    args
+		copies  int64
 		mockFn  func(repo *mocks.IDbRepository)
 		want    *domain.Book
 		wantErr assert.ErrorAssertionFunc
@@ -264,6 +449,7 @@ func TestDefaultService_UpdateBook(t *testing.T) {
 			args: args{
 				ctx:      context.Background(),
 				id:       "book-id-1",
+				version:  1,
 				title:    "New Title",
 				author:   "New Author",
 				category: "New Category",
@@ -272,6 +458,7 @@ func TestDefaultService_UpdateBook(t *testing.T) {
 			mockFn: func(repo *mocks.IDbRepository) {
 				origBook := &domain.Book{
 					ID:       "book-id-1",
+					Version:  1,
 					Title:    "Old Title",
 					Author:   "Old Author",
 					Category: "Old Category",
@@ -314,6 +501,7 @@ func TestDefaultService_UpdateBook(t *testing.T) {
 			args: args{
 				ctx:      context.Background(),
 				id:       "book-id-2",
+				version:  1,
 				title:    "Title",
 				author:   "Author",
 				category: "Category",
@@ -330,6 +518,7 @@ func TestDefaultService_UpdateBook(t *testing.T) {
 			args: args{
 				ctx:      context.Background(),
 				id:       "book-id-3",
+				version:  1,
 				title:    "Title",
 				author:   "Author",
 				category: "Category",
@@ -338,6 +527,7 @@ func TestDefaultService_UpdateBook(t *testing.T) {
 			mockFn: func(repo *mocks.IDbRepository) {
 				origBook := &domain.Book{
 					ID:       "book-id-3",
+					Version:  1,
 					Title:    "Old Title",
 					Author:   "Old Author",
 					Category: "Old Category",
@@ -354,6 +544,7 @@ func TestDefaultService_UpdateBook(t *testing.T) {
 			args: args{
 				ctx:      context.Background(),
 				id:       "book-id-4",
+				version:  1,
 				title:    "Partial Title",
 				author:   "",
 				category: "",
@@ -362,6 +553,7 @@ func TestDefaultService_UpdateBook(t *testing.T) {
 			mockFn: func(repo *mocks.IDbRepository) {
 				origBook := &domain.Book{
 					ID:       "book-id-4",
+					Version:  1,
 					Title:    "Old Title",
 					Author:   "Old Author",
 					Category: "Old Category",
@@ -385,6 +577,184 @@ func TestDefaultService_UpdateBook(t *testing.T) {
 			},
 			wantErr: assert.NoError,
 		},
+		{
+			name: "field mask sets stock to zero",
+			args: args{
+				ctx:     context.Background(),
+				id:      "book-id-5",
+				version: 1,
+				stock:   0,
+				paths:   []string{"stock"},
+			},
+			mockFn: func(repo *mocks.IDbRepository) {
+				origBook := &domain.Book{
+					ID:       "book-id-5",
+					Version:  1,
+					Title:    "Old Title",
+					Author:   "Old Author",
+					Category: "Old Category",
+					Stock:    7,
+				}
+				repo.On("GetByID", mock.Anything, "book-id-5").Return(origBook, nil)
+				repo.On("Update", mock.Anything, mock.MatchedBy(func(b *domain.Book) bool {
+					return b.ID == "book-id-5" &&
+						b.Title == "Old Title" &&
+						b.Stock == 0
+				})).Return(nil)
+			},
+			want: &domain.Book{
+				ID:       "book-id-5",
+				Title:    "Old Title",
+				Author:   "Old Author",
+				Category: "Old Category",
+				Stock:    0,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "stock of a book with copies",
+			args: args{
+				ctx:     context.Background(),
+				id:      "book-id-12",
+				version: 1,
+				stock:   3,
+				paths:   []string{"stock"},
+			},
+			copies: 2,
+			mockFn: func(repo *mocks.IDbRepository) {
+				repo.On("GetByID", mock.Anything, "book-id-12").Return(&domain.Book{ID: "book-id-12", Version: 1, Stock: 2}, nil)
+			},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrStockTracked, i...)
+			},
+		},
+		{
+			name: "field mask ignores unmasked fields",
+			args: args{
+				ctx:      context.Background(),
+				id:       "book-id-6",
+				version:  1,
+				title:    "Masked Title",
+				author:   "Unmasked Author",
+				category: "Unmasked Category",
+				stock:    3,
+				paths:    []string{"title"},
+			},
+			mockFn: func(repo *mocks.IDbRepository) {
+				origBook := &domain.Book{
+					ID:       "book-id-6",
+					Version:  1,
+					Title:    "Old Title",
+					Author:   "Old Author",
+					Category: "Old Category",
+					Stock:    7,
+				}
+				repo.On("GetByID", mock.Anything, "book-id-6").Return(origBook, nil)
+				repo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Book")).Return(nil)
+			},
+			want: &domain.Book{
+				ID:       "book-id-6",
+				Title:    "Masked Title",
+				Author:   "Old Author",
+				Category: "Old Category",
+				Stock:    7,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "field mask with unknown path",
+			args: args{
+				ctx:     context.Background(),
+				id:      "book-id-7",
+				version: 1,
+				paths:   []string{"popularity"},
+			},
+			mockFn: func(repo *mocks.IDbRepository) {
+				repo.On("GetByID", mock.Anything, "book-id-7").Return(&domain.Book{ID: "book-id-7", Version: 1}, nil)
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "field mask sets metadata",
+			args: args{
+				ctx:      context.Background(),
+				id:       "book-id-9",
+				version:  1,
+				metadata: domain.BookMetadata{ISBN: "0306406152", Language: "en", PageCount: 320},
+				paths:    []string{"isbn", "language", "page_count"},
+			},
+			mockFn: func(repo *mocks.IDbRepository) {
+				repo.On("GetByID", mock.Anything, "book-id-9").Return(&domain.Book{ID: "book-id-9", Version: 1, Title: "Old Title"}, nil)
+				repo.On("GetByISBN", mock.Anything, "9780306406157").Return(&domain.Book{ID: "book-id-9"}, nil)
+				repo.On("Update", mock.Anything, mock.MatchedBy(func(b *domain.Book) bool {
+					return b.ISBN == "9780306406157" && b.Language == "en" && b.PageCount == 320
+				})).Return(nil)
+			},
+			want:    &domain.Book{ID: "book-id-9", Title: "Old Title"},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "isbn taken by another book",
+			args: args{
+				ctx:      context.Background(),
+				id:       "book-id-10",
+				version:  1,
+				metadata: domain.BookMetadata{ISBN: "9780306406157"},
+			},
+			mockFn: func(repo *mocks.IDbRepository) {
+				repo.On("GetByID", mock.Anything, "book-id-10").Return(&domain.Book{ID: "book-id-10", Version: 1}, nil)
+				repo.On("GetByISBN", mock.Anything, "9780306406157").Return(&domain.Book{ID: "book-id-11"}, nil)
+			},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrDuplicateISBN, i...)
+			},
+		},
+		{
+			name: "field mask clears required field",
+			args: args{
+				ctx:     context.Background(),
+				id:      "book-id-8",
+				version: 1,
+				title:   "",
+				paths:   []string{"title"},
+			},
+			mockFn: func(repo *mocks.IDbRepository) {
+				repo.On("GetByID", mock.Anything, "book-id-8").Return(&domain.Book{ID: "book-id-8", Version: 1, Title: "Old Title"}, nil)
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "book changed since it was read",
+			args: args{
+				ctx:     context.Background(),
+				id:      "book-id-13",
+				version: 1,
+				title:   "New Title",
+			},
+			mockFn: func(repo *mocks.IDbRepository) {
+				repo.On("GetByID", mock.Anything, "book-id-13").Return(&domain.Book{ID: "book-id-13", Version: 2, Title: "Old Title"}, nil)
+			},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrVersionConflict, i...)
+			},
+		},
+		{
+			name: "missing version",
+			args: args{
+				ctx:   context.Background(),
+				id:    "book-id-14",
+				title: "New Title",
+			},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrInvalidInput, i...)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -393,11 +763,14 @@ func TestDefaultService_UpdateBook(t *testing.T) {
 			if tt.mockFn != nil {
 				tt.mockFn(repo)
 			}
+			copyRepo := new(mocks.ICopyRepository)
+			copyRepo.On("CountByBook", mock.Anything, tt.args.id).Return(tt.copies, nil).Maybe()
 			s := &DefaultService{
-				repoDb: repo,
+				repoDb:   repo,
+				copyRepo: copyRepo,
 			}
-			got, err := s.UpdateBook(tt.args.ctx, tt.args.id, tt.args.title, tt.args.author, tt.args.category, tt.args.stock)
-			if !tt.wantErr(t, err, fmt.Sprintf("UpdateBook(%v, %v, %v, %v, %v, %v)", tt.args.ctx, tt.args.id, tt.args.title, tt.args.author, tt.args.category, tt.args.stock)) {
+			got, err := s.UpdateBook(tt.args.ctx, tt.args.id, tt.args.version, tt.args.title, tt.args.author, tt.args.category, tt.args.stock, tt.args.metadata, tt.args.paths)
+			if !tt.wantErr(t, err, fmt.Sprintf("UpdateBook(%v, %v, %v, %v, %v, %v, %v)", tt.args.ctx, tt.args.id, tt.args.title, tt.args.author, tt.args.category, tt.args.stock, tt.args.paths)) {
 				return
 			}
 			if tt.want != nil {
@@ -447,6 +820,15 @@ func TestDefaultService_DeleteBook(t *testing.T) {
 			},
 			wantErr: assert.Error,
 		},
+		{
+			name: "invalid input - empty id",
+			args: args{
+				ctx: context.Background(),
+				id:  "",
+			},
+			mockFn:  nil,
+			wantErr: assert.Error,
+		},
 	}
 
 	for _, tt := range tests {
@@ -464,35 +846,396 @@ func TestDefaultService_DeleteBook(t *testing.T) {
 	}
 }
 
-func TestDefaultService_RecommendBooks(t *testing.T) {
+func TestDefaultService_ReserveStock(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            string
+		barcode       string
+		transactionID string
+		reopen        bool
+		mockFn        func(repo *mocks.IDbRepository, reservationRepo *mocks.IReservationRepository)
+		want          *domain.Book
+		wantCopy      *domain.BookCopy
+		wantErr       error
+	}{
+		{
+			name:          "success",
+			id:            "book-id-1",
+			transactionID: "tx-1",
+			mockFn: func(repo *mocks.IDbRepository, reservationRepo *mocks.IReservationRepository) {
+				reservationRepo.On("Reserve", mock.Anything, "book-id-1", "", "tx-1", false).Return(nil, nil)
+				repo.On("GetByID", mock.Anything, "book-id-1").Return(&domain.Book{ID: "book-id-1", Stock: 4}, nil)
+			},
+			want: &domain.Book{ID: "book-id-1", Stock: 4},
+		},
+		{
+			name:          "insufficient stock",
+			id:            "book-id-2",
+			transactionID: "tx-2",
+			mockFn: func(repo *mocks.IDbRepository, reservationRepo *mocks.IReservationRepository) {
+				reservationRepo.On("Reserve", mock.Anything, "book-id-2", "", "tx-2", false).Return(nil, ErrInsufficientStock)
+			},
+			wantErr: ErrInsufficientStock,
+		},
+		{
+			name:          "checks out a copy",
+			id:            "book-id-3",
+			transactionID: "tx-3",
+			mockFn: func(repo *mocks.IDbRepository, reservationRepo *mocks.IReservationRepository) {
+				reservationRepo.On("Reserve", mock.Anything, "book-id-3", "", "tx-3", false).
+					Return(&domain.BookCopy{BookID: "book-id-3", Barcode: "LIB-0001", Status: domain.CopyStatusOnLoan}, nil)
+				repo.On("GetByID", mock.Anything, "book-id-3").Return(&domain.Book{ID: "book-id-3", Stock: 1}, nil)
+			},
+			want:     &domain.Book{ID: "book-id-3", Stock: 1},
+			wantCopy: &domain.BookCopy{BookID: "book-id-3", Barcode: "LIB-0001", Status: domain.CopyStatusOnLoan},
+		},
+		{
+			name:          "cancelled borrow",
+			id:            "book-id-3",
+			barcode:       "LIB-0002",
+			transactionID: "tx-4",
+			mockFn: func(repo *mocks.IDbRepository, reservationRepo *mocks.IReservationRepository) {
+				reservationRepo.On("Reserve", mock.Anything, "book-id-3", "LIB-0002", "tx-4", false).Return(nil, ErrReservationCancelled)
+			},
+			wantErr: ErrReservationCancelled,
+		},
+		{
+			name:          "reopens a restored loan",
+			id:            "book-id-1",
+			transactionID: "tx-4",
+			reopen:        true,
+			mockFn: func(repo *mocks.IDbRepository, reservationRepo *mocks.IReservationRepository) {
+				reservationRepo.On("Reserve", mock.Anything, "book-id-1", "", "tx-4", true).Return(nil, nil)
+				repo.On("GetByID", mock.Anything, "book-id-1").Return(&domain.Book{ID: "book-id-1", Stock: 3}, nil)
+			},
+			want: &domain.Book{ID: "book-id-1", Stock: 3},
+		},
+		{
+			name:          "invalid input - empty id",
+			id:            "",
+			transactionID: "tx-1",
+			wantErr:       ErrInvalidInput,
+		},
+		{
+			name:    "invalid input - empty transaction id",
+			id:      "book-id-1",
+			wantErr: ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.IDbRepository)
+			reservationRepo := new(mocks.IReservationRepository)
+			if tt.mockFn != nil {
+				tt.mockFn(repo, reservationRepo)
+			}
+			s := &DefaultService{
+				repoDb:          repo,
+				reservationRepo: reservationRepo,
+			}
+			got, gotCopy, err := s.ReserveStock(context.Background(), tt.id, tt.barcode, tt.transactionID, tt.reopen)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantCopy, gotCopy)
+			repo.AssertExpectations(t)
+			reservationRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDefaultService_ReleaseStock(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            string
+		barcode       string
+		transactionID string
+		mockFn        func(repo *mocks.IDbRepository, reservationRepo *mocks.IReservationRepository)
+		want          *domain.Book
+		wantErr       error
+	}{
+		{
+			name:          "success",
+			id:            "book-id-1",
+			transactionID: "tx-1",
+			mockFn: func(repo *mocks.IDbRepository, reservationRepo *mocks.IReservationRepository) {
+				reservationRepo.On("Release", mock.Anything, "book-id-1", "", "tx-1").Return(nil)
+				repo.On("GetByID", mock.Anything, "book-id-1").Return(&domain.Book{ID: "book-id-1", Stock: 6}, nil)
+			},
+			want: &domain.Book{ID: "book-id-1", Stock: 6},
+		},
+		{
+			name:          "book not found",
+			id:            "book-id-2",
+			transactionID: "tx-2",
+			mockFn: func(repo *mocks.IDbRepository, reservationRepo *mocks.IReservationRepository) {
+				reservationRepo.On("Release", mock.Anything, "book-id-2", "", "tx-2").Return(ErrBookNotFound)
+			},
+			wantErr: ErrBookNotFound,
+		},
+		{
+			name:          "passes the scanned copy on",
+			id:            "book-id-3",
+			barcode:       "LIB-0001",
+			transactionID: "tx-3",
+			mockFn: func(repo *mocks.IDbRepository, reservationRepo *mocks.IReservationRepository) {
+				reservationRepo.On("Release", mock.Anything, "book-id-3", "LIB-0001", "tx-3").Return(nil)
+				repo.On("GetByID", mock.Anything, "book-id-3").Return(&domain.Book{ID: "book-id-3", Stock: 2}, nil)
+			},
+			want: &domain.Book{ID: "book-id-3", Stock: 2},
+		},
+		{
+			name:    "invalid input - empty transaction id",
+			id:      "book-id-1",
+			wantErr: ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.IDbRepository)
+			reservationRepo := new(mocks.IReservationRepository)
+			if tt.mockFn != nil {
+				tt.mockFn(repo, reservationRepo)
+			}
+			s := &DefaultService{
+				repoDb:          repo,
+				reservationRepo: reservationRepo,
+			}
+			got, err := s.ReleaseStock(context.Background(), tt.id, tt.barcode, tt.transactionID)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+			repo.AssertExpectations(t)
+			reservationRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDefaultService_CancelStock(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            string
+		transactionID string
+		mockFn        func(repo *mocks.IDbRepository, reservationRepo *mocks.IReservationRepository)
+		want          *domain.Book
+		wantErr       error
+	}{
+		{
+			name:          "success",
+			id:            "book-id-1",
+			transactionID: "tx-1",
+			mockFn: func(repo *mocks.IDbRepository, reservationRepo *mocks.IReservationRepository) {
+				reservationRepo.On("Cancel", mock.Anything, "book-id-1", "tx-1").Return(nil)
+				repo.On("GetByID", mock.Anything, "book-id-1").Return(&domain.Book{ID: "book-id-1", Stock: 5}, nil)
+			},
+			want: &domain.Book{ID: "book-id-1", Stock: 5},
+		},
+		{
+			name:          "book not found",
+			id:            "book-id-2",
+			transactionID: "tx-2",
+			mockFn: func(repo *mocks.IDbRepository, reservationRepo *mocks.IReservationRepository) {
+				reservationRepo.On("Cancel", mock.Anything, "book-id-2", "tx-2").Return(ErrBookNotFound)
+			},
+			wantErr: ErrBookNotFound,
+		},
+		{
+			name:          "invalid input - empty id",
+			transactionID: "tx-1",
+			wantErr:       ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.IDbRepository)
+			reservationRepo := new(mocks.IReservationRepository)
+			if tt.mockFn != nil {
+				tt.mockFn(repo, reservationRepo)
+			}
+			s := &DefaultService{
+				repoDb:          repo,
+				reservationRepo: reservationRepo,
+			}
+			got, err := s.CancelStock(context.Background(), tt.id, tt.transactionID)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+			repo.AssertExpectations(t)
+			reservationRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDefaultService_WriteOffStock(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            string
+		barcode       string
+		transactionID string
+		damaged       bool
+		mockFn        func(repo *mocks.IDbRepository, reservationRepo *mocks.IReservationRepository)
+		want          *domain.Book
+		wantErr       error
+	}{
+		{
+			name:          "writes off the loan's copy",
+			id:            "book-id-1",
+			barcode:       "LIB-0001",
+			transactionID: "tx-1",
+			mockFn: func(repo *mocks.IDbRepository, reservationRepo *mocks.IReservationRepository) {
+				reservationRepo.On("WriteOff", mock.Anything, "book-id-1", "LIB-0001", "tx-1", false).Return(nil)
+				repo.On("GetByID", mock.Anything, "book-id-1").Return(&domain.Book{ID: "book-id-1", Stock: 1}, nil)
+			},
+			want: &domain.Book{ID: "book-id-1", Stock: 1},
+		},
+		{
+			name:          "copy was returned",
+			id:            "book-id-2",
+			barcode:       "LIB-0002",
+			transactionID: "tx-2",
+			damaged:       true,
+			mockFn: func(repo *mocks.IDbRepository, reservationRepo *mocks.IReservationRepository) {
+				reservationRepo.On("WriteOff", mock.Anything, "book-id-2", "LIB-0002", "tx-2", true).Return(ErrReservationReleased)
+			},
+			wantErr: ErrReservationReleased,
+		},
+		{
+			name:    "missing transaction id",
+			id:      "book-id-3",
+			wantErr: ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.IDbRepository)
+			reservationRepo := new(mocks.IReservationRepository)
+			if tt.mockFn != nil {
+				tt.mockFn(repo, reservationRepo)
+			}
+			s := &DefaultService{
+				repoDb:          repo,
+				reservationRepo: reservationRepo,
+			}
+			got, err := s.WriteOffStock(context.Background(), tt.id, tt.barcode, tt.transactionID, tt.damaged)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+			repo.AssertExpectations(t)
+			reservationRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDefaultService_RestoreStock(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            string
+		barcode       string
+		transactionID string
+		mockFn        func(repo *mocks.IDbRepository, reservationRepo *mocks.IReservationRepository)
+		want          *domain.Book
+		wantErr       error
+	}{
+		{
+			name:          "puts the copy back on the shelf",
+			id:            "book-id-1",
+			barcode:       "LIB-0001",
+			transactionID: "tx-1",
+			mockFn: func(repo *mocks.IDbRepository, reservationRepo *mocks.IReservationRepository) {
+				reservationRepo.On("Restore", mock.Anything, "book-id-1", "LIB-0001", "tx-1").Return(nil)
+				repo.On("GetByID", mock.Anything, "book-id-1").Return(&domain.Book{ID: "book-id-1", Stock: 2}, nil)
+			},
+			want: &domain.Book{ID: "book-id-1", Stock: 2},
+		},
+		{
+			name:          "copy not lost",
+			id:            "book-id-2",
+			barcode:       "LIB-0002",
+			transactionID: "tx-2",
+			mockFn: func(repo *mocks.IDbRepository, reservationRepo *mocks.IReservationRepository) {
+				reservationRepo.On("Restore", mock.Anything, "book-id-2", "LIB-0002", "tx-2").Return(ErrCopyNotLost)
+			},
+			wantErr: ErrCopyNotLost,
+		},
+		{
+			name:    "missing transaction id",
+			id:      "book-id-3",
+			wantErr: ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.IDbRepository)
+			reservationRepo := new(mocks.IReservationRepository)
+			if tt.mockFn != nil {
+				tt.mockFn(repo, reservationRepo)
+			}
+			s := &DefaultService{
+				repoDb:          repo,
+				reservationRepo: reservationRepo,
+			}
+			got, err := s.RestoreStock(context.Background(), tt.id, tt.barcode, tt.transactionID)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+			repo.AssertExpectations(t)
+			reservationRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDefaultService_SearchBooks(t *testing.T) {
+	matches := []*domain.BookMatch{
+		{Book: domain.Book{ID: "1", Title: "Dune", Author: "Frank Herbert", Category: "Fiction", Stock: 2}, Rank: 0.5},
+	}
+	dune := []domain.BookSearchTerm{{Words: []string{"dune"}}}
+
 	tests := []struct {
 		name    string
+		q       string
+		limit   int
 		mockFn  func(repo *mocks.IDbRepository)
-		want    []*domain.Book
-		wantErr assert.ErrorAssertionFunc
+		want    []*domain.BookMatch
+		wantErr error
 	}{
 		{
-			name: "success",
+			name:  "success",
+			q:     "Dune",
+			limit: 5,
 			mockFn: func(repo *mocks.IDbRepository) {
-				books := []*domain.Book{
-					{ID: "1", Title: "Book1", Author: "Author1", Category: "Fiction", Stock: 5},
-					{ID: "2", Title: "Book2", Author: "Author2", Category: "NonFiction", Stock: 3},
-				}
-				repo.On("List", mock.Anything).Return(books, nil)
+				repo.On("Search", mock.Anything, domain.BookSearch{Terms: dune, Limit: 5}).Return(matches, nil)
 			},
-			want: []*domain.Book{
-				{ID: "1", Title: "Book1", Author: "Author1", Category: "Fiction", Stock: 5},
-				{ID: "2", Title: "Book2", Author: "Author2", Category: "NonFiction", Stock: 3},
+			want: matches,
+		},
+		{
+			name: "defaults limit",
+			q:    "Dune",
+			mockFn: func(repo *mocks.IDbRepository) {
+				repo.On("Search", mock.Anything, domain.BookSearch{Terms: dune, Limit: DefaultPageSize}).Return(matches, nil)
 			},
-			wantErr: assert.NoError,
+			want: matches,
+		},
+		{
+			name:  "caps limit",
+			q:     "Dune",
+			limit: 500,
+			mockFn: func(repo *mocks.IDbRepository) {
+				repo.On("Search", mock.Anything, domain.BookSearch{Terms: dune, Limit: MaxPageSize}).Return(matches, nil)
+			},
+			want: matches,
+		},
+		{
+			name:    "nothing to search for",
+			q:       `"" *`,
+			wantErr: ErrInvalidInput,
 		},
 		{
 			name: "repo returns error",
+			q:    "Dune",
 			mockFn: func(repo *mocks.IDbRepository) {
-				repo.On("List", mock.Anything).Return(nil, errors.New("db error"))
+				repo.On("Search", mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
 			},
-			want:    nil,
-			wantErr: assert.Error,
+			wantErr: errors.New("db error"),
 		},
 	}
 
@@ -505,11 +1248,14 @@ func TestDefaultService_RecommendBooks(t *testing.T) {
 			s := &DefaultService{
 				repoDb: repo,
 			}
-			got, err := s.RecommendBooks(context.Background())
-			if !tt.wantErr(t, err, fmt.Sprintf("RecommendBooks(%v)", context.Background())) {
-				return
+			got, err := s.SearchBooks(context.Background(), tt.q, tt.limit)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
 			}
-			assert.Equalf(t, tt.want, got, "RecommendBooks(%v)", context.Background())
 			repo.AssertExpectations(t)
 		})
 	}
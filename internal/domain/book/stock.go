@@ -0,0 +1,85 @@
+package book
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+)
+
+// movementPageToken is what an opaque stock movement page token decodes to.
+// The book is kept so a token cannot be replayed against another ledger.
+type movementPageToken struct {
+	BookID string `json:"b"`
+	Before uint64 `json:"m"`
+}
+
+// ListStockMovements retrieves one page of a book's stock ledger, newest
+// first. pageToken continues a previous listing of the same book. It returns
+// the token for the next page, empty on the last page.
+func (s *DefaultService) ListStockMovements(ctx context.Context, bookID string, pageSize int, pageToken string) ([]*domain.StockMovement, string, error) {
+	if bookID == "" {
+		return nil, "", ErrInvalidInput
+	}
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	var before uint64
+	if pageToken != "" {
+		token, err := decodeMovementPageToken(pageToken)
+		if err != nil || token.BookID != bookID {
+			return nil, "", ErrInvalidPageToken
+		}
+		before = token.Before
+	}
+
+	if _, err := s.repoDb.GetByID(ctx, bookID); err != nil {
+		return nil, "", err
+	}
+
+	// One movement past the page tells whether another page follows
+	movements, err := s.stockRepo.ListMovements(ctx, bookID, before, pageSize+1)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(movements) <= pageSize {
+		return movements, "", nil
+	}
+
+	movements = movements[:pageSize]
+	raw, err := json.Marshal(movementPageToken{BookID: bookID, Before: movements[pageSize-1].ID})
+	if err != nil {
+		return nil, "", err
+	}
+	return movements, base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// decodeMovementPageToken decodes a stock movement page token
+func decodeMovementPageToken(token string) (*movementPageToken, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	var decoded movementPageToken
+	if err := json.Unmarshal(raw, &decoded); err != nil || decoded.Before == 0 {
+		return nil, ErrInvalidPageToken
+	}
+	return &decoded, nil
+}
+
+// ReconcileStock recomputes the stock of every book, or only of bookID when
+// it is not empty, from the stock ledger. It returns the books whose stock
+// has drifted from their ledger and how many books were checked.
+func (s *DefaultService) ReconcileStock(ctx context.Context, bookID string) ([]*domain.StockDrift, int64, error) {
+	if bookID != "" {
+		if _, err := s.repoDb.GetByID(ctx, bookID); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return s.stockRepo.Reconcile(ctx, bookID)
+}
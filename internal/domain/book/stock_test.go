@@ -0,0 +1,153 @@
+package book
+
+import (
+	"context"
+	"encoding/base64"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"github.com/hinha/library-management-synapsis/internal/domain/book/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestDefaultService_ListStockMovements(t *testing.T) {
+	movements := []*domain.StockMovement{{ID: 9}, {ID: 7}, {ID: 4}}
+	nextPage := base64.RawURLEncoding.EncodeToString([]byte(`{"b":"book-1","m":7}`))
+
+	tests := []struct {
+		name          string
+		bookID        string
+		pageSize      int
+		pageToken     string
+		mockFn        func(repo *mocks.IDbRepository, stockRepo *mocks.IStockRepository)
+		wantIDs       []uint64
+		wantPageToken string
+		wantErr       error
+	}{
+		{
+			name:     "first page with more to come",
+			bookID:   "book-1",
+			pageSize: 2,
+			mockFn: func(repo *mocks.IDbRepository, stockRepo *mocks.IStockRepository) {
+				repo.On("GetByID", mock.Anything, "book-1").Return(&domain.Book{ID: "book-1"}, nil)
+				stockRepo.On("ListMovements", mock.Anything, "book-1", uint64(0), 3).Return(movements, nil)
+			},
+			wantIDs:       []uint64{9, 7},
+			wantPageToken: nextPage,
+		},
+		{
+			name:      "last page",
+			bookID:    "book-1",
+			pageToken: nextPage,
+			mockFn: func(repo *mocks.IDbRepository, stockRepo *mocks.IStockRepository) {
+				repo.On("GetByID", mock.Anything, "book-1").Return(&domain.Book{ID: "book-1"}, nil)
+				stockRepo.On("ListMovements", mock.Anything, "book-1", uint64(7), DefaultPageSize+1).Return(movements[2:], nil)
+			},
+			wantIDs: []uint64{4},
+		},
+		{
+			name:      "token of another book",
+			bookID:    "book-2",
+			pageToken: nextPage,
+			wantErr:   ErrInvalidPageToken,
+		},
+		{
+			name:      "malformed token",
+			bookID:    "book-1",
+			pageToken: "not-a-token",
+			wantErr:   ErrInvalidPageToken,
+		},
+		{
+			name:   "book not found",
+			bookID: "book-1",
+			mockFn: func(repo *mocks.IDbRepository, stockRepo *mocks.IStockRepository) {
+				repo.On("GetByID", mock.Anything, "book-1").Return(nil, ErrBookNotFound)
+			},
+			wantErr: ErrBookNotFound,
+		},
+		{
+			name:    "missing book",
+			wantErr: ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.IDbRepository)
+			stockRepo := new(mocks.IStockRepository)
+			if tt.mockFn != nil {
+				tt.mockFn(repo, stockRepo)
+			}
+			s := &DefaultService{repoDb: repo, stockRepo: stockRepo}
+
+			got, pageToken, err := s.ListStockMovements(context.Background(), tt.bookID, tt.pageSize, tt.pageToken)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil && assert.Len(t, got, len(tt.wantIDs)) {
+				for i, movement := range got {
+					assert.Equal(t, tt.wantIDs[i], movement.ID)
+				}
+				assert.Equal(t, tt.wantPageToken, pageToken)
+			}
+			repo.AssertExpectations(t)
+			stockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDefaultService_ReconcileStock(t *testing.T) {
+	drifts := []*domain.StockDrift{{BookID: "book-1", Title: "Dune", Stock: 5, LedgerStock: 3}}
+
+	tests := []struct {
+		name        string
+		bookID      string
+		mockFn      func(repo *mocks.IDbRepository, stockRepo *mocks.IStockRepository)
+		wantDrifts  []*domain.StockDrift
+		wantChecked int64
+		wantErr     error
+	}{
+		{
+			name: "all books",
+			mockFn: func(repo *mocks.IDbRepository, stockRepo *mocks.IStockRepository) {
+				stockRepo.On("Reconcile", mock.Anything, "").Return(drifts, int64(12), nil)
+			},
+			wantDrifts:  drifts,
+			wantChecked: 12,
+		},
+		{
+			name:   "one book",
+			bookID: "book-1",
+			mockFn: func(repo *mocks.IDbRepository, stockRepo *mocks.IStockRepository) {
+				repo.On("GetByID", mock.Anything, "book-1").Return(&domain.Book{ID: "book-1"}, nil)
+				stockRepo.On("Reconcile", mock.Anything, "book-1").Return(drifts, int64(1), nil)
+			},
+			wantDrifts:  drifts,
+			wantChecked: 1,
+		},
+		{
+			name:   "book not found",
+			bookID: "book-1",
+			mockFn: func(repo *mocks.IDbRepository, stockRepo *mocks.IStockRepository) {
+				repo.On("GetByID", mock.Anything, "book-1").Return(nil, ErrBookNotFound)
+			},
+			wantErr: ErrBookNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.IDbRepository)
+			stockRepo := new(mocks.IStockRepository)
+			tt.mockFn(repo, stockRepo)
+			s := &DefaultService{repoDb: repo, stockRepo: stockRepo}
+
+			got, checked, err := s.ReconcileStock(context.Background(), tt.bookID)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantDrifts, got)
+			assert.Equal(t, tt.wantChecked, checked)
+			repo.AssertExpectations(t)
+			stockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	pb "github.com/hinha/library-management-synapsis/gen/api/proto/book"
+)
+
+// CopyStatus is where a physical copy of a book is in circulation
+type CopyStatus string
+
+const (
+	// CopyStatusAvailable means the copy is on the shelf and can be borrowed
+	CopyStatusAvailable CopyStatus = "available"
+	// CopyStatusOnLoan means a patron has the copy
+	CopyStatusOnLoan CopyStatus = "on_loan"
+	// CopyStatusInRepair means the copy is being mended
+	CopyStatusInRepair CopyStatus = "in_repair"
+	// CopyStatusLost means the copy cannot be found
+	CopyStatusLost CopyStatus = "lost"
+	// CopyStatusWithdrawn means the copy has been taken out of circulation for good
+	CopyStatusWithdrawn CopyStatus = "withdrawn"
+)
+
+// CopyCondition is the physical state of a copy
+type CopyCondition string
+
+const (
+	CopyConditionNew     CopyCondition = "new"
+	CopyConditionGood    CopyCondition = "good"
+	CopyConditionFair    CopyCondition = "fair"
+	CopyConditionPoor    CopyCondition = "poor"
+	CopyConditionDamaged CopyCondition = "damaged"
+)
+
+// BookCopy is a physical copy of a book, identified by the barcode on it.
+// A book's stock is the number of its copies that are available.
+type BookCopy struct {
+	ID            string        `gorm:"primaryKey" json:"id"`
+	BookID        string        `gorm:"not null;index" json:"book_id"`
+	Barcode       string        `gorm:"size:64;not null;uniqueIndex" json:"barcode"`
+	ShelfLocation string        `json:"shelf_location,omitempty"`
+	Condition     CopyCondition `gorm:"not null" json:"condition"`
+	Status        CopyStatus    `gorm:"not null;index" json:"status"`
+	CreatedAt     time.Time     `gorm:"not null" json:"created_at"`
+	UpdatedAt     time.Time     `gorm:"not null" json:"updated_at"`
+}
+
+// NewBookCopy creates a new available copy of a book in good condition
+// unless condition says otherwise
+func NewBookCopy(bookID, barcode, shelfLocation string, condition CopyCondition) *BookCopy {
+	if condition == "" {
+		condition = CopyConditionGood
+	}
+	now := time.Now()
+	return &BookCopy{
+		ID:            uuid.New().String(),
+		BookID:        bookID,
+		Barcode:       barcode,
+		ShelfLocation: shelfLocation,
+		Condition:     condition,
+		Status:        CopyStatusAvailable,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// SetStatus moves the copy to status
+func (c *BookCopy) SetStatus(status CopyStatus) {
+	c.Status = status
+	c.UpdatedAt = time.Now()
+}
+
+// IsAvailable checks if the copy can be borrowed
+func (c *BookCopy) IsAvailable() bool {
+	return c.Status == CopyStatusAvailable
+}
+
+// ToProto converts the copy entity to a protobuf book copy
+func (c *BookCopy) ToProto() *pb.BookCopy {
+	return &pb.BookCopy{
+		Id:            c.ID,
+		BookId:        c.BookID,
+		Barcode:       c.Barcode,
+		ShelfLocation: c.ShelfLocation,
+		Condition:     string(c.Condition),
+		Status:        string(c.Status),
+		CreatedAt:     c.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:     c.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -10,14 +10,42 @@ import (
 
 // Book represents a book entity in the system
 type Book struct {
-	ID        string         `gorm:"primaryKey"`
-	Title     string         `gorm:"not null"`
-	Author    string         `gorm:"not null"`
-	Category  string         `gorm:"not null"`
-	Stock     int32          `gorm:"not null"`
-	CreatedAt time.Time      `gorm:"not null"`
-	UpdatedAt time.Time      `gorm:"not null"`
-	DeletedAt gorm.DeletedAt `gorm:"index"`
+	ID       string `gorm:"primaryKey" json:"id"`
+	Title    string `gorm:"not null" json:"title"`
+	Author   string `gorm:"not null;index" json:"author"`
+	Category string `gorm:"not null;index" json:"category"`
+	Stock    int32  `gorm:"not null" json:"stock"`
+	BookMetadata
+	Version   int64          `gorm:"not null;default:1" json:"version"` // bumped by every write
+	CreatedAt time.Time      `gorm:"not null;index" json:"created_at"`
+	UpdatedAt time.Time      `gorm:"not null" json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// BookMetadata is the bibliographic description of a book. ISBN holds the
+// normalized 13 digit form and is unique among books that are not deleted.
+type BookMetadata struct {
+	ISBN            string `gorm:"size:13;uniqueIndex:idx_books_isbn,where:isbn <> '' AND deleted_at IS NULL" json:"isbn,omitempty"`
+	Publisher       string `json:"publisher,omitempty"`
+	PublicationYear int32  `json:"publication_year,omitempty"`
+	Language        string `json:"language,omitempty"`
+	PageCount       int32  `json:"page_count,omitempty"`
+	Edition         string `json:"edition,omitempty"`
+	Description     string `gorm:"type:text" json:"description,omitempty"`
+}
+
+// Normalize brings the ISBN into its 13 digit form, returning ErrInvalidISBN
+// when it is malformed. An empty ISBN is left as is.
+func (m *BookMetadata) Normalize() error {
+	if m.ISBN == "" {
+		return nil
+	}
+	isbn, err := NormalizeISBN(m.ISBN)
+	if err != nil {
+		return err
+	}
+	m.ISBN = isbn
+	return nil
 }
 
 // NewBook creates a new book entity
@@ -28,6 +56,7 @@ func NewBook(title, author, category string, stock int32) *Book {
 		Author:    author,
 		Category:  category,
 		Stock:     stock,
+		Version:   1,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
@@ -41,6 +70,16 @@ func (b *Book) ToProto() *pb.BookResponse {
 		Author:   b.Author,
 		Category: b.Category,
 		Stock:    b.Stock,
+
+		Isbn:            b.ISBN,
+		Publisher:       b.Publisher,
+		PublicationYear: b.PublicationYear,
+		Language:        b.Language,
+		PageCount:       b.PageCount,
+		Edition:         b.Edition,
+		Description:     b.Description,
+		Version:         b.Version,
+		DeletedAt:       formatDeletedAt(b.DeletedAt),
 	}
 }
 
@@ -0,0 +1,8 @@
+package domain
+
+// ExportWriter encodes the books of a catalogue export one at a time. Flush
+// writes out whatever is still buffered once the last book was written.
+type ExportWriter interface {
+	Write(book *Book) error
+	Flush() error
+}
@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"time"
+
+	pb "github.com/hinha/library-management-synapsis/gen/api/proto/book"
+)
+
+// DefaultImportBatchSize is how many records an import saves per transaction
+// when the caller does not say
+const DefaultImportBatchSize = 100
+
+// ImportRecord is one book read from a catalogue import file. Err is set when
+// the record could not be read or is invalid; such records are rejected.
+type ImportRecord struct {
+	Row      int
+	Title    string
+	Author   string
+	Category string
+	Stock    int32
+	BookMetadata
+	Err error
+}
+
+// ImportReader reads the records of an import file one at a time. Next
+// returns io.EOF after the last record.
+type ImportReader interface {
+	Next() (*ImportRecord, error)
+}
+
+// ApplyTo writes the record over an existing book. Fields the record leaves
+// empty keep their current values, and the stock is only set when setStock
+// is true.
+func (r *ImportRecord) ApplyTo(book *Book, setStock bool) {
+	setString := func(dst *string, value string) {
+		if value != "" {
+			*dst = value
+		}
+	}
+	setInt := func(dst *int32, value int32) {
+		if value != 0 {
+			*dst = value
+		}
+	}
+
+	setString(&book.Title, r.Title)
+	setString(&book.Author, r.Author)
+	setString(&book.Category, r.Category)
+	if setStock {
+		book.Stock = r.Stock
+	}
+	setString(&book.Publisher, r.Publisher)
+	setInt(&book.PublicationYear, r.PublicationYear)
+	setString(&book.Language, r.Language)
+	setInt(&book.PageCount, r.PageCount)
+	setString(&book.Edition, r.Edition)
+	setString(&book.Description, r.Description)
+	book.UpdatedAt = time.Now()
+}
+
+// ImportStatus is what an import did with a record
+type ImportStatus string
+
+const (
+	ImportStatusCreated  ImportStatus = "created"
+	ImportStatusUpdated  ImportStatus = "updated"
+	ImportStatusRejected ImportStatus = "rejected"
+)
+
+// ImportResult is the outcome of importing one record
+type ImportResult struct {
+	Row    int          `json:"row"`
+	Status ImportStatus `json:"status"`
+	BookID string       `json:"book_id,omitempty"`
+	ISBN   string       `json:"isbn,omitempty"`
+	Title  string       `json:"title,omitempty"`
+	Reason string       `json:"reason,omitempty"`
+}
+
+// ImportReport lists the outcome of every record of an import
+type ImportReport struct {
+	DryRun   bool           `json:"dry_run"`
+	Created  int            `json:"created"`
+	Updated  int            `json:"updated"`
+	Rejected int            `json:"rejected"`
+	Results  []ImportResult `json:"results"`
+}
+
+// Add appends a record outcome to the report
+func (r *ImportReport) Add(result ImportResult) {
+	switch result.Status {
+	case ImportStatusCreated:
+		r.Created++
+	case ImportStatusUpdated:
+		r.Updated++
+	case ImportStatusRejected:
+		r.Rejected++
+	}
+	r.Results = append(r.Results, result)
+}
+
+// Reject appends a rejected record to the report
+func (r *ImportReport) Reject(record *ImportRecord, reason string) {
+	r.Add(ImportResult{
+		Row:    record.Row,
+		Status: ImportStatusRejected,
+		ISBN:   record.ISBN,
+		Title:  record.Title,
+		Reason: reason,
+	})
+}
+
+// ToProto converts the import report to a protobuf import response
+func (r *ImportReport) ToProto() *pb.ImportBooksResponse {
+	response := &pb.ImportBooksResponse{
+		DryRun:   r.DryRun,
+		Created:  int32(r.Created),
+		Updated:  int32(r.Updated),
+		Rejected: int32(r.Rejected),
+		Results:  make([]*pb.ImportRecordResult, len(r.Results)),
+	}
+	for i, result := range r.Results {
+		response.Results[i] = &pb.ImportRecordResult{
+			Row:    int32(result.Row),
+			Status: string(result.Status),
+			BookId: result.BookID,
+			Isbn:   result.ISBN,
+			Title:  result.Title,
+			Reason: result.Reason,
+		}
+	}
+	return response
+}
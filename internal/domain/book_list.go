@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+// BookFilter narrows down and orders a book listing
+type BookFilter struct {
+	Category      string     `json:"category,omitempty"`
+	Author        string     `json:"author,omitempty"`
+	InStock       bool       `json:"in_stock,omitempty"`
+	CreatedAfter  *time.Time `json:"created_after,omitempty"`
+	CreatedBefore *time.Time `json:"created_before,omitempty"`
+	SortBy        string     `json:"sort_by"`
+	Descending    bool       `json:"descending,omitempty"`
+}
+
+// BookCursor is the position after which the next page of a listing starts:
+// the sort field value and ID of the last book on the previous page
+type BookCursor struct {
+	Value string `json:"v"`
+	ID    string `json:"id"`
+}
+
+// BookQuery asks for one page of a book listing
+type BookQuery struct {
+	Filter BookFilter
+	Limit  int
+	After  *BookCursor
+}
@@ -0,0 +1,33 @@
+package domain
+
+import pb "github.com/hinha/library-management-synapsis/gen/api/proto/book"
+
+// BookSearchTerm is one part of a catalogue search: a single word or a quoted
+// phrase. All words of a phrase must appear next to each other. A prefix term
+// also matches words starting with its last word.
+type BookSearchTerm struct {
+	Words  []string
+	Prefix bool
+}
+
+// BookSearch asks for the books matching every term, best match first
+type BookSearch struct {
+	Terms []BookSearchTerm
+	Limit int
+}
+
+// BookMatch is a book found by a catalogue search
+type BookMatch struct {
+	Book    `gorm:"embedded"`
+	Rank    float64
+	Snippet string
+}
+
+// ToProto converts the match to a protobuf search result
+func (m *BookMatch) ToProto() *pb.SearchResult {
+	return &pb.SearchResult{
+		Book:    m.Book.ToProto(),
+		Rank:    float32(m.Rank),
+		Snippet: m.Snippet,
+	}
+}
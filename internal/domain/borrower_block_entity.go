@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"time"
+
+	pb "github.com/hinha/library-management-synapsis/gen/api/proto/transaction"
+)
+
+// BorrowerBlock stops a user from borrowing until it expires or an admin
+// lifts it. A user has at most one block; blocking again replaces it.
+type BorrowerBlock struct {
+	UserID    string     `gorm:"primaryKey" json:"user_id"`
+	Reason    string     `gorm:"not null" json:"reason"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	CreatedAt time.Time  `gorm:"not null" json:"created_at"`
+	UpdatedAt time.Time  `gorm:"not null" json:"updated_at"`
+}
+
+// NewBorrowerBlock creates a block on userID. A nil expiresAt blocks until
+// the block is lifted.
+func NewBorrowerBlock(userID, reason string, expiresAt *time.Time) *BorrowerBlock {
+	now := time.Now()
+	return &BorrowerBlock{
+		UserID:    userID,
+		Reason:    reason,
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// IsActive checks if the block still applies at now
+func (b *BorrowerBlock) IsActive(now time.Time) bool {
+	return b.ExpiresAt == nil || now.Before(*b.ExpiresAt)
+}
+
+// ToProto converts the block entity to a protobuf borrower block response
+func (b *BorrowerBlock) ToProto() *pb.BorrowerBlockResponse {
+	response := &pb.BorrowerBlockResponse{
+		UserId:    b.UserID,
+		Reason:    b.Reason,
+		CreatedAt: b.CreatedAt.Format(time.RFC3339),
+	}
+
+	if b.ExpiresAt != nil {
+		response.ExpiresAt = b.ExpiresAt.Format(time.RFC3339)
+	}
+
+	return response
+}
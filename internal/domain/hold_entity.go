@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	pb "github.com/hinha/library-management-synapsis/gen/api/proto/transaction"
+)
+
+// HoldStatus is the state of a hold in a book's queue
+type HoldStatus string
+
+const (
+	// HoldStatusWaiting means the patron is in line for the next returned copy
+	HoldStatusWaiting HoldStatus = "waiting"
+	// HoldStatusReady means a copy has been set aside for the patron to pick up
+	HoldStatusReady HoldStatus = "ready"
+	// HoldStatusFulfilled means the patron borrowed the copy set aside for them
+	HoldStatusFulfilled HoldStatus = "fulfilled"
+	// HoldStatusCancelled means the patron or an admin cancelled the hold
+	HoldStatusCancelled HoldStatus = "cancelled"
+	// HoldStatusExpired means the copy was not picked up in time
+	HoldStatusExpired HoldStatus = "expired"
+)
+
+// Hold is a patron's place in the queue for a book that has no copy available
+type Hold struct {
+	ID        string     `gorm:"primaryKey" json:"id"`
+	UserID    string     `gorm:"not null;index" json:"user_id"`
+	BookID    string     `gorm:"not null;index" json:"book_id"`
+	Status    HoldStatus `gorm:"not null;index" json:"status"`
+	ReadyAt   *time.Time `json:"ready_at"`
+	ExpiresAt *time.Time `gorm:"index" json:"expires_at"`
+	CreatedAt time.Time  `gorm:"not null;index" json:"created_at"`
+	UpdatedAt time.Time  `gorm:"not null" json:"updated_at"`
+
+	// Position is the 1-based place in the book's queue of a waiting hold
+	Position int32 `gorm:"-" json:"-"`
+}
+
+// NewHold creates a new waiting hold
+func NewHold(userID, bookID string) *Hold {
+	now := time.Now()
+	return &Hold{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		BookID:    bookID,
+		Status:    HoldStatusWaiting,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// MarkReady sets a copy aside for the patron until pickupPeriod has passed
+func (h *Hold) MarkReady(pickupPeriod time.Duration) {
+	now := time.Now()
+	expiresAt := now.Add(pickupPeriod)
+	h.Status = HoldStatusReady
+	h.ReadyAt = &now
+	h.ExpiresAt = &expiresAt
+	h.UpdatedAt = now
+}
+
+// SetStatus moves the hold to status
+func (h *Hold) SetStatus(status HoldStatus) {
+	h.Status = status
+	h.UpdatedAt = time.Now()
+}
+
+// IsActive checks if the hold is still waiting or ready
+func (h *Hold) IsActive() bool {
+	return h.Status == HoldStatusWaiting || h.Status == HoldStatusReady
+}
+
+// IsReady checks if a copy is set aside for the patron at now
+func (h *Hold) IsReady(now time.Time) bool {
+	return h.Status == HoldStatusReady && h.ExpiresAt != nil && now.Before(*h.ExpiresAt)
+}
+
+// ToProto converts the hold entity to a protobuf hold response
+func (h *Hold) ToProto() *pb.HoldResponse {
+	response := &pb.HoldResponse{
+		Id:        h.ID,
+		UserId:    h.UserID,
+		BookId:    h.BookID,
+		Status:    string(h.Status),
+		Position:  h.Position,
+		CreatedAt: h.CreatedAt.Format(time.RFC3339),
+	}
+
+	if h.ReadyAt != nil {
+		response.ReadyAt = h.ReadyAt.Format(time.RFC3339)
+	}
+	if h.ExpiresAt != nil {
+		response.ExpiresAt = h.ExpiresAt.Format(time.RFC3339)
+	}
+
+	return response
+}
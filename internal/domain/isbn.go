@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrInvalidISBN is returned when an ISBN is malformed or its check digit is wrong
+var ErrInvalidISBN = errors.New("invalid isbn")
+
+// NormalizeISBN returns the 13 digit form of an ISBN-10 or ISBN-13, ignoring
+// hyphens and spaces, after verifying its check digit
+func NormalizeISBN(raw string) (string, error) {
+	isbn := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(raw))
+
+	switch len(isbn) {
+	case 10:
+		if !validISBN10(isbn) {
+			return "", ErrInvalidISBN
+		}
+		// ISBN-10s carry over to the 978 prefix with a new check digit
+		prefixed := "978" + isbn[:9]
+		return prefixed + string(isbn13CheckDigit(prefixed)), nil
+	case 13:
+		if !allDigits(isbn) || isbn13CheckDigit(isbn[:12]) != isbn[12] {
+			return "", ErrInvalidISBN
+		}
+		return isbn, nil
+	default:
+		return "", ErrInvalidISBN
+	}
+}
+
+// validISBN10 checks the digits and the mod 11 check digit of an ISBN-10,
+// whose last character may be X for 10
+func validISBN10(isbn string) bool {
+	if !allDigits(isbn[:9]) {
+		return false
+	}
+	sum := 0
+	for i := 0; i < 9; i++ {
+		sum += (10 - i) * int(isbn[i]-'0')
+	}
+	switch check := isbn[9]; {
+	case check == 'X':
+		sum += 10
+	case check >= '0' && check <= '9':
+		sum += int(check - '0')
+	default:
+		return false
+	}
+	return sum%11 == 0
+}
+
+// isbn13CheckDigit computes the mod 10 check digit of the first 12 digits of
+// an ISBN-13
+func isbn13CheckDigit(digits string) byte {
+	sum := 0
+	for i := 0; i < 12; i++ {
+		weight := 1
+		if i%2 == 1 {
+			weight = 3
+		}
+		sum += weight * int(digits[i]-'0')
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// allDigits checks s only holds ASCII digits
+func allDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNormalizeISBN(t *testing.T) {
+	tests := []struct {
+		name    string
+		isbn    string
+		want    string
+		wantErr error
+	}{
+		{name: "isbn-13", isbn: "9780306406157", want: "9780306406157"},
+		{name: "isbn-13 with hyphens", isbn: "978-0-306-40615-7", want: "9780306406157"},
+		{name: "isbn-10", isbn: "0-306-40615-2", want: "9780306406157"},
+		{name: "isbn-10 with X check digit", isbn: "0 8044 2957 x", want: "9780804429573"},
+		{name: "isbn-10 wrong check digit", isbn: "0306406153", wantErr: ErrInvalidISBN},
+		{name: "isbn-13 wrong check digit", isbn: "9780306406158", wantErr: ErrInvalidISBN},
+		{name: "letters", isbn: "97803064061AB", wantErr: ErrInvalidISBN},
+		{name: "X inside isbn-10", isbn: "03064X6152", wantErr: ErrInvalidISBN},
+		{name: "wrong length", isbn: "12345", wantErr: ErrInvalidISBN},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeISBN(tt.isbn)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
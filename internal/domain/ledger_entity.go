@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	pb "github.com/hinha/library-management-synapsis/gen/api/proto/transaction"
+)
+
+// LedgerEntryType is the kind of movement on a user's fines ledger
+type LedgerEntryType string
+
+const (
+	// LedgerEntryCharge is a late fee charged when an overdue loan is
+	// returned, or a replacement fee for a lost or damaged book
+	LedgerEntryCharge LedgerEntryType = "charge"
+	// LedgerEntryPayment is money the patron paid towards their balance
+	LedgerEntryPayment LedgerEntryType = "payment"
+	// LedgerEntryWaiver is part of the balance forgiven by an admin
+	LedgerEntryWaiver LedgerEntryType = "waiver"
+	// LedgerEntryRefund gives back a replacement fee once a lost book turns
+	// up, or a fee charged for a loan that was reopened
+	LedgerEntryRefund LedgerEntryType = "refund"
+)
+
+// LedgerEntry is a single movement on a user's fines ledger. Amount is in
+// minor currency units and always positive; Type decides whether it adds to
+// or takes from the balance.
+type LedgerEntry struct {
+	ID            string          `gorm:"primaryKey" json:"id"`
+	UserID        string          `gorm:"not null;index" json:"user_id"`
+	TransactionID string          `gorm:"index" json:"transaction_id,omitempty"`
+	Type          LedgerEntryType `gorm:"not null" json:"type"`
+	Amount        int64           `gorm:"not null" json:"amount"`
+	Note          string          `json:"note,omitempty"`
+	CreatedAt     time.Time       `gorm:"not null;index" json:"created_at"`
+}
+
+// NewLedgerEntry creates a new ledger entry
+func NewLedgerEntry(userID string, entryType LedgerEntryType, amount int64, note string) *LedgerEntry {
+	return &LedgerEntry{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Type:      entryType,
+		Amount:    amount,
+		Note:      note,
+		CreatedAt: time.Now(),
+	}
+}
+
+// NewFineCharge creates a late fee charge for an overdue loan
+func NewFineCharge(transaction *Transaction, amount int64, note string) *LedgerEntry {
+	entry := NewLedgerEntry(transaction.UserID, LedgerEntryCharge, amount, note)
+	entry.TransactionID = transaction.ID
+	return entry
+}
+
+// LateFee works out the late fee for a loan as it is returned, or nil when
+// nothing is owed
+type LateFee func(transaction *Transaction) *LedgerEntry
+
+// NewFineRefund creates a refund of a fee charged for a loan
+func NewFineRefund(transaction *Transaction, amount int64, note string) *LedgerEntry {
+	entry := NewLedgerEntry(transaction.UserID, LedgerEntryRefund, amount, note)
+	entry.TransactionID = transaction.ID
+	return entry
+}
+
+// Signed returns the entry's effect on the balance: positive for charges,
+// negative for payments, waivers and refunds
+func (e *LedgerEntry) Signed() int64 {
+	if e.Type == LedgerEntryCharge {
+		return e.Amount
+	}
+	return -e.Amount
+}
+
+// ToProto converts the ledger entry to a protobuf ledger entry response
+func (e *LedgerEntry) ToProto() *pb.LedgerEntryResponse {
+	return &pb.LedgerEntryResponse{
+		Id:            e.ID,
+		UserId:        e.UserID,
+		TransactionId: e.TransactionID,
+		Type:          string(e.Type),
+		Amount:        e.Amount,
+		Note:          e.Note,
+		CreatedAt:     e.CreatedAt.Format(time.RFC3339),
+	}
+}
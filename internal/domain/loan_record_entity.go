@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+// LoanRecord is the book service's copy of a loan opened in the transaction
+// service, kept to compute recommendations from borrowing data
+type LoanRecord struct {
+	TransactionID string    `gorm:"primaryKey" json:"transaction_id"`
+	UserID        string    `gorm:"not null;index" json:"user_id"`
+	BookID        string    `gorm:"not null;index" json:"book_id"`
+	BorrowedAt    time.Time `gorm:"not null;index" json:"borrowed_at"`
+	CreatedAt     time.Time `gorm:"not null" json:"created_at"`
+}
+
+// NewLoanRecord creates a loan record from a transaction
+func NewLoanRecord(transaction *Transaction) *LoanRecord {
+	return &LoanRecord{
+		TransactionID: transaction.ID,
+		UserID:        transaction.UserID,
+		BookID:        transaction.BookID,
+		BorrowedAt:    transaction.BorrowedAt,
+		CreatedAt:     time.Now(),
+	}
+}
+
+// FeedCursor remembers how far a consumer got through another service's
+// event stream so it can resume there after a restart
+type FeedCursor struct {
+	Name      string    `gorm:"primaryKey" json:"name"`
+	Cursor    uint64    `gorm:"not null" json:"cursor"`
+	UpdatedAt time.Time `gorm:"not null" json:"updated_at"`
+}
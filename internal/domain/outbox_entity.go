@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	pb "github.com/hinha/library-management-synapsis/gen/api/proto/common"
+)
+
+// Aggregate types that emit domain events
+const (
+	AggregateBook        = "book"
+	AggregateUser        = "user"
+	AggregateTransaction = "transaction"
+	AggregateHold        = "hold"
+	AggregateLedger      = "ledger"
+	AggregateBorrower    = "borrower"
+)
+
+// Domain event types written to the outbox
+const (
+	EventBookCreated  = "BookCreated"
+	EventBookUpdated  = "BookUpdated"
+	EventBookDeleted  = "BookDeleted"
+	EventBookRestored = "BookRestored"
+	EventStockChanged = "StockChanged"
+	EventCopyAdded    = "CopyAdded"
+	EventCopyUpdated  = "CopyUpdated"
+
+	EventUserRegistered = "UserRegistered"
+	EventUserUpdated    = "UserUpdated"
+	EventUserDeleted    = "UserDeleted"
+	EventUserRestored   = "UserRestored"
+
+	EventLoanOpened   = "LoanOpened"
+	EventLoanReturned = "LoanReturned"
+	EventLoanRenewed  = "LoanRenewed"
+	EventLoanReopened = "LoanReopened"
+	EventLoanVoided   = "LoanVoided"
+	EventLoanRestored = "LoanRestored"
+	EventLoanLost     = "LoanLost"
+	EventLoanDamaged  = "LoanDamaged"
+	EventLoanFound    = "LoanFound"
+
+	EventHoldPlaced    = "HoldPlaced"
+	EventHoldReady     = "HoldReady"
+	EventHoldFulfilled = "HoldFulfilled"
+	EventHoldCancelled = "HoldCancelled"
+	EventHoldExpired   = "HoldExpired"
+
+	EventFineCharged  = "FineCharged"
+	EventFinePaid     = "FinePaid"
+	EventFineWaived   = "FineWaived"
+	EventFineRefunded = "FineRefunded"
+
+	EventBorrowerBlocked   = "BorrowerBlocked"
+	EventBorrowerUnblocked = "BorrowerUnblocked"
+)
+
+// OutboxEvent is a domain event stored in the same database transaction as
+// the change that caused it. IDs are handed out as events are written, not
+// as they commit, so consumers resume from Position instead: the relay
+// numbers committed events one after the other.
+type OutboxEvent struct {
+	ID            uint64     `gorm:"primaryKey;autoIncrement"`
+	Position      uint64     `gorm:"->"`
+	EventID       string     `gorm:"uniqueIndex;size:36;not null"`
+	AggregateType string     `gorm:"not null"`
+	AggregateID   string     `gorm:"not null;index"`
+	Type          string     `gorm:"not null;index"`
+	Payload       string     `gorm:"type:jsonb;not null"`
+	CreatedAt     time.Time  `gorm:"not null"`
+	PublishedAt   *time.Time `gorm:"index"`
+}
+
+// NewOutboxEvent creates a new outbox event with payload encoded as JSON
+func NewOutboxEvent(eventType, aggregateType, aggregateID string, payload interface{}) (*OutboxEvent, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OutboxEvent{
+		EventID:       uuid.New().String(),
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Type:          eventType,
+		Payload:       string(data),
+		CreatedAt:     time.Now(),
+	}, nil
+}
+
+// ToProto converts the outbox event to a protobuf event
+func (e *OutboxEvent) ToProto() *pb.Event {
+	return &pb.Event{
+		Cursor:        e.Position,
+		EventId:       e.EventID,
+		AggregateType: e.AggregateType,
+		AggregateId:   e.AggregateID,
+		Type:          e.Type,
+		Payload:       e.Payload,
+		CreatedAt:     e.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SagaType identifies the workflow a saga coordinates
+type SagaType string
+
+const (
+	// SagaTypeBorrow opens a loan, or restores a deleted one, and reserves a
+	// copy in the book service
+	SagaTypeBorrow SagaType = "borrow"
+	// SagaTypeReturn closes a loan and releases the copy in the book service
+	SagaTypeReturn SagaType = "return"
+	// SagaTypeWriteOff closes a loan as lost or damaged and writes the copy
+	// off in the book service
+	SagaTypeWriteOff SagaType = "write_off"
+	// SagaTypeFound marks a lost loan as found and puts the copy back into
+	// stock in the book service
+	SagaTypeFound SagaType = "found"
+)
+
+// SagaStep is the last step a saga has completed
+type SagaStep string
+
+const (
+	// SagaStepStarted means no step has completed yet
+	SagaStepStarted SagaStep = "started"
+	// SagaStepLoanCreated means the loan row has been written or restored
+	SagaStepLoanCreated SagaStep = "loan_created"
+	// SagaStepStockReserved means the book service has taken a copy out of
+	// stock, the one in CopyBarcode for books with copies
+	SagaStepStockReserved SagaStep = "stock_reserved"
+	// SagaStepLoanReturned means the loan has been marked as returned
+	SagaStepLoanReturned SagaStep = "loan_returned"
+	// SagaStepStockReleased means the book service has put the copy back into stock
+	SagaStepStockReleased SagaStep = "stock_released"
+	// SagaStepLoanClosed means the loan has been closed as lost or damaged
+	SagaStepLoanClosed SagaStep = "loan_closed"
+	// SagaStepStockWrittenOff means the book service has written the copy off
+	SagaStepStockWrittenOff SagaStep = "stock_written_off"
+	// SagaStepLoanFound means the lost loan has been marked as found
+	SagaStepLoanFound SagaStep = "loan_found"
+	// SagaStepStockRestored means the book service has put the lost copy
+	// back into stock
+	SagaStepStockRestored SagaStep = "stock_restored"
+)
+
+// SagaStatus is the overall state of a saga
+type SagaStatus string
+
+const (
+	// SagaStatusRunning means the saga is executing its forward steps
+	SagaStatusRunning SagaStatus = "running"
+	// SagaStatusCompensating means a step failed and compensations are running,
+	// or have to be retried
+	SagaStatusCompensating SagaStatus = "compensating"
+	// SagaStatusCompleted means every forward step succeeded
+	SagaStatusCompleted SagaStatus = "completed"
+	// SagaStatusCompensated means the completed steps were undone
+	SagaStatusCompensated SagaStatus = "compensated"
+)
+
+// Saga records the progress of a borrow, return or write-off that spans the transaction
+// and book services, so it can be compensated or recovered after a crash
+type Saga struct {
+	ID            string   `gorm:"primaryKey"`
+	Type          SagaType `gorm:"not null"`
+	TransactionID string   `gorm:"not null;index"`
+	UserID        string   `gorm:"not null"`
+	BookID        string   `gorm:"not null"`
+	CopyBarcode   string
+	Step          SagaStep   `gorm:"not null"`
+	Status        SagaStatus `gorm:"not null;index"`
+	LastError     string
+	CreatedAt     time.Time `gorm:"not null"`
+	UpdatedAt     time.Time `gorm:"not null"`
+}
+
+// NewSaga creates a new running saga for the given loan
+func NewSaga(sagaType SagaType, transactionID, userID, bookID string) *Saga {
+	now := time.Now()
+	return &Saga{
+		ID:            uuid.New().String(),
+		Type:          sagaType,
+		TransactionID: transactionID,
+		UserID:        userID,
+		BookID:        bookID,
+		Step:          SagaStepStarted,
+		Status:        SagaStatusRunning,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// Advance records that step has completed
+func (s *Saga) Advance(step SagaStep) {
+	s.Step = step
+	s.UpdatedAt = time.Now()
+}
+
+// SetStatus moves the saga to status, remembering the error that caused it if any
+func (s *Saga) SetStatus(status SagaStatus, err error) {
+	s.Status = status
+	if err != nil {
+		s.LastError = err.Error()
+	}
+	s.UpdatedAt = time.Now()
+}
+
+// IsFinished checks if the saga has reached a terminal status
+func (s *Saga) IsFinished() bool {
+	return s.Status == SagaStatusCompleted || s.Status == SagaStatusCompensated
+}
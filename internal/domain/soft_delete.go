@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DeletedCursor is the position of the last record of a page of
+// soft-deleted records, which are listed most recently deleted first
+type DeletedCursor struct {
+	DeletedAt time.Time `json:"d"`
+	ID        string    `json:"i"`
+}
+
+// Encode returns the opaque page token continuing after the cursor
+func (c DeletedCursor) Encode() (string, error) {
+	raw, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// DecodeDeletedCursor returns the cursor in a page token issued by Encode,
+// or false when the token is malformed
+func DecodeDeletedCursor(token string) (*DeletedCursor, bool) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, false
+	}
+	var cursor DeletedCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.ID == "" || cursor.DeletedAt.IsZero() {
+		return nil, false
+	}
+	return &cursor, true
+}
+
+// formatDeletedAt returns when a record was soft-deleted in RFC 3339, empty
+// for records that are not deleted
+func formatDeletedAt(deletedAt gorm.DeletedAt) string {
+	if !deletedAt.Valid {
+		return ""
+	}
+	return deletedAt.Time.Format(time.RFC3339)
+}
@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	pb "github.com/hinha/library-management-synapsis/gen/api/proto/book"
+)
+
+// StockReason is why a book's stock changed
+type StockReason string
+
+const (
+	// StockReasonAcquisition is a new book or copy coming into stock
+	StockReasonAcquisition StockReason = "acquisition"
+	// StockReasonLoan is a copy going out on loan
+	StockReasonLoan StockReason = "loan"
+	// StockReasonReturn is a copy coming back from a loan
+	StockReasonReturn StockReason = "return"
+	// StockReasonAdjustment is a correction made by an admin or an import
+	StockReasonAdjustment StockReason = "adjustment"
+	// StockReasonLoss is a copy reported lost
+	StockReasonLoss StockReason = "loss"
+	// StockReasonFound is a lost copy turning up again
+	StockReasonFound StockReason = "found"
+	// StockReasonWithdrawal is a copy taken out of circulation
+	StockReasonWithdrawal StockReason = "withdrawal"
+	// StockReasonOpening is the stock a book had before the ledger existed
+	StockReasonOpening StockReason = "opening"
+)
+
+// StockMovement is an append-only entry of a book's stock ledger. The deltas
+// of a book's movements add up to its stock. ID is a monotonically increasing
+// sequence that orders the movements.
+type StockMovement struct {
+	ID            uint64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	BookID        string      `gorm:"not null;index" json:"book_id"`
+	Delta         int32       `gorm:"not null" json:"delta"`
+	Reason        StockReason `gorm:"not null" json:"reason"`
+	ActorID       string      `gorm:"index" json:"actor_id,omitempty"`
+	CorrelationID string      `gorm:"index" json:"correlation_id,omitempty"`
+	Barcode       string      `json:"barcode,omitempty"`
+	CreatedAt     time.Time   `gorm:"not null" json:"created_at"`
+}
+
+// NewStockMovement creates a stock movement made on behalf of origin
+func NewStockMovement(bookID string, delta int32, reason StockReason, origin StockOrigin, barcode string) *StockMovement {
+	return &StockMovement{
+		BookID:        bookID,
+		Delta:         delta,
+		Reason:        reason,
+		ActorID:       origin.ActorID,
+		CorrelationID: origin.CorrelationID,
+		Barcode:       barcode,
+		CreatedAt:     time.Now(),
+	}
+}
+
+// ToProto converts the stock movement to a protobuf stock movement
+func (m *StockMovement) ToProto() *pb.StockMovement {
+	return &pb.StockMovement{
+		Id:            strconv.FormatUint(m.ID, 10),
+		BookId:        m.BookID,
+		Delta:         m.Delta,
+		Reason:        string(m.Reason),
+		ActorId:       m.ActorID,
+		CorrelationId: m.CorrelationID,
+		Barcode:       m.Barcode,
+		CreatedAt:     m.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// StockDrift is a book whose stock differs from the sum of its stock ledger
+type StockDrift struct {
+	BookID      string
+	Title       string
+	Stock       int32
+	LedgerStock int32
+}
+
+// Drift returns how far the book's stock is off its ledger
+func (d *StockDrift) Drift() int32 {
+	return d.Stock - d.LedgerStock
+}
+
+// ToProto converts the drift to a protobuf stock drift
+func (d *StockDrift) ToProto() *pb.StockDrift {
+	return &pb.StockDrift{
+		BookId:      d.BookID,
+		Title:       d.Title,
+		Stock:       d.Stock,
+		LedgerStock: d.LedgerStock,
+		Drift:       d.Drift(),
+	}
+}
+
+// StockOrigin is who changes a book's stock and what the change belongs to,
+// such as the loan it was made for. It travels in the context so every stock
+// movement written during a call records it.
+type StockOrigin struct {
+	ActorID       string
+	CorrelationID string
+}
+
+type stockOriginKey struct{}
+
+// WithStockOrigin returns a copy of ctx carrying origin
+func WithStockOrigin(ctx context.Context, origin StockOrigin) context.Context {
+	return context.WithValue(ctx, stockOriginKey{}, origin)
+}
+
+// StockOriginFromContext returns the origin set by WithStockOrigin, or the
+// zero origin for changes the service makes itself
+func StockOriginFromContext(ctx context.Context) StockOrigin {
+	origin, _ := ctx.Value(stockOriginKey{}).(StockOrigin)
+	return origin
+}
@@ -0,0 +1,53 @@
+package domain
+
+import "time"
+
+// ReservationStatus is what became of the stock a loan took
+type ReservationStatus string
+
+const (
+	// ReservationReserved means the loan has the copy
+	ReservationReserved ReservationStatus = "reserved"
+	// ReservationReleased means the copy was returned
+	ReservationReleased ReservationStatus = "released"
+	// ReservationCancelled means the borrow was undone, possibly before the
+	// reservation arrived, which is then refused
+	ReservationCancelled ReservationStatus = "cancelled"
+	// ReservationWrittenOff means the patron lost or damaged the copy, which
+	// was taken out of circulation
+	ReservationWrittenOff ReservationStatus = "written_off"
+	// ReservationRestored means the lost copy turned up and is back in stock
+	ReservationRestored ReservationStatus = "restored"
+)
+
+// StockReservation is the stock a loan of the transaction service took from
+// a book. It is keyed by the loan's transaction ID, so the transaction
+// service can retry reserving, releasing, writing off and restoring it
+// without counting twice.
+type StockReservation struct {
+	TransactionID string            `gorm:"primaryKey" json:"transaction_id"`
+	BookID        string            `gorm:"not null;index" json:"book_id"`
+	Barcode       string            `gorm:"size:64" json:"barcode,omitempty"`
+	Status        ReservationStatus `gorm:"not null" json:"status"`
+	CreatedAt     time.Time         `gorm:"not null" json:"created_at"`
+	UpdatedAt     time.Time         `gorm:"not null" json:"updated_at"`
+}
+
+// NewStockReservation creates a reservation of the loan transactionID in status
+func NewStockReservation(transactionID, bookID, barcode string, status ReservationStatus) *StockReservation {
+	now := time.Now()
+	return &StockReservation{
+		TransactionID: transactionID,
+		BookID:        bookID,
+		Barcode:       barcode,
+		Status:        status,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// SetStatus moves the reservation to status
+func (r *StockReservation) SetStatus(status ReservationStatus) {
+	r.Status = status
+	r.UpdatedAt = time.Now()
+}
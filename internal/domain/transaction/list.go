@@ -0,0 +1,57 @@
+package transaction
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+)
+
+// The listing types live in the domain package so repository mocks can use them
+type (
+	ListFilter = domain.TransactionFilter
+	Cursor     = domain.TransactionCursor
+	ListQuery  = domain.TransactionQuery
+)
+
+// pageToken is what an opaque page token decodes to. The filter is kept so a
+// token cannot be replayed against a different listing.
+type pageToken struct {
+	Filter ListFilter `json:"f"`
+	Cursor Cursor     `json:"c"`
+}
+
+// encodePageToken returns the page token continuing filter after transaction
+func encodePageToken(filter ListFilter, transaction *domain.Transaction) (string, error) {
+	raw, err := json.Marshal(pageToken{Filter: filter, Cursor: Cursor{BorrowedAt: transaction.BorrowedAt, ID: transaction.ID}})
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// decodePageToken returns the cursor in token, checking it was issued for filter
+func decodePageToken(token string, filter ListFilter) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	var decoded pageToken
+	if err := json.Unmarshal(raw, &decoded); err != nil || decoded.Cursor.ID == "" {
+		return nil, ErrInvalidPageToken
+	}
+
+	// Compare the encoded forms, times lose their location in JSON
+	want, err := json.Marshal(filter)
+	if err != nil {
+		return nil, err
+	}
+	got, err := json.Marshal(decoded.Filter)
+	if err != nil {
+		return nil, err
+	}
+	if string(want) != string(got) {
+		return nil, ErrInvalidPageToken
+	}
+
+	return &decoded.Cursor, nil
+}
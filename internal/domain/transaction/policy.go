@@ -0,0 +1,104 @@
+package transaction
+
+import (
+	"time"
+
+	"github.com/hinha/library-management-synapsis/internal/domain"
+)
+
+// LoanPolicy decides how long a book may be borrowed for
+type LoanPolicy struct {
+	// DefaultPeriod applies when neither the category nor the role has a period
+	DefaultPeriod time.Duration
+	// CategoryPeriods sets the loan period for books of a category
+	CategoryPeriods map[string]time.Duration
+	// RolePeriods sets the loan period for users of a role
+	RolePeriods map[domain.Role]time.Duration
+	// MaxRenewals is how many times a loan may be renewed
+	MaxRenewals int32
+	// RenewalGrace is how long past its due date a loan may still be renewed
+	RenewalGrace time.Duration
+	// HoldPickupPeriod is how long a copy stays set aside for a ready hold
+	HoldPickupPeriod time.Duration
+	// Fines decides what an overdue return costs
+	Fines FinePolicy
+	// DefaultLimits applies to users whose role has no limits of its own
+	DefaultLimits BorrowLimits
+	// RoleLimits sets the borrowing limits for users of a role
+	RoleLimits map[domain.Role]BorrowLimits
+}
+
+// BorrowLimits caps what a user may have on loan at once. A zero maximum
+// means unlimited.
+type BorrowLimits struct {
+	// MaxActiveLoans is how many books the user may have on loan
+	MaxActiveLoans int32
+	// MaxCopiesPerTitle is how many copies of one book the user may have on loan
+	MaxCopiesPerTitle int32
+	// AllowOverdue lets the user borrow while holding overdue loans
+	AllowOverdue bool
+}
+
+// LoanPeriod returns the loan period for a book of category borrowed by a
+// user with role. A category period wins over a role period, so short loan
+// collections stay short for everyone.
+func (p LoanPolicy) LoanPeriod(category string, role domain.Role) time.Duration {
+	if period, ok := p.CategoryPeriods[category]; ok {
+		return period
+	}
+	if period, ok := p.RolePeriods[role]; ok {
+		return period
+	}
+	return p.DefaultPeriod
+}
+
+// Limits returns the borrowing limits for a user with role
+func (p LoanPolicy) Limits(role domain.Role) BorrowLimits {
+	if limits, ok := p.RoleLimits[role]; ok {
+		return limits
+	}
+	return p.DefaultLimits
+}
+
+// FinePolicy decides the late fee charged when an overdue loan is returned.
+// Amounts are in minor currency units.
+type FinePolicy struct {
+	// PerDay is charged for each day late when the category has no rate
+	PerDay int64
+	// CategoryPerDay sets the daily rate for books of a category
+	CategoryPerDay map[string]int64
+	// Max caps the fee charged for a single loan; zero means uncapped
+	Max int64
+	// BorrowLimit is the highest outstanding balance a user may borrow with
+	BorrowLimit int64
+	// Replacement is charged for a lost or damaged book when the category
+	// has no fee
+	Replacement int64
+	// CategoryReplacement sets the replacement fee for books of a category
+	CategoryReplacement map[string]int64
+}
+
+// Fine returns the late fee for a book of category returned daysLate days late
+func (p FinePolicy) Fine(category string, daysLate int32) int64 {
+	if daysLate <= 0 {
+		return 0
+	}
+
+	rate, ok := p.CategoryPerDay[category]
+	if !ok {
+		rate = p.PerDay
+	}
+	fine := rate * int64(daysLate)
+	if p.Max > 0 && fine > p.Max {
+		return p.Max
+	}
+	return fine
+}
+
+// ReplacementFee returns what a lost or damaged book of category costs
+func (p FinePolicy) ReplacementFee(category string) int64 {
+	if fee, ok := p.CategoryReplacement[category]; ok {
+		return fee
+	}
+	return p.Replacement
+}
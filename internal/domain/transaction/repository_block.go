@@ -0,0 +1,76 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// ErrBlockNotFound is returned when a user has no borrowing block
+var ErrBlockNotFound = errors.New("borrower block not found")
+
+// IBlockRepository defines the interface for borrower block data access
+//
+//go:generate mockery --name=IBlockRepository --output=mocks --outpkg=mocks
+type IBlockRepository interface {
+	Save(ctx context.Context, block *domain.BorrowerBlock) error
+	Get(ctx context.Context, userID string) (*domain.BorrowerBlock, error)
+	Delete(ctx context.Context, userID string) error
+}
+
+// BlockRepository implements IBlockRepository using GORM
+type BlockRepository struct {
+	db *gorm.DB
+}
+
+// NewBlockRepository creates a new BlockRepository
+func NewBlockRepository(db *gorm.DB) IBlockRepository {
+	return &BlockRepository{db: db}
+}
+
+// Save stores a block, replacing any existing block on the user
+func (r *BlockRepository) Save(ctx context.Context, block *domain.BorrowerBlock) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(block).Error; err != nil {
+			return err
+		}
+		return writeBlockEvent(tx, domain.EventBorrowerBlocked, block)
+	})
+}
+
+// Get retrieves the block on a user, whether or not it has expired
+func (r *BlockRepository) Get(ctx context.Context, userID string) (*domain.BorrowerBlock, error) {
+	var block domain.BorrowerBlock
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&block).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBlockNotFound
+		}
+		return nil, err
+	}
+	return &block, nil
+}
+
+// Delete lifts the block on a user
+func (r *BlockRepository) Delete(ctx context.Context, userID string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("user_id = ?", userID).Delete(&domain.BorrowerBlock{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrBlockNotFound
+		}
+		return writeBlockEvent(tx, domain.EventBorrowerUnblocked, &domain.BorrowerBlock{UserID: userID})
+	})
+}
+
+// writeBlockEvent appends a borrower block event to the outbox inside tx
+func writeBlockEvent(tx *gorm.DB, eventType string, block *domain.BorrowerBlock) error {
+	event, err := domain.NewOutboxEvent(eventType, domain.AggregateBorrower, block.UserID, block)
+	if err != nil {
+		return err
+	}
+	return tx.Create(event).Error
+}
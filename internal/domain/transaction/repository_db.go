@@ -3,9 +3,13 @@ package transaction
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/hinha/library-management-synapsis/internal/domain"
+	"hash/fnv"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var (
@@ -13,14 +17,42 @@ var (
 	ErrTransactionNotFound = errors.New("transaction not found")
 	// ErrAlreadyReturned is returned when a book has already been returned
 	ErrAlreadyReturned = errors.New("book already returned")
+	// ErrConcurrentUpdate is returned when a transaction changed while it was being updated
+	ErrConcurrentUpdate = errors.New("transaction was modified concurrently")
+	// ErrNotLost is returned when a loan marked as found was not closed as lost
+	ErrNotLost = errors.New("loan is not lost")
 )
 
+// loanEvents maps the status a closed loan takes onto the event recording it
+var loanEvents = map[domain.LoanStatus]string{
+	domain.LoanStatusLost:    domain.EventLoanLost,
+	domain.LoanStatusDamaged: domain.EventLoanDamaged,
+	domain.LoanStatusFound:   domain.EventLoanFound,
+}
+
 // IDbRepository defines the interface for transaction data access
+//
+//go:generate mockery --name=IDbRepository --output=mocks --outpkg=mocks
 type IDbRepository interface {
 	Create(ctx context.Context, transaction *domain.Transaction) error
 	GetByID(ctx context.Context, id string) (*domain.Transaction, error)
-	GetByUserID(ctx context.Context, userID string) ([]*domain.Transaction, error)
-	MarkAsReturned(ctx context.Context, id string) error
+	ListPage(ctx context.Context, query ListQuery) ([]*domain.Transaction, error)
+	ListOpenByUser(ctx context.Context, userID string) ([]*domain.Transaction, error)
+	LockBorrower(ctx context.Context, userID string) (unlock func(), err error)
+	ListOverdue(ctx context.Context, now time.Time) ([]*domain.Transaction, error)
+	GetOpenByBarcode(ctx context.Context, barcode string) (*domain.Transaction, error)
+	GetOpenByUserAndBook(ctx context.Context, userID, bookID string) (*domain.Transaction, error)
+	AssignCopy(ctx context.Context, id, barcode string) error
+	MarkAsReturned(ctx context.Context, id string, lateFee domain.LateFee) error
+	Close(ctx context.Context, transaction *domain.Transaction, note string) error
+	SetStatus(ctx context.Context, id string, from, to domain.LoanStatus, note string) error
+	Renew(ctx context.Context, transaction *domain.Transaction) error
+	ReopenLoan(ctx context.Context, id string) error
+	Delete(ctx context.Context, id string) error
+	ListDeleted(ctx context.Context, after *domain.DeletedCursor, limit int) ([]*domain.Transaction, error)
+	GetDeleted(ctx context.Context, id string) (*domain.Transaction, error)
+	Restore(ctx context.Context, transaction *domain.Transaction) error
+	Purge(ctx context.Context, before time.Time) (int64, error)
 	Ping(ctx context.Context) (err error)
 }
 
@@ -36,7 +68,12 @@ func NewGormRepository(db *gorm.DB) IDbRepository {
 
 // Create creates a new transaction
 func (r *DBRepository) Create(ctx context.Context, transaction *domain.Transaction) error {
-	return r.db.WithContext(ctx).Create(transaction).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(transaction).Error; err != nil {
+			return err
+		}
+		return writeEvent(tx, domain.EventLoanOpened, transaction.ID, transaction)
+	})
 }
 
 // GetByID retrieves a transaction by ID
@@ -51,28 +88,342 @@ func (r *DBRepository) GetByID(ctx context.Context, id string) (*domain.Transact
 	return &transaction, nil
 }
 
-// GetByUserID retrieves all transactions for a user
-func (r *DBRepository) GetByUserID(ctx context.Context, userID string) ([]*domain.Transaction, error) {
+// ListPage retrieves up to query.Limit transactions matching the filter,
+// sorted by borrowed_at and continuing after the cursor when it is set
+func (r *DBRepository) ListPage(ctx context.Context, query ListQuery) ([]*domain.Transaction, error) {
+	filter := query.Filter
+	db := r.db.WithContext(ctx).Model(&domain.Transaction{})
+	if filter.UserID != "" {
+		db = db.Where("user_id = ?", filter.UserID)
+	}
+	if filter.BookID != "" {
+		db = db.Where("book_id = ?", filter.BookID)
+	}
+	switch filter.Status {
+	case domain.TransactionStatusActive:
+		db = db.Where("returned_at IS NULL")
+	case domain.TransactionStatusReturned:
+		db = db.Where("returned_at IS NOT NULL AND status NOT IN ?", []domain.LoanStatus{domain.LoanStatusLost, domain.LoanStatusDamaged, domain.LoanStatusFound})
+	case domain.TransactionStatusLost:
+		db = db.Where("status = ?", domain.LoanStatusLost)
+	case domain.TransactionStatusDamaged:
+		db = db.Where("status = ?", domain.LoanStatusDamaged)
+	case domain.TransactionStatusFound:
+		db = db.Where("status = ?", domain.LoanStatusFound)
+	case domain.TransactionStatusOverdue:
+		db = db.Where("returned_at IS NULL AND due_at < ?", query.Now)
+	}
+	if filter.BorrowedAfter != nil {
+		db = db.Where("borrowed_at >= ?", *filter.BorrowedAfter)
+	}
+	if filter.BorrowedBefore != nil {
+		db = db.Where("borrowed_at < ?", *filter.BorrowedBefore)
+	}
+
+	direction, comparison := "DESC", "<"
+	if filter.Ascending {
+		direction, comparison = "ASC", ">"
+	}
+	if query.After != nil {
+		// Row comparison keeps the keyset stable when borrow times repeat
+		db = db.Where(fmt.Sprintf("(borrowed_at, id) %s (?, ?)", comparison), query.After.BorrowedAt, query.After.ID)
+	}
+
 	var transactions []*domain.Transaction
-	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Find(&transactions).Error; err != nil {
+	if err := db.
+		Order(fmt.Sprintf("borrowed_at %s, id %s", direction, direction)).
+		Limit(query.Limit).
+		Find(&transactions).Error; err != nil {
 		return nil, err
 	}
 	return transactions, nil
 }
 
-// MarkAsReturned marks a transaction as returned
-func (r *DBRepository) MarkAsReturned(ctx context.Context, id string) error {
-	transaction, err := r.GetByID(ctx, id)
-	if err != nil {
-		return err
+// ListOpenByUser retrieves the transactions a user has not returned yet
+func (r *DBRepository) ListOpenByUser(ctx context.Context, userID string) ([]*domain.Transaction, error) {
+	var transactions []*domain.Transaction
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND returned_at IS NULL", userID).
+		Find(&transactions).Error; err != nil {
+		return nil, err
 	}
+	return transactions, nil
+}
 
-	if transaction.IsReturned() {
-		return ErrAlreadyReturned
+// LockBorrower takes an advisory lock on the loans of userID, held by a
+// database transaction of its own until unlock is called. Borrows check the
+// user's limits and open their loans under it, so concurrent borrows of one
+// user cannot together take them past the limits.
+func (r *DBRepository) LockBorrower(ctx context.Context, userID string) (func(), error) {
+	tx := r.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
 	}
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", borrowerLock(userID)).Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	return func() { tx.Rollback() }, nil
+}
+
+// borrowerLock returns the advisory lock borrows of userID hold
+func borrowerLock(userID string) int64 {
+	hash := fnv.New64a()
+	hash.Write([]byte("transactions:borrower:" + userID))
+	return int64(hash.Sum64())
+}
 
-	transaction.MarkAsReturned()
-	return r.db.WithContext(ctx).Save(transaction).Error
+// ListOverdue retrieves open transactions due before now, oldest due date first
+func (r *DBRepository) ListOverdue(ctx context.Context, now time.Time) ([]*domain.Transaction, error) {
+	var transactions []*domain.Transaction
+	if err := r.db.WithContext(ctx).
+		Where("returned_at IS NULL AND due_at < ?", now).
+		Order("due_at").
+		Find(&transactions).Error; err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
+// GetOpenByBarcode retrieves the open transaction of the copy with the barcode
+func (r *DBRepository) GetOpenByBarcode(ctx context.Context, barcode string) (*domain.Transaction, error) {
+	var transaction domain.Transaction
+	if err := r.db.WithContext(ctx).
+		Where("copy_barcode = ? AND returned_at IS NULL", barcode).
+		First(&transaction).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTransactionNotFound
+		}
+		return nil, err
+	}
+	return &transaction, nil
+}
+
+// GetOpenByUserAndBook retrieves the oldest open transaction of a user for a book
+func (r *DBRepository) GetOpenByUserAndBook(ctx context.Context, userID, bookID string) (*domain.Transaction, error) {
+	var transaction domain.Transaction
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND book_id = ? AND returned_at IS NULL", userID, bookID).
+		Order("borrowed_at").
+		First(&transaction).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTransactionNotFound
+		}
+		return nil, err
+	}
+	return &transaction, nil
+}
+
+// AssignCopy records the barcode of the copy a transaction's borrower has
+func (r *DBRepository) AssignCopy(ctx context.Context, id, barcode string) error {
+	result := r.db.WithContext(ctx).
+		Model(&domain.Transaction{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"copy_barcode": barcode,
+			"updated_at":   time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTransactionNotFound
+	}
+	return nil
+}
+
+// MarkAsReturned marks a transaction as returned. The late fee lateFee works
+// out, if any, is charged in the same database transaction.
+func (r *DBRepository) MarkAsReturned(ctx context.Context, id string, lateFee domain.LateFee) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var transaction domain.Transaction
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&transaction).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrTransactionNotFound
+			}
+			return err
+		}
+		if transaction.IsReturned() {
+			return ErrAlreadyReturned
+		}
+
+		transaction.MarkAsReturned()
+		if err := tx.Save(&transaction).Error; err != nil {
+			return err
+		}
+		if lateFee != nil {
+			if charge := lateFee(&transaction); charge != nil {
+				if err := writeLedgerEntry(tx, charge); err != nil {
+					return err
+				}
+			}
+		}
+		return writeEvent(tx, domain.EventLoanReturned, transaction.ID, &transaction)
+	})
+}
+
+// Renew saves the new due date and renewal count of an open transaction.
+// It fails with ErrConcurrentUpdate if the loan was returned or renewed since
+// it was read.
+func (r *DBRepository) Renew(ctx context.Context, transaction *domain.Transaction) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Transaction{}).
+			Where("id = ? AND returned_at IS NULL AND renewal_count = ?", transaction.ID, transaction.RenewalCount-1).
+			Updates(map[string]interface{}{
+				"due_at":        transaction.DueAt,
+				"renewal_count": transaction.RenewalCount,
+				"updated_at":    transaction.UpdatedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrConcurrentUpdate
+		}
+		return writeEvent(tx, domain.EventLoanRenewed, transaction.ID, transaction)
+	})
+}
+
+// Close saves an open transaction closed as lost or damaged, with its
+// replacement fee, and charges the fee to the borrower with note in the same
+// database transaction. It fails with ErrAlreadyReturned if the loan was
+// closed since it was read.
+func (r *DBRepository) Close(ctx context.Context, transaction *domain.Transaction, note string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Transaction{}).
+			Where("id = ? AND returned_at IS NULL", transaction.ID).
+			Updates(map[string]interface{}{
+				"returned_at":     transaction.ReturnedAt,
+				"status":          transaction.Status,
+				"replacement_fee": transaction.ReplacementFee,
+				"updated_at":      transaction.UpdatedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrAlreadyReturned
+		}
+		if transaction.ReplacementFee > 0 {
+			if err := writeLedgerEntry(tx, domain.NewFineCharge(transaction, transaction.ReplacementFee, note)); err != nil {
+				return err
+			}
+		}
+		return writeEvent(tx, loanEvents[transaction.Status], transaction.ID, transaction)
+	})
+}
+
+// SetStatus moves a closed transaction from one status to another, such as a
+// lost loan whose book was found. The replacement fee is refunded with note
+// when the loan becomes found, and charged again when it stops being found,
+// in the same database transaction. It fails with ErrNotLost if the loan
+// does not have the from status.
+func (r *DBRepository) SetStatus(ctx context.Context, id string, from, to domain.LoanStatus, note string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var transaction domain.Transaction
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ? AND status = ?", id, from).First(&transaction).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrNotLost
+			}
+			return err
+		}
+
+		transaction.Status = to
+		transaction.UpdatedAt = time.Now()
+		if err := tx.Model(&transaction).Updates(map[string]interface{}{
+			"status":     transaction.Status,
+			"updated_at": transaction.UpdatedAt,
+		}).Error; err != nil {
+			return err
+		}
+
+		if transaction.ReplacementFee > 0 {
+			var entry *domain.LedgerEntry
+			switch {
+			case to == domain.LoanStatusFound:
+				entry = domain.NewFineRefund(&transaction, transaction.ReplacementFee, note)
+			case from == domain.LoanStatusFound:
+				entry = domain.NewFineCharge(&transaction, transaction.ReplacementFee, note)
+			}
+			if entry != nil {
+				if err := writeLedgerEntry(tx, entry); err != nil {
+					return err
+				}
+			}
+		}
+		return writeEvent(tx, loanEvents[to], id, map[string]string{"id": id, "status": string(to)})
+	})
+}
+
+// ReopenLoan clears the return of a transaction, undoing MarkAsReturned or
+// Close. A late fee MarkAsReturned or a replacement fee Close charged is
+// refunded in the same database transaction.
+func (r *DBRepository) ReopenLoan(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var transaction domain.Transaction
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&transaction).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrTransactionNotFound
+			}
+			return err
+		}
+
+		if err := tx.Model(&transaction).Updates(map[string]interface{}{
+			"returned_at":     nil,
+			"status":          "",
+			"replacement_fee": 0,
+			"updated_at":      time.Now(),
+		}).Error; err != nil {
+			return err
+		}
+
+		switch {
+		case transaction.Status == "" && transaction.IsReturned():
+			var lateFee int64
+			if err := tx.Model(&domain.LedgerEntry{}).
+				Select("COALESCE(SUM(CASE WHEN type = ? THEN amount ELSE -amount END), 0)", domain.LedgerEntryCharge).
+				Where("transaction_id = ? AND type IN ?", id, []domain.LedgerEntryType{domain.LedgerEntryCharge, domain.LedgerEntryRefund}).
+				Scan(&lateFee).Error; err != nil {
+				return err
+			}
+			if lateFee > 0 {
+				refund := domain.NewFineRefund(&transaction, lateFee, "refund of late fee, loan reopened")
+				if err := writeLedgerEntry(tx, refund); err != nil {
+					return err
+				}
+			}
+		// A found loan had its fee refunded already
+		case transaction.ReplacementFee > 0 && transaction.Status != domain.LoanStatusFound:
+			refund := domain.NewFineRefund(&transaction, transaction.ReplacementFee, "refund of replacement fee, loan reopened")
+			if err := writeLedgerEntry(tx, refund); err != nil {
+				return err
+			}
+		}
+		return writeEvent(tx, domain.EventLoanReopened, id, map[string]string{"id": id})
+	})
+}
+
+// Delete voids a transaction
+func (r *DBRepository) Delete(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&domain.Transaction{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrTransactionNotFound
+		}
+		return writeEvent(tx, domain.EventLoanVoided, id, map[string]string{"id": id})
+	})
+}
+
+// writeEvent appends a transaction event to the outbox inside tx
+func writeEvent(tx *gorm.DB, eventType, transactionID string, payload interface{}) error {
+	event, err := domain.NewOutboxEvent(eventType, domain.AggregateTransaction, transactionID, payload)
+	if err != nil {
+		return err
+	}
+	return tx.Create(event).Error
 }
 
 func (r *DBRepository) Ping(ctx context.Context) (err error) {
@@ -0,0 +1,71 @@
+package transaction
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// newDbRepository returns a DBRepository on a mocked database
+func newDbRepository(t *testing.T) (*DBRepository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+	return &DBRepository{db: gdb}, mock
+}
+
+func TestDBRepository_ListPage_Status(t *testing.T) {
+	now := time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		status    string
+		wantWhere string
+		wantArgs  []driver.Value
+	}{
+		{status: domain.TransactionStatusActive, wantWhere: `returned_at IS NULL`},
+		{
+			status:    domain.TransactionStatusReturned,
+			wantWhere: `(returned_at IS NOT NULL AND status NOT IN ($2,$3,$4))`,
+			wantArgs:  []driver.Value{domain.LoanStatusLost, domain.LoanStatusDamaged, domain.LoanStatusFound},
+		},
+		{status: domain.TransactionStatusOverdue, wantWhere: `(returned_at IS NULL AND due_at < $2)`, wantArgs: []driver.Value{now}},
+		{status: domain.TransactionStatusLost, wantWhere: `status = $2`, wantArgs: []driver.Value{domain.LoanStatusLost}},
+		{status: domain.TransactionStatusDamaged, wantWhere: `status = $2`, wantArgs: []driver.Value{domain.LoanStatusDamaged}},
+		{status: domain.TransactionStatusFound, wantWhere: `status = $2`, wantArgs: []driver.Value{domain.LoanStatusFound}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			repo, mock := newDbRepository(t)
+			args := append([]driver.Value{"1"}, tt.wantArgs...)
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "transactions" WHERE user_id = $1 AND ` + tt.wantWhere + ` AND "transactions"."deleted_at" IS NULL ORDER BY borrowed_at DESC, id DESC LIMIT $`)).
+				WithArgs(append(args, 20)...).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow("tx-1", ""))
+
+			got, err := repo.ListPage(context.Background(), ListQuery{
+				Filter: ListFilter{UserID: "1", Status: tt.status},
+				Limit:  20,
+				Now:    now,
+			})
+
+			assert.NoError(t, err)
+			assert.Len(t, got, 1)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
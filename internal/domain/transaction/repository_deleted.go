@@ -0,0 +1,71 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ListDeleted retrieves up to limit soft-deleted transactions, most recently
+// deleted first, continuing after the cursor when it is set
+func (r *DBRepository) ListDeleted(ctx context.Context, after *domain.DeletedCursor, limit int) ([]*domain.Transaction, error) {
+	db := r.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL")
+	if after != nil {
+		db = db.Where("(deleted_at, id) < (?, ?)", after.DeletedAt, after.ID)
+	}
+
+	var transactions []*domain.Transaction
+	if err := db.Order("deleted_at DESC, id DESC").Limit(limit).Find(&transactions).Error; err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
+// GetDeleted retrieves a soft-deleted transaction by ID
+func (r *DBRepository) GetDeleted(ctx context.Context, id string) (*domain.Transaction, error) {
+	var transaction domain.Transaction
+	if err := r.db.WithContext(ctx).Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&transaction).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTransactionNotFound
+		}
+		return nil, err
+	}
+	return &transaction, nil
+}
+
+// Restore brings a soft-deleted transaction back with the copy barcode it
+// is given. It returns ErrTransactionNotFound when the transaction is not
+// deleted (anymore).
+func (r *DBRepository) Restore(ctx context.Context, transaction *domain.Transaction) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		transaction.DeletedAt = gorm.DeletedAt{}
+		transaction.UpdatedAt = time.Now()
+		result := tx.Unscoped().Model(&domain.Transaction{}).
+			Where("id = ? AND deleted_at IS NOT NULL", transaction.ID).
+			Updates(map[string]interface{}{
+				"deleted_at":   nil,
+				"copy_barcode": transaction.CopyBarcode,
+				"updated_at":   transaction.UpdatedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrTransactionNotFound
+		}
+		return writeEvent(tx, domain.EventLoanRestored, transaction.ID, transaction)
+	})
+}
+
+// Purge permanently removes the transactions soft-deleted before the given
+// time. It returns how many transactions were removed.
+func (r *DBRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Unscoped().Where("deleted_at < ?", before).Delete(&domain.Transaction{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
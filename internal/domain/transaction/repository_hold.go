@@ -0,0 +1,124 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrHoldNotFound is returned when a hold is not found
+var ErrHoldNotFound = errors.New("hold not found")
+
+// activeHoldStatuses are the statuses of holds still in a book's queue
+var activeHoldStatuses = []domain.HoldStatus{domain.HoldStatusWaiting, domain.HoldStatusReady}
+
+// holdEvents maps the status a hold moves to onto the event recording it
+var holdEvents = map[domain.HoldStatus]string{
+	domain.HoldStatusWaiting:   domain.EventHoldPlaced,
+	domain.HoldStatusReady:     domain.EventHoldReady,
+	domain.HoldStatusFulfilled: domain.EventHoldFulfilled,
+	domain.HoldStatusCancelled: domain.EventHoldCancelled,
+	domain.HoldStatusExpired:   domain.EventHoldExpired,
+}
+
+// IHoldRepository defines the interface for hold data access
+//
+//go:generate mockery --name=IHoldRepository --output=mocks --outpkg=mocks
+type IHoldRepository interface {
+	Create(ctx context.Context, hold *domain.Hold) error
+	Update(ctx context.Context, hold *domain.Hold) error
+	GetByID(ctx context.Context, id string) (*domain.Hold, error)
+	ListByUser(ctx context.Context, userID string) ([]*domain.Hold, error)
+	ListByBook(ctx context.Context, bookID string) ([]*domain.Hold, error)
+	ListExpired(ctx context.Context, now time.Time) ([]*domain.Hold, error)
+}
+
+// HoldRepository implements IHoldRepository using GORM
+type HoldRepository struct {
+	db *gorm.DB
+}
+
+// NewHoldRepository creates a new HoldRepository
+func NewHoldRepository(db *gorm.DB) IHoldRepository {
+	return &HoldRepository{db: db}
+}
+
+// Create stores a new hold
+func (r *HoldRepository) Create(ctx context.Context, hold *domain.Hold) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(hold).Error; err != nil {
+			return err
+		}
+		return writeHoldEvent(tx, hold)
+	})
+}
+
+// Update stores the current status of a hold
+func (r *HoldRepository) Update(ctx context.Context, hold *domain.Hold) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(hold).Error; err != nil {
+			return err
+		}
+		return writeHoldEvent(tx, hold)
+	})
+}
+
+// GetByID retrieves a hold by ID
+func (r *HoldRepository) GetByID(ctx context.Context, id string) (*domain.Hold, error) {
+	var hold domain.Hold
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&hold).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrHoldNotFound
+		}
+		return nil, err
+	}
+	return &hold, nil
+}
+
+// ListByUser retrieves the waiting and ready holds of a user, oldest first
+func (r *HoldRepository) ListByUser(ctx context.Context, userID string) ([]*domain.Hold, error) {
+	var holds []*domain.Hold
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND status IN ?", userID, activeHoldStatuses).
+		Order("created_at, id").
+		Find(&holds).Error; err != nil {
+		return nil, err
+	}
+	return holds, nil
+}
+
+// ListByBook retrieves the waiting and ready holds on a book in queue order
+func (r *HoldRepository) ListByBook(ctx context.Context, bookID string) ([]*domain.Hold, error) {
+	var holds []*domain.Hold
+	if err := r.db.WithContext(ctx).
+		Where("book_id = ? AND status IN ?", bookID, activeHoldStatuses).
+		Order("created_at, id").
+		Find(&holds).Error; err != nil {
+		return nil, err
+	}
+	return holds, nil
+}
+
+// ListExpired retrieves ready holds whose pickup deadline passed before now
+func (r *HoldRepository) ListExpired(ctx context.Context, now time.Time) ([]*domain.Hold, error) {
+	var holds []*domain.Hold
+	if err := r.db.WithContext(ctx).
+		Where("status = ? AND expires_at < ?", domain.HoldStatusReady, now).
+		Order("expires_at").
+		Find(&holds).Error; err != nil {
+		return nil, err
+	}
+	return holds, nil
+}
+
+// writeHoldEvent appends the event for the hold's current status to the outbox inside tx
+func writeHoldEvent(tx *gorm.DB, hold *domain.Hold) error {
+	event, err := domain.NewOutboxEvent(holdEvents[hold.Status], domain.AggregateHold, hold.ID, hold)
+	if err != nil {
+		return err
+	}
+	return tx.Create(event).Error
+}
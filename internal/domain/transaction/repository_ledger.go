@@ -0,0 +1,115 @@
+package transaction
+
+import (
+	"context"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"hash/fnv"
+
+	"gorm.io/gorm"
+)
+
+// ledgerEvents maps the type of a ledger entry onto the event recording it
+var ledgerEvents = map[domain.LedgerEntryType]string{
+	domain.LedgerEntryCharge:  domain.EventFineCharged,
+	domain.LedgerEntryPayment: domain.EventFinePaid,
+	domain.LedgerEntryWaiver:  domain.EventFineWaived,
+	domain.LedgerEntryRefund:  domain.EventFineRefunded,
+}
+
+// ILedgerRepository defines the interface for fines ledger data access
+//
+//go:generate mockery --name=ILedgerRepository --output=mocks --outpkg=mocks
+type ILedgerRepository interface {
+	Create(ctx context.Context, entry *domain.LedgerEntry) error
+	Settle(ctx context.Context, entry *domain.LedgerEntry) error
+	ListByUser(ctx context.Context, userID string) ([]*domain.LedgerEntry, error)
+	Balance(ctx context.Context, userID string) (int64, error)
+}
+
+// LedgerRepository implements ILedgerRepository using GORM
+type LedgerRepository struct {
+	db *gorm.DB
+}
+
+// NewLedgerRepository creates a new LedgerRepository
+func NewLedgerRepository(db *gorm.DB) ILedgerRepository {
+	return &LedgerRepository{db: db}
+}
+
+// Create appends an entry to a user's ledger. Entries are never changed
+// afterwards; a mistake is corrected with a further entry.
+func (r *LedgerRepository) Create(ctx context.Context, entry *domain.LedgerEntry) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return writeLedgerEntry(tx, entry)
+	})
+}
+
+// Settle appends a payment or waiver to a user's ledger, failing with
+// ErrExceedsBalance when it is larger than what the user owes. The balance is
+// read and the entry written under an advisory lock on the user's ledger, so
+// concurrent settlements cannot together take more than the balance.
+func (r *LedgerRepository) Settle(ctx context.Context, entry *domain.LedgerEntry) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", ledgerLock(entry.UserID)).Error; err != nil {
+			return err
+		}
+		balance, err := userBalance(tx, entry.UserID)
+		if err != nil {
+			return err
+		}
+		if entry.Amount > balance {
+			return ErrExceedsBalance
+		}
+		return writeLedgerEntry(tx, entry)
+	})
+}
+
+// ledgerLock returns the advisory lock settlements of userID hold
+func ledgerLock(userID string) int64 {
+	hash := fnv.New64a()
+	hash.Write([]byte("ledger_entries:" + userID))
+	return int64(hash.Sum64())
+}
+
+// writeLedgerEntry appends an entry to a user's ledger inside tx, along with
+// the event recording it
+func writeLedgerEntry(tx *gorm.DB, entry *domain.LedgerEntry) error {
+	if err := tx.Create(entry).Error; err != nil {
+		return err
+	}
+	event, err := domain.NewOutboxEvent(ledgerEvents[entry.Type], domain.AggregateLedger, entry.UserID, entry)
+	if err != nil {
+		return err
+	}
+	return tx.Create(event).Error
+}
+
+// ListByUser retrieves the ledger of a user, oldest first
+func (r *LedgerRepository) ListByUser(ctx context.Context, userID string) ([]*domain.LedgerEntry, error) {
+	var entries []*domain.LedgerEntry
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at, id").
+		Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Balance sums a user's charges less their payments, waivers and refunds
+func (r *LedgerRepository) Balance(ctx context.Context, userID string) (int64, error) {
+	return userBalance(r.db.WithContext(ctx), userID)
+}
+
+// userBalance sums the balance of userID inside tx
+func userBalance(tx *gorm.DB, userID string) (int64, error) {
+	var balance int64
+	if err := tx.
+		Model(&domain.LedgerEntry{}).
+		Select("COALESCE(SUM(CASE WHEN type = ? THEN amount ELSE -amount END), 0)", domain.LedgerEntryCharge).
+		Where("user_id = ?", userID).
+		Scan(&balance).Error; err != nil {
+		return 0, err
+	}
+	return balance, nil
+}
@@ -0,0 +1,71 @@
+package transaction
+
+import (
+	"context"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ISagaRepository defines the interface for saga state persistence
+//
+//go:generate mockery --name=ISagaRepository --output=mocks --outpkg=mocks
+type ISagaRepository interface {
+	Create(ctx context.Context, saga *domain.Saga) error
+	Update(ctx context.Context, saga *domain.Saga) error
+	ClaimStale(ctx context.Context, updatedBefore time.Time) ([]*domain.Saga, error)
+}
+
+// SagaRepository implements ISagaRepository using GORM
+type SagaRepository struct {
+	db *gorm.DB
+}
+
+// NewSagaRepository creates a new SagaRepository
+func NewSagaRepository(db *gorm.DB) ISagaRepository {
+	return &SagaRepository{db: db}
+}
+
+// Create stores a new saga
+func (r *SagaRepository) Create(ctx context.Context, saga *domain.Saga) error {
+	return r.db.WithContext(ctx).Create(saga).Error
+}
+
+// Update stores the current step and status of a saga
+func (r *SagaRepository) Update(ctx context.Context, saga *domain.Saga) error {
+	return r.db.WithContext(ctx).Save(saga).Error
+}
+
+// ClaimStale claims the sagas that are still running or compensating and
+// have not been touched since updatedBefore. Sagas another replica is
+// claiming are skipped, and the claimed ones are touched in the same
+// transaction, so they are not claimed again until they go stale once more.
+func (r *SagaRepository) ClaimStale(ctx context.Context, updatedBefore time.Time) ([]*domain.Saga, error) {
+	var sagas []*domain.Saga
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status IN ?", []domain.SagaStatus{domain.SagaStatusRunning, domain.SagaStatusCompensating}).
+			Where("updated_at < ?", updatedBefore).
+			Order("created_at").
+			Find(&sagas).Error; err != nil {
+			return err
+		}
+		if len(sagas) == 0 {
+			return nil
+		}
+
+		now := time.Now()
+		ids := make([]string, len(sagas))
+		for i, saga := range sagas {
+			ids[i] = saga.ID
+			saga.UpdatedAt = now
+		}
+		return tx.Model(&domain.Saga{}).Where("id IN ?", ids).Update("updated_at", now).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sagas, nil
+}
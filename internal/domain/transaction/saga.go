@@ -0,0 +1,397 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"github.com/rs/zerolog/log"
+	"time"
+)
+
+// ErrSagaAbandoned is recorded on sagas rolled back by recovery because the
+// service stopped before they could finish
+var ErrSagaAbandoned = errors.New("saga abandoned before completion")
+
+// SagaCoordinator runs borrows, returns and write-offs as sagas spanning the transaction
+// database and the book service. Every completed step is recorded, so a
+// failure can be compensated and a crash can be recovered later.
+type SagaCoordinator struct {
+	repoDb   IDbRepository
+	sagaRepo ISagaRepository
+	bookRepo BookRepository
+}
+
+// NewSagaCoordinator creates a new SagaCoordinator
+func NewSagaCoordinator(repoDb IDbRepository, sagaRepo ISagaRepository, bookRepo BookRepository) *SagaCoordinator {
+	return &SagaCoordinator{
+		repoDb:   repoDb,
+		sagaRepo: sagaRepo,
+		bookRepo: bookRepo,
+	}
+}
+
+// Borrow opens the loan and then reserves a copy in the book service: the
+// copy in transaction.CopyBarcode, or any copy when it is empty, in which case
+// the copy the book service picked is recorded on the loan. If the
+// reservation fails the loan is voided again.
+func (c *SagaCoordinator) Borrow(ctx context.Context, transaction *domain.Transaction) error {
+	return c.borrow(ctx, transaction, c.repoDb.Create, c.bookRepo.ReserveStock)
+}
+
+// Restore brings a soft-deleted open loan back and then takes its copy, or
+// any copy when it is empty, out of stock again in the book service. It runs
+// as a borrow: if the copy cannot be taken the loan is deleted again.
+func (c *SagaCoordinator) Restore(ctx context.Context, transaction *domain.Transaction) error {
+	return c.borrow(ctx, transaction, c.repoDb.Restore, c.bookRepo.ReopenStock)
+}
+
+// borrow runs a borrow saga, writing the loan with open and taking the copy
+// out of stock with reserve
+func (c *SagaCoordinator) borrow(
+	ctx context.Context,
+	transaction *domain.Transaction,
+	open func(ctx context.Context, transaction *domain.Transaction) error,
+	reserve func(ctx context.Context, id, barcode, transactionID string) (string, error),
+) error {
+	saga := domain.NewSaga(domain.SagaTypeBorrow, transaction.ID, transaction.UserID, transaction.BookID)
+	saga.CopyBarcode = transaction.CopyBarcode
+	if err := c.sagaRepo.Create(ctx, saga); err != nil {
+		return err
+	}
+
+	if err := open(ctx, transaction); err != nil {
+		c.compensate(ctx, saga, err)
+		return err
+	}
+	if err := c.advance(ctx, saga, domain.SagaStepLoanCreated); err != nil {
+		c.compensate(ctx, saga, err)
+		return err
+	}
+
+	barcode, err := reserve(ctx, transaction.BookID, transaction.CopyBarcode, transaction.ID)
+	if err != nil {
+		c.compensate(ctx, saga, err)
+		return err
+	}
+	saga.CopyBarcode = barcode
+	if err := c.advance(ctx, saga, domain.SagaStepStockReserved); err != nil {
+		c.compensate(ctx, saga, err)
+		return err
+	}
+
+	if barcode != transaction.CopyBarcode {
+		if err := c.repoDb.AssignCopy(ctx, transaction.ID, barcode); err != nil {
+			c.compensate(ctx, saga, err)
+			return err
+		}
+		transaction.CopyBarcode = barcode
+	}
+
+	c.complete(ctx, saga)
+	return nil
+}
+
+// Return closes the loan, charging the late fee lateFee works out in the same
+// database transaction, and then releases the copy in the book service.
+// Once the loan is closed the return goes through: a failed release is
+// recorded on the saga and retried by recovery, the release being keyed by
+// the loan.
+func (c *SagaCoordinator) Return(ctx context.Context, transaction *domain.Transaction, lateFee domain.LateFee) error {
+	saga := domain.NewSaga(domain.SagaTypeReturn, transaction.ID, transaction.UserID, transaction.BookID)
+	saga.CopyBarcode = transaction.CopyBarcode
+	if err := c.sagaRepo.Create(ctx, saga); err != nil {
+		return err
+	}
+
+	if err := c.repoDb.MarkAsReturned(ctx, transaction.ID, lateFee); err != nil {
+		c.compensate(ctx, saga, err)
+		return err
+	}
+	if err := c.advance(ctx, saga, domain.SagaStepLoanReturned); err != nil {
+		c.compensate(ctx, saga, err)
+		return err
+	}
+
+	if err := c.bookRepo.ReleaseStock(ctx, transaction.BookID, transaction.CopyBarcode, transaction.ID); err != nil {
+		log.Error().Err(err).Str("saga_id", saga.ID).Msg("Failed to release stock, leaving it to saga recovery")
+		saga.SetStatus(domain.SagaStatusRunning, err)
+		c.save(ctx, saga)
+		return nil
+	}
+	saga.Advance(domain.SagaStepStockReleased)
+
+	c.complete(ctx, saga)
+	return nil
+}
+
+// WriteOff closes the loan as lost or damaged, with the status and fee set on
+// transaction by Close and the fee charged with note, and then writes the
+// copy off in the book service. If the write-off fails the loan is reopened
+// again and the fee refunded; once it succeeded the copy is gone and the saga
+// can only complete.
+func (c *SagaCoordinator) WriteOff(ctx context.Context, transaction *domain.Transaction, note string) error {
+	saga := domain.NewSaga(domain.SagaTypeWriteOff, transaction.ID, transaction.UserID, transaction.BookID)
+	saga.CopyBarcode = transaction.CopyBarcode
+	if err := c.sagaRepo.Create(ctx, saga); err != nil {
+		return err
+	}
+
+	if err := c.repoDb.Close(ctx, transaction, note); err != nil {
+		c.compensate(ctx, saga, err)
+		return err
+	}
+	if err := c.advance(ctx, saga, domain.SagaStepLoanClosed); err != nil {
+		c.compensate(ctx, saga, err)
+		return err
+	}
+
+	damaged := transaction.Status == domain.LoanStatusDamaged
+	if err := c.bookRepo.WriteOffStock(ctx, transaction.BookID, transaction.CopyBarcode, transaction.ID, damaged); err != nil {
+		c.compensate(ctx, saga, err)
+		return err
+	}
+	saga.Advance(domain.SagaStepStockWrittenOff)
+
+	c.complete(ctx, saga)
+	return nil
+}
+
+// Found marks a lost loan as found, refunding its fee with note, and then
+// puts the copy back into stock in the book service. If that fails the loan
+// is marked as lost and charged again; once the copy is back the saga can
+// only complete.
+func (c *SagaCoordinator) Found(ctx context.Context, transaction *domain.Transaction, note string) error {
+	saga := domain.NewSaga(domain.SagaTypeFound, transaction.ID, transaction.UserID, transaction.BookID)
+	saga.CopyBarcode = transaction.CopyBarcode
+	if err := c.sagaRepo.Create(ctx, saga); err != nil {
+		return err
+	}
+
+	if err := c.repoDb.SetStatus(ctx, transaction.ID, domain.LoanStatusLost, domain.LoanStatusFound, note); err != nil {
+		c.compensate(ctx, saga, err)
+		return err
+	}
+	if err := c.advance(ctx, saga, domain.SagaStepLoanFound); err != nil {
+		c.compensate(ctx, saga, err)
+		return err
+	}
+
+	if err := c.bookRepo.RestoreStock(ctx, transaction.BookID, transaction.CopyBarcode, transaction.ID); err != nil {
+		c.compensate(ctx, saga, err)
+		return err
+	}
+	saga.Advance(domain.SagaStepStockRestored)
+
+	c.complete(ctx, saga)
+	return nil
+}
+
+// Recover finishes or rolls back sagas that have not been updated for
+// staleAfter, typically because the service stopped between two steps, or
+// a return could not release its copy. Each saga is claimed first, so
+// replicas recovering at the same time never handle the same one.
+// Borrows are only finished once the copy is known to be reserved, returns
+// are finished as soon as the loan is closed since the copy is back on the shelf.
+// Write-offs and found loans are likewise finished once the loan has changed.
+func (c *SagaCoordinator) Recover(ctx context.Context, staleAfter time.Duration) error {
+	sagas, err := c.sagaRepo.ClaimStale(ctx, time.Now().Add(-staleAfter))
+	if err != nil {
+		return err
+	}
+
+	for _, saga := range sagas {
+		c.recoverSaga(ctx, saga)
+	}
+	return nil
+}
+
+// RunRecovery calls Recover every interval until ctx is cancelled
+func (c *SagaCoordinator) RunRecovery(ctx context.Context, interval, staleAfter time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.Recover(ctx, staleAfter); err != nil {
+				log.Error().Err(err).Msg("Failed to recover sagas")
+			}
+		}
+	}
+}
+
+func (c *SagaCoordinator) recoverSaga(ctx context.Context, saga *domain.Saga) {
+	if saga.Status == domain.SagaStatusCompensating {
+		c.compensate(ctx, saga, nil)
+		return
+	}
+
+	switch saga.Type {
+	case domain.SagaTypeBorrow:
+		if saga.Step == domain.SagaStepStockReserved {
+			// The copy may have been reserved without being recorded on the loan
+			if saga.CopyBarcode != "" {
+				if err := c.repoDb.AssignCopy(ctx, saga.TransactionID, saga.CopyBarcode); err != nil {
+					log.Error().Err(err).Str("saga_id", saga.ID).Msg("Failed to assign copy during saga recovery")
+					return
+				}
+			}
+			c.complete(ctx, saga)
+			return
+		}
+		c.compensate(ctx, saga, ErrSagaAbandoned)
+	case domain.SagaTypeReturn:
+		if saga.Step == domain.SagaStepStarted {
+			// The loan may have been closed without the step being recorded
+			transaction, err := c.repoDb.GetByID(ctx, saga.TransactionID)
+			if err != nil {
+				log.Error().Err(err).Str("saga_id", saga.ID).Msg("Failed to load transaction for saga recovery")
+				return
+			}
+			if !transaction.IsReturned() {
+				c.compensate(ctx, saga, ErrSagaAbandoned)
+				return
+			}
+			saga.Advance(domain.SagaStepLoanReturned)
+		}
+		if saga.Step == domain.SagaStepLoanReturned {
+			if err := c.bookRepo.ReleaseStock(ctx, saga.BookID, saga.CopyBarcode, saga.TransactionID); err != nil {
+				log.Error().Err(err).Str("saga_id", saga.ID).Msg("Failed to release stock during saga recovery")
+				return
+			}
+			saga.Advance(domain.SagaStepStockReleased)
+		}
+		c.complete(ctx, saga)
+	case domain.SagaTypeWriteOff:
+		// The loan may have been closed without the step being recorded, and
+		// its status tells whether the copy was lost or damaged
+		transaction, err := c.repoDb.GetByID(ctx, saga.TransactionID)
+		if err != nil {
+			log.Error().Err(err).Str("saga_id", saga.ID).Msg("Failed to load transaction for saga recovery")
+			return
+		}
+		if saga.Step == domain.SagaStepStarted {
+			if transaction.Status != domain.LoanStatusLost && transaction.Status != domain.LoanStatusDamaged {
+				c.compensate(ctx, saga, ErrSagaAbandoned)
+				return
+			}
+			saga.Advance(domain.SagaStepLoanClosed)
+		}
+		if saga.Step == domain.SagaStepLoanClosed {
+			damaged := transaction.Status == domain.LoanStatusDamaged
+			if err := c.bookRepo.WriteOffStock(ctx, saga.BookID, saga.CopyBarcode, saga.TransactionID, damaged); err != nil {
+				log.Error().Err(err).Str("saga_id", saga.ID).Msg("Failed to write off stock during saga recovery")
+				return
+			}
+			saga.Advance(domain.SagaStepStockWrittenOff)
+		}
+		c.complete(ctx, saga)
+	case domain.SagaTypeFound:
+		if saga.Step == domain.SagaStepStarted {
+			// The loan may have been marked as found without the step being recorded
+			transaction, err := c.repoDb.GetByID(ctx, saga.TransactionID)
+			if err != nil {
+				log.Error().Err(err).Str("saga_id", saga.ID).Msg("Failed to load transaction for saga recovery")
+				return
+			}
+			if transaction.Status != domain.LoanStatusFound {
+				c.compensate(ctx, saga, ErrSagaAbandoned)
+				return
+			}
+			saga.Advance(domain.SagaStepLoanFound)
+		}
+		if saga.Step == domain.SagaStepLoanFound {
+			if err := c.bookRepo.RestoreStock(ctx, saga.BookID, saga.CopyBarcode, saga.TransactionID); err != nil {
+				log.Error().Err(err).Str("saga_id", saga.ID).Msg("Failed to restore stock during saga recovery")
+				return
+			}
+			saga.Advance(domain.SagaStepStockRestored)
+		}
+		c.complete(ctx, saga)
+	}
+}
+
+// compensate undoes the steps recorded on saga. Compensations run even if the
+// caller's context has been cancelled; if one fails the saga stays in
+// compensating and is retried by recovery.
+func (c *SagaCoordinator) compensate(ctx context.Context, saga *domain.Saga, cause error) {
+	ctx = context.WithoutCancel(ctx)
+
+	saga.SetStatus(domain.SagaStatusCompensating, cause)
+	c.save(ctx, saga)
+
+	if err := c.undo(ctx, saga); err != nil {
+		log.Error().Err(err).Str("saga_id", saga.ID).Msg("Failed to compensate saga")
+		saga.SetStatus(domain.SagaStatusCompensating, err)
+		c.save(ctx, saga)
+		return
+	}
+
+	saga.SetStatus(domain.SagaStatusCompensated, nil)
+	c.save(ctx, saga)
+}
+
+// undo walks the saga's steps backwards, moving Step back as each one is
+// undone so a retried compensation resumes where it stopped
+func (c *SagaCoordinator) undo(ctx context.Context, saga *domain.Saga) error {
+	switch saga.Type {
+	case domain.SagaTypeBorrow:
+		// The reservation may have gone through even if the step was never
+		// recorded, it is cancelled by the loan's key, which also refuses it
+		// should it still be on its way
+		if saga.Step != domain.SagaStepStarted {
+			if err := c.bookRepo.CancelStock(ctx, saga.BookID, saga.TransactionID); err != nil {
+				return err
+			}
+			saga.Advance(domain.SagaStepLoanCreated)
+		}
+		// The loan may exist even if the step was never recorded
+		if err := c.repoDb.Delete(ctx, saga.TransactionID); err != nil && !errors.Is(err, ErrTransactionNotFound) {
+			return err
+		}
+		saga.Advance(domain.SagaStepStarted)
+	case domain.SagaTypeReturn:
+		// Returns are only compensated before the copy is released
+		if saga.Step == domain.SagaStepLoanReturned {
+			if err := c.repoDb.ReopenLoan(ctx, saga.TransactionID); err != nil {
+				return err
+			}
+			saga.Advance(domain.SagaStepStarted)
+		}
+	case domain.SagaTypeWriteOff:
+		if saga.Step == domain.SagaStepLoanClosed {
+			if err := c.repoDb.ReopenLoan(ctx, saga.TransactionID); err != nil {
+				return err
+			}
+			saga.Advance(domain.SagaStepStarted)
+		}
+	case domain.SagaTypeFound:
+		if saga.Step == domain.SagaStepLoanFound {
+			if err := c.repoDb.SetStatus(ctx, saga.TransactionID, domain.LoanStatusFound, domain.LoanStatusLost, "replacement fee charged again, book not restored"); err != nil {
+				return err
+			}
+			saga.Advance(domain.SagaStepStarted)
+		}
+	}
+	return nil
+}
+
+func (c *SagaCoordinator) advance(ctx context.Context, saga *domain.Saga, step domain.SagaStep) error {
+	saga.Advance(step)
+	return c.sagaRepo.Update(ctx, saga)
+}
+
+// complete marks the saga as completed. The forward steps have already
+// succeeded, so a failure to record it is left for recovery to finish.
+func (c *SagaCoordinator) complete(ctx context.Context, saga *domain.Saga) {
+	saga.SetStatus(domain.SagaStatusCompleted, nil)
+	c.save(ctx, saga)
+}
+
+func (c *SagaCoordinator) save(ctx context.Context, saga *domain.Saga) {
+	if err := c.sagaRepo.Update(ctx, saga); err != nil {
+		log.Error().Err(err).Str("saga_id", saga.ID).Msg("Failed to record saga state")
+	}
+}
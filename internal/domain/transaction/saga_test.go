@@ -0,0 +1,457 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"github.com/hinha/library-management-synapsis/internal/domain/book"
+	"github.com/hinha/library-management-synapsis/internal/domain/transaction/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+// sagaState matches a saga update by its step and status
+func sagaState(step domain.SagaStep, status domain.SagaStatus) interface{} {
+	return mock.MatchedBy(func(s *domain.Saga) bool {
+		return s.Step == step && s.Status == status
+	})
+}
+
+func TestSagaCoordinator_Borrow(t *testing.T) {
+	tests := []struct {
+		name    string
+		mockFn  func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository)
+		wantErr error
+	}{
+		{
+			name: "success",
+			mockFn: func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository) {
+				sagaRepo.On("Create", mock.Anything, sagaState(domain.SagaStepStarted, domain.SagaStatusRunning)).Return(nil)
+				repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Transaction")).Return(nil)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepLoanCreated, domain.SagaStatusRunning)).Return(nil).Once()
+				bookRepo.On("ReserveStock", mock.Anything, "book-1", "", mock.Anything).Return("", nil)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepStockReserved, domain.SagaStatusRunning)).Return(nil).Once()
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepStockReserved, domain.SagaStatusCompleted)).Return(nil).Once()
+			},
+		},
+		{
+			name: "records the copy the book service picked",
+			mockFn: func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository) {
+				sagaRepo.On("Create", mock.Anything, sagaState(domain.SagaStepStarted, domain.SagaStatusRunning)).Return(nil)
+				repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Transaction")).Return(nil)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepLoanCreated, domain.SagaStatusRunning)).Return(nil).Once()
+				bookRepo.On("ReserveStock", mock.Anything, "book-1", "", mock.Anything).Return("LIB-0001", nil)
+				sagaRepo.On("Update", mock.Anything, mock.MatchedBy(func(s *domain.Saga) bool {
+					return s.Step == domain.SagaStepStockReserved && s.Status == domain.SagaStatusRunning && s.CopyBarcode == "LIB-0001"
+				})).Return(nil).Once()
+				repo.On("AssignCopy", mock.Anything, mock.Anything, "LIB-0001").Return(nil)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepStockReserved, domain.SagaStatusCompleted)).Return(nil).Once()
+			},
+		},
+		{
+			name: "saga create fails",
+			mockFn: func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository) {
+				sagaRepo.On("Create", mock.Anything, mock.Anything).Return(errors.New("db error"))
+			},
+			wantErr: errors.New("db error"),
+		},
+		{
+			name: "loan create fails",
+			mockFn: func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository) {
+				sagaRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				repo.On("Create", mock.Anything, mock.Anything).Return(errors.New("insert error"))
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepStarted, domain.SagaStatusCompensating)).Return(nil).Once()
+				repo.On("Delete", mock.Anything, mock.Anything).Return(ErrTransactionNotFound)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepStarted, domain.SagaStatusCompensated)).Return(nil).Once()
+			},
+			wantErr: errors.New("insert error"),
+		},
+		{
+			name: "recording loan step fails",
+			mockFn: func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository) {
+				sagaRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepLoanCreated, domain.SagaStatusRunning)).Return(errors.New("update error")).Once()
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepLoanCreated, domain.SagaStatusCompensating)).Return(nil).Once()
+				bookRepo.On("CancelStock", mock.Anything, "book-1", mock.Anything).Return(nil)
+				repo.On("Delete", mock.Anything, mock.Anything).Return(nil)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepStarted, domain.SagaStatusCompensated)).Return(nil).Once()
+			},
+			wantErr: errors.New("update error"),
+		},
+		{
+			name: "reserve stock fails voids the loan",
+			mockFn: func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository) {
+				sagaRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepLoanCreated, domain.SagaStatusRunning)).Return(nil).Once()
+				bookRepo.On("ReserveStock", mock.Anything, "book-1", "", mock.Anything).Return("", book.ErrInsufficientStock)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepLoanCreated, domain.SagaStatusCompensating)).Return(nil).Once()
+				// The reservation may have gone through before the call failed
+				bookRepo.On("CancelStock", mock.Anything, "book-1", mock.Anything).Return(nil)
+				repo.On("Delete", mock.Anything, mock.Anything).Return(nil)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepStarted, domain.SagaStatusCompensated)).Return(nil).Once()
+			},
+			wantErr: book.ErrInsufficientStock,
+		},
+		{
+			name: "recording stock step fails cancels the reservation",
+			mockFn: func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository) {
+				sagaRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepLoanCreated, domain.SagaStatusRunning)).Return(nil).Once()
+				bookRepo.On("ReserveStock", mock.Anything, "book-1", "", mock.Anything).Return("", nil)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepStockReserved, domain.SagaStatusRunning)).Return(errors.New("update error")).Once()
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepStockReserved, domain.SagaStatusCompensating)).Return(nil).Once()
+				bookRepo.On("CancelStock", mock.Anything, "book-1", mock.Anything).Return(nil)
+				repo.On("Delete", mock.Anything, mock.Anything).Return(nil)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepStarted, domain.SagaStatusCompensated)).Return(nil).Once()
+			},
+			wantErr: errors.New("update error"),
+		},
+		{
+			name: "compensation fails leaves saga compensating",
+			mockFn: func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository) {
+				sagaRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepLoanCreated, domain.SagaStatusRunning)).Return(nil).Once()
+				bookRepo.On("ReserveStock", mock.Anything, "book-1", "", mock.Anything).Return("", errors.New("book service down"))
+				bookRepo.On("CancelStock", mock.Anything, "book-1", mock.Anything).Return(nil)
+				repo.On("Delete", mock.Anything, mock.Anything).Return(errors.New("delete error"))
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepLoanCreated, domain.SagaStatusCompensating)).Return(nil).Twice()
+			},
+			wantErr: errors.New("book service down"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.IDbRepository)
+			sagaRepo := new(mocks.ISagaRepository)
+			bookRepo := new(mocks.BookRepository)
+			tt.mockFn(repo, sagaRepo, bookRepo)
+
+			c := NewSagaCoordinator(repo, sagaRepo, bookRepo)
+			err := c.Borrow(context.Background(), domain.NewTransaction("user-1", "book-1", 14*24*time.Hour))
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			repo.AssertExpectations(t)
+			sagaRepo.AssertExpectations(t)
+			bookRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSagaCoordinator_Return(t *testing.T) {
+	tests := []struct {
+		name    string
+		mockFn  func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository)
+		wantErr error
+	}{
+		{
+			name: "success",
+			mockFn: func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository) {
+				sagaRepo.On("Create", mock.Anything, sagaState(domain.SagaStepStarted, domain.SagaStatusRunning)).Return(nil)
+				repo.On("MarkAsReturned", mock.Anything, "tx-1", mock.Anything).Return(nil)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepLoanReturned, domain.SagaStatusRunning)).Return(nil).Once()
+				bookRepo.On("ReleaseStock", mock.Anything, "book-1", "", "tx-1").Return(nil)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepStockReleased, domain.SagaStatusCompleted)).Return(nil).Once()
+			},
+		},
+		{
+			name: "mark as returned fails",
+			mockFn: func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository) {
+				sagaRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				repo.On("MarkAsReturned", mock.Anything, "tx-1", mock.Anything).Return(ErrAlreadyReturned)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepStarted, domain.SagaStatusCompensating)).Return(nil).Once()
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepStarted, domain.SagaStatusCompensated)).Return(nil).Once()
+			},
+			wantErr: ErrAlreadyReturned,
+		},
+		{
+			name: "recording loan step fails reopens the loan",
+			mockFn: func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository) {
+				sagaRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				repo.On("MarkAsReturned", mock.Anything, "tx-1", mock.Anything).Return(nil)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepLoanReturned, domain.SagaStatusRunning)).Return(errors.New("update error")).Once()
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepLoanReturned, domain.SagaStatusCompensating)).Return(nil).Once()
+				repo.On("ReopenLoan", mock.Anything, "tx-1").Return(nil)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepStarted, domain.SagaStatusCompensated)).Return(nil).Once()
+			},
+			wantErr: errors.New("update error"),
+		},
+		{
+			name: "release stock fails is left to recovery",
+			mockFn: func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository) {
+				sagaRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				repo.On("MarkAsReturned", mock.Anything, "tx-1", mock.Anything).Return(nil)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepLoanReturned, domain.SagaStatusRunning)).Return(nil).Once()
+				bookRepo.On("ReleaseStock", mock.Anything, "book-1", "", "tx-1").Return(errors.New("book service down"))
+				sagaRepo.On("Update", mock.Anything, mock.MatchedBy(func(s *domain.Saga) bool {
+					return s.Step == domain.SagaStepLoanReturned && s.Status == domain.SagaStatusRunning && s.LastError == "book service down"
+				})).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.IDbRepository)
+			sagaRepo := new(mocks.ISagaRepository)
+			bookRepo := new(mocks.BookRepository)
+			tt.mockFn(repo, sagaRepo, bookRepo)
+
+			c := NewSagaCoordinator(repo, sagaRepo, bookRepo)
+			err := c.Return(context.Background(), &domain.Transaction{ID: "tx-1", UserID: "user-1", BookID: "book-1"}, nil)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			repo.AssertExpectations(t)
+			sagaRepo.AssertExpectations(t)
+			bookRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSagaCoordinator_WriteOff(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  domain.LoanStatus
+		mockFn  func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository)
+		wantErr error
+	}{
+		{
+			name:   "lost",
+			status: domain.LoanStatusLost,
+			mockFn: func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository) {
+				sagaRepo.On("Create", mock.Anything, sagaState(domain.SagaStepStarted, domain.SagaStatusRunning)).Return(nil)
+				repo.On("Close", mock.Anything, mock.AnythingOfType("*domain.Transaction"), "replacement fee for lost book").Return(nil)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepLoanClosed, domain.SagaStatusRunning)).Return(nil).Once()
+				bookRepo.On("WriteOffStock", mock.Anything, "book-1", "LIB-0001", "tx-1", false).Return(nil)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepStockWrittenOff, domain.SagaStatusCompleted)).Return(nil).Once()
+			},
+		},
+		{
+			name:   "damaged",
+			status: domain.LoanStatusDamaged,
+			mockFn: func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository) {
+				sagaRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				repo.On("Close", mock.Anything, mock.AnythingOfType("*domain.Transaction"), "replacement fee for lost book").Return(nil)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepLoanClosed, domain.SagaStatusRunning)).Return(nil).Once()
+				bookRepo.On("WriteOffStock", mock.Anything, "book-1", "LIB-0001", "tx-1", true).Return(nil)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepStockWrittenOff, domain.SagaStatusCompleted)).Return(nil).Once()
+			},
+		},
+		{
+			name:   "loan closed meanwhile",
+			status: domain.LoanStatusLost,
+			mockFn: func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository) {
+				sagaRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				repo.On("Close", mock.Anything, mock.AnythingOfType("*domain.Transaction"), "replacement fee for lost book").Return(ErrAlreadyReturned)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepStarted, domain.SagaStatusCompensating)).Return(nil).Once()
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepStarted, domain.SagaStatusCompensated)).Return(nil).Once()
+			},
+			wantErr: ErrAlreadyReturned,
+		},
+		{
+			name:   "write-off fails reopens the loan",
+			status: domain.LoanStatusLost,
+			mockFn: func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository) {
+				sagaRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				repo.On("Close", mock.Anything, mock.AnythingOfType("*domain.Transaction"), "replacement fee for lost book").Return(nil)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepLoanClosed, domain.SagaStatusRunning)).Return(nil).Once()
+				bookRepo.On("WriteOffStock", mock.Anything, "book-1", "LIB-0001", "tx-1", false).Return(book.ErrCopyNotOnLoan)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepLoanClosed, domain.SagaStatusCompensating)).Return(nil).Once()
+				repo.On("ReopenLoan", mock.Anything, "tx-1").Return(nil)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepStarted, domain.SagaStatusCompensated)).Return(nil).Once()
+			},
+			wantErr: book.ErrCopyNotOnLoan,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.IDbRepository)
+			sagaRepo := new(mocks.ISagaRepository)
+			bookRepo := new(mocks.BookRepository)
+			tt.mockFn(repo, sagaRepo, bookRepo)
+
+			transaction := &domain.Transaction{ID: "tx-1", UserID: "user-1", BookID: "book-1", CopyBarcode: "LIB-0001"}
+			transaction.Close(tt.status, 5000)
+			c := NewSagaCoordinator(repo, sagaRepo, bookRepo)
+			err := c.WriteOff(context.Background(), transaction, "replacement fee for lost book")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			repo.AssertExpectations(t)
+			sagaRepo.AssertExpectations(t)
+			bookRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSagaCoordinator_Found(t *testing.T) {
+	tests := []struct {
+		name    string
+		mockFn  func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository)
+		wantErr error
+	}{
+		{
+			name: "success",
+			mockFn: func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository) {
+				sagaRepo.On("Create", mock.Anything, sagaState(domain.SagaStepStarted, domain.SagaStatusRunning)).Return(nil)
+				repo.On("SetStatus", mock.Anything, "tx-1", domain.LoanStatusLost, domain.LoanStatusFound, "refund of replacement fee, book found").Return(nil)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepLoanFound, domain.SagaStatusRunning)).Return(nil).Once()
+				bookRepo.On("RestoreStock", mock.Anything, "book-1", "LIB-0001", "tx-1").Return(nil)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepStockRestored, domain.SagaStatusCompleted)).Return(nil).Once()
+			},
+		},
+		{
+			name: "restore fails marks the loan lost again",
+			mockFn: func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository) {
+				sagaRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				repo.On("SetStatus", mock.Anything, "tx-1", domain.LoanStatusLost, domain.LoanStatusFound, "refund of replacement fee, book found").Return(nil)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepLoanFound, domain.SagaStatusRunning)).Return(nil).Once()
+				bookRepo.On("RestoreStock", mock.Anything, "book-1", "LIB-0001", "tx-1").Return(book.ErrCopyNotLost)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepLoanFound, domain.SagaStatusCompensating)).Return(nil).Once()
+				repo.On("SetStatus", mock.Anything, "tx-1", domain.LoanStatusFound, domain.LoanStatusLost, mock.Anything).Return(nil)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepStarted, domain.SagaStatusCompensated)).Return(nil).Once()
+			},
+			wantErr: book.ErrCopyNotLost,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.IDbRepository)
+			sagaRepo := new(mocks.ISagaRepository)
+			bookRepo := new(mocks.BookRepository)
+			tt.mockFn(repo, sagaRepo, bookRepo)
+
+			c := NewSagaCoordinator(repo, sagaRepo, bookRepo)
+			err := c.Found(context.Background(), &domain.Transaction{ID: "tx-1", UserID: "user-1", BookID: "book-1", CopyBarcode: "LIB-0001", Status: domain.LoanStatusLost}, "refund of replacement fee, book found")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			repo.AssertExpectations(t)
+			sagaRepo.AssertExpectations(t)
+			bookRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSagaCoordinator_Recover(t *testing.T) {
+	returnedAt := time.Now()
+
+	tests := []struct {
+		name   string
+		saga   *domain.Saga
+		mockFn func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository)
+	}{
+		{
+			name: "borrow with reserved stock is completed",
+			saga: &domain.Saga{ID: "s-1", Type: domain.SagaTypeBorrow, TransactionID: "tx-1", BookID: "book-1", Step: domain.SagaStepStockReserved, Status: domain.SagaStatusRunning},
+			mockFn: func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository) {
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepStockReserved, domain.SagaStatusCompleted)).Return(nil).Once()
+			},
+		},
+		{
+			name: "borrow without reserved stock is rolled back",
+			saga: &domain.Saga{ID: "s-2", Type: domain.SagaTypeBorrow, TransactionID: "tx-2", BookID: "book-1", Step: domain.SagaStepLoanCreated, Status: domain.SagaStatusRunning},
+			mockFn: func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository) {
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepLoanCreated, domain.SagaStatusCompensating)).Return(nil).Once()
+				bookRepo.On("CancelStock", mock.Anything, "book-1", "tx-2").Return(nil)
+				repo.On("Delete", mock.Anything, "tx-2").Return(nil)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepStarted, domain.SagaStatusCompensated)).Return(nil).Once()
+			},
+		},
+		{
+			name: "interrupted compensation is resumed",
+			saga: &domain.Saga{ID: "s-3", Type: domain.SagaTypeBorrow, TransactionID: "tx-3", BookID: "book-1", Step: domain.SagaStepStockReserved, Status: domain.SagaStatusCompensating},
+			mockFn: func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository) {
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepStockReserved, domain.SagaStatusCompensating)).Return(nil).Once()
+				bookRepo.On("CancelStock", mock.Anything, "book-1", "tx-3").Return(nil)
+				repo.On("Delete", mock.Anything, "tx-3").Return(nil)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepStarted, domain.SagaStatusCompensated)).Return(nil).Once()
+			},
+		},
+		{
+			name: "return with closed loan releases stock",
+			saga: &domain.Saga{ID: "s-4", Type: domain.SagaTypeReturn, TransactionID: "tx-4", BookID: "book-1", Step: domain.SagaStepStarted, Status: domain.SagaStatusRunning},
+			mockFn: func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository) {
+				repo.On("GetByID", mock.Anything, "tx-4").Return(&domain.Transaction{ID: "tx-4", ReturnedAt: &returnedAt}, nil)
+				bookRepo.On("ReleaseStock", mock.Anything, "book-1", "", "tx-4").Return(nil)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepStockReleased, domain.SagaStatusCompleted)).Return(nil).Once()
+			},
+		},
+		{
+			name: "return with open loan is abandoned",
+			saga: &domain.Saga{ID: "s-5", Type: domain.SagaTypeReturn, TransactionID: "tx-5", BookID: "book-1", Step: domain.SagaStepStarted, Status: domain.SagaStatusRunning},
+			mockFn: func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository) {
+				repo.On("GetByID", mock.Anything, "tx-5").Return(&domain.Transaction{ID: "tx-5"}, nil)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepStarted, domain.SagaStatusCompensating)).Return(nil).Once()
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepStarted, domain.SagaStatusCompensated)).Return(nil).Once()
+			},
+		},
+		{
+			name: "return release failure is retried later",
+			saga: &domain.Saga{ID: "s-6", Type: domain.SagaTypeReturn, TransactionID: "tx-6", BookID: "book-1", Step: domain.SagaStepLoanReturned, Status: domain.SagaStatusRunning},
+			mockFn: func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository) {
+				bookRepo.On("ReleaseStock", mock.Anything, "book-1", "", "tx-6").Return(errors.New("book service down"))
+			},
+		},
+		{
+			name: "write-off with closed loan writes the copy off",
+			saga: &domain.Saga{ID: "s-7", Type: domain.SagaTypeWriteOff, TransactionID: "tx-7", BookID: "book-1", Step: domain.SagaStepStarted, Status: domain.SagaStatusRunning},
+			mockFn: func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository) {
+				repo.On("GetByID", mock.Anything, "tx-7").Return(&domain.Transaction{ID: "tx-7", ReturnedAt: &returnedAt, Status: domain.LoanStatusDamaged}, nil)
+				bookRepo.On("WriteOffStock", mock.Anything, "book-1", "", "tx-7", true).Return(nil)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepStockWrittenOff, domain.SagaStatusCompleted)).Return(nil).Once()
+			},
+		},
+		{
+			name: "write-off with open loan is abandoned",
+			saga: &domain.Saga{ID: "s-8", Type: domain.SagaTypeWriteOff, TransactionID: "tx-8", BookID: "book-1", Step: domain.SagaStepStarted, Status: domain.SagaStatusRunning},
+			mockFn: func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository) {
+				repo.On("GetByID", mock.Anything, "tx-8").Return(&domain.Transaction{ID: "tx-8"}, nil)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepStarted, domain.SagaStatusCompensating)).Return(nil).Once()
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepStarted, domain.SagaStatusCompensated)).Return(nil).Once()
+			},
+		},
+		{
+			name: "found loan restores stock",
+			saga: &domain.Saga{ID: "s-9", Type: domain.SagaTypeFound, TransactionID: "tx-9", BookID: "book-1", Step: domain.SagaStepLoanFound, Status: domain.SagaStatusRunning},
+			mockFn: func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository) {
+				bookRepo.On("RestoreStock", mock.Anything, "book-1", "", "tx-9").Return(nil)
+				sagaRepo.On("Update", mock.Anything, sagaState(domain.SagaStepStockRestored, domain.SagaStatusCompleted)).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.IDbRepository)
+			sagaRepo := new(mocks.ISagaRepository)
+			bookRepo := new(mocks.BookRepository)
+			sagaRepo.On("ClaimStale", mock.Anything, mock.AnythingOfType("time.Time")).Return([]*domain.Saga{tt.saga}, nil)
+			tt.mockFn(repo, sagaRepo, bookRepo)
+
+			c := NewSagaCoordinator(repo, sagaRepo, bookRepo)
+			assert.NoError(t, c.Recover(context.Background(), time.Minute))
+			repo.AssertExpectations(t)
+			sagaRepo.AssertExpectations(t)
+			bookRepo.AssertExpectations(t)
+		})
+	}
+}
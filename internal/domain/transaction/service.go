@@ -5,8 +5,9 @@ import (
 	"errors"
 	pb "github.com/hinha/library-management-synapsis/gen/api/proto/transaction"
 	"github.com/hinha/library-management-synapsis/internal/domain"
+	"time"
 
-	"github.com/hinha/library-management-synapsis/internal/domain/book"
+	"github.com/rs/zerolog/log"
 )
 
 var (
@@ -14,107 +15,291 @@ var (
 	ErrInvalidInput = errors.New("invalid input")
 	// ErrBookNotAvailable is returned when a book is not available for borrowing
 	ErrBookNotAvailable = errors.New("book not available")
+	// ErrRenewalLimitReached is returned when a loan has been renewed the maximum number of times
+	ErrRenewalLimitReached = errors.New("renewal limit reached")
+	// ErrOverdueBeyondGrace is returned when a loan is too far overdue to be renewed
+	ErrOverdueBeyondGrace = errors.New("loan is overdue beyond the grace period")
+	// ErrBookOnHold is returned when the available copies are set aside for, or
+	// awaited by, other patrons
+	ErrBookOnHold = errors.New("book is on hold for another patron")
+	// ErrOutstandingFines is returned when a user owes more than the borrow limit
+	ErrOutstandingFines = errors.New("outstanding fines exceed the borrowing limit")
+	// ErrInvalidPageToken is returned when a page token is malformed or was
+	// issued for a different filter
+	ErrInvalidPageToken = errors.New("invalid page token")
+)
+
+const (
+	// DefaultPageSize is the page size used when the client does not ask for one
+	DefaultPageSize = 20
+	// MaxPageSize is the largest page a client can ask for
+	MaxPageSize = 100
 )
 
 // Service defines the interface for transaction business logic
+//
+//go:generate mockery --name=Service --structname=TransactionService --filename=TransactionService.go --output=../../delivery/mocks --outpkg=mocks
 type Service interface {
-	BorrowBook(ctx context.Context, userID, bookID string) (*domain.Transaction, error)
+	BorrowBook(ctx context.Context, userID, bookID, barcode string) (*domain.Transaction, error)
 	ReturnBook(ctx context.Context, transactionID string) (*domain.Transaction, error)
-	GetUserHistory(ctx context.Context, userID string) ([]*domain.Transaction, error)
+	GetOpenLoanByBarcode(ctx context.Context, barcode string) (*domain.Transaction, error)
+	GetOpenLoanByBook(ctx context.Context, userID, bookID string) (*domain.Transaction, error)
+	BatchBorrow(ctx context.Context, userID string, items []BatchItem) ([]*BatchOutcome, error)
+	BatchReturn(ctx context.Context, userID string, items []BatchItem) ([]*BatchOutcome, error)
+	RenewLoan(ctx context.Context, transactionID string) (*domain.Transaction, error)
+	DeclareLost(ctx context.Context, transactionID string, fee int64, note string) (*domain.Transaction, error)
+	ReportDamage(ctx context.Context, transactionID string, fee int64, note string) (*domain.Transaction, error)
+	MarkFound(ctx context.Context, transactionID, note string) (*domain.Transaction, error)
+	GetTransaction(ctx context.Context, transactionID string) (*domain.Transaction, error)
+	GetUserHistory(ctx context.Context, userID string, filter ListFilter, pageSize int, pageToken string) ([]*domain.Transaction, string, error)
+	ListTransactions(ctx context.Context, filter ListFilter, pageSize int, pageToken string) ([]*domain.Transaction, string, error)
+	PlaceHold(ctx context.Context, userID, bookID string) (*domain.Hold, error)
+	GetHold(ctx context.Context, holdID string) (*domain.Hold, error)
+	CancelHold(ctx context.Context, holdID string) (*domain.Hold, error)
+	ListHolds(ctx context.Context, userID, bookID string) ([]*domain.Hold, error)
+	ListOverdue(ctx context.Context) ([]*domain.Transaction, error)
+	GetBalance(ctx context.Context, userID string) (int64, error)
+	ListLedger(ctx context.Context, userID string) ([]*domain.LedgerEntry, int64, error)
+	RecordPayment(ctx context.Context, userID string, amount int64, note string) (*domain.LedgerEntry, error)
+	WaiveFine(ctx context.Context, userID string, amount int64, reason string) (*domain.LedgerEntry, error)
+	BlockBorrower(ctx context.Context, userID, reason string, expiresAt *time.Time) (*domain.BorrowerBlock, error)
+	UnblockBorrower(ctx context.Context, userID string) (*domain.BorrowerBlock, error)
+	ListDeletedTransactions(ctx context.Context, pageSize int, pageToken string) ([]*domain.Transaction, string, error)
+	RestoreTransaction(ctx context.Context, transactionID string) (*domain.Transaction, error)
+	PurgeDeletedTransactions(ctx context.Context, retention time.Duration) (int64, time.Time, error)
 	Health(ctx context.Context) (*pb.HealthCheckResponse, error)
 }
 
 // BookRepository defines the interface for book operations needed by the transaction service
+//
+//go:generate mockery --name=BookRepository --output=mocks --outpkg=mocks
 type BookRepository interface {
 	GetByID(ctx context.Context, id string) (*domain.Book, error)
-	UpdateStock(ctx context.Context, id string, change int32) error
+	GetCopy(ctx context.Context, barcode string) (*domain.BookCopy, error)
+	ReserveStock(ctx context.Context, id, barcode, transactionID string) (string, error)
+	ReopenStock(ctx context.Context, id, barcode, transactionID string) (string, error)
+	ReleaseStock(ctx context.Context, id, barcode, transactionID string) error
+	CancelStock(ctx context.Context, id, transactionID string) error
+	WriteOffStock(ctx context.Context, id, barcode, transactionID string, damaged bool) error
+	RestoreStock(ctx context.Context, id, barcode, transactionID string) error
+}
+
+// UserRepository defines the interface for user operations needed by the transaction service
+//
+//go:generate mockery --name=UserRepository --output=mocks --outpkg=mocks
+type UserRepository interface {
+	GetByID(ctx context.Context, id string) (*domain.User, error)
 }
 
 // DefaultService implements Service
 type DefaultService struct {
-	repoDb   IDbRepository
-	bookRepo BookRepository
+	repoDb     IDbRepository
+	holdRepo   IHoldRepository
+	ledgerRepo ILedgerRepository
+	blockRepo  IBlockRepository
+	bookRepo   BookRepository
+	userRepo   UserRepository
+	saga       *SagaCoordinator
+	policy     LoanPolicy
 }
 
 // NewService creates a new DefaultService
-func NewService(repo IDbRepository, bookRepo BookRepository) *DefaultService {
+func NewService(repo IDbRepository, holdRepo IHoldRepository, ledgerRepo ILedgerRepository, blockRepo IBlockRepository, bookRepo BookRepository, userRepo UserRepository, saga *SagaCoordinator, policy LoanPolicy) *DefaultService {
 	return &DefaultService{
-		repoDb:   repo,
-		bookRepo: bookRepo,
+		repoDb:     repo,
+		holdRepo:   holdRepo,
+		ledgerRepo: ledgerRepo,
+		blockRepo:  blockRepo,
+		bookRepo:   bookRepo,
+		userRepo:   userRepo,
+		saga:       saga,
+		policy:     policy,
 	}
 }
 
-// BorrowBook creates a new transaction for borrowing a book
-func (s *DefaultService) BorrowBook(ctx context.Context, userID, bookID string) (*domain.Transaction, error) {
-	if userID == "" || bookID == "" {
+// BorrowBook creates a new transaction for borrowing a book. With a barcode
+// the scanned copy is borrowed and bookID may be left empty, otherwise the
+// book service picks a copy.
+func (s *DefaultService) BorrowBook(ctx context.Context, userID, bookID, barcode string) (*domain.Transaction, error) {
+	outcomes, err := s.BatchBorrow(ctx, userID, []BatchItem{{BookID: bookID, Barcode: barcode}})
+	if err != nil {
+		return nil, err
+	}
+	return outcomes[0].Transaction, outcomes[0].Err
+}
+
+// ReturnBook marks a transaction as returned
+func (s *DefaultService) ReturnBook(ctx context.Context, transactionID string) (*domain.Transaction, error) {
+	if transactionID == "" {
 		return nil, ErrInvalidInput
 	}
 
-	// Check if book exists and has available stock
-	b, err := s.bookRepo.GetByID(ctx, bookID)
+	// Get transaction
+	transaction, err := s.repoDb.GetByID(ctx, transactionID)
 	if err != nil {
-		if errors.Is(err, book.ErrBookNotFound) {
-			return nil, book.ErrBookNotFound
-		}
 		return nil, err
 	}
 
-	if b.Stock <= 0 {
-		return nil, ErrBookNotAvailable
+	// Check if already returned
+	if transaction.IsReturned() {
+		return nil, ErrAlreadyReturned
 	}
 
-	// Create transaction
-	transaction := domain.NewTransaction(userID, bookID)
-	if err := s.repoDb.Create(ctx, transaction); err != nil {
+	// Close the loan, charging any late fee with it, and put the copy back
+	// into stock
+	if err := s.saga.Return(ctx, transaction, s.lateFee(ctx, transaction)); err != nil {
 		return nil, err
 	}
 
-	// Update book stock
-	if err := s.bookRepo.UpdateStock(ctx, bookID, -1); err != nil {
+	// The returned copy goes to the next patron in line, if any
+	if err := s.promoteNextHold(ctx, transaction.BookID); err != nil {
+		log.Error().Err(err).Str("book_id", transaction.BookID).Msg("Failed to promote next hold")
+	}
+
+	// Get updated transaction
+	transaction, err = s.repoDb.GetByID(ctx, transactionID)
+	if err != nil {
 		return nil, err
 	}
 
 	return transaction, nil
 }
 
-// ReturnBook marks a transaction as returned
-func (s *DefaultService) ReturnBook(ctx context.Context, transactionID string) (*domain.Transaction, error) {
+// GetOpenLoanByBarcode retrieves the open loan of the copy with the barcode
+func (s *DefaultService) GetOpenLoanByBarcode(ctx context.Context, barcode string) (*domain.Transaction, error) {
+	if barcode == "" {
+		return nil, ErrInvalidInput
+	}
+
+	return s.repoDb.GetOpenByBarcode(ctx, barcode)
+}
+
+// GetOpenLoanByBook retrieves the oldest open loan of a user for a book
+func (s *DefaultService) GetOpenLoanByBook(ctx context.Context, userID, bookID string) (*domain.Transaction, error) {
+	if userID == "" || bookID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	return s.repoDb.GetOpenByUserAndBook(ctx, userID, bookID)
+}
+
+// RenewLoan pushes the due date of an open loan forward by its loan period
+func (s *DefaultService) RenewLoan(ctx context.Context, transactionID string) (*domain.Transaction, error) {
 	if transactionID == "" {
 		return nil, ErrInvalidInput
 	}
 
-	// Get transaction
 	transaction, err := s.repoDb.GetByID(ctx, transactionID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Check if already returned
 	if transaction.IsReturned() {
 		return nil, ErrAlreadyReturned
 	}
+	if transaction.RenewalCount >= s.policy.MaxRenewals {
+		return nil, ErrRenewalLimitReached
+	}
+	if transaction.DueAt != nil && time.Now().After(transaction.DueAt.Add(s.policy.RenewalGrace)) {
+		return nil, ErrOverdueBeyondGrace
+	}
 
-	// Mark as returned
-	if err := s.repoDb.MarkAsReturned(ctx, transactionID); err != nil {
+	// Patrons waiting for the book get it back on time
+	queue, err := s.holdRepo.ListByBook(ctx, transaction.BookID)
+	if err != nil {
 		return nil, err
 	}
+	for _, hold := range queue {
+		if hold.Status == domain.HoldStatusWaiting {
+			return nil, ErrBookOnHold
+		}
+	}
 
-	// Update book stock
-	if err := s.bookRepo.UpdateStock(ctx, transaction.BookID, 1); err != nil {
+	// The loan period may have changed since the book was borrowed
+	u, err := s.userRepo.GetByID(ctx, transaction.UserID)
+	if err != nil {
+		return nil, err
+	}
+	b, err := s.bookRepo.GetByID(ctx, transaction.BookID)
+	if err != nil {
 		return nil, err
 	}
 
-	// Get updated transaction
+	transaction.Renew(s.policy.LoanPeriod(b.Category, u.Role))
+	if err := s.repoDb.Renew(ctx, transaction); err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// GetTransaction retrieves a transaction by ID
+func (s *DefaultService) GetTransaction(ctx context.Context, transactionID string) (*domain.Transaction, error) {
+	if transactionID == "" {
+		return nil, ErrInvalidInput
+	}
+
 	return s.repoDb.GetByID(ctx, transactionID)
 }
 
-// GetUserHistory retrieves a user's transaction history
-func (s *DefaultService) GetUserHistory(ctx context.Context, userID string) ([]*domain.Transaction, error) {
+// GetUserHistory retrieves one page of a user's transactions matching
+// filter. It returns the token for the next page, empty on the last page.
+func (s *DefaultService) GetUserHistory(ctx context.Context, userID string, filter ListFilter, pageSize int, pageToken string) ([]*domain.Transaction, string, error) {
 	if userID == "" {
-		return nil, ErrInvalidInput
+		return nil, "", ErrInvalidInput
+	}
+
+	filter.UserID = userID
+	return s.ListTransactions(ctx, filter, pageSize, pageToken)
+}
+
+// ListTransactions retrieves one page of the transactions matching filter,
+// newest first unless the filter asks for ascending order. pageToken
+// continues a previous listing with the same filter. It returns the token for
+// the next page, empty on the last page.
+func (s *DefaultService) ListTransactions(ctx context.Context, filter ListFilter, pageSize int, pageToken string) ([]*domain.Transaction, string, error) {
+	switch filter.Status {
+	case "", domain.TransactionStatusActive, domain.TransactionStatusReturned, domain.TransactionStatusOverdue,
+		domain.TransactionStatusLost, domain.TransactionStatusDamaged, domain.TransactionStatusFound:
+	default:
+		return nil, "", ErrInvalidInput
+	}
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
 	}
 
-	return s.repoDb.GetByUserID(ctx, userID)
+	query := ListQuery{Filter: filter, Limit: pageSize + 1, Now: time.Now()}
+	if pageToken != "" {
+		cursor, err := decodePageToken(pageToken, filter)
+		if err != nil {
+			return nil, "", err
+		}
+		query.After = cursor
+	}
+
+	// One transaction past the page tells whether another page follows
+	transactions, err := s.repoDb.ListPage(ctx, query)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(transactions) <= pageSize {
+		return transactions, "", nil
+	}
+
+	transactions = transactions[:pageSize]
+	nextPageToken, err := encodePageToken(filter, transactions[pageSize-1])
+	if err != nil {
+		return nil, "", err
+	}
+	return transactions, nextPageToken, nil
+}
+
+// ListOverdue retrieves open loans past their due date, most overdue first
+func (s *DefaultService) ListOverdue(ctx context.Context) ([]*domain.Transaction, error) {
+	return s.repoDb.ListOverdue(ctx, time.Now())
 }
 
 func (s *DefaultService) Health(ctx context.Context) (*pb.HealthCheckResponse, error) {
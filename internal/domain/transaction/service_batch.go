@@ -0,0 +1,237 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"time"
+
+	"github.com/hinha/library-management-synapsis/internal/domain/book"
+	"github.com/hinha/library-management-synapsis/internal/domain/user"
+)
+
+// MaxBatchSize is the largest basket a batch checkout or checkin takes
+const MaxBatchSize = 50
+
+// ErrNotBorrower is returned when a loan checked in for a user belongs to someone else
+var ErrNotBorrower = errors.New("loan belongs to another user")
+
+// BatchItem is one book of a basket at the circulation desk. Checkouts name
+// a scanned copy barcode or a book to pick a copy of; checkins name a loan,
+// a copy barcode or a book the user has out.
+type BatchItem struct {
+	TransactionID string
+	BookID        string
+	Barcode       string
+}
+
+// BatchOutcome is what became of one item of a basket: the loan it opened or
+// closed, or the error it failed with
+type BatchOutcome struct {
+	Item        BatchItem
+	Transaction *domain.Transaction
+	Err         error
+}
+
+// basketTitle is a book being checked out, with the copies other patrons'
+// ready holds set aside and the copies the basket already takes
+type basketTitle struct {
+	bookID   string
+	book     *domain.Book
+	ownHold  *domain.Hold
+	reserved int32
+	taken    int32
+}
+
+// BatchBorrow checks out a basket of books for a user. Items that cannot be
+// borrowed, such as unknown books or copies off the shelf, fail on their own.
+// Users who may not borrow at all are refused up front, and the loan limits
+// are checked for the basket as a whole, so a user the basket would take past
+// them borrows nothing. Only then are copies reserved.
+func (s *DefaultService) BatchBorrow(ctx context.Context, userID string, items []BatchItem) ([]*BatchOutcome, error) {
+	if userID == "" || len(items) == 0 || len(items) > MaxBatchSize {
+		return nil, ErrInvalidInput
+	}
+
+	// The borrower's role decides the loan period
+	u, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			return nil, user.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	// The limits are checked and the loans opened under the borrower's lock,
+	// so another borrow of the same user waits for this one
+	unlock, err := s.repoDb.LockBorrower(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	// Blocked patrons, patrons who owe too much and patrons with overdue
+	// loans must sort that out first
+	loans, err := s.checkBorrower(ctx, userID, u.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	outcomes := make([]*BatchOutcome, len(items))
+	picked := make([]*basketTitle, len(items))
+	titles := make(map[string]*basketTitle)
+	barcodes := make(map[string]bool)
+	var basket []string
+	for i, item := range items {
+		outcomes[i] = &BatchOutcome{Item: item}
+		title, err := s.pickTitle(ctx, userID, item, titles, barcodes)
+		if err != nil {
+			outcomes[i].Err = err
+			continue
+		}
+		picked[i] = title
+		basket = append(basket, title.bookID)
+	}
+	if len(basket) == 0 {
+		return outcomes, nil
+	}
+
+	// The loan limits apply to the basket as a whole, so a patron it would
+	// take past them borrows nothing rather than the first few books
+	if err := s.checkBasket(u.Role, loans, basket); err != nil {
+		return nil, err
+	}
+
+	for i, title := range picked {
+		if title == nil {
+			continue
+		}
+
+		// Open the loan and reserve a copy; the book service refuses when the
+		// last copy is already gone, in which case the loan is voided again
+		transaction := domain.NewTransaction(userID, title.bookID, s.policy.LoanPeriod(title.book.Category, u.Role))
+		transaction.CopyBarcode = items[i].Barcode
+		if err := s.saga.Borrow(ctx, transaction); err != nil {
+			if lent(err) {
+				err = ErrBookNotAvailable
+			}
+			outcomes[i].Err = err
+			continue
+		}
+		outcomes[i].Transaction = transaction
+
+		if title.ownHold != nil {
+			s.updateHold(ctx, title.ownHold, domain.HoldStatusFulfilled)
+			title.ownHold = nil
+		}
+	}
+
+	return outcomes, nil
+}
+
+// pickTitle looks up the book a checkout item borrows and checks that a copy
+// is free for userID, counting the copies earlier items of the basket take
+func (s *DefaultService) pickTitle(ctx context.Context, userID string, item BatchItem, titles map[string]*basketTitle, barcodes map[string]bool) (*basketTitle, error) {
+	bookID := item.BookID
+	if item.Barcode != "" {
+		if barcodes[item.Barcode] {
+			return nil, ErrInvalidInput
+		}
+		bookCopy, err := s.bookRepo.GetCopy(ctx, item.Barcode)
+		if err != nil {
+			return nil, err
+		}
+		if bookID != "" && bookID != bookCopy.BookID {
+			return nil, ErrInvalidInput
+		}
+		if !bookCopy.IsAvailable() {
+			return nil, ErrBookNotAvailable
+		}
+		barcodes[item.Barcode] = true
+		bookID = bookCopy.BookID
+	}
+	if bookID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	title, ok := titles[bookID]
+	if !ok {
+		b, err := s.bookRepo.GetByID(ctx, bookID)
+		if err != nil {
+			if errors.Is(err, book.ErrBookNotFound) {
+				return nil, book.ErrBookNotFound
+			}
+			return nil, err
+		}
+		queue, err := s.holdRepo.ListByBook(ctx, bookID)
+		if err != nil {
+			return nil, err
+		}
+		title = &basketTitle{bookID: bookID, book: b}
+		title.ownHold, title.reserved = holdsFor(queue, userID, time.Now())
+		titles[bookID] = title
+	}
+
+	free := title.book.Stock - title.taken
+	if free <= 0 {
+		return nil, ErrBookNotAvailable
+	}
+	// Copies set aside for ready holds can only be borrowed by their holders
+	if free <= title.reserved {
+		return nil, ErrBookOnHold
+	}
+
+	title.taken++
+	return title, nil
+}
+
+// BatchReturn checks in a basket of books handed back by a user. Every item
+// is returned on its own and fails when it matches no open loan of the user.
+func (s *DefaultService) BatchReturn(ctx context.Context, userID string, items []BatchItem) ([]*BatchOutcome, error) {
+	if userID == "" || len(items) == 0 || len(items) > MaxBatchSize {
+		return nil, ErrInvalidInput
+	}
+
+	outcomes := make([]*BatchOutcome, len(items))
+	for i, item := range items {
+		outcomes[i] = &BatchOutcome{Item: item}
+		loan, err := s.findLoan(ctx, userID, item)
+		if err != nil {
+			outcomes[i].Err = err
+			continue
+		}
+		outcomes[i].Transaction, outcomes[i].Err = s.ReturnBook(ctx, loan.ID)
+	}
+
+	return outcomes, nil
+}
+
+// findLoan returns the loan of userID a checkin item closes
+func (s *DefaultService) findLoan(ctx context.Context, userID string, item BatchItem) (*domain.Transaction, error) {
+	var loan *domain.Transaction
+	var err error
+	switch {
+	case item.TransactionID != "":
+		loan, err = s.repoDb.GetByID(ctx, item.TransactionID)
+	case item.Barcode != "":
+		loan, err = s.repoDb.GetOpenByBarcode(ctx, item.Barcode)
+	case item.BookID != "":
+		loan, err = s.repoDb.GetOpenByUserAndBook(ctx, userID, item.BookID)
+	default:
+		return nil, ErrInvalidInput
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if loan.UserID != userID {
+		return nil, ErrNotBorrower
+	}
+	return loan, nil
+}
+
+// lent reports whether the book service refused a reservation because the
+// book, or the copy asked for, has been lent since it was checked
+func lent(err error) bool {
+	return errors.Is(err, book.ErrInsufficientStock) || errors.Is(err, book.ErrCopyNotAvailable)
+}
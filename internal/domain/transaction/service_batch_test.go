@@ -0,0 +1,194 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"github.com/hinha/library-management-synapsis/internal/domain/book"
+	"github.com/hinha/library-management-synapsis/internal/domain/transaction/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestDefaultService_BatchBorrow(t *testing.T) {
+	tests := []struct {
+		name         string
+		role         domain.Role
+		loans        []*domain.Transaction
+		items        []BatchItem
+		mockFn       func(bookRepo *mocks.BookRepository)
+		wantErrs     []error
+		wantReserved int
+		wantErr      error
+		wantReason   string
+	}{
+		{
+			name:  "whole basket",
+			role:  domain.RoleOperation,
+			items: []BatchItem{{BookID: "book-1"}, {Barcode: "LIB-0002"}},
+			mockFn: func(bookRepo *mocks.BookRepository) {
+				bookRepo.On("GetByID", mock.Anything, "book-1").Return(&domain.Book{ID: "book-1", Stock: 2}, nil)
+				bookRepo.On("GetCopy", mock.Anything, "LIB-0002").Return(&domain.BookCopy{BookID: "book-2", Barcode: "LIB-0002", Status: domain.CopyStatusAvailable}, nil)
+				bookRepo.On("GetByID", mock.Anything, "book-2").Return(&domain.Book{ID: "book-2", Stock: 1}, nil)
+			},
+			wantErrs:     []error{nil, nil},
+			wantReserved: 2,
+		},
+		{
+			name:  "failed items do not stop the rest",
+			role:  domain.RoleOperation,
+			items: []BatchItem{{BookID: "missing"}, {Barcode: "LIB-0009"}, {BookID: "book-1"}},
+			mockFn: func(bookRepo *mocks.BookRepository) {
+				bookRepo.On("GetByID", mock.Anything, "missing").Return(nil, book.ErrBookNotFound)
+				bookRepo.On("GetCopy", mock.Anything, "LIB-0009").Return(&domain.BookCopy{BookID: "book-9", Barcode: "LIB-0009", Status: domain.CopyStatusInRepair}, nil)
+				bookRepo.On("GetByID", mock.Anything, "book-1").Return(&domain.Book{ID: "book-1", Stock: 2}, nil)
+			},
+			wantErrs:     []error{book.ErrBookNotFound, ErrBookNotAvailable, nil},
+			wantReserved: 1,
+		},
+		{
+			name:  "basket takes the last copy",
+			role:  domain.RoleAdmin,
+			items: []BatchItem{{BookID: "book-1"}, {BookID: "book-1"}},
+			mockFn: func(bookRepo *mocks.BookRepository) {
+				bookRepo.On("GetByID", mock.Anything, "book-1").Return(&domain.Book{ID: "book-1", Stock: 1}, nil).Once()
+			},
+			wantErrs:     []error{nil, ErrBookNotAvailable},
+			wantReserved: 1,
+		},
+		{
+			name:  "same copy twice",
+			role:  domain.RoleOperation,
+			items: []BatchItem{{Barcode: "LIB-0002"}, {Barcode: "LIB-0002"}},
+			mockFn: func(bookRepo *mocks.BookRepository) {
+				bookRepo.On("GetCopy", mock.Anything, "LIB-0002").Return(&domain.BookCopy{BookID: "book-2", Barcode: "LIB-0002", Status: domain.CopyStatusAvailable}, nil).Once()
+				bookRepo.On("GetByID", mock.Anything, "book-2").Return(&domain.Book{ID: "book-2", Stock: 1}, nil)
+			},
+			wantErrs:     []error{nil, ErrInvalidInput},
+			wantReserved: 1,
+		},
+		{
+			name:  "basket over the active loan limit",
+			role:  domain.RoleOperation,
+			loans: []*domain.Transaction{openLoan("book-3", day)},
+			items: []BatchItem{{BookID: "book-1"}, {BookID: "book-2"}},
+			mockFn: func(bookRepo *mocks.BookRepository) {
+				bookRepo.On("GetByID", mock.Anything, "book-1").Return(&domain.Book{ID: "book-1", Stock: 2}, nil)
+				bookRepo.On("GetByID", mock.Anything, "book-2").Return(&domain.Book{ID: "book-2", Stock: 2}, nil)
+			},
+			wantErr:    ErrActiveLoanLimit,
+			wantReason: ReasonActiveLoanLimit,
+		},
+		{
+			name:  "basket over the copies per title limit",
+			role:  domain.RoleOperation,
+			items: []BatchItem{{BookID: "book-1"}, {BookID: "book-1"}},
+			mockFn: func(bookRepo *mocks.BookRepository) {
+				bookRepo.On("GetByID", mock.Anything, "book-1").Return(&domain.Book{ID: "book-1", Stock: 2}, nil)
+			},
+			wantErr:    ErrTitleLoanLimit,
+			wantReason: ReasonTitleLoanLimit,
+		},
+		{
+			name:       "overdue borrower",
+			role:       domain.RoleOperation,
+			loans:      []*domain.Transaction{openLoan("book-3", -day)},
+			items:      []BatchItem{{BookID: "book-1"}},
+			wantErr:    ErrOverdueLoans,
+			wantReason: ReasonOverdueLoans,
+		},
+		{
+			name:    "basket too large",
+			role:    domain.RoleOperation,
+			items:   make([]BatchItem, MaxBatchSize+1),
+			wantErr: ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.IDbRepository)
+			sagaRepo := new(mocks.ISagaRepository)
+			bookRepo := new(mocks.BookRepository)
+			userRepo := new(mocks.UserRepository)
+			userRepo.On("GetByID", mock.Anything, "1").Return(&domain.User{ID: 1, Role: tt.role}, nil).Maybe()
+			repo.On("ListOpenByUser", mock.Anything, "1").Return(tt.loans, nil).Maybe()
+			repo.On("LockBorrower", mock.Anything, "1").Return(func() {}, nil).Maybe()
+			repo.On("Create", mock.Anything, mock.Anything).Return(nil).Maybe()
+			sagaRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Maybe()
+			sagaRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Maybe()
+			bookRepo.On("ReserveStock", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(func(ctx context.Context, id, barcode, transactionID string) string { return barcode }, nil).Maybe()
+			if tt.mockFn != nil {
+				tt.mockFn(bookRepo)
+			}
+			s := NewService(repo, emptyHoldQueue(), noFines(), noBlocks(), bookRepo, userRepo, NewSagaCoordinator(repo, sagaRepo, bookRepo), limitPolicy)
+
+			got, err := s.BatchBorrow(context.Background(), "1", tt.items)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+				if tt.wantReason != "" {
+					var limitErr *LimitError
+					if assert.True(t, errors.As(err, &limitErr)) {
+						assert.Equal(t, tt.wantReason, limitErr.Reason)
+					}
+				}
+			} else if assert.NoError(t, err) && assert.Len(t, got, len(tt.wantErrs)) {
+				for i, outcome := range got {
+					assert.Equal(t, tt.items[i], outcome.Item)
+					if tt.wantErrs[i] != nil {
+						assert.ErrorIs(t, outcome.Err, tt.wantErrs[i])
+						assert.Nil(t, outcome.Transaction)
+					} else {
+						assert.NoError(t, outcome.Err)
+						assert.NotNil(t, outcome.Transaction)
+					}
+				}
+			}
+			bookRepo.AssertNumberOfCalls(t, "ReserveStock", tt.wantReserved)
+			bookRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDefaultService_BatchReturn(t *testing.T) {
+	repo := new(mocks.IDbRepository)
+	sagaRepo := new(mocks.ISagaRepository)
+	bookRepo := new(mocks.BookRepository)
+	returnedAt := time.Now()
+	dueAt := returnedAt.Add(day)
+	open := &domain.Transaction{ID: "tx-1", UserID: "1", BookID: "book-1", DueAt: &dueAt}
+	returned := &domain.Transaction{ID: "tx-1", UserID: "1", BookID: "book-1", DueAt: &dueAt, ReturnedAt: &returnedAt}
+	repo.On("GetOpenByUserAndBook", mock.Anything, "1", "book-1").Return(open, nil)
+	repo.On("GetOpenByBarcode", mock.Anything, "LIB-0002").Return(&domain.Transaction{ID: "tx-2", UserID: "2", BookID: "book-2"}, nil)
+	repo.On("GetByID", mock.Anything, "tx-9").Return(nil, ErrTransactionNotFound)
+	repo.On("GetByID", mock.Anything, "tx-1").Return(open, nil).Once()
+	repo.On("GetByID", mock.Anything, "tx-1").Return(returned, nil).Once()
+	repo.On("MarkAsReturned", mock.Anything, "tx-1", mock.Anything).Return(nil)
+	bookRepo.On("ReleaseStock", mock.Anything, "book-1", "", mock.Anything).Return(nil)
+	sagaRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	sagaRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	s := NewService(repo, emptyHoldQueue(), noFines(), nil, bookRepo, nil, NewSagaCoordinator(repo, sagaRepo, bookRepo), testPolicy)
+
+	got, err := s.BatchReturn(context.Background(), "1", []BatchItem{
+		{BookID: "book-1"},
+		{Barcode: "LIB-0002"},
+		{TransactionID: "tx-9"},
+		{},
+	})
+
+	assert.NoError(t, err)
+	if assert.Len(t, got, 4) {
+		assert.NoError(t, got[0].Err)
+		assert.True(t, got[0].Transaction.IsReturned())
+		assert.ErrorIs(t, got[1].Err, ErrNotBorrower)
+		assert.ErrorIs(t, got[2].Err, ErrTransactionNotFound)
+		assert.ErrorIs(t, got[3].Err, ErrInvalidInput)
+	}
+	repo.AssertExpectations(t)
+	bookRepo.AssertExpectations(t)
+}
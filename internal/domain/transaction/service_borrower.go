@@ -0,0 +1,170 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrActiveLoanLimit is returned when a user already has as many loans as their role allows
+	ErrActiveLoanLimit = errors.New("active loan limit reached")
+	// ErrTitleLoanLimit is returned when a user already has as many copies of a book as their role allows
+	ErrTitleLoanLimit = errors.New("copies per title limit reached")
+	// ErrOverdueLoans is returned when a user holding overdue loans tries to borrow
+	ErrOverdueLoans = errors.New("user has overdue loans")
+	// ErrBorrowerBlocked is returned when an admin has blocked the user from borrowing
+	ErrBorrowerBlocked = errors.New("user is blocked from borrowing")
+)
+
+// Machine-readable reasons a user may not borrow, reported in LimitError
+const (
+	ReasonActiveLoanLimit  = "ACTIVE_LOAN_LIMIT"
+	ReasonTitleLoanLimit   = "TITLE_LOAN_LIMIT"
+	ReasonOverdueLoans     = "OVERDUE_LOANS"
+	ReasonBorrowerBlocked  = "BORROWER_BLOCKED"
+	ReasonOutstandingFines = "OUTSTANDING_FINES"
+)
+
+// LimitError reports a borrowing rule the user breaks. Err is the sentinel
+// error for errors.Is, Reason a stable code clients can act on and Metadata
+// the figures behind it.
+type LimitError struct {
+	Err      error
+	Reason   string
+	Metadata map[string]string
+}
+
+func (e *LimitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *LimitError) Unwrap() error {
+	return e.Err
+}
+
+// BlockBorrower stops a user from borrowing until expiresAt, or until the
+// block is lifted when expiresAt is nil
+func (s *DefaultService) BlockBorrower(ctx context.Context, userID, reason string, expiresAt *time.Time) (*domain.BorrowerBlock, error) {
+	if userID == "" || reason == "" {
+		return nil, ErrInvalidInput
+	}
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, ErrInvalidInput
+	}
+
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	block := domain.NewBorrowerBlock(userID, reason, expiresAt)
+	if err := s.blockRepo.Save(ctx, block); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+// UnblockBorrower lifts a user's borrowing block and returns it
+func (s *DefaultService) UnblockBorrower(ctx context.Context, userID string) (*domain.BorrowerBlock, error) {
+	if userID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	block, err := s.blockRepo.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.blockRepo.Delete(ctx, userID); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+// checkBorrower enforces the rules a user must meet before borrowing anything:
+// no active block, fines within the limit and no overdue loans unless the
+// role allows them. It returns the user's open loans for checkBasket.
+func (s *DefaultService) checkBorrower(ctx context.Context, userID string, role domain.Role) ([]*domain.Transaction, error) {
+	now := time.Now()
+
+	block, err := s.blockRepo.Get(ctx, userID)
+	if err != nil && !errors.Is(err, ErrBlockNotFound) {
+		return nil, err
+	}
+	if block != nil && block.IsActive(now) {
+		metadata := map[string]string{"reason": block.Reason}
+		if block.ExpiresAt != nil {
+			metadata["expires_at"] = block.ExpiresAt.Format(time.RFC3339)
+		}
+		return nil, &LimitError{Err: ErrBorrowerBlocked, Reason: ReasonBorrowerBlocked, Metadata: metadata}
+	}
+
+	balance, err := s.ledgerRepo.Balance(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if balance > s.policy.Fines.BorrowLimit {
+		return nil, &LimitError{Err: ErrOutstandingFines, Reason: ReasonOutstandingFines, Metadata: map[string]string{
+			"balance": strconv.FormatInt(balance, 10),
+			"limit":   strconv.FormatInt(s.policy.Fines.BorrowLimit, 10),
+		}}
+	}
+
+	loans, err := s.repoDb.ListOpenByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var overdue int32
+	for _, loan := range loans {
+		if loan.IsOverdue(now) {
+			overdue++
+		}
+	}
+	if overdue > 0 && !s.policy.Limits(role).AllowOverdue {
+		return nil, &LimitError{Err: ErrOverdueLoans, Reason: ReasonOverdueLoans, Metadata: map[string]string{
+			"overdue_loans": strconv.Itoa(int(overdue)),
+		}}
+	}
+
+	return loans, nil
+}
+
+// checkBasket enforces the role's active loan and copies per title limits
+// for a user with the open loans borrowing the books of a basket, listed once
+// per copy
+func (s *DefaultService) checkBasket(role domain.Role, loans []*domain.Transaction, basket []string) error {
+	limits := s.policy.Limits(role)
+	if limits.MaxActiveLoans > 0 && int32(len(loans)+len(basket)) > limits.MaxActiveLoans {
+		return &LimitError{Err: ErrActiveLoanLimit, Reason: ReasonActiveLoanLimit, Metadata: map[string]string{
+			"active_loans": strconv.Itoa(len(loans)),
+			"requested":    strconv.Itoa(len(basket)),
+			"limit":        strconv.Itoa(int(limits.MaxActiveLoans)),
+		}}
+	}
+	if limits.MaxCopiesPerTitle <= 0 {
+		return nil
+	}
+
+	copies := make(map[string]int32)
+	for _, loan := range loans {
+		copies[loan.BookID]++
+	}
+	requested := make(map[string]int32)
+	for _, bookID := range basket {
+		requested[bookID]++
+	}
+	for _, bookID := range basket {
+		if copies[bookID]+requested[bookID] > limits.MaxCopiesPerTitle {
+			return &LimitError{Err: ErrTitleLoanLimit, Reason: ReasonTitleLoanLimit, Metadata: map[string]string{
+				"book_id":   bookID,
+				"copies":    strconv.Itoa(int(copies[bookID])),
+				"requested": strconv.Itoa(int(requested[bookID])),
+				"limit":     strconv.Itoa(int(limits.MaxCopiesPerTitle)),
+			}}
+		}
+	}
+
+	return nil
+}
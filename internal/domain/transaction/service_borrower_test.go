@@ -0,0 +1,295 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"github.com/hinha/library-management-synapsis/internal/domain/transaction/mocks"
+	"github.com/hinha/library-management-synapsis/internal/domain/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+var limitPolicy = LoanPolicy{
+	DefaultPeriod: 14 * day,
+	Fines:         FinePolicy{BorrowLimit: 1000},
+	DefaultLimits: BorrowLimits{MaxActiveLoans: 2, MaxCopiesPerTitle: 1},
+	RoleLimits: map[domain.Role]BorrowLimits{
+		domain.RoleAdmin: {MaxActiveLoans: 5, MaxCopiesPerTitle: 2, AllowOverdue: true},
+	},
+}
+
+// openLoan returns an open loan of bookID due after dueIn
+func openLoan(bookID string, dueIn time.Duration) *domain.Transaction {
+	dueAt := time.Now().Add(dueIn)
+	return &domain.Transaction{ID: "tx-" + bookID, UserID: "1", BookID: bookID, BorrowedAt: time.Now(), DueAt: &dueAt}
+}
+
+func TestDefaultService_BorrowBook_Limits(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name       string
+		role       domain.Role
+		block      *domain.BorrowerBlock
+		balance    int64
+		loans      []*domain.Transaction
+		wantErr    error
+		wantReason string
+	}{
+		{
+			name:  "within limits",
+			role:  domain.RoleOperation,
+			loans: []*domain.Transaction{openLoan("book-2", day)},
+		},
+		{
+			name:       "blocked until lifted",
+			role:       domain.RoleOperation,
+			block:      &domain.BorrowerBlock{UserID: "1", Reason: "lost library card"},
+			wantErr:    ErrBorrowerBlocked,
+			wantReason: ReasonBorrowerBlocked,
+		},
+		{
+			name:       "blocked until a future date",
+			role:       domain.RoleOperation,
+			block:      &domain.BorrowerBlock{UserID: "1", Reason: "damaged books", ExpiresAt: &future},
+			wantErr:    ErrBorrowerBlocked,
+			wantReason: ReasonBorrowerBlocked,
+		},
+		{
+			name:  "expired block",
+			role:  domain.RoleOperation,
+			block: &domain.BorrowerBlock{UserID: "1", Reason: "damaged books", ExpiresAt: &past},
+		},
+		{
+			name:       "outstanding fines",
+			role:       domain.RoleOperation,
+			balance:    1500,
+			wantErr:    ErrOutstandingFines,
+			wantReason: ReasonOutstandingFines,
+		},
+		{
+			name:       "overdue loan",
+			role:       domain.RoleOperation,
+			loans:      []*domain.Transaction{openLoan("book-2", -day)},
+			wantErr:    ErrOverdueLoans,
+			wantReason: ReasonOverdueLoans,
+		},
+		{
+			name:  "role may borrow while overdue",
+			role:  domain.RoleAdmin,
+			loans: []*domain.Transaction{openLoan("book-2", -day)},
+		},
+		{
+			name:       "active loan limit",
+			role:       domain.RoleOperation,
+			loans:      []*domain.Transaction{openLoan("book-2", day), openLoan("book-3", day)},
+			wantErr:    ErrActiveLoanLimit,
+			wantReason: ReasonActiveLoanLimit,
+		},
+		{
+			name:       "copies per title limit",
+			role:       domain.RoleOperation,
+			loans:      []*domain.Transaction{openLoan("book-1", day)},
+			wantErr:    ErrTitleLoanLimit,
+			wantReason: ReasonTitleLoanLimit,
+		},
+		{
+			name:  "role limits apply",
+			role:  domain.RoleAdmin,
+			loans: []*domain.Transaction{openLoan("book-1", day), openLoan("book-2", day), openLoan("book-3", day)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.IDbRepository)
+			sagaRepo := new(mocks.ISagaRepository)
+			ledgerRepo := new(mocks.ILedgerRepository)
+			blockRepo := new(mocks.IBlockRepository)
+			bookRepo := new(mocks.BookRepository)
+			userRepo := new(mocks.UserRepository)
+			userRepo.On("GetByID", mock.Anything, "1").Return(&domain.User{ID: 1, Role: tt.role}, nil)
+			if tt.block != nil {
+				blockRepo.On("Get", mock.Anything, "1").Return(tt.block, nil)
+			} else {
+				blockRepo.On("Get", mock.Anything, "1").Return(nil, ErrBlockNotFound)
+			}
+			ledgerRepo.On("Balance", mock.Anything, "1").Return(tt.balance, nil).Maybe()
+			repo.On("ListOpenByUser", mock.Anything, "1").Return(tt.loans, nil).Maybe()
+			repo.On("LockBorrower", mock.Anything, "1").Return(func() {}, nil).Maybe()
+			bookRepo.On("GetByID", mock.Anything, "book-1").Return(&domain.Book{ID: "book-1", Stock: 3}, nil).Maybe()
+			sagaRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Maybe()
+			sagaRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Maybe()
+			repo.On("Create", mock.Anything, mock.Anything).Return(nil).Maybe()
+			bookRepo.On("ReserveStock", mock.Anything, "book-1", "", mock.Anything).Return("", nil).Maybe()
+			s := NewService(repo, emptyHoldQueue(), ledgerRepo, blockRepo, bookRepo, userRepo, NewSagaCoordinator(repo, sagaRepo, bookRepo), limitPolicy)
+
+			got, err := s.BorrowBook(context.Background(), "1", "book-1", "")
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+				var limitErr *LimitError
+				if assert.True(t, errors.As(err, &limitErr)) {
+					assert.Equal(t, tt.wantReason, limitErr.Reason)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, got)
+			}
+		})
+	}
+}
+
+func TestDefaultService_BorrowBook_LocksBorrower(t *testing.T) {
+	t.Run("checks and opens the loan under the lock", func(t *testing.T) {
+		repo := new(mocks.IDbRepository)
+		sagaRepo := new(mocks.ISagaRepository)
+		bookRepo := new(mocks.BookRepository)
+		userRepo := new(mocks.UserRepository)
+		var locked, unlocked bool
+		userRepo.On("GetByID", mock.Anything, "1").Return(&domain.User{ID: 1, Role: domain.RoleOperation}, nil)
+		repo.On("LockBorrower", mock.Anything, "1").Run(func(mock.Arguments) { locked = true }).Return(func() { unlocked = true }, nil)
+		repo.On("ListOpenByUser", mock.Anything, "1").Run(func(mock.Arguments) {
+			assert.True(t, locked && !unlocked, "limits checked outside the lock")
+		}).Return([]*domain.Transaction{}, nil)
+		repo.On("Create", mock.Anything, mock.Anything).Run(func(mock.Arguments) {
+			assert.False(t, unlocked, "loan opened outside the lock")
+		}).Return(nil)
+		bookRepo.On("GetByID", mock.Anything, "book-1").Return(&domain.Book{ID: "book-1", Stock: 1}, nil)
+		bookRepo.On("ReserveStock", mock.Anything, "book-1", "", mock.Anything).Return("", nil)
+		sagaRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		sagaRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		s := NewService(repo, emptyHoldQueue(), noFines(), noBlocks(), bookRepo, userRepo, NewSagaCoordinator(repo, sagaRepo, bookRepo), limitPolicy)
+
+		_, err := s.BorrowBook(context.Background(), "1", "book-1", "")
+
+		assert.NoError(t, err)
+		assert.True(t, unlocked)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("lock fails", func(t *testing.T) {
+		repo := new(mocks.IDbRepository)
+		userRepo := new(mocks.UserRepository)
+		userRepo.On("GetByID", mock.Anything, "1").Return(&domain.User{ID: 1, Role: domain.RoleOperation}, nil)
+		repo.On("LockBorrower", mock.Anything, "1").Return(nil, errors.New("db down"))
+		s := NewService(repo, nil, nil, nil, nil, userRepo, nil, limitPolicy)
+
+		got, err := s.BorrowBook(context.Background(), "1", "book-1", "")
+
+		assert.EqualError(t, err, "db down")
+		assert.Nil(t, got)
+	})
+}
+
+func TestDefaultService_BlockBorrower(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		userID    string
+		reason    string
+		expiresAt *time.Time
+		mockFn    func(blockRepo *mocks.IBlockRepository, userRepo *mocks.UserRepository)
+		wantErr   error
+	}{
+		{
+			name:   "block until lifted",
+			userID: "1",
+			reason: "lost library card",
+			mockFn: func(blockRepo *mocks.IBlockRepository, userRepo *mocks.UserRepository) {
+				userRepo.On("GetByID", mock.Anything, "1").Return(&domain.User{ID: 1}, nil)
+				blockRepo.On("Save", mock.Anything, mock.MatchedBy(func(b *domain.BorrowerBlock) bool {
+					return b.UserID == "1" && b.ExpiresAt == nil
+				})).Return(nil)
+			},
+		},
+		{
+			name:      "block until a date",
+			userID:    "1",
+			reason:    "damaged books",
+			expiresAt: &future,
+			mockFn: func(blockRepo *mocks.IBlockRepository, userRepo *mocks.UserRepository) {
+				userRepo.On("GetByID", mock.Anything, "1").Return(&domain.User{ID: 1}, nil)
+				blockRepo.On("Save", mock.Anything, mock.MatchedBy(func(b *domain.BorrowerBlock) bool {
+					return b.ExpiresAt != nil && b.ExpiresAt.Equal(future)
+				})).Return(nil)
+			},
+		},
+		{
+			name:      "expiry in the past",
+			userID:    "1",
+			reason:    "damaged books",
+			expiresAt: &past,
+			wantErr:   ErrInvalidInput,
+		},
+		{
+			name:    "missing reason",
+			userID:  "1",
+			wantErr: ErrInvalidInput,
+		},
+		{
+			name:   "user not found",
+			userID: "9",
+			reason: "lost library card",
+			mockFn: func(blockRepo *mocks.IBlockRepository, userRepo *mocks.UserRepository) {
+				userRepo.On("GetByID", mock.Anything, "9").Return(nil, user.ErrUserNotFound)
+			},
+			wantErr: user.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blockRepo := new(mocks.IBlockRepository)
+			userRepo := new(mocks.UserRepository)
+			if tt.mockFn != nil {
+				tt.mockFn(blockRepo, userRepo)
+			}
+			s := NewService(nil, nil, nil, blockRepo, nil, userRepo, nil, limitPolicy)
+
+			got, err := s.BlockBorrower(context.Background(), tt.userID, tt.reason, tt.expiresAt)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.reason, got.Reason)
+			}
+			blockRepo.AssertExpectations(t)
+			userRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDefaultService_UnblockBorrower(t *testing.T) {
+	t.Run("lifts the block", func(t *testing.T) {
+		blockRepo := new(mocks.IBlockRepository)
+		blockRepo.On("Get", mock.Anything, "1").Return(&domain.BorrowerBlock{UserID: "1", Reason: "lost library card"}, nil)
+		blockRepo.On("Delete", mock.Anything, "1").Return(nil)
+		s := NewService(nil, nil, nil, blockRepo, nil, nil, nil, limitPolicy)
+
+		got, err := s.UnblockBorrower(context.Background(), "1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "lost library card", got.Reason)
+		blockRepo.AssertExpectations(t)
+	})
+
+	t.Run("not blocked", func(t *testing.T) {
+		blockRepo := new(mocks.IBlockRepository)
+		blockRepo.On("Get", mock.Anything, "1").Return(nil, ErrBlockNotFound)
+		s := NewService(nil, nil, nil, blockRepo, nil, nil, nil, limitPolicy)
+
+		_, err := s.UnblockBorrower(context.Background(), "1")
+
+		assert.ErrorIs(t, err, ErrBlockNotFound)
+	})
+}
@@ -0,0 +1,140 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"time"
+
+	"github.com/hinha/library-management-synapsis/internal/domain/user"
+	"github.com/rs/zerolog/log"
+)
+
+// ListDeletedTransactions retrieves one page of the soft-deleted
+// transactions, most recently deleted first. It returns the token for the
+// next page, empty on the last page.
+func (s *DefaultService) ListDeletedTransactions(ctx context.Context, pageSize int, pageToken string) ([]*domain.Transaction, string, error) {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	var after *domain.DeletedCursor
+	if pageToken != "" {
+		cursor, ok := domain.DecodeDeletedCursor(pageToken)
+		if !ok {
+			return nil, "", ErrInvalidPageToken
+		}
+		after = cursor
+	}
+
+	// One transaction past the page tells whether another page follows
+	transactions, err := s.repoDb.ListDeleted(ctx, after, pageSize+1)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(transactions) <= pageSize {
+		return transactions, "", nil
+	}
+
+	transactions = transactions[:pageSize]
+	last := transactions[pageSize-1]
+	nextPageToken, err := domain.DeletedCursor{DeletedAt: last.DeletedAt.Time, ID: last.ID}.Encode()
+	if err != nil {
+		return nil, "", err
+	}
+	return transactions, nextPageToken, nil
+}
+
+// RestoreTransaction brings a soft-deleted transaction back. An unreturned
+// loan is restored like a borrow: the borrower must still be allowed to
+// borrow the book, and its copy is taken out of stock again, so it fails with
+// ErrBookNotAvailable when the copy, or every copy, has been lent since.
+func (s *DefaultService) RestoreTransaction(ctx context.Context, transactionID string) (*domain.Transaction, error) {
+	if transactionID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	transaction, err := s.repoDb.GetDeleted(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if transaction.IsReturned() {
+		if err := s.repoDb.Restore(ctx, transaction); err != nil {
+			return nil, err
+		}
+		return transaction, nil
+	}
+
+	u, err := s.userRepo.GetByID(ctx, transaction.UserID)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			return nil, user.ErrUserNotFound
+		}
+		return nil, err
+	}
+	unlock, err := s.repoDb.LockBorrower(ctx, transaction.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	loans, err := s.checkBorrower(ctx, transaction.UserID, u.Role)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkBasket(u.Role, loans, []string{transaction.BookID}); err != nil {
+		return nil, err
+	}
+
+	// The loan was deleted when its borrow was undone, which cancelled its
+	// reservation; the saga reopens it and deletes the loan again if it cannot
+	if err := s.saga.Restore(ctx, transaction); err != nil {
+		if lent(err) {
+			return nil, ErrBookNotAvailable
+		}
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// PurgeDeletedTransactions permanently removes the transactions soft-deleted
+// longer ago than retention. It returns how many transactions were removed
+// and the cutoff.
+func (s *DefaultService) PurgeDeletedTransactions(ctx context.Context, retention time.Duration) (int64, time.Time, error) {
+	if retention <= 0 {
+		return 0, time.Time{}, ErrInvalidInput
+	}
+
+	before := time.Now().Add(-retention)
+	purged, err := s.repoDb.Purge(ctx, before)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	return purged, before, nil
+}
+
+// RunRetention calls PurgeDeletedTransactions every interval until ctx is
+// cancelled
+func (s *DefaultService) RunRetention(ctx context.Context, interval, retention time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, _, err := s.PurgeDeletedTransactions(ctx, retention)
+			if err != nil {
+				log.Error().Err(err).Msg("Failed to purge deleted transactions")
+			} else if purged > 0 {
+				log.Info().Int64("transactions", purged).Msg("Purged deleted transactions")
+			}
+		}
+	}
+}
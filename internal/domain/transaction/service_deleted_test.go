@@ -0,0 +1,167 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"github.com/hinha/library-management-synapsis/internal/domain/book"
+	"github.com/hinha/library-management-synapsis/internal/domain/transaction/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+func TestDefaultService_ListDeletedTransactions(t *testing.T) {
+	deletedAt := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	transactions := []*domain.Transaction{
+		{ID: "tx-2", DeletedAt: gorm.DeletedAt{Time: deletedAt, Valid: true}},
+		{ID: "tx-1", DeletedAt: gorm.DeletedAt{Time: deletedAt, Valid: true}},
+	}
+	nextPage, _ := domain.DeletedCursor{DeletedAt: deletedAt, ID: "tx-2"}.Encode()
+
+	repo := new(mocks.IDbRepository)
+	repo.On("ListDeleted", mock.Anything, (*domain.DeletedCursor)(nil), 2).Return(transactions, nil)
+	s := NewService(repo, nil, nil, nil, nil, nil, nil, testPolicy)
+
+	got, pageToken, err := s.ListDeletedTransactions(context.Background(), 1, "")
+
+	assert.NoError(t, err)
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, "tx-2", got[0].ID)
+	}
+	assert.Equal(t, nextPage, pageToken)
+
+	_, _, err = s.ListDeletedTransactions(context.Background(), 1, "not-a-token")
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+	repo.AssertExpectations(t)
+}
+
+func TestDefaultService_RestoreTransaction(t *testing.T) {
+	returnedAt := time.Date(2025, 6, 5, 9, 0, 0, 0, time.UTC)
+	overdueAt := time.Now().Add(-day)
+	openLoan := func() *domain.Transaction {
+		return &domain.Transaction{ID: "tx-1", UserID: "1", BookID: "book-1"}
+	}
+
+	tests := []struct {
+		name        string
+		transaction *domain.Transaction
+		openLoans   []*domain.Transaction
+		mockFn      func(repo *mocks.IDbRepository, bookRepo *mocks.BookRepository)
+		wantBarcode string
+		wantErr     error
+	}{
+		{
+			name:        "open loan takes a copy out of stock again",
+			transaction: openLoan(),
+			mockFn: func(repo *mocks.IDbRepository, bookRepo *mocks.BookRepository) {
+				repo.On("Restore", mock.Anything, mock.AnythingOfType("*domain.Transaction")).Return(nil)
+				bookRepo.On("ReopenStock", mock.Anything, "book-1", "", "tx-1").Return("LIB-0002", nil)
+				repo.On("AssignCopy", mock.Anything, "tx-1", "LIB-0002").Return(nil)
+			},
+			wantBarcode: "LIB-0002",
+		},
+		{
+			name:        "returned loan leaves the stock alone",
+			transaction: &domain.Transaction{ID: "tx-1", UserID: "1", BookID: "book-1", CopyBarcode: "LIB-0001", ReturnedAt: &returnedAt},
+			mockFn: func(repo *mocks.IDbRepository, bookRepo *mocks.BookRepository) {
+				repo.On("Restore", mock.Anything, mock.AnythingOfType("*domain.Transaction")).Return(nil)
+			},
+			wantBarcode: "LIB-0001",
+		},
+		{
+			name:        "no copy left deletes the loan again",
+			transaction: openLoan(),
+			mockFn: func(repo *mocks.IDbRepository, bookRepo *mocks.BookRepository) {
+				repo.On("Restore", mock.Anything, mock.AnythingOfType("*domain.Transaction")).Return(nil)
+				bookRepo.On("ReopenStock", mock.Anything, "book-1", "", "tx-1").Return("", book.ErrInsufficientStock)
+				bookRepo.On("CancelStock", mock.Anything, "book-1", "tx-1").Return(nil)
+				repo.On("Delete", mock.Anything, "tx-1").Return(nil)
+			},
+			wantErr: ErrBookNotAvailable,
+		},
+		{
+			name:        "copy lent since deletes the loan again",
+			transaction: &domain.Transaction{ID: "tx-1", UserID: "1", BookID: "book-1", CopyBarcode: "LIB-0001"},
+			mockFn: func(repo *mocks.IDbRepository, bookRepo *mocks.BookRepository) {
+				repo.On("Restore", mock.Anything, mock.AnythingOfType("*domain.Transaction")).Return(nil)
+				bookRepo.On("ReopenStock", mock.Anything, "book-1", "LIB-0001", "tx-1").Return("", book.ErrCopyNotAvailable)
+				bookRepo.On("CancelStock", mock.Anything, "book-1", "tx-1").Return(nil)
+				repo.On("Delete", mock.Anything, "tx-1").Return(nil)
+			},
+			wantErr: ErrBookNotAvailable,
+		},
+		{
+			name:        "restore fails",
+			transaction: openLoan(),
+			mockFn: func(repo *mocks.IDbRepository, bookRepo *mocks.BookRepository) {
+				repo.On("Restore", mock.Anything, mock.AnythingOfType("*domain.Transaction")).Return(errors.New("db down"))
+				repo.On("Delete", mock.Anything, "tx-1").Return(ErrTransactionNotFound)
+			},
+			wantErr: errors.New("db down"),
+		},
+		{
+			name:        "borrower has overdue loans",
+			transaction: openLoan(),
+			openLoans:   []*domain.Transaction{{ID: "tx-2", UserID: "1", BookID: "book-2", DueAt: &overdueAt}},
+			mockFn:      func(repo *mocks.IDbRepository, bookRepo *mocks.BookRepository) {},
+			wantErr:     ErrOverdueLoans,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.IDbRepository)
+			sagaRepo := new(mocks.ISagaRepository)
+			bookRepo := new(mocks.BookRepository)
+			userRepo := new(mocks.UserRepository)
+			repo.On("GetDeleted", mock.Anything, "tx-1").Return(tt.transaction, nil)
+			repo.On("ListOpenByUser", mock.Anything, "1").Return(tt.openLoans, nil).Maybe()
+			repo.On("LockBorrower", mock.Anything, "1").Return(func() {}, nil).Maybe()
+			userRepo.On("GetByID", mock.Anything, "1").Return(&domain.User{ID: 1, Role: domain.RoleOperation}, nil).Maybe()
+			sagaRepo.On("Create", mock.Anything, mock.MatchedBy(func(saga *domain.Saga) bool {
+				return saga.Type == domain.SagaTypeBorrow
+			})).Return(nil).Maybe()
+			sagaRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Maybe()
+			tt.mockFn(repo, bookRepo)
+			s := NewService(repo, nil, noFines(), noBlocks(), bookRepo, userRepo, NewSagaCoordinator(repo, sagaRepo, bookRepo), testPolicy)
+
+			got, err := s.RestoreTransaction(context.Background(), "tx-1")
+
+			if tt.wantErr != nil {
+				assert.ErrorContains(t, err, tt.wantErr.Error())
+			} else if assert.NoError(t, err) {
+				assert.Equal(t, tt.wantBarcode, got.CopyBarcode)
+			}
+			repo.AssertExpectations(t)
+			bookRepo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("not deleted", func(t *testing.T) {
+		repo := new(mocks.IDbRepository)
+		repo.On("GetDeleted", mock.Anything, "tx-9").Return(nil, ErrTransactionNotFound)
+		s := NewService(repo, nil, nil, nil, nil, nil, nil, testPolicy)
+
+		_, err := s.RestoreTransaction(context.Background(), "tx-9")
+
+		assert.ErrorIs(t, err, ErrTransactionNotFound)
+	})
+}
+
+func TestDefaultService_PurgeDeletedTransactions(t *testing.T) {
+	repo := new(mocks.IDbRepository)
+	repo.On("Purge", mock.Anything, mock.AnythingOfType("time.Time")).Return(int64(4), nil)
+	s := NewService(repo, nil, nil, nil, nil, nil, nil, testPolicy)
+
+	purged, before, err := s.PurgeDeletedTransactions(context.Background(), 48*time.Hour)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), purged)
+	assert.WithinDuration(t, time.Now().Add(-48*time.Hour), before, time.Minute)
+
+	_, _, err = s.PurgeDeletedTransactions(context.Background(), -time.Hour)
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
@@ -0,0 +1,92 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"time"
+)
+
+// ErrExceedsBalance is returned when a payment or waiver is larger than what the user owes
+var ErrExceedsBalance = errors.New("amount exceeds outstanding balance")
+
+// GetBalance returns what a user owes in fines
+func (s *DefaultService) GetBalance(ctx context.Context, userID string) (int64, error) {
+	if userID == "" {
+		return 0, ErrInvalidInput
+	}
+
+	return s.ledgerRepo.Balance(ctx, userID)
+}
+
+// ListLedger retrieves a user's ledger, oldest first, with the balance it adds up to
+func (s *DefaultService) ListLedger(ctx context.Context, userID string) ([]*domain.LedgerEntry, int64, error) {
+	if userID == "" {
+		return nil, 0, ErrInvalidInput
+	}
+
+	entries, err := s.ledgerRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var balance int64
+	for _, entry := range entries {
+		balance += entry.Signed()
+	}
+	return entries, balance, nil
+}
+
+// RecordPayment records money a user paid towards their balance
+func (s *DefaultService) RecordPayment(ctx context.Context, userID string, amount int64, note string) (*domain.LedgerEntry, error) {
+	return s.settle(ctx, userID, domain.LedgerEntryPayment, amount, note)
+}
+
+// WaiveFine forgives part of a user's balance
+func (s *DefaultService) WaiveFine(ctx context.Context, userID string, amount int64, reason string) (*domain.LedgerEntry, error) {
+	if reason == "" {
+		return nil, ErrInvalidInput
+	}
+	return s.settle(ctx, userID, domain.LedgerEntryWaiver, amount, reason)
+}
+
+// settle takes amount off a user's balance. Nothing is taken off beyond what
+// the user owes, so the balance never turns into credit; the repository
+// checks the balance and writes the entry in one go.
+func (s *DefaultService) settle(ctx context.Context, userID string, entryType domain.LedgerEntryType, amount int64, note string) (*domain.LedgerEntry, error) {
+	if userID == "" || amount <= 0 {
+		return nil, ErrInvalidInput
+	}
+
+	entry := domain.NewLedgerEntry(userID, entryType, amount, note)
+	if err := s.ledgerRepo.Settle(ctx, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// lateFee returns the late fee charged when transaction is returned. The
+// book's category picks the daily rate; when the book service cannot be
+// reached the default rate applies. A loan that is not overdue yet is not
+// charged, so the book service is only asked about overdue loans.
+func (s *DefaultService) lateFee(ctx context.Context, transaction *domain.Transaction) domain.LateFee {
+	if transaction.DaysLate(time.Now()) <= 0 {
+		return nil
+	}
+
+	var category string
+	if b, err := s.bookRepo.GetByID(ctx, transaction.BookID); err == nil {
+		category = b.Category
+	}
+
+	return func(returned *domain.Transaction) *domain.LedgerEntry {
+		daysLate := returned.DaysLate(*returned.ReturnedAt)
+		amount := s.policy.Fines.Fine(category, daysLate)
+		if amount <= 0 {
+			return nil
+		}
+		note := fmt.Sprintf("returned %d day(s) late on %s", daysLate, returned.ReturnedAt.Format(time.DateOnly))
+		return domain.NewFineCharge(returned, amount, note)
+	}
+}
@@ -0,0 +1,196 @@
+package transaction
+
+import (
+	"context"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"github.com/hinha/library-management-synapsis/internal/domain/transaction/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+var testFines = FinePolicy{
+	PerDay:         100,
+	CategoryPerDay: map[string]int64{"Reference": 500},
+	Max:            2000,
+	BorrowLimit:    1000,
+}
+
+func TestFinePolicy_Fine(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   FinePolicy
+		category string
+		daysLate int32
+		want     int64
+	}{
+		{name: "on time", policy: testFines, category: "Fiction", daysLate: 0, want: 0},
+		{name: "default rate", policy: testFines, category: "Fiction", daysLate: 3, want: 300},
+		{name: "category rate", policy: testFines, category: "Reference", daysLate: 2, want: 1000},
+		{name: "capped", policy: testFines, category: "Reference", daysLate: 10, want: 2000},
+		{name: "uncapped", policy: FinePolicy{PerDay: 100}, category: "Fiction", daysLate: 30, want: 3000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.policy.Fine(tt.category, tt.daysLate))
+		})
+	}
+}
+
+func TestDefaultService_BorrowBook_OutstandingFines(t *testing.T) {
+	repo := new(mocks.IDbRepository)
+	userRepo := new(mocks.UserRepository)
+	ledgerRepo := new(mocks.ILedgerRepository)
+	repo.On("LockBorrower", mock.Anything, "1").Return(func() {}, nil)
+	userRepo.On("GetByID", mock.Anything, "1").Return(&domain.User{ID: 1, Role: domain.RoleOperation}, nil)
+	ledgerRepo.On("Balance", mock.Anything, "1").Return(int64(1001), nil)
+	s := NewService(repo, nil, ledgerRepo, noBlocks(), nil, userRepo, nil, LoanPolicy{Fines: testFines})
+
+	got, err := s.BorrowBook(context.Background(), "1", "book-1", "")
+
+	assert.ErrorIs(t, err, ErrOutstandingFines)
+	assert.Nil(t, got)
+	ledgerRepo.AssertExpectations(t)
+}
+
+func TestDefaultService_ReturnBook_ChargesLateFee(t *testing.T) {
+	tests := []struct {
+		name     string
+		dueIn    time.Duration
+		category string
+		want     int64
+	}{
+		{name: "on time", dueIn: day, category: "Fiction"},
+		{name: "three days late", dueIn: -3 * day, category: "Fiction", want: 300},
+		{name: "capped", dueIn: -10 * day, category: "Reference", want: 2000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.IDbRepository)
+			sagaRepo := new(mocks.ISagaRepository)
+			bookRepo := new(mocks.BookRepository)
+			ledgerRepo := new(mocks.ILedgerRepository)
+			returnedAt := time.Now()
+			dueAt := returnedAt.Add(tt.dueIn)
+			open := &domain.Transaction{ID: "tx-1", UserID: "1", BookID: "book-1", DueAt: &dueAt}
+			returned := &domain.Transaction{ID: "tx-1", UserID: "1", BookID: "book-1", DueAt: &dueAt, ReturnedAt: &returnedAt}
+			repo.On("GetByID", mock.Anything, "tx-1").Return(open, nil).Once()
+			repo.On("GetByID", mock.Anything, "tx-1").Return(returned, nil).Once()
+			var charge *domain.LedgerEntry
+			repo.On("MarkAsReturned", mock.Anything, "tx-1", mock.Anything).Run(func(args mock.Arguments) {
+				if lateFee := args.Get(2).(domain.LateFee); lateFee != nil {
+					charge = lateFee(returned)
+				}
+			}).Return(nil)
+			bookRepo.On("ReleaseStock", mock.Anything, "book-1", "", mock.Anything).Return(nil)
+			sagaRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+			sagaRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+			if tt.want > 0 {
+				bookRepo.On("GetByID", mock.Anything, "book-1").Return(&domain.Book{ID: "book-1", Category: tt.category}, nil)
+			}
+			s := NewService(repo, emptyHoldQueue(), ledgerRepo, nil, bookRepo, nil, NewSagaCoordinator(repo, sagaRepo, bookRepo), LoanPolicy{Fines: testFines})
+
+			got, err := s.ReturnBook(context.Background(), "tx-1")
+
+			assert.NoError(t, err)
+			assert.True(t, got.IsReturned())
+			if tt.want > 0 && assert.NotNil(t, charge) {
+				assert.Equal(t, domain.LedgerEntryCharge, charge.Type)
+				assert.Equal(t, tt.want, charge.Amount)
+				assert.Equal(t, "tx-1", charge.TransactionID)
+				assert.Equal(t, "1", charge.UserID)
+			} else {
+				assert.Nil(t, charge)
+			}
+			bookRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDefaultService_Settle(t *testing.T) {
+	tests := []struct {
+		name     string
+		settle   func(s *DefaultService) (*domain.LedgerEntry, error)
+		wantType domain.LedgerEntryType
+		wantErr  error
+	}{
+		{
+			name: "payment",
+			settle: func(s *DefaultService) (*domain.LedgerEntry, error) {
+				return s.RecordPayment(context.Background(), "1", 300, "cash")
+			},
+			wantType: domain.LedgerEntryPayment,
+		},
+		{
+			name: "waiver",
+			settle: func(s *DefaultService) (*domain.LedgerEntry, error) {
+				return s.WaiveFine(context.Background(), "1", 500, "book was damaged on arrival")
+			},
+			wantType: domain.LedgerEntryWaiver,
+		},
+		{
+			name: "payment beyond balance",
+			settle: func(s *DefaultService) (*domain.LedgerEntry, error) {
+				return s.RecordPayment(context.Background(), "1", 600, "")
+			},
+			wantType: domain.LedgerEntryPayment,
+			wantErr:  ErrExceedsBalance,
+		},
+		{
+			name: "waiver without reason",
+			settle: func(s *DefaultService) (*domain.LedgerEntry, error) {
+				return s.WaiveFine(context.Background(), "1", 100, "")
+			},
+			wantErr: ErrInvalidInput,
+		},
+		{
+			name: "non-positive amount",
+			settle: func(s *DefaultService) (*domain.LedgerEntry, error) {
+				return s.RecordPayment(context.Background(), "1", 0, "")
+			},
+			wantErr: ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ledgerRepo := new(mocks.ILedgerRepository)
+			if tt.wantErr != ErrInvalidInput {
+				ledgerRepo.On("Settle", mock.Anything, mock.MatchedBy(func(e *domain.LedgerEntry) bool {
+					return e.Type == tt.wantType && e.UserID == "1"
+				})).Return(tt.wantErr)
+			}
+			s := NewService(nil, nil, ledgerRepo, nil, nil, nil, nil, LoanPolicy{Fines: testFines})
+
+			got, err := tt.settle(s)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantType, got.Type)
+			}
+			ledgerRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDefaultService_ListLedger(t *testing.T) {
+	ledgerRepo := new(mocks.ILedgerRepository)
+	ledgerRepo.On("ListByUser", mock.Anything, "1").Return([]*domain.LedgerEntry{
+		domain.NewLedgerEntry("1", domain.LedgerEntryCharge, 700, ""),
+		domain.NewLedgerEntry("1", domain.LedgerEntryPayment, 200, ""),
+		domain.NewLedgerEntry("1", domain.LedgerEntryWaiver, 100, ""),
+	}, nil)
+	s := NewService(nil, nil, ledgerRepo, nil, nil, nil, nil, testPolicy)
+
+	entries, balance, err := s.ListLedger(context.Background(), "1")
+
+	assert.NoError(t, err)
+	assert.Len(t, entries, 3)
+	assert.Equal(t, int64(400), balance)
+}
@@ -0,0 +1,235 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"github.com/rs/zerolog/log"
+	"time"
+)
+
+var (
+	// ErrHoldExists is returned when the user already has an active hold on the book
+	ErrHoldExists = errors.New("hold already exists")
+	// ErrHoldNotActive is returned when a fulfilled, cancelled or expired hold is changed
+	ErrHoldNotActive = errors.New("hold is no longer active")
+	// ErrBookAvailable is returned when a hold is placed on a book that can be borrowed now
+	ErrBookAvailable = errors.New("book is available to borrow")
+)
+
+// PlaceHold puts the user at the end of the queue for a book with no copy available
+func (s *DefaultService) PlaceHold(ctx context.Context, userID, bookID string) (*domain.Hold, error) {
+	if userID == "" || bookID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	b, err := s.bookRepo.GetByID(ctx, bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	queue, err := s.holdRepo.ListByBook(ctx, bookID)
+	if err != nil {
+		return nil, err
+	}
+	ownHold, reserved := holdsFor(queue, userID, time.Now())
+	if ownHold != nil {
+		return nil, ErrHoldExists
+	}
+	if b.Stock > reserved {
+		return nil, ErrBookAvailable
+	}
+
+	hold := domain.NewHold(userID, bookID)
+	if err := s.holdRepo.Create(ctx, hold); err != nil {
+		return nil, err
+	}
+
+	setPositions(append(queue, hold))
+	return hold, nil
+}
+
+// GetHold retrieves a hold by ID
+func (s *DefaultService) GetHold(ctx context.Context, holdID string) (*domain.Hold, error) {
+	if holdID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	return s.holdRepo.GetByID(ctx, holdID)
+}
+
+// CancelHold cancels an active hold. A copy set aside for it goes to the next
+// patron in line.
+func (s *DefaultService) CancelHold(ctx context.Context, holdID string) (*domain.Hold, error) {
+	if holdID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	hold, err := s.holdRepo.GetByID(ctx, holdID)
+	if err != nil {
+		return nil, err
+	}
+	if !hold.IsActive() {
+		return nil, ErrHoldNotActive
+	}
+
+	wasReady := hold.Status == domain.HoldStatusReady
+	hold.SetStatus(domain.HoldStatusCancelled)
+	if err := s.holdRepo.Update(ctx, hold); err != nil {
+		return nil, err
+	}
+
+	if wasReady {
+		if err := s.promoteNextHold(ctx, hold.BookID); err != nil {
+			log.Error().Err(err).Str("book_id", hold.BookID).Msg("Failed to promote next hold")
+		}
+	}
+
+	return hold, nil
+}
+
+// ListHolds retrieves the queue of a book, or the active holds of a user when
+// no book is given. Given both, it retrieves the user's hold on the book.
+func (s *DefaultService) ListHolds(ctx context.Context, userID, bookID string) ([]*domain.Hold, error) {
+	if userID == "" && bookID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	if bookID != "" {
+		queue, err := s.holdRepo.ListByBook(ctx, bookID)
+		if err != nil {
+			return nil, err
+		}
+		setPositions(queue)
+		if userID == "" {
+			return queue, nil
+		}
+
+		holds := make([]*domain.Hold, 0, 1)
+		for _, hold := range queue {
+			if hold.UserID == userID {
+				holds = append(holds, hold)
+			}
+		}
+		return holds, nil
+	}
+
+	holds, err := s.holdRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, hold := range holds {
+		if hold.Status != domain.HoldStatusWaiting {
+			continue
+		}
+		queue, err := s.holdRepo.ListByBook(ctx, hold.BookID)
+		if err != nil {
+			return nil, err
+		}
+		for _, queued := range setPositions(queue) {
+			if queued.ID == hold.ID {
+				hold.Position = queued.Position
+			}
+		}
+	}
+	return holds, nil
+}
+
+// ExpireHolds expires ready holds that were not picked up in time and passes
+// their copies on to the next patron in line. A hold that fails is logged and
+// skipped so it does not hold up the rest; the failures are returned joined.
+func (s *DefaultService) ExpireHolds(ctx context.Context) error {
+	holds, err := s.holdRepo.ListExpired(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, hold := range holds {
+		hold.SetStatus(domain.HoldStatusExpired)
+		if err := s.holdRepo.Update(ctx, hold); err != nil {
+			log.Error().Err(err).Str("hold_id", hold.ID).Msg("Failed to expire hold")
+			errs = append(errs, err)
+			continue
+		}
+		if err := s.promoteNextHold(ctx, hold.BookID); err != nil {
+			log.Error().Err(err).Str("hold_id", hold.ID).Str("book_id", hold.BookID).Msg("Failed to promote next hold")
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RunHoldExpiry calls ExpireHolds every interval until ctx is cancelled
+func (s *DefaultService) RunHoldExpiry(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.ExpireHolds(ctx); err != nil {
+				log.Error().Err(err).Msg("Failed to expire holds")
+			}
+		}
+	}
+}
+
+// promoteNextHold sets a copy of the book aside for the first waiting hold
+func (s *DefaultService) promoteNextHold(ctx context.Context, bookID string) error {
+	queue, err := s.holdRepo.ListByBook(ctx, bookID)
+	if err != nil {
+		return err
+	}
+
+	for _, hold := range queue {
+		if hold.Status == domain.HoldStatusWaiting {
+			hold.MarkReady(s.policy.HoldPickupPeriod)
+			return s.holdRepo.Update(ctx, hold)
+		}
+	}
+	return nil
+}
+
+// updateHold moves a hold to status. The loan it belongs to has already been
+// recorded, so a failure is only logged.
+func (s *DefaultService) updateHold(ctx context.Context, hold *domain.Hold, status domain.HoldStatus) {
+	hold.SetStatus(status)
+	if err := s.holdRepo.Update(ctx, hold); err != nil {
+		log.Error().Err(err).Str("hold_id", hold.ID).Msg("Failed to update hold")
+	}
+}
+
+// holdsFor returns the user's active hold in queue, if any, and how many
+// copies are set aside for other patrons' ready holds at now
+func holdsFor(queue []*domain.Hold, userID string, now time.Time) (*domain.Hold, int32) {
+	var own *domain.Hold
+	var reserved int32
+	for _, hold := range queue {
+		if hold.UserID == userID {
+			own = hold
+			continue
+		}
+		if hold.IsReady(now) {
+			reserved++
+		}
+	}
+	return own, reserved
+}
+
+// setPositions numbers the waiting holds of a queue in order
+func setPositions(queue []*domain.Hold) []*domain.Hold {
+	var position int32
+	for _, hold := range queue {
+		if hold.Status == domain.HoldStatusWaiting {
+			position++
+			hold.Position = position
+		}
+	}
+	return queue
+}
@@ -0,0 +1,336 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"github.com/hinha/library-management-synapsis/internal/domain/transaction/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+// testHold creates a hold on book-1 in the given state
+func testHold(id, userID string, status domain.HoldStatus) *domain.Hold {
+	hold := &domain.Hold{ID: id, UserID: userID, BookID: "book-1", Status: status}
+	if status == domain.HoldStatusReady {
+		hold.MarkReady(time.Hour)
+	}
+	return hold
+}
+
+// holdState matches a hold update by its id and status
+func holdState(id string, status domain.HoldStatus) interface{} {
+	return mock.MatchedBy(func(h *domain.Hold) bool {
+		return h.ID == id && h.Status == status
+	})
+}
+
+func TestDefaultService_PlaceHold(t *testing.T) {
+	tests := []struct {
+		name         string
+		stock        int32
+		queue        []*domain.Hold
+		mockFn       func(holdRepo *mocks.IHoldRepository)
+		wantPosition int32
+		wantErr      error
+	}{
+		{
+			name:  "joins the end of the queue",
+			stock: 0,
+			queue: []*domain.Hold{testHold("h-1", "2", domain.HoldStatusWaiting)},
+			mockFn: func(holdRepo *mocks.IHoldRepository) {
+				holdRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Hold")).Return(nil)
+			},
+			wantPosition: 2,
+		},
+		{
+			name:  "only copy is set aside for another patron",
+			stock: 1,
+			queue: []*domain.Hold{testHold("h-1", "2", domain.HoldStatusReady)},
+			mockFn: func(holdRepo *mocks.IHoldRepository) {
+				holdRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Hold")).Return(nil)
+			},
+			wantPosition: 1,
+		},
+		{
+			name:    "book available",
+			stock:   1,
+			wantErr: ErrBookAvailable,
+		},
+		{
+			name:    "already holding",
+			stock:   0,
+			queue:   []*domain.Hold{testHold("h-1", "1", domain.HoldStatusWaiting)},
+			wantErr: ErrHoldExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			holdRepo := new(mocks.IHoldRepository)
+			bookRepo := new(mocks.BookRepository)
+			userRepo := new(mocks.UserRepository)
+			userRepo.On("GetByID", mock.Anything, "1").Return(&domain.User{ID: 1}, nil)
+			bookRepo.On("GetByID", mock.Anything, "book-1").Return(&domain.Book{ID: "book-1", Stock: tt.stock}, nil)
+			holdRepo.On("ListByBook", mock.Anything, "book-1").Return(tt.queue, nil)
+			if tt.mockFn != nil {
+				tt.mockFn(holdRepo)
+			}
+			s := NewService(nil, holdRepo, nil, nil, bookRepo, userRepo, nil, testPolicy)
+
+			got, err := s.PlaceHold(context.Background(), "1", "book-1")
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, domain.HoldStatusWaiting, got.Status)
+				assert.Equal(t, tt.wantPosition, got.Position)
+			}
+			holdRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDefaultService_CancelHold(t *testing.T) {
+	tests := []struct {
+		name    string
+		hold    *domain.Hold
+		mockFn  func(holdRepo *mocks.IHoldRepository)
+		wantErr error
+	}{
+		{
+			name: "waiting hold",
+			hold: testHold("h-1", "1", domain.HoldStatusWaiting),
+			mockFn: func(holdRepo *mocks.IHoldRepository) {
+				holdRepo.On("Update", mock.Anything, holdState("h-1", domain.HoldStatusCancelled)).Return(nil)
+			},
+		},
+		{
+			name: "ready hold passes the copy on",
+			hold: testHold("h-1", "1", domain.HoldStatusReady),
+			mockFn: func(holdRepo *mocks.IHoldRepository) {
+				holdRepo.On("Update", mock.Anything, holdState("h-1", domain.HoldStatusCancelled)).Return(nil)
+				holdRepo.On("ListByBook", mock.Anything, "book-1").Return([]*domain.Hold{testHold("h-2", "2", domain.HoldStatusWaiting)}, nil)
+				holdRepo.On("Update", mock.Anything, holdState("h-2", domain.HoldStatusReady)).Return(nil)
+			},
+		},
+		{
+			name:    "no longer active",
+			hold:    testHold("h-1", "1", domain.HoldStatusFulfilled),
+			wantErr: ErrHoldNotActive,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			holdRepo := new(mocks.IHoldRepository)
+			holdRepo.On("GetByID", mock.Anything, tt.hold.ID).Return(tt.hold, nil)
+			if tt.mockFn != nil {
+				tt.mockFn(holdRepo)
+			}
+			s := NewService(nil, holdRepo, nil, nil, nil, nil, nil, testPolicy)
+
+			got, err := s.CancelHold(context.Background(), tt.hold.ID)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, domain.HoldStatusCancelled, got.Status)
+			}
+			holdRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDefaultService_ListHolds(t *testing.T) {
+	queue := func() []*domain.Hold {
+		return []*domain.Hold{
+			testHold("h-1", "1", domain.HoldStatusReady),
+			testHold("h-2", "2", domain.HoldStatusWaiting),
+			testHold("h-3", "3", domain.HoldStatusWaiting),
+		}
+	}
+
+	t.Run("book queue", func(t *testing.T) {
+		holdRepo := new(mocks.IHoldRepository)
+		holdRepo.On("ListByBook", mock.Anything, "book-1").Return(queue(), nil)
+		s := NewService(nil, holdRepo, nil, nil, nil, nil, nil, testPolicy)
+
+		got, err := s.ListHolds(context.Background(), "", "book-1")
+
+		assert.NoError(t, err)
+		assert.Len(t, got, 3)
+		assert.Equal(t, []int32{0, 1, 2}, []int32{got[0].Position, got[1].Position, got[2].Position})
+	})
+
+	t.Run("user holds", func(t *testing.T) {
+		holdRepo := new(mocks.IHoldRepository)
+		holdRepo.On("ListByUser", mock.Anything, "3").Return([]*domain.Hold{testHold("h-3", "3", domain.HoldStatusWaiting)}, nil)
+		holdRepo.On("ListByBook", mock.Anything, "book-1").Return(queue(), nil)
+		s := NewService(nil, holdRepo, nil, nil, nil, nil, nil, testPolicy)
+
+		got, err := s.ListHolds(context.Background(), "3", "")
+
+		assert.NoError(t, err)
+		assert.Len(t, got, 1)
+		assert.Equal(t, int32(2), got[0].Position)
+	})
+
+	t.Run("missing filter", func(t *testing.T) {
+		s := NewService(nil, nil, nil, nil, nil, nil, nil, testPolicy)
+
+		_, err := s.ListHolds(context.Background(), "", "")
+
+		assert.ErrorIs(t, err, ErrInvalidInput)
+	})
+}
+
+func TestDefaultService_BorrowBook_Holds(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  string
+		stock   int32
+		queue   []*domain.Hold
+		mockFn  func(holdRepo *mocks.IHoldRepository)
+		wantErr error
+	}{
+		{
+			name:   "holder borrows the copy set aside",
+			userID: "1",
+			stock:  1,
+			queue:  []*domain.Hold{testHold("h-1", "1", domain.HoldStatusReady)},
+			mockFn: func(holdRepo *mocks.IHoldRepository) {
+				holdRepo.On("Update", mock.Anything, holdState("h-1", domain.HoldStatusFulfilled)).Return(nil)
+			},
+		},
+		{
+			name:    "copy set aside for another patron",
+			userID:  "2",
+			stock:   1,
+			queue:   []*domain.Hold{testHold("h-1", "1", domain.HoldStatusReady)},
+			wantErr: ErrBookOnHold,
+		},
+		{
+			name:   "spare copy beyond the ready holds",
+			userID: "2",
+			stock:  2,
+			queue:  []*domain.Hold{testHold("h-1", "1", domain.HoldStatusReady)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.IDbRepository)
+			sagaRepo := new(mocks.ISagaRepository)
+			holdRepo := new(mocks.IHoldRepository)
+			bookRepo := new(mocks.BookRepository)
+			userRepo := new(mocks.UserRepository)
+			userRepo.On("GetByID", mock.Anything, tt.userID).Return(&domain.User{Role: domain.RoleOperation}, nil)
+			bookRepo.On("GetByID", mock.Anything, "book-1").Return(&domain.Book{ID: "book-1", Stock: tt.stock}, nil)
+			holdRepo.On("ListByBook", mock.Anything, "book-1").Return(tt.queue, nil)
+			sagaRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Maybe()
+			sagaRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Maybe()
+			repo.On("Create", mock.Anything, mock.Anything).Return(nil).Maybe()
+			repo.On("ListOpenByUser", mock.Anything, tt.userID).Return([]*domain.Transaction{}, nil)
+			repo.On("LockBorrower", mock.Anything, tt.userID).Return(func() {}, nil).Maybe()
+			bookRepo.On("ReserveStock", mock.Anything, "book-1", "", mock.Anything).Return("", nil).Maybe()
+			if tt.mockFn != nil {
+				tt.mockFn(holdRepo)
+			}
+			s := NewService(repo, holdRepo, noFines(), noBlocks(), bookRepo, userRepo, NewSagaCoordinator(repo, sagaRepo, bookRepo), testPolicy)
+
+			_, err := s.BorrowBook(context.Background(), tt.userID, "book-1", "")
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			holdRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDefaultService_ReturnBook_PromotesHold(t *testing.T) {
+	repo := new(mocks.IDbRepository)
+	sagaRepo := new(mocks.ISagaRepository)
+	holdRepo := new(mocks.IHoldRepository)
+	bookRepo := new(mocks.BookRepository)
+	transaction := &domain.Transaction{ID: "tx-1", UserID: "1", BookID: "book-1"}
+	repo.On("GetByID", mock.Anything, "tx-1").Return(transaction, nil)
+	repo.On("MarkAsReturned", mock.Anything, "tx-1", mock.Anything).Return(nil)
+	bookRepo.On("ReleaseStock", mock.Anything, "book-1", "", mock.Anything).Return(nil)
+	sagaRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	sagaRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	holdRepo.On("ListByBook", mock.Anything, "book-1").Return([]*domain.Hold{
+		testHold("h-1", "2", domain.HoldStatusWaiting),
+		testHold("h-2", "3", domain.HoldStatusWaiting),
+	}, nil)
+	holdRepo.On("Update", mock.Anything, mock.MatchedBy(func(h *domain.Hold) bool {
+		return h.ID == "h-1" && h.Status == domain.HoldStatusReady && h.ExpiresAt != nil
+	})).Return(nil).Once()
+	s := NewService(repo, holdRepo, nil, nil, bookRepo, nil, NewSagaCoordinator(repo, sagaRepo, bookRepo), testPolicy)
+
+	_, err := s.ReturnBook(context.Background(), "tx-1")
+
+	assert.NoError(t, err)
+	holdRepo.AssertExpectations(t)
+}
+
+func TestDefaultService_ExpireHolds(t *testing.T) {
+	holdRepo := new(mocks.IHoldRepository)
+	expired := testHold("h-1", "1", domain.HoldStatusReady)
+	holdRepo.On("ListExpired", mock.Anything, mock.Anything).Return([]*domain.Hold{expired}, nil)
+	holdRepo.On("Update", mock.Anything, holdState("h-1", domain.HoldStatusExpired)).Return(nil).Once()
+	holdRepo.On("ListByBook", mock.Anything, "book-1").Return([]*domain.Hold{testHold("h-2", "2", domain.HoldStatusWaiting)}, nil)
+	holdRepo.On("Update", mock.Anything, holdState("h-2", domain.HoldStatusReady)).Return(nil).Once()
+	s := NewService(nil, holdRepo, nil, nil, nil, nil, nil, testPolicy)
+
+	err := s.ExpireHolds(context.Background())
+
+	assert.NoError(t, err)
+	holdRepo.AssertExpectations(t)
+}
+
+func TestDefaultService_ExpireHolds_ContinuesPastFailures(t *testing.T) {
+	holdRepo := new(mocks.IHoldRepository)
+	first := testHold("h-1", "1", domain.HoldStatusReady)
+	middle := testHold("h-2", "1", domain.HoldStatusReady)
+	middle.BookID = "book-2"
+	last := testHold("h-3", "1", domain.HoldStatusReady)
+	last.BookID = "book-3"
+	holdRepo.On("ListExpired", mock.Anything, mock.Anything).Return([]*domain.Hold{first, middle, last}, nil)
+	holdRepo.On("Update", mock.Anything, holdState("h-1", domain.HoldStatusExpired)).Return(nil).Once()
+	holdRepo.On("ListByBook", mock.Anything, "book-1").Return([]*domain.Hold{testHold("h-4", "2", domain.HoldStatusWaiting)}, nil)
+	holdRepo.On("Update", mock.Anything, holdState("h-4", domain.HoldStatusReady)).Return(nil).Once()
+	holdRepo.On("Update", mock.Anything, holdState("h-2", domain.HoldStatusExpired)).Return(nil).Once()
+	holdRepo.On("ListByBook", mock.Anything, "book-2").Return(nil, errors.New("db down"))
+	holdRepo.On("Update", mock.Anything, holdState("h-3", domain.HoldStatusExpired)).Return(nil).Once()
+	holdRepo.On("ListByBook", mock.Anything, "book-3").Return([]*domain.Hold{}, nil)
+	s := NewService(nil, holdRepo, nil, nil, nil, nil, nil, testPolicy)
+
+	err := s.ExpireHolds(context.Background())
+
+	assert.EqualError(t, err, "db down")
+	holdRepo.AssertExpectations(t)
+}
+
+func TestDefaultService_RenewLoan_WaitingHold(t *testing.T) {
+	repo := new(mocks.IDbRepository)
+	holdRepo := new(mocks.IHoldRepository)
+	dueAt := time.Now().Add(day)
+	repo.On("GetByID", mock.Anything, "tx-1").Return(&domain.Transaction{ID: "tx-1", BookID: "book-1", DueAt: &dueAt}, nil)
+	holdRepo.On("ListByBook", mock.Anything, "book-1").Return([]*domain.Hold{testHold("h-1", "2", domain.HoldStatusWaiting)}, nil)
+	policy := testPolicy
+	policy.MaxRenewals = 1
+	s := NewService(repo, holdRepo, nil, nil, nil, nil, nil, policy)
+
+	_, err := s.RenewLoan(context.Background(), "tx-1")
+
+	assert.ErrorIs(t, err, ErrBookOnHold)
+}
@@ -0,0 +1,93 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+
+	"github.com/rs/zerolog/log"
+)
+
+// DeclareLost closes an open loan whose book the borrower lost, writes the
+// copy off and charges the borrower fee for it, or the replacement fee of the
+// book's category when fee is zero
+func (s *DefaultService) DeclareLost(ctx context.Context, transactionID string, fee int64, note string) (*domain.Transaction, error) {
+	return s.writeOff(ctx, transactionID, domain.LoanStatusLost, fee, note)
+}
+
+// ReportDamage closes an open loan whose book came back damaged beyond
+// repair, withdraws the copy and charges the borrower fee for it, or the
+// replacement fee of the book's category when fee is zero
+func (s *DefaultService) ReportDamage(ctx context.Context, transactionID string, fee int64, note string) (*domain.Transaction, error) {
+	return s.writeOff(ctx, transactionID, domain.LoanStatusDamaged, fee, note)
+}
+
+// MarkFound reverses DeclareLost once the book turns up: the copy goes back
+// into stock, the replacement fee is refunded and the next patron in line for
+// the book is served
+func (s *DefaultService) MarkFound(ctx context.Context, transactionID, note string) (*domain.Transaction, error) {
+	if transactionID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	transaction, err := s.repoDb.GetByID(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if transaction.Status != domain.LoanStatusLost {
+		return nil, ErrNotLost
+	}
+
+	// Mark the loan as found, refunding the fee, and put the copy back into stock
+	if err := s.saga.Found(ctx, transaction, feeNote("refund of replacement fee, book found", note)); err != nil {
+		return nil, err
+	}
+
+	if err := s.promoteNextHold(ctx, transaction.BookID); err != nil {
+		log.Error().Err(err).Str("book_id", transaction.BookID).Msg("Failed to promote next hold")
+	}
+
+	return s.repoDb.GetByID(ctx, transactionID)
+}
+
+// writeOff closes an open loan with status, charging the replacement fee
+// along with it, and writes the copy off in the book service
+func (s *DefaultService) writeOff(ctx context.Context, transactionID string, status domain.LoanStatus, fee int64, note string) (*domain.Transaction, error) {
+	if transactionID == "" || fee < 0 {
+		return nil, ErrInvalidInput
+	}
+
+	transaction, err := s.repoDb.GetByID(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if transaction.IsReturned() {
+		return nil, ErrAlreadyReturned
+	}
+
+	// The book's category picks the fee; when the book service cannot be
+	// reached the default fee applies
+	if fee == 0 {
+		var category string
+		if b, err := s.bookRepo.GetByID(ctx, transaction.BookID); err == nil {
+			category = b.Category
+		}
+		fee = s.policy.Fines.ReplacementFee(category)
+	}
+
+	transaction.Close(status, fee)
+	if err := s.saga.WriteOff(ctx, transaction, feeNote(fmt.Sprintf("replacement fee for %s book", status), note)); err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// feeNote returns the ledger note of a replacement fee or refund, followed by
+// the admin's note if any
+func feeNote(reason, note string) string {
+	if note == "" {
+		return reason
+	}
+	return reason + ": " + note
+}
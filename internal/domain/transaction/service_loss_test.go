@@ -0,0 +1,159 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"github.com/hinha/library-management-synapsis/internal/domain/transaction/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestFinePolicy_ReplacementFee(t *testing.T) {
+	policy := FinePolicy{Replacement: 5000, CategoryReplacement: map[string]int64{"Reference": 15000}}
+
+	assert.Equal(t, int64(5000), policy.ReplacementFee("Fiction"))
+	assert.Equal(t, int64(15000), policy.ReplacementFee("Reference"))
+}
+
+func TestDefaultService_WriteOff(t *testing.T) {
+	replacementPolicy := LoanPolicy{Fines: FinePolicy{Replacement: 5000, CategoryReplacement: map[string]int64{"Reference": 15000}}}
+
+	tests := []struct {
+		name       string
+		damaged    bool
+		fee        int64
+		loan       *domain.Transaction
+		mockFn     func(bookRepo *mocks.BookRepository)
+		wantStatus domain.LoanStatus
+		wantFee    int64
+		wantErr    error
+	}{
+		{
+			name: "lost at the category fee",
+			loan: &domain.Transaction{ID: "tx-1", UserID: "1", BookID: "book-1", CopyBarcode: "LIB-0001"},
+			mockFn: func(bookRepo *mocks.BookRepository) {
+				bookRepo.On("GetByID", mock.Anything, "book-1").Return(&domain.Book{ID: "book-1", Category: "Reference"}, nil)
+				bookRepo.On("WriteOffStock", mock.Anything, "book-1", "LIB-0001", "tx-1", false).Return(nil)
+			},
+			wantStatus: domain.LoanStatusLost,
+			wantFee:    15000,
+		},
+		{
+			name: "lost with the book service down charges the default fee",
+			loan: &domain.Transaction{ID: "tx-1", UserID: "1", BookID: "book-1"},
+			mockFn: func(bookRepo *mocks.BookRepository) {
+				bookRepo.On("GetByID", mock.Anything, "book-1").Return(nil, errors.New("unavailable"))
+				bookRepo.On("WriteOffStock", mock.Anything, "book-1", "", "tx-1", false).Return(nil)
+			},
+			wantStatus: domain.LoanStatusLost,
+			wantFee:    5000,
+		},
+		{
+			name:    "damaged at a fee of the admin's choosing",
+			damaged: true,
+			fee:     2500,
+			loan:    &domain.Transaction{ID: "tx-1", UserID: "1", BookID: "book-1", CopyBarcode: "LIB-0001"},
+			mockFn: func(bookRepo *mocks.BookRepository) {
+				bookRepo.On("WriteOffStock", mock.Anything, "book-1", "LIB-0001", "tx-1", true).Return(nil)
+			},
+			wantStatus: domain.LoanStatusDamaged,
+			wantFee:    2500,
+		},
+		{
+			name:    "loan already closed",
+			loan:    &domain.Transaction{ID: "tx-1", UserID: "1", BookID: "book-1", ReturnedAt: &time.Time{}},
+			wantErr: ErrAlreadyReturned,
+		},
+		{
+			name:    "negative fee",
+			fee:     -1,
+			wantErr: ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.IDbRepository)
+			sagaRepo := new(mocks.ISagaRepository)
+			bookRepo := new(mocks.BookRepository)
+			ledgerRepo := new(mocks.ILedgerRepository)
+			if tt.loan != nil {
+				repo.On("GetByID", mock.Anything, "tx-1").Return(tt.loan, nil)
+			}
+			if tt.wantStatus != "" {
+				repo.On("Close", mock.Anything, mock.AnythingOfType("*domain.Transaction"), "replacement fee for "+string(tt.wantStatus)+" book").Return(nil)
+			}
+			sagaRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Maybe()
+			sagaRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Maybe()
+			if tt.mockFn != nil {
+				tt.mockFn(bookRepo)
+			}
+			s := NewService(repo, emptyHoldQueue(), ledgerRepo, nil, bookRepo, nil, NewSagaCoordinator(repo, sagaRepo, bookRepo), replacementPolicy)
+
+			writeOff := s.DeclareLost
+			if tt.damaged {
+				writeOff = s.ReportDamage
+			}
+			got, err := writeOff(context.Background(), "tx-1", tt.fee, "")
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else if assert.NoError(t, err) {
+				assert.Equal(t, tt.wantStatus, got.Status)
+				assert.Equal(t, tt.wantFee, got.ReplacementFee)
+				assert.True(t, got.IsReturned())
+			}
+			repo.AssertExpectations(t)
+			bookRepo.AssertExpectations(t)
+			ledgerRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDefaultService_MarkFound(t *testing.T) {
+	t.Run("restores stock and refunds the fee", func(t *testing.T) {
+		repo := new(mocks.IDbRepository)
+		sagaRepo := new(mocks.ISagaRepository)
+		bookRepo := new(mocks.BookRepository)
+		ledgerRepo := new(mocks.ILedgerRepository)
+		holdRepo := new(mocks.IHoldRepository)
+		returnedAt := time.Now()
+		lost := &domain.Transaction{ID: "tx-1", UserID: "1", BookID: "book-1", CopyBarcode: "LIB-0001", ReturnedAt: &returnedAt, Status: domain.LoanStatusLost, ReplacementFee: 5000}
+		found := &domain.Transaction{ID: "tx-1", UserID: "1", BookID: "book-1", CopyBarcode: "LIB-0001", ReturnedAt: &returnedAt, Status: domain.LoanStatusFound, ReplacementFee: 5000}
+		waiting := &domain.Hold{ID: "h-1", UserID: "2", BookID: "book-1", Status: domain.HoldStatusWaiting}
+		repo.On("GetByID", mock.Anything, "tx-1").Return(lost, nil).Once()
+		repo.On("GetByID", mock.Anything, "tx-1").Return(found, nil).Once()
+		repo.On("SetStatus", mock.Anything, "tx-1", domain.LoanStatusLost, domain.LoanStatusFound, "refund of replacement fee, book found: under the seat").Return(nil)
+		bookRepo.On("RestoreStock", mock.Anything, "book-1", "LIB-0001", "tx-1").Return(nil)
+		sagaRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		sagaRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		holdRepo.On("ListByBook", mock.Anything, "book-1").Return([]*domain.Hold{waiting}, nil)
+		holdRepo.On("Update", mock.Anything, waiting).Return(nil)
+		s := NewService(repo, holdRepo, ledgerRepo, nil, bookRepo, nil, NewSagaCoordinator(repo, sagaRepo, bookRepo), testPolicy)
+
+		got, err := s.MarkFound(context.Background(), "tx-1", "under the seat")
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.LoanStatusFound, got.Status)
+		assert.Equal(t, domain.HoldStatusReady, waiting.Status)
+		repo.AssertExpectations(t)
+		bookRepo.AssertExpectations(t)
+		ledgerRepo.AssertExpectations(t)
+		holdRepo.AssertExpectations(t)
+	})
+
+	t.Run("loan not lost", func(t *testing.T) {
+		repo := new(mocks.IDbRepository)
+		repo.On("GetByID", mock.Anything, "tx-1").Return(&domain.Transaction{ID: "tx-1", Status: domain.LoanStatusDamaged}, nil)
+		s := NewService(repo, nil, nil, nil, nil, nil, nil, testPolicy)
+
+		got, err := s.MarkFound(context.Background(), "tx-1", "")
+
+		assert.ErrorIs(t, err, ErrNotLost)
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,486 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"github.com/hinha/library-management-synapsis/internal/domain/book"
+	"github.com/hinha/library-management-synapsis/internal/domain/transaction/mocks"
+	"github.com/hinha/library-management-synapsis/internal/domain/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+const day = 24 * time.Hour
+
+var testPolicy = LoanPolicy{
+	DefaultPeriod:   14 * day,
+	CategoryPeriods: map[string]time.Duration{"Reference": 3 * day},
+	RolePeriods:     map[domain.Role]time.Duration{domain.RoleAdmin: 30 * day},
+}
+
+func TestLoanPolicy_LoanPeriod(t *testing.T) {
+	tests := []struct {
+		name     string
+		category string
+		role     domain.Role
+		want     time.Duration
+	}{
+		{name: "default", category: "Fiction", role: domain.RoleOperation, want: 14 * day},
+		{name: "role period", category: "Fiction", role: domain.RoleAdmin, want: 30 * day},
+		{name: "category wins over role", category: "Reference", role: domain.RoleAdmin, want: 3 * day},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, testPolicy.LoanPeriod(tt.category, tt.role))
+		})
+	}
+}
+
+// emptyHoldQueue returns a hold repository where no book has holds
+func emptyHoldQueue() *mocks.IHoldRepository {
+	holdRepo := new(mocks.IHoldRepository)
+	holdRepo.On("ListByBook", mock.Anything, mock.Anything).Return([]*domain.Hold{}, nil).Maybe()
+	return holdRepo
+}
+
+// noFines returns a ledger repository where nobody owes anything
+func noFines() *mocks.ILedgerRepository {
+	ledgerRepo := new(mocks.ILedgerRepository)
+	ledgerRepo.On("Balance", mock.Anything, mock.Anything).Return(int64(0), nil).Maybe()
+	return ledgerRepo
+}
+
+// noBlocks returns a block repository where nobody is blocked
+func noBlocks() *mocks.IBlockRepository {
+	blockRepo := new(mocks.IBlockRepository)
+	blockRepo.On("Get", mock.Anything, mock.Anything).Return(nil, ErrBlockNotFound).Maybe()
+	return blockRepo
+}
+
+// dueAfter matches a transaction due period after it was borrowed
+func dueAfter(period time.Duration) interface{} {
+	return mock.MatchedBy(func(tx *domain.Transaction) bool {
+		return tx.DueAt != nil && tx.DueAt.Sub(tx.BorrowedAt) == period
+	})
+}
+
+func TestDefaultService_BorrowBook(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  string
+		bookID  string
+		barcode string
+		mockFn  func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository, userRepo *mocks.UserRepository)
+		wantDue time.Duration
+		wantErr error
+	}{
+		{
+			name:   "operation user gets the default period",
+			userID: "1",
+			bookID: "book-1",
+			mockFn: func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository, userRepo *mocks.UserRepository) {
+				userRepo.On("GetByID", mock.Anything, "1").Return(&domain.User{ID: 1, Role: domain.RoleOperation}, nil)
+				bookRepo.On("GetByID", mock.Anything, "book-1").Return(&domain.Book{ID: "book-1", Category: "Fiction", Stock: 2}, nil)
+				sagaRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				repo.On("Create", mock.Anything, dueAfter(14*day)).Return(nil)
+				bookRepo.On("ReserveStock", mock.Anything, "book-1", "", mock.Anything).Return("", nil)
+				sagaRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+			},
+			wantDue: 14 * day,
+		},
+		{
+			name:   "category period applies",
+			userID: "2",
+			bookID: "book-2",
+			mockFn: func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository, userRepo *mocks.UserRepository) {
+				userRepo.On("GetByID", mock.Anything, "2").Return(&domain.User{ID: 2, Role: domain.RoleAdmin}, nil)
+				bookRepo.On("GetByID", mock.Anything, "book-2").Return(&domain.Book{ID: "book-2", Category: "Reference", Stock: 1}, nil)
+				sagaRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				repo.On("Create", mock.Anything, dueAfter(3*day)).Return(nil)
+				bookRepo.On("ReserveStock", mock.Anything, "book-2", "", mock.Anything).Return("", nil)
+				sagaRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+			},
+			wantDue: 3 * day,
+		},
+		{
+			name:    "copy by barcode",
+			userID:  "1",
+			barcode: "LIB-0001",
+			mockFn: func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository, userRepo *mocks.UserRepository) {
+				userRepo.On("GetByID", mock.Anything, "1").Return(&domain.User{ID: 1, Role: domain.RoleOperation}, nil)
+				bookRepo.On("GetCopy", mock.Anything, "LIB-0001").Return(&domain.BookCopy{BookID: "book-1", Barcode: "LIB-0001", Status: domain.CopyStatusAvailable}, nil)
+				bookRepo.On("GetByID", mock.Anything, "book-1").Return(&domain.Book{ID: "book-1", Category: "Fiction", Stock: 2}, nil)
+				sagaRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				repo.On("Create", mock.Anything, mock.MatchedBy(func(tx *domain.Transaction) bool {
+					return tx.BookID == "book-1" && tx.CopyBarcode == "LIB-0001"
+				})).Return(nil)
+				bookRepo.On("ReserveStock", mock.Anything, "book-1", "LIB-0001", mock.Anything).Return("LIB-0001", nil)
+				sagaRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+			},
+			wantDue: 14 * day,
+		},
+		{
+			name:    "copy of another book",
+			userID:  "1",
+			bookID:  "book-2",
+			barcode: "LIB-0001",
+			mockFn: func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository, userRepo *mocks.UserRepository) {
+				userRepo.On("GetByID", mock.Anything, "1").Return(&domain.User{ID: 1, Role: domain.RoleOperation}, nil)
+				bookRepo.On("GetCopy", mock.Anything, "LIB-0001").Return(&domain.BookCopy{BookID: "book-1", Barcode: "LIB-0001", Status: domain.CopyStatusAvailable}, nil)
+			},
+			wantErr: ErrInvalidInput,
+		},
+		{
+			name:    "copy not on the shelf",
+			userID:  "1",
+			barcode: "LIB-0001",
+			mockFn: func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository, userRepo *mocks.UserRepository) {
+				userRepo.On("GetByID", mock.Anything, "1").Return(&domain.User{ID: 1, Role: domain.RoleOperation}, nil)
+				bookRepo.On("GetCopy", mock.Anything, "LIB-0001").Return(&domain.BookCopy{BookID: "book-1", Barcode: "LIB-0001", Status: domain.CopyStatusInRepair}, nil)
+			},
+			wantErr: ErrBookNotAvailable,
+		},
+		{
+			name:    "missing ids",
+			wantErr: ErrInvalidInput,
+		},
+		{
+			name:   "user not found",
+			userID: "3",
+			bookID: "book-1",
+			mockFn: func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository, userRepo *mocks.UserRepository) {
+				userRepo.On("GetByID", mock.Anything, "3").Return(nil, user.ErrUserNotFound)
+			},
+			wantErr: user.ErrUserNotFound,
+		},
+		{
+			name:   "book not found",
+			userID: "1",
+			bookID: "missing",
+			mockFn: func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository, userRepo *mocks.UserRepository) {
+				userRepo.On("GetByID", mock.Anything, "1").Return(&domain.User{ID: 1, Role: domain.RoleOperation}, nil)
+				bookRepo.On("GetByID", mock.Anything, "missing").Return(nil, book.ErrBookNotFound)
+			},
+			wantErr: book.ErrBookNotFound,
+		},
+		{
+			name:   "out of stock",
+			userID: "1",
+			bookID: "book-3",
+			mockFn: func(repo *mocks.IDbRepository, sagaRepo *mocks.ISagaRepository, bookRepo *mocks.BookRepository, userRepo *mocks.UserRepository) {
+				userRepo.On("GetByID", mock.Anything, "1").Return(&domain.User{ID: 1, Role: domain.RoleOperation}, nil)
+				bookRepo.On("GetByID", mock.Anything, "book-3").Return(&domain.Book{ID: "book-3", Stock: 0}, nil)
+			},
+			wantErr: ErrBookNotAvailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.IDbRepository)
+			sagaRepo := new(mocks.ISagaRepository)
+			bookRepo := new(mocks.BookRepository)
+			userRepo := new(mocks.UserRepository)
+			holdRepo := emptyHoldQueue()
+			repo.On("ListOpenByUser", mock.Anything, mock.Anything).Return([]*domain.Transaction{}, nil).Maybe()
+			repo.On("LockBorrower", mock.Anything, mock.Anything).Return(func() {}, nil).Maybe()
+			if tt.mockFn != nil {
+				tt.mockFn(repo, sagaRepo, bookRepo, userRepo)
+			}
+			s := NewService(repo, holdRepo, noFines(), noBlocks(), bookRepo, userRepo, NewSagaCoordinator(repo, sagaRepo, bookRepo), testPolicy)
+
+			got, err := s.BorrowBook(context.Background(), tt.userID, tt.bookID, tt.barcode)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantDue, got.DueAt.Sub(got.BorrowedAt))
+			}
+			repo.AssertExpectations(t)
+			bookRepo.AssertExpectations(t)
+			userRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDefaultService_ListOverdue(t *testing.T) {
+	dueAt := time.Now().Add(-2 * day)
+	overdue := []*domain.Transaction{{ID: "tx-1", DueAt: &dueAt}}
+
+	t.Run("success", func(t *testing.T) {
+		repo := new(mocks.IDbRepository)
+		repo.On("ListOverdue", mock.Anything, mock.AnythingOfType("time.Time")).Return(overdue, nil)
+		s := NewService(repo, nil, nil, nil, nil, nil, nil, testPolicy)
+
+		got, err := s.ListOverdue(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, overdue, got)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("repo error", func(t *testing.T) {
+		repo := new(mocks.IDbRepository)
+		repo.On("ListOverdue", mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+		s := NewService(repo, nil, nil, nil, nil, nil, nil, testPolicy)
+
+		got, err := s.ListOverdue(context.Background())
+
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestDefaultService_ListTransactions(t *testing.T) {
+	borrowedAt := time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)
+	transactions := []*domain.Transaction{
+		{ID: "tx-3", BorrowedAt: borrowedAt.Add(time.Hour)},
+		{ID: "tx-2", BorrowedAt: borrowedAt},
+		{ID: "tx-1", BorrowedAt: borrowedAt},
+	}
+	filter := ListFilter{BookID: "book-1", Status: domain.TransactionStatusReturned}
+	nextPage, _ := encodePageToken(filter, transactions[1])
+
+	tests := []struct {
+		name          string
+		filter        ListFilter
+		pageSize      int
+		pageToken     string
+		mockFn        func(repo *mocks.IDbRepository)
+		wantIDs       []string
+		wantPageToken string
+		wantErr       error
+	}{
+		{
+			name:     "first page with more to come",
+			filter:   filter,
+			pageSize: 2,
+			mockFn: func(repo *mocks.IDbRepository) {
+				repo.On("ListPage", mock.Anything, mock.MatchedBy(func(query ListQuery) bool {
+					return query.Filter == filter && query.Limit == 3 && query.After == nil && !query.Now.IsZero()
+				})).Return(transactions, nil)
+			},
+			wantIDs:       []string{"tx-3", "tx-2"},
+			wantPageToken: nextPage,
+		},
+		{
+			name:      "last page",
+			filter:    filter,
+			pageToken: nextPage,
+			mockFn: func(repo *mocks.IDbRepository) {
+				repo.On("ListPage", mock.Anything, mock.MatchedBy(func(query ListQuery) bool {
+					return query.Limit == DefaultPageSize+1 && query.After.ID == "tx-2" && query.After.BorrowedAt.Equal(borrowedAt)
+				})).Return(transactions[2:], nil)
+			},
+			wantIDs: []string{"tx-1"},
+		},
+		{
+			name:      "token of another filter",
+			filter:    ListFilter{BookID: "book-2"},
+			pageToken: nextPage,
+			wantErr:   ErrInvalidPageToken,
+		},
+		{
+			name:      "malformed token",
+			pageToken: "not-a-token",
+			wantErr:   ErrInvalidPageToken,
+		},
+		{
+			name:    "unknown status",
+			filter:  ListFilter{Status: "missing"},
+			wantErr: ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.IDbRepository)
+			if tt.mockFn != nil {
+				tt.mockFn(repo)
+			}
+			s := NewService(repo, nil, nil, nil, nil, nil, nil, testPolicy)
+
+			got, pageToken, err := s.ListTransactions(context.Background(), tt.filter, tt.pageSize, tt.pageToken)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil && assert.Len(t, got, len(tt.wantIDs)) {
+				for i, transaction := range got {
+					assert.Equal(t, tt.wantIDs[i], transaction.ID)
+				}
+				assert.Equal(t, tt.wantPageToken, pageToken)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+
+	for _, status := range []string{
+		domain.TransactionStatusActive, domain.TransactionStatusReturned, domain.TransactionStatusOverdue,
+		domain.TransactionStatusLost, domain.TransactionStatusDamaged, domain.TransactionStatusFound,
+	} {
+		t.Run("status "+status, func(t *testing.T) {
+			repo := new(mocks.IDbRepository)
+			repo.On("ListPage", mock.Anything, mock.MatchedBy(func(query ListQuery) bool {
+				return query.Filter.Status == status
+			})).Return([]*domain.Transaction{}, nil)
+			s := NewService(repo, nil, nil, nil, nil, nil, nil, testPolicy)
+
+			_, _, err := s.ListTransactions(context.Background(), ListFilter{Status: status}, 0, "")
+
+			assert.NoError(t, err)
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDefaultService_GetUserHistory(t *testing.T) {
+	t.Run("scoped to the user", func(t *testing.T) {
+		repo := new(mocks.IDbRepository)
+		repo.On("ListPage", mock.Anything, mock.MatchedBy(func(query ListQuery) bool {
+			return query.Filter.UserID == "1" && query.Filter.Status == domain.TransactionStatusActive
+		})).Return([]*domain.Transaction{{ID: "tx-1"}}, nil)
+		s := NewService(repo, nil, nil, nil, nil, nil, nil, testPolicy)
+
+		got, pageToken, err := s.GetUserHistory(context.Background(), "1", ListFilter{UserID: "2", Status: domain.TransactionStatusActive}, 0, "")
+
+		assert.NoError(t, err)
+		assert.Len(t, got, 1)
+		assert.Empty(t, pageToken)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("missing user", func(t *testing.T) {
+		s := NewService(new(mocks.IDbRepository), nil, nil, nil, nil, nil, nil, testPolicy)
+
+		_, _, err := s.GetUserHistory(context.Background(), "", ListFilter{}, 0, "")
+
+		assert.ErrorIs(t, err, ErrInvalidInput)
+	})
+}
+
+func TestTransaction_IsOverdue(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-36 * time.Hour)
+	future := now.Add(day)
+	lateReturn := past.Add(2 * time.Hour)
+	earlyReturn := past.Add(-time.Hour)
+
+	tests := []struct {
+		name         string
+		transaction  *domain.Transaction
+		wantOverdue  bool
+		wantDaysLate int32
+	}{
+		{name: "no due date", transaction: &domain.Transaction{}},
+		{name: "not due yet", transaction: &domain.Transaction{DueAt: &future}},
+		{name: "open past due", transaction: &domain.Transaction{DueAt: &past}, wantOverdue: true, wantDaysLate: 2},
+		{name: "returned late", transaction: &domain.Transaction{DueAt: &past, ReturnedAt: &lateReturn}, wantOverdue: true, wantDaysLate: 1},
+		{name: "returned on time", transaction: &domain.Transaction{DueAt: &past, ReturnedAt: &earlyReturn}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantOverdue, tt.transaction.IsOverdue(now))
+			assert.Equal(t, tt.wantDaysLate, tt.transaction.DaysLate(now))
+		})
+	}
+}
+
+func TestDefaultService_RenewLoan(t *testing.T) {
+	policy := testPolicy
+	policy.MaxRenewals = 2
+	policy.RenewalGrace = day
+
+	now := time.Now()
+	dueSoon := now.Add(2 * day)
+	dueYesterday := now.Add(-12 * time.Hour)
+	dueLastWeek := now.Add(-7 * day)
+	returnedAt := now.Add(-time.Hour)
+
+	tests := []struct {
+		name        string
+		transaction *domain.Transaction
+		mockFn      func(repo *mocks.IDbRepository, bookRepo *mocks.BookRepository, userRepo *mocks.UserRepository)
+		wantDue     time.Time
+		wantErr     error
+	}{
+		{
+			name:        "success",
+			transaction: &domain.Transaction{ID: "tx-1", UserID: "1", BookID: "book-1", DueAt: &dueSoon},
+			mockFn: func(repo *mocks.IDbRepository, bookRepo *mocks.BookRepository, userRepo *mocks.UserRepository) {
+				userRepo.On("GetByID", mock.Anything, "1").Return(&domain.User{ID: 1, Role: domain.RoleOperation}, nil)
+				bookRepo.On("GetByID", mock.Anything, "book-1").Return(&domain.Book{ID: "book-1", Category: "Fiction"}, nil)
+				repo.On("Renew", mock.Anything, mock.MatchedBy(func(tx *domain.Transaction) bool {
+					return tx.RenewalCount == 1
+				})).Return(nil)
+			},
+			wantDue: dueSoon.Add(14 * day),
+		},
+		{
+			name:        "overdue within grace",
+			transaction: &domain.Transaction{ID: "tx-2", UserID: "1", BookID: "book-2", DueAt: &dueYesterday, RenewalCount: 1},
+			mockFn: func(repo *mocks.IDbRepository, bookRepo *mocks.BookRepository, userRepo *mocks.UserRepository) {
+				userRepo.On("GetByID", mock.Anything, "1").Return(&domain.User{ID: 1, Role: domain.RoleOperation}, nil)
+				bookRepo.On("GetByID", mock.Anything, "book-2").Return(&domain.Book{ID: "book-2", Category: "Reference"}, nil)
+				repo.On("Renew", mock.Anything, mock.Anything).Return(nil)
+			},
+			wantDue: dueYesterday.Add(3 * day),
+		},
+		{
+			name:        "already returned",
+			transaction: &domain.Transaction{ID: "tx-3", DueAt: &dueSoon, ReturnedAt: &returnedAt},
+			wantErr:     ErrAlreadyReturned,
+		},
+		{
+			name:        "renewal limit reached",
+			transaction: &domain.Transaction{ID: "tx-4", DueAt: &dueSoon, RenewalCount: 2},
+			wantErr:     ErrRenewalLimitReached,
+		},
+		{
+			name:        "overdue beyond grace",
+			transaction: &domain.Transaction{ID: "tx-5", DueAt: &dueLastWeek},
+			wantErr:     ErrOverdueBeyondGrace,
+		},
+		{
+			name:        "concurrent renewal",
+			transaction: &domain.Transaction{ID: "tx-6", UserID: "1", BookID: "book-1", DueAt: &dueSoon},
+			mockFn: func(repo *mocks.IDbRepository, bookRepo *mocks.BookRepository, userRepo *mocks.UserRepository) {
+				userRepo.On("GetByID", mock.Anything, "1").Return(&domain.User{ID: 1, Role: domain.RoleOperation}, nil)
+				bookRepo.On("GetByID", mock.Anything, "book-1").Return(&domain.Book{ID: "book-1"}, nil)
+				repo.On("Renew", mock.Anything, mock.Anything).Return(ErrConcurrentUpdate)
+			},
+			wantErr: ErrConcurrentUpdate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.IDbRepository)
+			bookRepo := new(mocks.BookRepository)
+			userRepo := new(mocks.UserRepository)
+			repo.On("GetByID", mock.Anything, tt.transaction.ID).Return(tt.transaction, nil)
+			if tt.mockFn != nil {
+				tt.mockFn(repo, bookRepo, userRepo)
+			}
+			s := NewService(repo, emptyHoldQueue(), nil, nil, bookRepo, userRepo, nil, policy)
+
+			got, err := s.RenewLoan(context.Background(), tt.transaction.ID)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, tt.wantDue.Equal(*got.DueAt))
+			}
+			repo.AssertExpectations(t)
+			bookRepo.AssertExpectations(t)
+			userRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -8,26 +8,51 @@ import (
 	"gorm.io/gorm"
 )
 
-// Transaction represents a book borrowing transaction
+// LoanStatus is how a loan that did not end in an ordinary return was closed.
+// Loans that are open or were returned have no status.
+type LoanStatus string
+
+const (
+	// LoanStatusLost means the borrower lost the book and was charged for it
+	LoanStatusLost LoanStatus = "lost"
+	// LoanStatusDamaged means the book came back damaged beyond repair and
+	// the borrower was charged for it
+	LoanStatusDamaged LoanStatus = "damaged"
+	// LoanStatusFound means a lost book turned up again and the replacement
+	// fee was refunded
+	LoanStatusFound LoanStatus = "found"
+)
+
+// Transaction represents a book borrowing transaction. Listings page through
+// transactions by (borrowed_at, id), per user, per book or across all of them,
+// which the borrowed_at indexes serve. Loans closed as lost or damaged have
+// ReturnedAt set as well, so they no longer count as open anywhere.
 type Transaction struct {
-	ID         string         `gorm:"primaryKey"`
-	UserID     string         `gorm:"not null;index"`
-	BookID     string         `gorm:"not null;index"`
-	BorrowedAt time.Time      `gorm:"not null"`
-	ReturnedAt *time.Time     `gorm:"default:null"`
-	CreatedAt  time.Time      `gorm:"not null"`
-	UpdatedAt  time.Time      `gorm:"not null"`
-	DeletedAt  gorm.DeletedAt `gorm:"index"`
-}
-
-// NewTransaction creates a new transaction
-func NewTransaction(userID, bookID string) *Transaction {
+	ID             string         `gorm:"primaryKey" json:"id"`
+	UserID         string         `gorm:"not null;index:idx_transactions_user_borrowed_at,priority:1" json:"user_id"`
+	BookID         string         `gorm:"not null;index:idx_transactions_book_borrowed_at,priority:1" json:"book_id"`
+	CopyBarcode    string         `gorm:"index" json:"copy_barcode,omitempty"`
+	BorrowedAt     time.Time      `gorm:"not null;index:idx_transactions_user_borrowed_at,priority:2;index:idx_transactions_book_borrowed_at,priority:2;index" json:"borrowed_at"`
+	ReturnedAt     *time.Time     `gorm:"default:null" json:"returned_at"`
+	DueAt          *time.Time     `gorm:"index" json:"due_at"`
+	RenewalCount   int32          `gorm:"not null;default:0" json:"renewal_count"`
+	Status         LoanStatus     `gorm:"size:16;not null;default:'';index" json:"status,omitempty"`
+	ReplacementFee int64          `gorm:"not null;default:0" json:"replacement_fee,omitempty"`
+	CreatedAt      time.Time      `gorm:"not null" json:"created_at"`
+	UpdatedAt      time.Time      `gorm:"not null" json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// NewTransaction creates a new transaction due back after loanPeriod
+func NewTransaction(userID, bookID string, loanPeriod time.Duration) *Transaction {
 	now := time.Now()
+	dueAt := now.Add(loanPeriod)
 	return &Transaction{
 		ID:         uuid.New().String(),
 		UserID:     userID,
 		BookID:     bookID,
 		BorrowedAt: now,
+		DueAt:      &dueAt,
 		CreatedAt:  now,
 		UpdatedAt:  now,
 	}
@@ -40,23 +65,94 @@ func (t *Transaction) MarkAsReturned() {
 	t.UpdatedAt = now
 }
 
-// IsReturned checks if the book has been returned
+// IsReturned checks if the loan is closed, whether the book was returned or
+// the loan was closed as lost or damaged
 func (t *Transaction) IsReturned() bool {
 	return t.ReturnedAt != nil
 }
 
+// Close ends the loan as lost or damaged, charging fee for the book
+func (t *Transaction) Close(status LoanStatus, fee int64) {
+	now := time.Now()
+	t.ReturnedAt = &now
+	t.Status = status
+	t.ReplacementFee = fee
+	t.UpdatedAt = now
+}
+
+// StatusName returns where the loan stands: "active", "returned", or the
+// status it was closed with
+func (t *Transaction) StatusName() string {
+	switch {
+	case t.Status != "":
+		return string(t.Status)
+	case t.ReturnedAt != nil:
+		return TransactionStatusReturned
+	default:
+		return TransactionStatusActive
+	}
+}
+
+// Renew pushes the due date forward by loanPeriod and counts the renewal.
+// Loans without a due date are renewed from now.
+func (t *Transaction) Renew(loanPeriod time.Duration) {
+	now := time.Now()
+	from := now
+	if t.DueAt != nil {
+		from = *t.DueAt
+	}
+	dueAt := from.Add(loanPeriod)
+	t.DueAt = &dueAt
+	t.RenewalCount++
+	t.UpdatedAt = now
+}
+
+// IsOverdue checks if the book is still out past its due date at now, or was
+// returned after it. Loans opened before due dates existed are never overdue.
+func (t *Transaction) IsOverdue(now time.Time) bool {
+	if t.DueAt == nil {
+		return false
+	}
+	if t.ReturnedAt != nil {
+		return t.ReturnedAt.After(*t.DueAt)
+	}
+	return now.After(*t.DueAt)
+}
+
+// DaysLate returns the number of started days the loan is overdue at now
+func (t *Transaction) DaysLate(now time.Time) int32 {
+	if !t.IsOverdue(now) {
+		return 0
+	}
+	end := now
+	if t.ReturnedAt != nil {
+		end = *t.ReturnedAt
+	}
+	late := end.Sub(*t.DueAt)
+	return int32((late + 24*time.Hour - 1) / (24 * time.Hour))
+}
+
 // ToProto converts the transaction entity to a protobuf transaction response
 func (t *Transaction) ToProto() *pb.TransactionResponse {
 	response := &pb.TransactionResponse{
-		TransactionId: t.ID,
-		UserId:        t.UserID,
-		BookId:        t.BookID,
-		BorrowedAt:    t.BorrowedAt.Format(time.RFC3339),
+		TransactionId:  t.ID,
+		UserId:         t.UserID,
+		BookId:         t.BookID,
+		BorrowedAt:     t.BorrowedAt.Format(time.RFC3339),
+		Overdue:        t.IsOverdue(time.Now()),
+		RenewalCount:   t.RenewalCount,
+		CopyBarcode:    t.CopyBarcode,
+		DeletedAt:      formatDeletedAt(t.DeletedAt),
+		Status:         t.StatusName(),
+		ReplacementFee: t.ReplacementFee,
 	}
 
 	if t.ReturnedAt != nil {
 		response.ReturnedAt = t.ReturnedAt.Format(time.RFC3339)
 	}
+	if t.DueAt != nil {
+		response.DueAt = t.DueAt.Format(time.RFC3339)
+	}
 
 	return response
 }
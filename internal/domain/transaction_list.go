@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+// Statuses a transaction listing can be narrowed to. Active loans are the
+// ones not returned yet, overdue loans the active ones past their due date.
+// Returned loans are the ones handed back, not the ones closed as lost or
+// damaged, nor lost ones that were found again.
+const (
+	TransactionStatusActive   = "active"
+	TransactionStatusReturned = "returned"
+	TransactionStatusOverdue  = "overdue"
+	TransactionStatusLost     = "lost"
+	TransactionStatusDamaged  = "damaged"
+	TransactionStatusFound    = "found"
+)
+
+// TransactionFilter narrows down and orders a transaction listing. Listings
+// are sorted by borrowed_at.
+type TransactionFilter struct {
+	UserID         string     `json:"user_id,omitempty"`
+	BookID         string     `json:"book_id,omitempty"`
+	Status         string     `json:"status,omitempty"`
+	BorrowedAfter  *time.Time `json:"borrowed_after,omitempty"`
+	BorrowedBefore *time.Time `json:"borrowed_before,omitempty"`
+	Ascending      bool       `json:"ascending,omitempty"`
+}
+
+// TransactionCursor is the position after which the next page of a listing
+// starts: the borrowed_at and ID of the last transaction on the previous page
+type TransactionCursor struct {
+	BorrowedAt time.Time `json:"b"`
+	ID         string    `json:"id"`
+}
+
+// TransactionQuery asks for one page of a transaction listing. Now is the
+// time overdue loans are due before.
+type TransactionQuery struct {
+	Filter TransactionFilter
+	Limit  int
+	After  *TransactionCursor
+	Now    time.Time
+}
@@ -0,0 +1,96 @@
+package user
+
+import (
+	"context"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ListDeletedUsers retrieves one page of the soft-deleted users, most
+// recently deleted first. It returns the token for the next page, empty on
+// the last page.
+func (s *DefaultService) ListDeletedUsers(ctx context.Context, pageSize int, pageToken string) ([]*domain.User, string, error) {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	var after *domain.DeletedCursor
+	if pageToken != "" {
+		cursor, ok := domain.DecodeDeletedCursor(pageToken)
+		if !ok {
+			return nil, "", ErrInvalidPageToken
+		}
+		if _, err := strconv.ParseUint(cursor.ID, 10, 64); err != nil {
+			return nil, "", ErrInvalidPageToken
+		}
+		after = cursor
+	}
+
+	// One user past the page tells whether another page follows
+	users, err := s.repoDb.ListDeleted(ctx, after, pageSize+1)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(users) <= pageSize {
+		return users, "", nil
+	}
+
+	users = users[:pageSize]
+	last := users[pageSize-1]
+	nextPageToken, err := domain.DeletedCursor{DeletedAt: last.DeletedAt.Time, ID: last.UserIDString()}.Encode()
+	if err != nil {
+		return nil, "", err
+	}
+	return users, nextPageToken, nil
+}
+
+// RestoreUser brings a soft-deleted user back. It returns
+// ErrEmailAlreadyExists when another user has taken its email since it was
+// deleted.
+func (s *DefaultService) RestoreUser(ctx context.Context, id string) (*domain.User, error) {
+	if id == "" {
+		return nil, ErrInvalidInput
+	}
+	return s.repoDb.Restore(ctx, id)
+}
+
+// PurgeDeletedUsers permanently removes the users soft-deleted longer ago
+// than retention. It returns how many users were removed and the cutoff.
+func (s *DefaultService) PurgeDeletedUsers(ctx context.Context, retention time.Duration) (int64, time.Time, error) {
+	if retention <= 0 {
+		return 0, time.Time{}, ErrInvalidInput
+	}
+
+	before := time.Now().Add(-retention)
+	purged, err := s.repoDb.Purge(ctx, before)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	return purged, before, nil
+}
+
+// RunRetention calls PurgeDeletedUsers every interval until ctx is cancelled
+func (s *DefaultService) RunRetention(ctx context.Context, interval, retention time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, _, err := s.PurgeDeletedUsers(ctx, retention)
+			if err != nil {
+				log.Error().Err(err).Msg("Failed to purge deleted users")
+			} else if purged > 0 {
+				log.Info().Int64("users", purged).Msg("Purged deleted users")
+			}
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"github.com/hinha/library-management-synapsis/internal/domain/user/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestDefaultService_ListDeletedUsers(t *testing.T) {
+	deletedAt := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	users := []*domain.User{
+		{ID: 9, DeletedAt: gorm.DeletedAt{Time: deletedAt, Valid: true}},
+		{ID: 7, DeletedAt: gorm.DeletedAt{Time: deletedAt, Valid: true}},
+	}
+	nextPage, _ := domain.DeletedCursor{DeletedAt: deletedAt, ID: "9"}.Encode()
+	foreignPage, _ := domain.DeletedCursor{DeletedAt: deletedAt, ID: "book-1"}.Encode()
+
+	tests := []struct {
+		name          string
+		pageSize      int
+		pageToken     string
+		mockFn        func(repo *mocks.IDbRepository)
+		wantIDs       []uint
+		wantPageToken string
+		wantErr       error
+	}{
+		{
+			name:     "first page with more to come",
+			pageSize: 1,
+			mockFn: func(repo *mocks.IDbRepository) {
+				repo.On("ListDeleted", mock.Anything, (*domain.DeletedCursor)(nil), 2).Return(users, nil)
+			},
+			wantIDs:       []uint{9},
+			wantPageToken: nextPage,
+		},
+		{
+			name:      "last page",
+			pageSize:  MaxPageSize + 1,
+			pageToken: nextPage,
+			mockFn: func(repo *mocks.IDbRepository) {
+				repo.On("ListDeleted", mock.Anything, mock.MatchedBy(func(after *domain.DeletedCursor) bool {
+					return after.ID == "9"
+				}), MaxPageSize+1).Return(users[1:], nil)
+			},
+			wantIDs: []uint{7},
+		},
+		{
+			name:      "token of another service",
+			pageToken: foreignPage,
+			wantErr:   ErrInvalidPageToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.IDbRepository)
+			if tt.mockFn != nil {
+				tt.mockFn(repo)
+			}
+			s := &DefaultService{repoDb: repo}
+
+			got, pageToken, err := s.ListDeletedUsers(context.Background(), tt.pageSize, tt.pageToken)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil && assert.Len(t, got, len(tt.wantIDs)) {
+				for i, user := range got {
+					assert.Equal(t, tt.wantIDs[i], user.ID)
+				}
+				assert.Equal(t, tt.wantPageToken, pageToken)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDefaultService_RestoreUser(t *testing.T) {
+	t.Run("email taken since", func(t *testing.T) {
+		repo := new(mocks.IDbRepository)
+		repo.On("Restore", mock.Anything, "5").Return(nil, ErrEmailAlreadyExists)
+		s := &DefaultService{repoDb: repo}
+
+		_, err := s.RestoreUser(context.Background(), "5")
+
+		assert.ErrorIs(t, err, ErrEmailAlreadyExists)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("missing id", func(t *testing.T) {
+		s := &DefaultService{repoDb: new(mocks.IDbRepository)}
+
+		_, err := s.RestoreUser(context.Background(), "")
+
+		assert.ErrorIs(t, err, ErrInvalidInput)
+	})
+}
+
+func TestDefaultService_PurgeDeletedUsers(t *testing.T) {
+	repo := new(mocks.IDbRepository)
+	repo.On("Purge", mock.Anything, mock.AnythingOfType("time.Time")).Return(int64(3), nil)
+	s := &DefaultService{repoDb: repo}
+
+	purged, before, err := s.PurgeDeletedUsers(context.Background(), 24*time.Hour)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), purged)
+	assert.WithinDuration(t, time.Now().Add(-24*time.Hour), before, time.Minute)
+
+	_, _, err = s.PurgeDeletedUsers(context.Background(), 0)
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
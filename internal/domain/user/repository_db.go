@@ -5,6 +5,7 @@ import (
 	"errors"
 	"github.com/hinha/library-management-synapsis/internal/domain"
 	"gorm.io/gorm"
+	"time"
 )
 
 var (
@@ -12,6 +13,8 @@ var (
 	ErrUserNotFound = errors.New("user not found")
 	// ErrEmailAlreadyExists is returned when a user with the same email already exists
 	ErrEmailAlreadyExists = errors.New("email already exists")
+	// ErrVersionConflict is returned when a user changed since it was read
+	ErrVersionConflict = errors.New("user was modified concurrently")
 )
 
 // IDbRepository defines the interface for user data access
@@ -23,6 +26,9 @@ type IDbRepository interface {
 	GetByEmail(ctx context.Context, email string) (*domain.User, error)
 	Update(ctx context.Context, user *domain.User) error
 	Delete(ctx context.Context, id string) error
+	ListDeleted(ctx context.Context, after *domain.DeletedCursor, limit int) ([]*domain.User, error)
+	Restore(ctx context.Context, id string) (*domain.User, error)
+	Purge(ctx context.Context, before time.Time) (int64, error)
 	Ping(ctx context.Context) (err error)
 }
 
@@ -46,7 +52,12 @@ func (r *DBRepository) Create(ctx context.Context, user *domain.User) error {
 		return ErrEmailAlreadyExists
 	}
 
-	return r.db.WithContext(ctx).Create(user).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		return writeEvent(tx, domain.EventUserRegistered, user.UserIDString(), user)
+	})
 }
 
 // GetByID retrieves a user by ID
@@ -73,28 +84,58 @@ func (r *DBRepository) GetByEmail(ctx context.Context, email string) (*domain.Us
 	return &user, nil
 }
 
-// Update updates a user
+// Update updates a user if it still has the version it was read at and
+// bumps its version. It returns ErrVersionConflict when the user has been
+// written since.
 func (r *DBRepository) Update(ctx context.Context, user *domain.User) error {
-	result := r.db.WithContext(ctx).Save(user)
-	if result.Error != nil {
-		return result.Error
-	}
-	if result.RowsAffected == 0 {
-		return ErrUserNotFound
+	version := user.Version
+	user.Version++
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(user).Where("version = ?", version).
+			Select("*").Omit("id", "created_at", "deleted_at").Updates(user)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			// Tell a missing user apart from one written since it was read
+			var count int64
+			if err := tx.Model(&domain.User{}).Where("id = ?", user.ID).Count(&count).Error; err != nil {
+				return err
+			}
+			if count == 0 {
+				return ErrUserNotFound
+			}
+			return ErrVersionConflict
+		}
+		return writeEvent(tx, domain.EventUserUpdated, user.UserIDString(), user)
+	})
+	if err != nil {
+		user.Version = version
 	}
-	return nil
+	return err
 }
 
 // Delete deletes a user
 func (r *DBRepository) Delete(ctx context.Context, id string) error {
-	result := r.db.WithContext(ctx).Delete(&domain.User{}, "id = ?", id)
-	if result.Error != nil {
-		return result.Error
-	}
-	if result.RowsAffected == 0 {
-		return ErrUserNotFound
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&domain.User{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrUserNotFound
+		}
+		return writeEvent(tx, domain.EventUserDeleted, id, map[string]string{"id": id})
+	})
+}
+
+// writeEvent appends a user event to the outbox inside tx
+func writeEvent(tx *gorm.DB, eventType, userID string, payload interface{}) error {
+	event, err := domain.NewOutboxEvent(eventType, domain.AggregateUser, userID, payload)
+	if err != nil {
+		return err
 	}
-	return nil
+	return tx.Create(event).Error
 }
 
 func (r *DBRepository) Ping(ctx context.Context) (err error) {
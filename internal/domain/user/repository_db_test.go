@@ -60,6 +60,13 @@ import (
 //	return args.Error(0)
 //}
 
+// expectOutboxEvent expects the outbox insert written alongside a user change
+func expectOutboxEvent(mock sqlmock.Sqlmock, eventType string) {
+	mock.ExpectQuery(`INSERT INTO "outbox_events"`).
+		WithArgs(sqlmock.AnyArg(), domain.AggregateUser, sqlmock.AnyArg(), eventType, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+}
+
 func TestDBRepository_Create(t *testing.T) {
 	type fields struct {
 		setupMock func(sqlmock.Sqlmock)
@@ -80,6 +87,7 @@ func TestDBRepository_Create(t *testing.T) {
 					mock.ExpectBegin()
 					mock.ExpectQuery(`INSERT INTO "users"`).
 						WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+					expectOutboxEvent(mock, domain.EventUserRegistered)
 					mock.ExpectCommit()
 				},
 			},
@@ -382,24 +390,44 @@ func TestDBRepository_Update(t *testing.T) {
 					mock.ExpectBegin()
 					mock.ExpectExec(`UPDATE "users" SET .+ WHERE .+`).
 						WillReturnResult(sqlmock.NewResult(0, 1))
+					expectOutboxEvent(mock, domain.EventUserUpdated)
 					mock.ExpectCommit()
 				},
 			},
 			expectedError: nil,
 		},
-		//{
-		//	name: "User Not Found",
-		//	user: userDummy,
-		//	fields: fields{
-		//		setupMock: func(mock sqlmock.Sqlmock) {
-		//			mock.ExpectBegin()
-		//			mock.ExpectExec(`UPDATE "users" SET .+ WHERE .+`).
-		//				WillReturnResult(sqlmock.NewResult(0, 0))
-		//			mock.ExpectCommit()
-		//		},
-		//	},
-		//	expectedError: ErrUserNotFound,
-		//},
+		{
+			name: "User Not Found",
+			user: &domain.User{ID: 999, Name: "Not Found User", Version: 1},
+			fields: fields{
+				setupMock: func(mock sqlmock.Sqlmock) {
+					mock.ExpectBegin()
+					mock.ExpectExec(`UPDATE "users" SET .+ WHERE version = \$\d+ AND .+`).
+						WillReturnResult(sqlmock.NewResult(0, 0))
+					mock.ExpectQuery(`SELECT count\(\*\) FROM "users" WHERE id = \$1`).
+						WithArgs(uint(999)).
+						WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+					mock.ExpectRollback()
+				},
+			},
+			expectedError: ErrUserNotFound,
+		},
+		{
+			name: "Version Conflict",
+			user: &domain.User{ID: 1, Name: "Stale User", Version: 1},
+			fields: fields{
+				setupMock: func(mock sqlmock.Sqlmock) {
+					mock.ExpectBegin()
+					mock.ExpectExec(`UPDATE "users" SET .+ WHERE version = \$\d+ AND .+`).
+						WillReturnResult(sqlmock.NewResult(0, 0))
+					mock.ExpectQuery(`SELECT count\(\*\) FROM "users" WHERE id = \$1`).
+						WithArgs(uint(1)).
+						WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+					mock.ExpectRollback()
+				},
+			},
+			expectedError: ErrVersionConflict,
+		},
 		{
 			name: "Database Error",
 			user: &domain.User{
@@ -468,6 +496,7 @@ func TestDBRepository_Delete(t *testing.T) {
 					mock.ExpectExec(`UPDATE "users" SET "deleted_at"=\$1 WHERE id = \$2 AND "users"\."deleted_at" IS NULL`).
 						WithArgs(sqlmock.AnyArg(), "1"). // AnyArg = deleted_at timestamp
 						WillReturnResult(sqlmock.NewResult(0, 1))
+					expectOutboxEvent(mock, domain.EventUserDeleted)
 					mock.ExpectCommit()
 				},
 			},
@@ -482,7 +511,7 @@ func TestDBRepository_Delete(t *testing.T) {
 					mock.ExpectExec(`UPDATE "users" SET "deleted_at"=\$1 WHERE id = \$2 AND "users"\."deleted_at" IS NULL`).
 						WithArgs(sqlmock.AnyArg(), "2").
 						WillReturnResult(sqlmock.NewResult(0, 0)) // penting: RowsAffected = 0
-					mock.ExpectCommit()
+					mock.ExpectRollback()
 				},
 			},
 			expectedError: ErrUserNotFound,
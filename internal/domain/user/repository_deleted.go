@@ -0,0 +1,81 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ListDeleted retrieves up to limit soft-deleted users, most recently
+// deleted first, continuing after the cursor when it is set
+func (r *DBRepository) ListDeleted(ctx context.Context, after *domain.DeletedCursor, limit int) ([]*domain.User, error) {
+	db := r.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL")
+	if after != nil {
+		id, err := strconv.ParseUint(after.ID, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		db = db.Where("(deleted_at, id) < (?, ?)", after.DeletedAt, id)
+	}
+
+	var users []*domain.User
+	if err := db.Order("deleted_at DESC, id DESC").Limit(limit).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// Restore brings a soft-deleted user back and bumps its version. It returns
+// ErrUserNotFound when no deleted user has the id, and ErrEmailAlreadyExists
+// when another user has taken its email since it was deleted.
+func (r *DBRepository) Restore(ctx context.Context, id string) (*domain.User, error) {
+	var user domain.User
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND deleted_at IS NOT NULL", id).First(&user).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrUserNotFound
+			}
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&domain.User{}).Where("email = ? AND id <> ?", user.Email, user.ID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrEmailAlreadyExists
+		}
+
+		user.DeletedAt = gorm.DeletedAt{}
+		user.Version++
+		user.UpdatedAt = time.Now()
+		if err := tx.Unscoped().Model(&user).Updates(map[string]interface{}{
+			"deleted_at": nil,
+			"version":    user.Version,
+			"updated_at": user.UpdatedAt,
+		}).Error; err != nil {
+			return err
+		}
+		return writeEvent(tx, domain.EventUserRestored, user.UserIDString(), &user)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Purge permanently removes the users soft-deleted before the given time.
+// It returns how many users were removed.
+func (r *DBRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Unscoped().Where("deleted_at < ?", before).Delete(&domain.User{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,137 @@
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// newDBRepository returns a DBRepository backed by sqlmock
+func newDBRepository(t *testing.T) (*DBRepository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	gdb, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+	return &DBRepository{db: gdb}, mock
+}
+
+func TestDBRepository_ListDeleted(t *testing.T) {
+	deletedAt := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	repo, mock := newDBRepository(t)
+
+	mock.ExpectQuery(`SELECT \* FROM "users" WHERE deleted_at IS NOT NULL AND \(deleted_at, id\) < \(\$1, \$2\) ORDER BY deleted_at DESC, id DESC LIMIT \$3`).
+		WithArgs(deletedAt, uint64(7), 3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "deleted_at"}).AddRow(5, "jane@example.com", deletedAt))
+
+	users, err := repo.ListDeleted(context.Background(), &domain.DeletedCursor{DeletedAt: deletedAt, ID: "7"}, 3)
+
+	assert.NoError(t, err)
+	if assert.Len(t, users, 1) {
+		assert.Equal(t, uint(5), users[0].ID)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDBRepository_Restore(t *testing.T) {
+	deletedAt := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	expectLock := func(mock sqlmock.Sqlmock, found bool) {
+		rows := sqlmock.NewRows([]string{"id", "email", "version", "deleted_at"})
+		if found {
+			rows.AddRow(5, "jane@example.com", 2, deletedAt)
+		}
+		mock.ExpectQuery(`SELECT \* FROM "users" WHERE id = \$1 AND deleted_at IS NOT NULL ORDER BY "users"\."id" LIMIT \$2 FOR UPDATE`).
+			WithArgs("5", 1).
+			WillReturnRows(rows)
+	}
+	expectEmailCount := func(mock sqlmock.Sqlmock, count int) {
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "users" WHERE \(email = \$1 AND id <> \$2\) AND "users"\."deleted_at" IS NULL`).
+			WithArgs("jane@example.com", 5).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(count))
+	}
+
+	tests := []struct {
+		name      string
+		setupMock func(mock sqlmock.Sqlmock)
+		wantErr   error
+	}{
+		{
+			name: "restored",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectLock(mock, true)
+				expectEmailCount(mock, 0)
+				mock.ExpectExec(`UPDATE "users" SET "deleted_at"=\$1,"updated_at"=\$2,"version"=\$3 WHERE "id" = \$4`).
+					WithArgs(nil, sqlmock.AnyArg(), int64(3), 5).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectOutboxEvent(mock, domain.EventUserRestored)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "email taken since",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectLock(mock, true)
+				expectEmailCount(mock, 1)
+				mock.ExpectRollback()
+			},
+			wantErr: ErrEmailAlreadyExists,
+		},
+		{
+			name: "not deleted",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectLock(mock, false)
+				mock.ExpectRollback()
+			},
+			wantErr: ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock := newDBRepository(t)
+			tt.setupMock(mock)
+
+			user, err := repo.Restore(context.Background(), "5")
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.False(t, user.DeletedAt.Valid)
+				assert.Equal(t, int64(3), user.Version)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDBRepository_Purge(t *testing.T) {
+	before := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	repo, mock := newDBRepository(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "users" WHERE deleted_at < \$1`).
+		WithArgs(before).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	purged, err := repo.Purge(context.Background(), before)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), purged)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -16,6 +16,17 @@ var (
 	ErrInvalidCredentials = errors.New("invalid credentials")
 	// ErrUnauthorized is returned when a user is not authorized to perform an action
 	ErrUnauthorized = errors.New("unauthorized")
+	// ErrInvalidInput is returned when the input is invalid
+	ErrInvalidInput = errors.New("invalid input")
+	// ErrInvalidPageToken is returned when a page token is malformed
+	ErrInvalidPageToken = errors.New("invalid page token")
+)
+
+const (
+	// DefaultPageSize is the page size used when the client does not ask for one
+	DefaultPageSize = 20
+	// MaxPageSize is the largest page a client can ask for
+	MaxPageSize = 100
 )
 
 // JWTConfig contains configuration for JWT token generation
@@ -31,8 +42,11 @@ type IService interface {
 	Register(ctx context.Context, name, email, password string, isAdmin bool) (*domain.User, error)
 	Login(ctx context.Context, email, password string) (string, string, error)
 	GetUser(ctx context.Context, id string) (*domain.User, error)
-	UpdateUser(ctx context.Context, id, name, email string) (*domain.User, error)
+	UpdateUser(ctx context.Context, id string, version int64, name, email string) (*domain.User, error)
 	ValidateToken(ctx context.Context, token string) (*Claims, error)
+	ListDeletedUsers(ctx context.Context, pageSize int, pageToken string) ([]*domain.User, string, error)
+	RestoreUser(ctx context.Context, id string) (*domain.User, error)
+	PurgeDeletedUsers(ctx context.Context, retention time.Duration) (int64, time.Time, error)
 	Health(ctx context.Context) (*pb.HealthCheckResponse, error)
 }
 
@@ -126,12 +140,17 @@ func (s *DefaultService) GetUser(ctx context.Context, id string) (*domain.User,
 	return s.repoDb.GetByID(ctx, id)
 }
 
-// UpdateUser updates a user's information
-func (s *DefaultService) UpdateUser(ctx context.Context, id, name, email string) (*domain.User, error) {
+// UpdateUser updates a user's information. version is the version of the
+// user the change is based on, ErrVersionConflict is returned when the user
+// has been written since.
+func (s *DefaultService) UpdateUser(ctx context.Context, id string, version int64, name, email string) (*domain.User, error) {
 	user, err := s.repoDb.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	if user.Version != version {
+		return nil, ErrVersionConflict
+	}
 
 	if name != "" {
 		user.Name = name
@@ -310,9 +310,10 @@ func TestDefaultService_GetUser(t *testing.T) {
 
 func TestDefaultService_UpdateUser(t *testing.T) {
 	type args struct {
-		id    string
-		name  string
-		email string
+		id      string
+		version int64
+		name    string
+		email   string
 	}
 	testCases := []struct {
 		name          string
@@ -323,7 +324,7 @@ func TestDefaultService_UpdateUser(t *testing.T) {
 	}{
 		{
 			name: "Success update name",
-			args: args{id: "1", name: "Updated Name", email: ""},
+			args: args{id: "1", version: 1, name: "Updated Name", email: ""},
 			setupMock: func(repo *mocks.IDbRepository, user *domain.User) {
 				repo.On("GetByID", mock.Anything, "1").Return(user, nil)
 				repo.On("Update", mock.Anything, mock.AnythingOfType("*domain.User")).Return(nil)
@@ -338,7 +339,7 @@ func TestDefaultService_UpdateUser(t *testing.T) {
 		},
 		{
 			name: "Success update email",
-			args: args{id: "1", name: "", email: "new@example.com"},
+			args: args{id: "1", version: 1, name: "", email: "new@example.com"},
 			setupMock: func(repo *mocks.IDbRepository, user *domain.User) {
 				repo.On("GetByID", mock.Anything, "1").Return(user, nil)
 				repo.On("GetByEmail", mock.Anything, "new@example.com").Return(nil, ErrUserNotFound)
@@ -354,7 +355,7 @@ func TestDefaultService_UpdateUser(t *testing.T) {
 		},
 		{
 			name: "Email already exists",
-			args: args{id: "1", name: "", email: "exists@example.com"},
+			args: args{id: "1", version: 1, name: "", email: "exists@example.com"},
 			setupMock: func(repo *mocks.IDbRepository, user *domain.User) {
 				repo.On("GetByID", mock.Anything, "1").Return(user, nil)
 				repo.On("GetByEmail", mock.Anything, "exists@example.com").Return(&domain.User{}, nil)
@@ -362,9 +363,18 @@ func TestDefaultService_UpdateUser(t *testing.T) {
 			expectedUser:  nil,
 			expectedError: ErrEmailAlreadyExists,
 		},
+		{
+			name: "User changed since it was read",
+			args: args{id: "1", version: 2, name: "Updated Name"},
+			setupMock: func(repo *mocks.IDbRepository, user *domain.User) {
+				repo.On("GetByID", mock.Anything, "1").Return(user, nil)
+			},
+			expectedUser:  nil,
+			expectedError: ErrVersionConflict,
+		},
 		{
 			name: "GetByID returns error",
-			args: args{id: "2", name: "Any", email: ""},
+			args: args{id: "2", version: 1, name: "Any", email: ""},
 			setupMock: func(repo *mocks.IDbRepository, user *domain.User) {
 				repo.On("GetByID", mock.Anything, "2").Return(nil, errors.New("not found"))
 			},
@@ -373,7 +383,7 @@ func TestDefaultService_UpdateUser(t *testing.T) {
 		},
 		{
 			name: "Update returns error",
-			args: args{id: "1", name: "Updated Name", email: ""},
+			args: args{id: "1", version: 1, name: "Updated Name", email: ""},
 			setupMock: func(repo *mocks.IDbRepository, user *domain.User) {
 				repo.On("GetByID", mock.Anything, "1").Return(user, nil)
 				repo.On("Update", mock.Anything, mock.AnythingOfType("*domain.User")).Return(errors.New("update error"))
@@ -395,12 +405,13 @@ func TestDefaultService_UpdateUser(t *testing.T) {
 					Email: "user@example.com",
 					Role:  domain.RoleOperation,
 				}
+				user.Version = 1
 			}
 			tc.setupMock(repo, user)
 
 			svc := &DefaultService{repoDb: repo}
 
-			gotUser, gotErr := svc.UpdateUser(context.Background(), tc.args.id, tc.args.name, tc.args.email)
+			gotUser, gotErr := svc.UpdateUser(context.Background(), tc.args.id, tc.args.version, tc.args.name, tc.args.email)
 
 			if tc.expectedError != nil {
 				assert.Error(t, gotErr)
@@ -28,9 +28,10 @@ type User struct {
 	Password  string         `gorm:"size:255;not null" json:"-"`
 	Role      Role           `gorm:"not null;default:'operation'" json:"role"`
 	Active    bool           `gorm:"default:true" json:"active"`
+	Version   int64          `gorm:"not null;default:1" json:"version"` // bumped by every write
 	CreatedAt time.Time      `gorm:"not null" json:"created_at"`
-	UpdatedAt time.Time      `gorm:"not null"`
-	DeletedAt gorm.DeletedAt `gorm:"index"`
+	UpdatedAt time.Time      `gorm:"not null" json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
 // NewUser creates a new user entity
@@ -48,6 +49,7 @@ func NewUser(name, email, password string, role Role) (*User, error) {
 		Email:     email,
 		Password:  string(hashedPassword),
 		Role:      role,
+		Version:   1,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}, nil
@@ -70,10 +72,12 @@ func (u *User) ToProto() *pb.UserResponse {
 		role = pb.UserRole_USER_ROLE_UNSPECIFIED
 	}
 	return &pb.UserResponse{
-		Id:    strconv.Itoa(int(u.ID)),
-		Name:  u.Name,
-		Email: u.Email,
-		Role:  role,
+		Id:        strconv.Itoa(int(u.ID)),
+		Name:      u.Name,
+		Email:     u.Email,
+		Role:      role,
+		Version:   u.Version,
+		DeletedAt: formatDeletedAt(u.DeletedAt),
 	}
 }
 
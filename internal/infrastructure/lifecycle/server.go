@@ -0,0 +1,306 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// ReadyPath is the gateway path answering 200 while the service takes
+// requests and 503 once it is shutting down or while a readiness check fails
+const ReadyPath = "/ready"
+
+// ErrInvalidShutdownTimeout is returned by New when the shutdown timeout is
+// not positive
+var ErrInvalidShutdownTimeout = errors.New("shutdown timeout must be positive")
+
+// Hook is run when a service starts or stops
+type Hook func(ctx context.Context) error
+
+// Check reports why a service cannot do its work, or nil when it can
+type Check func() error
+
+// Gateway builds the HTTP gateway in front of the gRPC server at grpcAddr.
+// Connections it opens should close when ctx is done, which happens once
+// the gateway has drained.
+type Gateway func(ctx context.Context, grpcAddr string) (http.Handler, error)
+
+// Config configures a Server
+type Config struct {
+	// Name of the service in log messages, such as "Book service"
+	Name     string
+	GrpcAddr string
+	HttpAddr string
+	// ReadinessDelay is how long the service keeps taking requests after
+	// reporting not ready, so load balancers stop sending new ones first
+	ReadinessDelay time.Duration
+	// ShutdownTimeout is how long in-flight requests and background workers
+	// get to finish, and separately how long the stop hooks get
+	ShutdownTimeout time.Duration
+}
+
+// namedHook is a hook with the name it is logged under
+type namedHook struct {
+	name string
+	hook Hook
+}
+
+// Server runs a service's gRPC server and HTTP gateway until SIGINT or
+// SIGTERM, then shuts them down gracefully:
+//
+//  1. readiness flips to not serving, on the gRPC health service and on
+//     ReadyPath, and the service waits ReadinessDelay
+//  2. the servers stop accepting requests and background workers are
+//     cancelled; in-flight requests and workers get ShutdownTimeout to
+//     finish, after which the remaining ones, such as open event streams,
+//     are cut off. The HTTP gateway drains first, since its requests are
+//     proxied to the gRPC server, and the gRPC server then drains within
+//     what is left of the deadline
+//  3. the stop hooks run in the order they were registered, closing the
+//     database, cache and client connections
+type Server struct {
+	cfg        Config
+	health     *health.Server
+	ready      atomic.Bool
+	startHooks []namedHook
+	stopHooks  []namedHook
+	workers    []namedHook
+	checks     []namedCheck
+}
+
+// namedCheck is a readiness check with the name it is reported under
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// New creates a Server. It returns ErrInvalidShutdownTimeout when
+// cfg.ShutdownTimeout is not positive, which would cut every request off at
+// shutdown.
+func New(cfg Config) (*Server, error) {
+	if cfg.ShutdownTimeout <= 0 {
+		return nil, ErrInvalidShutdownTimeout
+	}
+	return &Server{cfg: cfg, health: health.NewServer()}, nil
+}
+
+// OnStart registers a hook run before the servers start. Hooks run in the
+// order they were registered; the first to fail aborts the start.
+func (s *Server) OnStart(name string, hook Hook) {
+	s.startHooks = append(s.startHooks, namedHook{name: name, hook: hook})
+}
+
+// OnStop registers a hook run once the servers have drained. Hooks run in
+// the order they were registered, and all of them run even when some fail.
+// Stop hooks also run when the service fails to start.
+func (s *Server) OnStop(name string, hook Hook) {
+	s.stopHooks = append(s.stopHooks, namedHook{name: name, hook: hook})
+}
+
+// Go registers a background worker started after the start hooks. Its
+// context is cancelled when the service shuts down, and the stop hooks
+// wait for it to return, up to ShutdownTimeout.
+func (s *Server) Go(name string, worker func(ctx context.Context)) {
+	s.workers = append(s.workers, namedHook{name: name, hook: func(ctx context.Context) error {
+		worker(ctx)
+		return nil
+	}})
+}
+
+// ReadyWhen registers a readiness check. ReadyPath answers 503 while it
+// fails, such as while a background worker cannot authenticate to another
+// service, so the failure shows up in the deployment rather than only in
+// the logs.
+func (s *Server) ReadyWhen(name string, check Check) {
+	s.checks = append(s.checks, namedCheck{name: name, check: check})
+}
+
+// Ready reports whether the service takes requests
+func (s *Server) Ready() bool {
+	return s.ready.Load()
+}
+
+// Run starts the service and blocks until it is told to stop by a signal or
+// ctx, or until a server fails. It returns the error that stopped the
+// service, if any, along with the errors of the stop hooks.
+func (s *Server) Run(ctx context.Context, grpcServer *grpc.Server, gateway Gateway) (err error) {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	defer func() {
+		err = errors.Join(err, s.runStopHooks())
+	}()
+
+	for _, h := range s.startHooks {
+		if err := h.hook(ctx); err != nil {
+			return fmt.Errorf("start hook %s: %w", h.name, err)
+		}
+	}
+
+	grpcListener, err := net.Listen("tcp", s.cfg.GrpcAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.cfg.GrpcAddr, err)
+	}
+	httpListener, err := net.Listen("tcp", s.cfg.HttpAddr)
+	if err != nil {
+		grpcListener.Close()
+		return fmt.Errorf("failed to listen on %s: %w", s.cfg.HttpAddr, err)
+	}
+
+	// The gateway outlives the workers' context, it keeps proxying
+	// in-flight requests while they drain
+	gatewayCtx, closeGateway := context.WithCancel(context.Background())
+	defer closeGateway()
+	handler, err := gateway(gatewayCtx, grpcListener.Addr().String())
+	if err != nil {
+		grpcListener.Close()
+		httpListener.Close()
+		return fmt.Errorf("failed to build gateway: %w", err)
+	}
+	httpServer := &http.Server{Handler: s.readiness(handler)}
+
+	workerCtx, cancelWorkers := context.WithCancel(context.Background())
+	defer cancelWorkers()
+	var workers sync.WaitGroup
+	for _, w := range s.workers {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			w.hook(workerCtx)
+		}()
+	}
+
+	healthpb.RegisterHealthServer(grpcServer, s.health)
+	serveErr := make(chan error, 2)
+	go func() {
+		log.Info().Msgf("%s gRPC server listening at %v", s.cfg.Name, grpcListener.Addr())
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			serveErr <- fmt.Errorf("gRPC server: %w", err)
+		}
+	}()
+	go func() {
+		log.Info().Msgf("%s HTTP server listening at %v", s.cfg.Name, httpListener.Addr())
+		if err := httpServer.Serve(httpListener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- fmt.Errorf("HTTP server: %w", err)
+		}
+	}()
+
+	s.setReady(true)
+
+	select {
+	case <-ctx.Done():
+		// A second signal kills the service without waiting for the drain
+		stop()
+		log.Info().Msgf("Shutting down %s...", s.cfg.Name)
+		s.setReady(false)
+		if s.cfg.ReadinessDelay > 0 {
+			time.Sleep(s.cfg.ReadinessDelay)
+		}
+	case err = <-serveErr:
+		log.Error().Err(err).Msgf("Shutting down %s after a server failed", s.cfg.Name)
+		s.setReady(false)
+	}
+
+	drainCtx, cancel := context.WithTimeout(context.Background(), s.cfg.ShutdownTimeout)
+	defer cancel()
+	cancelWorkers()
+
+	var drained sync.WaitGroup
+	drained.Add(2)
+	go func() {
+		defer drained.Done()
+		// Gateway requests are proxied to the gRPC server, which keeps
+		// serving them until the gateway has drained
+		if err := httpServer.Shutdown(drainCtx); err != nil {
+			log.Warn().Err(err).Msg("HTTP requests still in flight at the shutdown deadline")
+			httpServer.Close()
+		}
+		closeGateway()
+
+		stopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-drainCtx.Done():
+			log.Warn().Msg("gRPC calls still in flight at the shutdown deadline")
+			grpcServer.Stop()
+		}
+	}()
+	go func() {
+		defer drained.Done()
+		finished := make(chan struct{})
+		go func() {
+			workers.Wait()
+			close(finished)
+		}()
+		select {
+		case <-finished:
+		case <-drainCtx.Done():
+			log.Warn().Msg("Background workers still running at the shutdown deadline")
+		}
+	}()
+	drained.Wait()
+
+	return err
+}
+
+// setReady sets whether the service reports it takes requests
+func (s *Server) setReady(ready bool) {
+	s.ready.Store(ready)
+	if ready {
+		s.health.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	} else {
+		s.health.Shutdown()
+	}
+}
+
+// readiness serves ReadyPath in front of handler
+func (s *Server) readiness(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != ReadyPath {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		if !s.Ready() {
+			http.Error(w, "shutting down", http.StatusServiceUnavailable)
+			return
+		}
+		for _, c := range s.checks {
+			if err := c.check(); err != nil {
+				http.Error(w, fmt.Sprintf("%s: %v", c.name, err), http.StatusServiceUnavailable)
+				return
+			}
+		}
+		fmt.Fprintln(w, "ready")
+	})
+}
+
+// runStopHooks runs every stop hook, each with ShutdownTimeout to finish
+func (s *Server) runStopHooks() error {
+	var errs []error
+	for _, h := range s.stopHooks {
+		ctx, cancel := context.WithTimeout(context.Background(), s.cfg.ShutdownTimeout)
+		if err := h.hook(ctx); err != nil {
+			log.Error().Err(err).Msgf("Stop hook %s failed", h.name)
+			errs = append(errs, fmt.Errorf("stop hook %s: %w", h.name, err))
+		}
+		cancel()
+	}
+	log.Info().Msgf("%s stopped", s.cfg.Name)
+	return errors.Join(errs...)
+}
@@ -0,0 +1,202 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// recorder records the order hooks and workers ran in
+type recorder struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (r *recorder) hook(name string, err error) Hook {
+	return func(context.Context) error {
+		r.record(name)
+		return err
+	}
+}
+
+func (r *recorder) record(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, name)
+}
+
+func testServer() *Server {
+	s, err := New(Config{
+		Name:            "Test service",
+		GrpcAddr:        "127.0.0.1:0",
+		HttpAddr:        "127.0.0.1:0",
+		ShutdownTimeout: time.Second,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func testGateway(context.Context, string) (http.Handler, error) {
+	return http.NotFoundHandler(), nil
+}
+
+func TestServer_Run(t *testing.T) {
+	s := testServer()
+	calls := &recorder{}
+	s.OnStart("first", calls.hook("start first", nil))
+	s.OnStart("second", calls.hook("start second", nil))
+	s.Go("worker", func(ctx context.Context) {
+		<-ctx.Done()
+		calls.record("worker stopped")
+	})
+	s.OnStop("database", calls.hook("stop database", nil))
+	s.OnStop("cache", calls.hook("stop cache", errors.New("already closed")))
+	s.OnStop("clients", calls.hook("stop clients", nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- s.Run(ctx, grpc.NewServer(), testGateway) }()
+
+	assert.Eventually(t, s.Ready, time.Second, 10*time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.ErrorContains(t, err, "stop hook cache: already closed")
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not shut down")
+	}
+	assert.False(t, s.Ready())
+	assert.Equal(t, []string{"start first", "start second", "worker stopped", "stop database", "stop cache", "stop clients"}, calls.calls)
+}
+
+func TestServer_Run_DrainsGatewayBeforeGrpc(t *testing.T) {
+	// Reserve a port for the gateway so the test can call it
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	httpAddr := listener.Addr().String()
+	listener.Close()
+
+	s, err := New(Config{Name: "Test service", GrpcAddr: "127.0.0.1:0", HttpAddr: httpAddr, ShutdownTimeout: 5 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The gateway request calls the gRPC server only once shutdown began
+	started, release := make(chan struct{}), make(chan struct{})
+	gateway := func(ctx context.Context, grpcAddr string) (http.Handler, error) {
+		conn, err := grpc.NewClient(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return nil, err
+		}
+		go func() {
+			<-ctx.Done()
+			conn.Close()
+		}()
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			<-release
+			if _, err := healthpb.NewHealthClient(conn).Check(r.Context(), &healthpb.HealthCheckRequest{}); err != nil {
+				http.Error(w, err.Error(), http.StatusBadGateway)
+			}
+		}), nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- s.Run(ctx, grpc.NewServer(), gateway) }()
+	assert.Eventually(t, s.Ready, time.Second, 10*time.Millisecond)
+
+	status := make(chan int)
+	go func() {
+		resp, err := http.Get("http://" + httpAddr + "/api/books")
+		if err != nil {
+			status <- 0
+			return
+		}
+		resp.Body.Close()
+		status <- resp.StatusCode
+	}()
+	<-started
+	cancel()
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+
+	assert.Equal(t, http.StatusOK, <-status)
+	assert.NoError(t, <-done)
+}
+
+func TestNew_InvalidShutdownTimeout(t *testing.T) {
+	s, err := New(Config{Name: "Test service", ShutdownTimeout: 0})
+
+	assert.ErrorIs(t, err, ErrInvalidShutdownTimeout)
+	assert.Nil(t, s)
+}
+
+func TestServer_Run_StartHookFails(t *testing.T) {
+	s := testServer()
+	calls := &recorder{}
+	s.OnStart("first", calls.hook("start first", errors.New("boom")))
+	s.OnStart("second", calls.hook("start second", nil))
+	s.OnStop("database", calls.hook("stop database", nil))
+
+	err := s.Run(context.Background(), grpc.NewServer(), testGateway)
+
+	assert.ErrorContains(t, err, "start hook first: boom")
+	assert.False(t, s.Ready())
+	assert.Equal(t, []string{"start first", "stop database"}, calls.calls)
+}
+
+func TestServer_Readiness(t *testing.T) {
+	s := testServer()
+	handler := s.readiness(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	serve := func(path string) int {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusServiceUnavailable, serve(ReadyPath))
+	s.setReady(true)
+	assert.Equal(t, http.StatusOK, serve(ReadyPath))
+	assert.Equal(t, http.StatusTeapot, serve("/api/books"))
+	s.setReady(false)
+	assert.Equal(t, http.StatusServiceUnavailable, serve(ReadyPath))
+}
+
+func TestServer_ReadyWhen(t *testing.T) {
+	s := testServer()
+	var feedErr error
+	s.ReadyWhen("loan feed", func() error { return feedErr })
+	handler := s.readiness(http.NotFoundHandler())
+	serve := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ReadyPath, nil))
+		return rec
+	}
+	s.setReady(true)
+
+	assert.Equal(t, http.StatusOK, serve().Code)
+	feedErr = errors.New("unauthorized")
+	rec := serve()
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Contains(t, rec.Body.String(), "loan feed: unauthorized")
+	feedErr = nil
+	assert.Equal(t, http.StatusOK, serve().Code)
+}
@@ -0,0 +1,108 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+// ErrUsage is returned by Run for unknown commands and malformed arguments
+var ErrUsage = errors.New("usage: migrate up | down [N] | status | force VERSION")
+
+// Run carries out a migrate subcommand of a service binary and prints what
+// it did to out:
+//
+//	migrate up              apply every pending migration
+//	migrate down [N]        roll back the last N migrations (default 1)
+//	migrate status          list the migrations and when they were applied
+//	migrate force VERSION   record VERSION as the current version without running SQL
+func Run(ctx context.Context, m *Migrator, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return ErrUsage
+	}
+
+	switch command, args := args[0], args[1:]; command {
+	case "up":
+		if len(args) != 0 {
+			return ErrUsage
+		}
+		applied, err := m.Up(ctx)
+		for _, migration := range applied {
+			fmt.Fprintf(out, "applied %d_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Fprintf(out, "schema is up to date at version %d\n", m.Latest())
+		}
+		return nil
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			return ErrUsage
+		}
+		if len(args) == 1 {
+			n, err := strconv.Atoi(args[0])
+			if err != nil {
+				return ErrUsage
+			}
+			steps = n
+		}
+		rolledBack, err := m.Down(ctx, steps)
+		for _, migration := range rolledBack {
+			fmt.Fprintf(out, "rolled back %d_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(rolledBack) == 0 {
+			fmt.Fprintln(out, "no migrations to roll back")
+		}
+		return nil
+
+	case "status":
+		if len(args) != 0 {
+			return ErrUsage
+		}
+		states, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED")
+		for _, state := range states {
+			applied := "pending"
+			if state.AppliedAt != nil {
+				applied = state.AppliedAt.Format(time.RFC3339)
+			}
+			if state.Missing {
+				applied += " (not in this build)"
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\n", state.Version, state.Name, applied)
+		}
+		return w.Flush()
+
+	case "force":
+		if len(args) != 1 {
+			return ErrUsage
+		}
+		version, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return ErrUsage
+		}
+		if err := m.Force(ctx, version); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "forced version %d\n", version)
+		return nil
+
+	default:
+		return ErrUsage
+	}
+}
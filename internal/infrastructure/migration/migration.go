@@ -0,0 +1,79 @@
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// ErrInvalidMigration is returned for migration files that are misnamed,
+// duplicated or missing their up or down half
+var ErrInvalidMigration = errors.New("invalid migration")
+
+// fileName matches migration files such as 0002_loan_status.up.sql
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration is one versioned change to a service's schema, with the SQL
+// applying it and the SQL rolling it back
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Load reads the migrations in the top directory of fsys, in version order.
+// Every version needs both an NNNN_name.up.sql and an NNNN_name.down.sql
+// file; other files are ignored.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("%w: %s: version must be a positive number", ErrInvalidMigration, entry.Name())
+		}
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("%w: version %d is named both %s and %s", ErrInvalidMigration, version, migration.Name, match[2])
+		}
+		sql := &migration.Up
+		if match[3] == "down" {
+			sql = &migration.Down
+		}
+		if *sql != "" {
+			return nil, fmt.Errorf("%w: %s: duplicate %s migration", ErrInvalidMigration, entry.Name(), match[3])
+		}
+		*sql = string(content)
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("%w: version %d needs both an up and a down file", ErrInvalidMigration, migration.Version)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
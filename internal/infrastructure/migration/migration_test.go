@@ -0,0 +1,83 @@
+package migration
+
+import (
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hinha/library-management-synapsis/migrate"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   fstest.MapFS
+		want    []Migration
+		wantErr error
+	}{
+		{
+			name: "Orders by version and ignores other files",
+			files: fstest.MapFS{
+				"0002_add_column.up.sql":       {Data: []byte("ALTER TABLE t ADD COLUMN c text")},
+				"0002_add_column.down.sql":     {Data: []byte("ALTER TABLE t DROP COLUMN c")},
+				"0001_initial_schema.up.sql":   {Data: []byte("CREATE TABLE t (id text)")},
+				"0001_initial_schema.down.sql": {Data: []byte("DROP TABLE t")},
+				"README.md":                    {Data: []byte("notes")},
+			},
+			want: []Migration{
+				{Version: 1, Name: "initial_schema", Up: "CREATE TABLE t (id text)", Down: "DROP TABLE t"},
+				{Version: 2, Name: "add_column", Up: "ALTER TABLE t ADD COLUMN c text", Down: "ALTER TABLE t DROP COLUMN c"},
+			},
+		},
+		{
+			name: "Missing down file",
+			files: fstest.MapFS{
+				"0001_initial_schema.up.sql": {Data: []byte("CREATE TABLE t (id text)")},
+			},
+			wantErr: ErrInvalidMigration,
+		},
+		{
+			name: "Version named twice",
+			files: fstest.MapFS{
+				"0001_initial_schema.up.sql": {Data: []byte("CREATE TABLE t (id text)")},
+				"0001_other.down.sql":        {Data: []byte("DROP TABLE t")},
+			},
+			wantErr: ErrInvalidMigration,
+		},
+		{
+			name: "Version zero",
+			files: fstest.MapFS{
+				"0000_initial_schema.up.sql":   {Data: []byte("CREATE TABLE t (id text)")},
+				"0000_initial_schema.down.sql": {Data: []byte("DROP TABLE t")},
+			},
+			wantErr: ErrInvalidMigration,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Load(tt.files)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLoad_Embedded(t *testing.T) {
+	for name, files := range map[string]func() fs.FS{"user": migrate.User, "book": migrate.Book, "transaction": migrate.Transaction} {
+		t.Run(name, func(t *testing.T) {
+			migrations, err := Load(files())
+
+			assert.NoError(t, err)
+			if assert.NotEmpty(t, migrations) {
+				assert.Equal(t, int64(1), migrations[0].Version)
+			}
+		})
+	}
+}
@@ -0,0 +1,310 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrSchemaBehind is returned by Check when migrations of this build
+	// have not been applied to the database yet
+	ErrSchemaBehind = errors.New("database schema is behind")
+	// ErrUnknownVersion is returned for versions this build has no migration for
+	ErrUnknownVersion = errors.New("unknown migration version")
+	// ErrInvalidSteps is returned when rolling back fewer than one migration
+	ErrInvalidSteps = errors.New("steps must be at least 1")
+)
+
+// createTableSQL creates the table recording the applied migrations
+const createTableSQL = `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version    bigint PRIMARY KEY,
+	name       text NOT NULL,
+	applied_at timestamptz NOT NULL
+)`
+
+// AppliedMigration is a row of the schema_migrations table
+type AppliedMigration struct {
+	Version   int64 `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	AppliedAt time.Time
+}
+
+// TableName specifies the table name for AppliedMigration
+func (AppliedMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// State is a migration and whether it has been applied. Missing migrations
+// were applied by a newer build and are unknown to this one.
+type State struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+	Missing   bool
+}
+
+// Migrator applies a service's migrations to its database. Changes hold a
+// PostgreSQL advisory lock for the service, so replicas starting together
+// apply each migration once, and every migration runs in its own
+// transaction along with its schema_migrations row.
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+	lockKey    int64
+}
+
+// New creates a Migrator for the migrations in fsys. The service name keys
+// the advisory lock, so services sharing a database do not wait on each other.
+func New(db *gorm.DB, fsys fs.FS, service string) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := fnv.New64a()
+	hash.Write([]byte("schema_migrations:" + service))
+	return &Migrator{db: db, migrations: migrations, lockKey: int64(hash.Sum64())}, nil
+}
+
+// Latest returns the version of the newest migration of this build, 0 when
+// there are none
+func (m *Migrator) Latest() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Status lists the migrations of this build and the versions applied by a
+// newer one, in version order
+func (m *Migrator) Status(ctx context.Context) ([]State, error) {
+	applied, err := m.applied(m.db.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return m.states(applied), nil
+}
+
+// Version returns the newest applied version, 0 on a database no migration
+// has been applied to
+func (m *Migrator) Version(ctx context.Context) (int64, error) {
+	applied, err := m.applied(m.db.WithContext(ctx))
+	if err != nil {
+		return 0, err
+	}
+	if len(applied) == 0 {
+		return 0, nil
+	}
+	return applied[len(applied)-1].Version, nil
+}
+
+// Check fails with ErrSchemaBehind when a migration of this build is not
+// applied. A schema ahead of this build, as during a rollout that migrated
+// first, only logs a warning since migrations are expected to stay
+// compatible with the previous release.
+func (m *Migrator) Check(ctx context.Context) error {
+	states, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	var pending, missing int
+	var version int64
+	for _, state := range states {
+		switch {
+		case state.Missing:
+			missing++
+			version = state.Version
+		case state.AppliedAt == nil:
+			pending++
+		default:
+			version = state.Version
+		}
+	}
+	if pending > 0 {
+		return fmt.Errorf("%w: version %d with %d pending migrations, want %d", ErrSchemaBehind, version, pending, m.Latest())
+	}
+	if missing > 0 {
+		log.Warn().Int64("version", version).Int64("latest", m.Latest()).Msg("Database schema is ahead of this build")
+	}
+	return nil
+}
+
+// Up applies every pending migration in version order and returns the ones
+// it applied. It stops at the first migration that fails, which is rolled
+// back as a whole.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func(conn *gorm.DB) error {
+		applied, err := m.applied(conn)
+		if err != nil {
+			return err
+		}
+		isApplied := make(map[int64]bool, len(applied))
+		for _, record := range applied {
+			isApplied[record.Version] = true
+		}
+
+		for _, migration := range m.migrations {
+			if isApplied[migration.Version] {
+				continue
+			}
+			if err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(migration.Up).Error; err != nil {
+					return err
+				}
+				return tx.Create(&AppliedMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now()}).Error
+			}); err != nil {
+				return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down rolls back the given number of most recently versioned applied
+// migrations and returns the ones it rolled back. It refuses to roll back
+// versions applied by a newer build, whose down SQL it does not have.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if steps < 1 {
+		return nil, ErrInvalidSteps
+	}
+
+	var done []Migration
+	err := m.withLock(ctx, func(conn *gorm.DB) error {
+		applied, err := m.applied(conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(applied) - 1; i >= 0 && len(done) < steps; i-- {
+			migration, ok := m.find(applied[i].Version)
+			if !ok {
+				return fmt.Errorf("%w: %d was applied by a newer build", ErrUnknownVersion, applied[i].Version)
+			}
+			if err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(migration.Down).Error; err != nil {
+					return err
+				}
+				return tx.Delete(&AppliedMigration{Version: migration.Version}).Error
+			}); err != nil {
+				return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Force records version and every migration before it as applied, and every
+// later one as not applied, without running any SQL. It repairs the record
+// after a failed migration was finished or undone by hand; 0 records none.
+func (m *Migrator) Force(ctx context.Context, version int64) error {
+	if _, ok := m.find(version); !ok && version != 0 {
+		return fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+
+	return m.withLock(ctx, func(conn *gorm.DB) error {
+		applied, err := m.applied(conn)
+		if err != nil {
+			return err
+		}
+		isApplied := make(map[int64]bool, len(applied))
+		for _, record := range applied {
+			isApplied[record.Version] = true
+		}
+
+		return conn.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Where("version > ?", version).Delete(&AppliedMigration{}).Error; err != nil {
+				return err
+			}
+			for _, migration := range m.migrations {
+				if migration.Version > version || isApplied[migration.Version] {
+					continue
+				}
+				if err := tx.Create(&AppliedMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now()}).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+}
+
+// withLock runs fn on a single connection holding the service's advisory
+// lock, after making sure the schema_migrations table exists
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *gorm.DB) error) error {
+	return m.db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		// A new session, so the statements on conn do not share conditions
+		conn = conn.Session(&gorm.Session{})
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", m.lockKey).Error; err != nil {
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+		// Unlock even when ctx is done, the lock is held until the session ends
+		defer conn.WithContext(context.WithoutCancel(ctx)).Exec("SELECT pg_advisory_unlock(?)", m.lockKey)
+
+		if err := conn.Exec(createTableSQL).Error; err != nil {
+			return err
+		}
+		return fn(conn)
+	})
+}
+
+// applied returns the applied migrations in version order, none when the
+// schema_migrations table does not exist yet
+func (m *Migrator) applied(db *gorm.DB) ([]AppliedMigration, error) {
+	var exists bool
+	if err := db.Raw("SELECT to_regclass('schema_migrations') IS NOT NULL").Scan(&exists).Error; err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	var applied []AppliedMigration
+	if err := db.Order("version").Find(&applied).Error; err != nil {
+		return nil, err
+	}
+	return applied, nil
+}
+
+// states merges the migrations of this build with the applied ones
+func (m *Migrator) states(applied []AppliedMigration) []State {
+	states := make([]State, 0, len(m.migrations))
+	i := 0
+	for _, migration := range m.migrations {
+		for ; i < len(applied) && applied[i].Version < migration.Version; i++ {
+			states = append(states, State{Version: applied[i].Version, Name: applied[i].Name, AppliedAt: &applied[i].AppliedAt, Missing: true})
+		}
+		state := State{Version: migration.Version, Name: migration.Name}
+		if i < len(applied) && applied[i].Version == migration.Version {
+			state.AppliedAt = &applied[i].AppliedAt
+			i++
+		}
+		states = append(states, state)
+	}
+	for ; i < len(applied); i++ {
+		states = append(states, State{Version: applied[i].Version, Name: applied[i].Name, AppliedAt: &applied[i].AppliedAt, Missing: true})
+	}
+	return states
+}
+
+// find returns the migration of this build with the given version
+func (m *Migrator) find(version int64) (Migration, bool) {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration, true
+		}
+	}
+	return Migration{}, false
+}
@@ -0,0 +1,277 @@
+package migration
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// testMigrations are two migrations, the second adding a column
+var testMigrations = fstest.MapFS{
+	"0001_initial_schema.up.sql":   {Data: []byte("CREATE TABLE widgets (id text)")},
+	"0001_initial_schema.down.sql": {Data: []byte("DROP TABLE widgets")},
+	"0002_widget_name.up.sql":      {Data: []byte("ALTER TABLE widgets ADD COLUMN name text")},
+	"0002_widget_name.down.sql":    {Data: []byte("ALTER TABLE widgets DROP COLUMN name")},
+}
+
+func setupMigrator(t *testing.T) (*Migrator, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	gdb, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+	m, err := New(gdb, testMigrations, "widget-service")
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+	return m, mock
+}
+
+// expectApplied expects the lookup of the applied migrations, with no
+// schema_migrations table when versions is nil
+func expectApplied(mock sqlmock.Sqlmock, versions ...int64) {
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT to_regclass('schema_migrations') IS NOT NULL")).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(versions != nil))
+	if versions == nil {
+		return
+	}
+	rows := sqlmock.NewRows([]string{"version", "name", "applied_at"})
+	names := map[int64]string{1: "initial_schema", 2: "widget_name", 3: "widget_color"}
+	for _, version := range versions {
+		rows.AddRow(version, names[version], time.Now())
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "schema_migrations" ORDER BY version`)).WillReturnRows(rows)
+}
+
+// expectLock expects the advisory lock and the schema_migrations table
+func expectLock(m *Migrator, mock sqlmock.Sqlmock) {
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_lock($1)")).WithArgs(m.lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS schema_migrations")).WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func expectUnlock(m *Migrator, mock sqlmock.Sqlmock) {
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_unlock($1)")).WithArgs(m.lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func TestMigrator_Up(t *testing.T) {
+	tests := []struct {
+		name      string
+		setupMock func(m *Migrator, mock sqlmock.Sqlmock)
+		want      []int64
+		wantErr   bool
+	}{
+		{
+			name: "Applies every migration to a new database",
+			setupMock: func(m *Migrator, mock sqlmock.Sqlmock) {
+				expectLock(m, mock)
+				expectApplied(mock, []int64{}...)
+				for _, sql := range []string{"CREATE TABLE widgets (id text)", "ALTER TABLE widgets ADD COLUMN name text"} {
+					mock.ExpectBegin()
+					mock.ExpectExec(regexp.QuoteMeta(sql)).WillReturnResult(sqlmock.NewResult(0, 0))
+					mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "schema_migrations"`)).WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectCommit()
+				}
+				expectUnlock(m, mock)
+			},
+			want: []int64{1, 2},
+		},
+		{
+			name: "Applies only the pending migrations",
+			setupMock: func(m *Migrator, mock sqlmock.Sqlmock) {
+				expectLock(m, mock)
+				expectApplied(mock, 1)
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE widgets ADD COLUMN name text")).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "schema_migrations"`)).
+					WithArgs(int64(2), "widget_name", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				expectUnlock(m, mock)
+			},
+			want: []int64{2},
+		},
+		{
+			name: "Stops at a failing migration",
+			setupMock: func(m *Migrator, mock sqlmock.Sqlmock) {
+				expectLock(m, mock)
+				expectApplied(mock, 1)
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE widgets ADD COLUMN name text")).WillReturnError(errors.New("syntax error"))
+				mock.ExpectRollback()
+				expectUnlock(m, mock)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Nothing pending",
+			setupMock: func(m *Migrator, mock sqlmock.Sqlmock) {
+				expectLock(m, mock)
+				expectApplied(mock, 1, 2)
+				expectUnlock(m, mock)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, mock := setupMigrator(t)
+			tt.setupMock(m, mock)
+
+			got, err := m.Up(context.Background())
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			var versions []int64
+			for _, migration := range got {
+				versions = append(versions, migration.Version)
+			}
+			assert.Equal(t, tt.want, versions)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMigrator_Down(t *testing.T) {
+	t.Run("Rolls back the newest migration", func(t *testing.T) {
+		m, mock := setupMigrator(t)
+		expectLock(m, mock)
+		expectApplied(mock, 1, 2)
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE widgets DROP COLUMN name")).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "schema_migrations" WHERE "schema_migrations"."version" = $1`)).
+			WithArgs(int64(2)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		expectUnlock(m, mock)
+
+		got, err := m.Down(context.Background(), 1)
+
+		assert.NoError(t, err)
+		if assert.Len(t, got, 1) {
+			assert.Equal(t, int64(2), got[0].Version)
+		}
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Refuses versions of a newer build", func(t *testing.T) {
+		m, mock := setupMigrator(t)
+		expectLock(m, mock)
+		expectApplied(mock, 1, 2, 3)
+		expectUnlock(m, mock)
+
+		got, err := m.Down(context.Background(), 1)
+
+		assert.ErrorIs(t, err, ErrUnknownVersion)
+		assert.Empty(t, got)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Invalid steps", func(t *testing.T) {
+		m, _ := setupMigrator(t)
+
+		_, err := m.Down(context.Background(), 0)
+
+		assert.ErrorIs(t, err, ErrInvalidSteps)
+	})
+}
+
+func TestMigrator_Force(t *testing.T) {
+	t.Run("Records the versions up to the forced one", func(t *testing.T) {
+		m, mock := setupMigrator(t)
+		expectLock(m, mock)
+		expectApplied(mock, 1)
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "schema_migrations" WHERE version > $1`)).
+			WithArgs(int64(2)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "schema_migrations"`)).
+			WithArgs(int64(2), "widget_name", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		expectUnlock(m, mock)
+
+		err := m.Force(context.Background(), 2)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unknown version", func(t *testing.T) {
+		m, mock := setupMigrator(t)
+
+		err := m.Force(context.Background(), 7)
+
+		assert.ErrorIs(t, err, ErrUnknownVersion)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestMigrator_Check(t *testing.T) {
+	tests := []struct {
+		name    string
+		applied []int64
+		wantErr error
+	}{
+		{name: "Up to date", applied: []int64{1, 2}},
+		{name: "Ahead of this build", applied: []int64{1, 2, 3}},
+		{name: "Behind", applied: []int64{1}, wantErr: ErrSchemaBehind},
+		{name: "New database", wantErr: ErrSchemaBehind},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, mock := setupMigrator(t)
+			expectApplied(mock, tt.applied...)
+
+			err := m.Check(context.Background())
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRun_Status(t *testing.T) {
+	m, mock := setupMigrator(t)
+	expectApplied(mock, 1, 3)
+	var out bytes.Buffer
+
+	err := Run(context.Background(), m, []string{"status"}, &out)
+
+	assert.NoError(t, err)
+	assert.Regexp(t, `(?m)^1\s+initial_schema\s+\d{4}-`, out.String())
+	assert.Regexp(t, `(?m)^2\s+widget_name\s+pending$`, out.String())
+	assert.Regexp(t, `(?m)^3\s+widget_color\s+.*\(not in this build\)$`, out.String())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRun_Usage(t *testing.T) {
+	m, _ := setupMigrator(t)
+
+	for _, args := range [][]string{nil, {"sideways"}, {"down", "two"}, {"force"}, {"up", "now"}} {
+		assert.ErrorIs(t, Run(context.Background(), m, args, &bytes.Buffer{}), ErrUsage, args)
+	}
+}
@@ -0,0 +1,108 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+)
+
+// ErrInvalidPageSize is returned when a feed is created with a page size
+// that is not positive
+var ErrInvalidPageSize = errors.New("page size must be positive")
+
+// Feed serves event subscriptions: it replays stored events after a cursor
+// and then tails new events as the relay hands them to the broker
+type Feed struct {
+	repo     IRepository
+	broker   *Broker
+	pageSize int
+}
+
+// NewFeed creates a new Feed replaying pageSize events at a time
+func NewFeed(repo IRepository, broker *Broker, pageSize int) (*Feed, error) {
+	if pageSize <= 0 {
+		return nil, ErrInvalidPageSize
+	}
+	return &Feed{
+		repo:     repo,
+		broker:   broker,
+		pageSize: pageSize,
+	}, nil
+}
+
+// Subscribe calls send for every event with a position after cursor whose
+// type is in types (all types when empty) until ctx is cancelled or send
+// fails
+func (f *Feed) Subscribe(ctx context.Context, cursor uint64, types []string, send func(*domain.OutboxEvent) error) error {
+	wanted := make(map[string]bool, len(types))
+	for _, t := range types {
+		wanted[t] = true
+	}
+	deliver := func(event *domain.OutboxEvent) error {
+		cursor = event.Position
+		if len(wanted) > 0 && !wanted[event.Type] {
+			return nil
+		}
+		return send(event)
+	}
+
+	for {
+		// Subscribe before replaying so nothing published meanwhile is missed
+		sub := f.broker.subscribe()
+
+		if err := f.replay(ctx, &cursor, deliver); err != nil {
+			f.broker.unsubscribe(sub)
+			return err
+		}
+
+		lagged, err := f.tail(ctx, sub, &cursor, deliver)
+		f.broker.unsubscribe(sub)
+		if err != nil || !lagged {
+			return err
+		}
+		// The broker dropped us for falling behind, or skipped positions
+		// another replica published; catch up from the table
+	}
+}
+
+func (f *Feed) replay(ctx context.Context, cursor *uint64, deliver func(*domain.OutboxEvent) error) error {
+	for {
+		events, err := f.repo.ListAfter(ctx, *cursor, f.pageSize)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			if err := deliver(event); err != nil {
+				return err
+			}
+		}
+		if len(events) < f.pageSize {
+			return nil
+		}
+	}
+}
+
+// tail delivers the events the broker hands over until ctx is cancelled. It
+// reports lagging once the broker drops the subscription or an event skips
+// a position, so the caller replays the missing ones from the table.
+func (f *Feed) tail(ctx context.Context, sub *subscription, cursor *uint64, deliver func(*domain.OutboxEvent) error) (bool, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return false, nil
+		case event, ok := <-sub.events:
+			if !ok {
+				return true, nil
+			}
+			if event.Position <= *cursor {
+				continue
+			}
+			if event.Position > *cursor+1 {
+				return true, nil
+			}
+			if err := deliver(event); err != nil {
+				return false, err
+			}
+		}
+	}
+}
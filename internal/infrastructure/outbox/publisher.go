@@ -0,0 +1,105 @@
+package outbox
+
+import (
+	"context"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"github.com/rs/zerolog/log"
+	"sync"
+)
+
+// Publisher delivers outbox events to consumers outside the service database
+type Publisher interface {
+	Publish(ctx context.Context, event *domain.OutboxEvent) error
+}
+
+// LogPublisher writes every event to the service log
+type LogPublisher struct{}
+
+// NewLogPublisher creates a new LogPublisher
+func NewLogPublisher() *LogPublisher {
+	return &LogPublisher{}
+}
+
+// Publish logs the event
+func (p *LogPublisher) Publish(_ context.Context, event *domain.OutboxEvent) error {
+	log.Info().
+		Uint64("cursor", event.Position).
+		Uint64("id", event.ID).
+		Str("event_id", event.EventID).
+		Str("type", event.Type).
+		Str("aggregate_type", event.AggregateType).
+		Str("aggregate_id", event.AggregateID).
+		Msg("Domain event published")
+	return nil
+}
+
+// MultiPublisher publishes every event to each of its publishers in order
+type MultiPublisher []Publisher
+
+// Publish publishes the event to every publisher, stopping at the first error
+func (m MultiPublisher) Publish(ctx context.Context, event *domain.OutboxEvent) error {
+	for _, p := range m {
+		if err := p.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// subscription is a single in-process consumer of a Broker
+type subscription struct {
+	events chan *domain.OutboxEvent
+}
+
+// Broker is an in-process publisher that fans events out to subscribers.
+// A subscriber that falls behind is dropped and its channel closed, so it
+// can catch up from the outbox table instead of blocking the relay.
+type Broker struct {
+	mu     sync.Mutex
+	subs   map[*subscription]struct{}
+	buffer int
+}
+
+// NewBroker creates a new Broker with the given per-subscriber buffer
+func NewBroker(buffer int) *Broker {
+	return &Broker{
+		subs:   make(map[*subscription]struct{}),
+		buffer: buffer,
+	}
+}
+
+// Publish hands the event to every subscriber without blocking
+func (b *Broker) Publish(_ context.Context, event *domain.OutboxEvent) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subs {
+		select {
+		case sub.events <- event:
+		default:
+			delete(b.subs, sub)
+			close(sub.events)
+		}
+	}
+	return nil
+}
+
+func (b *Broker) subscribe() *subscription {
+	sub := &subscription{events: make(chan *domain.OutboxEvent, b.buffer)}
+
+	b.mu.Lock()
+	b.subs[sub] = struct{}{}
+	b.mu.Unlock()
+
+	return sub
+}
+
+func (b *Broker) unsubscribe(sub *subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subs[sub]; ok {
+		delete(b.subs, sub)
+		close(sub.events)
+	}
+}
@@ -0,0 +1,71 @@
+package outbox
+
+import (
+	"context"
+	"github.com/rs/zerolog/log"
+	"time"
+)
+
+// Relay moves committed outbox events to a Publisher.
+// Delivery is at-least-once: an event published just before a crash is
+// published again, so consumers should deduplicate on the event ID.
+type Relay struct {
+	repo      IRepository
+	publisher Publisher
+	batchSize int
+}
+
+// NewRelay creates a new Relay
+func NewRelay(repo IRepository, publisher Publisher, batchSize int) *Relay {
+	return &Relay{
+		repo:      repo,
+		publisher: publisher,
+		batchSize: batchSize,
+	}
+}
+
+// Run flushes the outbox every interval until ctx is cancelled
+func (r *Relay) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := r.Flush(ctx); err != nil {
+				log.Error().Err(err).Msg("Failed to relay outbox events")
+			}
+		}
+	}
+}
+
+// Flush numbers the events committed since the last flush and publishes one
+// batch of unpublished events in order, returning how many were published.
+// It stops at the first event that fails to publish so ordering is preserved
+// on the next attempt.
+func (r *Relay) Flush(ctx context.Context) (int, error) {
+	if err := r.repo.AssignPositions(ctx, r.batchSize); err != nil {
+		return 0, err
+	}
+
+	events, err := r.repo.ListUnpublished(ctx, r.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	published := make([]uint64, 0, len(events))
+	var publishErr error
+	for _, event := range events {
+		if publishErr = r.publisher.Publish(ctx, event); publishErr != nil {
+			break
+		}
+		published = append(published, event.ID)
+	}
+
+	if err := r.repo.MarkPublished(ctx, published); err != nil {
+		return 0, err
+	}
+	return len(published), publishErr
+}
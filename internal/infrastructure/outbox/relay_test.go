@@ -0,0 +1,211 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"github.com/hinha/library-management-synapsis/internal/infrastructure/outbox/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// recordingPublisher records published events and fails on failAt
+type recordingPublisher struct {
+	events []*domain.OutboxEvent
+	failAt uint64
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, event *domain.OutboxEvent) error {
+	if event.ID == p.failAt {
+		return errors.New("publish error")
+	}
+	p.events = append(p.events, event)
+	return nil
+}
+
+func testEvents(ids ...uint64) []*domain.OutboxEvent {
+	events := make([]*domain.OutboxEvent, 0, len(ids))
+	for _, id := range ids {
+		events = append(events, &domain.OutboxEvent{ID: id, Position: id, Type: domain.EventBookCreated})
+	}
+	return events
+}
+
+func TestRelay_Flush(t *testing.T) {
+	tests := []struct {
+		name          string
+		failAt        uint64
+		setupMock     func(repo *mocks.IRepository)
+		wantPublished int
+		wantErr       bool
+	}{
+		{
+			name: "Publishes and marks the batch",
+			setupMock: func(repo *mocks.IRepository) {
+				repo.On("AssignPositions", mock.Anything, 10).Return(nil)
+				repo.On("ListUnpublished", mock.Anything, 10).Return(testEvents(1, 2, 3), nil)
+				repo.On("MarkPublished", mock.Anything, []uint64{1, 2, 3}).Return(nil)
+			},
+			wantPublished: 3,
+		},
+		{
+			name:   "Stops at the first failed event",
+			failAt: 2,
+			setupMock: func(repo *mocks.IRepository) {
+				repo.On("AssignPositions", mock.Anything, 10).Return(nil)
+				repo.On("ListUnpublished", mock.Anything, 10).Return(testEvents(1, 2, 3), nil)
+				repo.On("MarkPublished", mock.Anything, []uint64{1}).Return(nil)
+			},
+			wantPublished: 1,
+			wantErr:       true,
+		},
+		{
+			name: "Position error",
+			setupMock: func(repo *mocks.IRepository) {
+				repo.On("AssignPositions", mock.Anything, 10).Return(errors.New("db error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "List error",
+			setupMock: func(repo *mocks.IRepository) {
+				repo.On("AssignPositions", mock.Anything, 10).Return(nil)
+				repo.On("ListUnpublished", mock.Anything, 10).Return(nil, errors.New("db error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "Mark error",
+			setupMock: func(repo *mocks.IRepository) {
+				repo.On("AssignPositions", mock.Anything, 10).Return(nil)
+				repo.On("ListUnpublished", mock.Anything, 10).Return(testEvents(1), nil)
+				repo.On("MarkPublished", mock.Anything, []uint64{1}).Return(errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.IRepository)
+			tt.setupMock(repo)
+			publisher := &recordingPublisher{failAt: tt.failAt}
+
+			published, err := NewRelay(repo, publisher, 10).Flush(context.Background())
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantPublished, published)
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFeed_Subscribe(t *testing.T) {
+	t.Run("Replays stored events then tails the broker", func(t *testing.T) {
+		repo := new(mocks.IRepository)
+		repo.On("ListAfter", mock.Anything, uint64(0), 2).Return(testEvents(1, 2), nil).Once()
+		repo.On("ListAfter", mock.Anything, uint64(2), 2).Return(testEvents(3), nil).Once()
+
+		broker := NewBroker(10)
+		feed, err := NewFeed(repo, broker, 2)
+		assert.NoError(t, err)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var got []uint64
+		err = feed.Subscribe(ctx, 0, nil, func(event *domain.OutboxEvent) error {
+			got = append(got, event.ID)
+			if event.ID == 3 {
+				// Already replayed, must be skipped when it arrives live
+				_ = broker.Publish(ctx, testEvents(3)[0])
+				_ = broker.Publish(ctx, testEvents(4)[0])
+			}
+			if event.ID == 4 {
+				cancel()
+			}
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []uint64{1, 2, 3, 4}, got)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Filters by type", func(t *testing.T) {
+		events := testEvents(1, 2)
+		events[1].Type = domain.EventStockChanged
+		repo := new(mocks.IRepository)
+		repo.On("ListAfter", mock.Anything, uint64(0), 10).Return(events, nil).Once()
+
+		feed, err := NewFeed(repo, NewBroker(10), 10)
+		assert.NoError(t, err)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var got []uint64
+		err = feed.Subscribe(ctx, 0, []string{domain.EventStockChanged}, func(event *domain.OutboxEvent) error {
+			got = append(got, event.ID)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []uint64{2}, got)
+	})
+
+	t.Run("Send error stops the subscription", func(t *testing.T) {
+		repo := new(mocks.IRepository)
+		repo.On("ListAfter", mock.Anything, uint64(0), 10).Return(testEvents(1), nil).Once()
+
+		feed, err := NewFeed(repo, NewBroker(10), 10)
+		assert.NoError(t, err)
+		sendErr := errors.New("client gone")
+
+		err = feed.Subscribe(context.Background(), 0, nil, func(*domain.OutboxEvent) error {
+			return sendErr
+		})
+
+		assert.ErrorIs(t, err, sendErr)
+	})
+
+	t.Run("Catches up on a skipped position", func(t *testing.T) {
+		repo := new(mocks.IRepository)
+		repo.On("ListAfter", mock.Anything, uint64(0), 10).Return(testEvents(1), nil).Once()
+		repo.On("ListAfter", mock.Anything, uint64(1), 10).Return(testEvents(2, 3), nil).Once()
+
+		broker := NewBroker(10)
+		feed, err := NewFeed(repo, broker, 10)
+		assert.NoError(t, err)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var got []uint64
+		err = feed.Subscribe(ctx, 0, nil, func(event *domain.OutboxEvent) error {
+			got = append(got, event.ID)
+			switch event.ID {
+			case 1:
+				// Position 2 was published by another replica's relay
+				_ = broker.Publish(ctx, testEvents(3)[0])
+			case 3:
+				cancel()
+			}
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []uint64{1, 2, 3}, got)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Page size must be positive", func(t *testing.T) {
+		feed, err := NewFeed(new(mocks.IRepository), NewBroker(10), 0)
+
+		assert.ErrorIs(t, err, ErrInvalidPageSize)
+		assert.Nil(t, feed)
+	})
+}
@@ -0,0 +1,95 @@
+package outbox
+
+import (
+	"context"
+	"github.com/hinha/library-management-synapsis/internal/domain"
+	"hash/fnv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// IRepository defines the interface for outbox data access
+//
+//go:generate mockery --name=IRepository --output=mocks --outpkg=mocks
+type IRepository interface {
+	AssignPositions(ctx context.Context, limit int) error
+	ListUnpublished(ctx context.Context, limit int) ([]*domain.OutboxEvent, error)
+	MarkPublished(ctx context.Context, ids []uint64) error
+	ListAfter(ctx context.Context, cursor uint64, limit int) ([]*domain.OutboxEvent, error)
+}
+
+// DBRepository implements IRepository using GORM.
+// Events are written by the domain repositories inside their own transactions.
+type DBRepository struct {
+	db *gorm.DB
+}
+
+// NewDbRepository creates a new DBRepository
+func NewDbRepository(db *gorm.DB) IRepository {
+	return &DBRepository{db: db}
+}
+
+// positionLock is the advisory lock relays hold while assigning positions
+var positionLock = func() int64 {
+	hash := fnv.New64a()
+	hash.Write([]byte("outbox_events:position"))
+	return int64(hash.Sum64())
+}()
+
+// AssignPositions numbers up to limit committed events that have no position
+// yet, following the last position handed out. Positions are assigned under
+// an advisory lock, so each batch commits before the next one is numbered:
+// a consumer that has seen a position never misses a lower one, even when
+// the transaction that wrote the event committed late.
+func (r *DBRepository) AssignPositions(ctx context.Context, limit int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", positionLock).Error; err != nil {
+			return err
+		}
+		return tx.Exec(`UPDATE outbox_events SET position = numbered.position
+FROM (
+    SELECT id, (SELECT COALESCE(MAX(position), 0) FROM outbox_events) + row_number() OVER (ORDER BY id) AS position
+    FROM outbox_events WHERE position = 0 ORDER BY id LIMIT ?
+) AS numbered
+WHERE outbox_events.id = numbered.id`, limit).Error
+	})
+}
+
+// ListUnpublished retrieves the events the relay has not published yet, in
+// position order
+func (r *DBRepository) ListUnpublished(ctx context.Context, limit int) ([]*domain.OutboxEvent, error) {
+	var events []*domain.OutboxEvent
+	if err := r.db.WithContext(ctx).
+		Where("published_at IS NULL AND position > 0").
+		Order("position").
+		Limit(limit).
+		Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// MarkPublished records that the given events have been published
+func (r *DBRepository) MarkPublished(ctx context.Context, ids []uint64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Model(&domain.OutboxEvent{}).
+		Where("id IN ?", ids).
+		Update("published_at", time.Now()).Error
+}
+
+// ListAfter retrieves events with a position greater than cursor, in
+// position order
+func (r *DBRepository) ListAfter(ctx context.Context, cursor uint64, limit int) ([]*domain.OutboxEvent, error) {
+	var events []*domain.OutboxEvent
+	if err := r.db.WithContext(ctx).
+		Where("position > ?", cursor).
+		Order("position").
+		Limit(limit).
+		Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
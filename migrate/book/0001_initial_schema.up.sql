@@ -0,0 +1,15 @@
+-- Books, exactly as the book service created them on startup before
+-- versioned migrations. Every later change is its own migration adding only
+-- what is missing, so a database created by any earlier release adopts the
+-- migrations with migrate up.
+CREATE TABLE IF NOT EXISTS books (
+    id         text PRIMARY KEY,
+    title      text NOT NULL,
+    author     text NOT NULL,
+    category   text NOT NULL,
+    stock      integer NOT NULL,
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL,
+    deleted_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_books_deleted_at ON books (deleted_at);
@@ -0,0 +1,15 @@
+-- The outbox of events published once the change raising them commits
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id             bigserial PRIMARY KEY,
+    event_id       varchar(36) NOT NULL,
+    aggregate_type text NOT NULL,
+    aggregate_id   text NOT NULL,
+    type           text NOT NULL,
+    payload        jsonb NOT NULL,
+    created_at     timestamptz NOT NULL,
+    published_at   timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_outbox_events_event_id ON outbox_events (event_id);
+CREATE INDEX IF NOT EXISTS idx_outbox_events_aggregate_id ON outbox_events (aggregate_id);
+CREATE INDEX IF NOT EXISTS idx_outbox_events_type ON outbox_events (type);
+CREATE INDEX IF NOT EXISTS idx_outbox_events_published_at ON outbox_events (published_at);
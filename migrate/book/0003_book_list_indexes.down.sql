@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_books_created_at;
+DROP INDEX IF EXISTS idx_books_category;
+DROP INDEX IF EXISTS idx_books_author;
@@ -0,0 +1,4 @@
+-- Filtering and sorting of the book list
+CREATE INDEX IF NOT EXISTS idx_books_author ON books (author);
+CREATE INDEX IF NOT EXISTS idx_books_category ON books (category);
+CREATE INDEX IF NOT EXISTS idx_books_created_at ON books (created_at);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS feed_cursors;
+DROP TABLE IF EXISTS loan_records;
@@ -0,0 +1,18 @@
+-- The loan history recommendations are computed from, and how far the loan
+-- event feed has been read
+CREATE TABLE IF NOT EXISTS loan_records (
+    transaction_id text PRIMARY KEY,
+    user_id        text NOT NULL,
+    book_id        text NOT NULL,
+    borrowed_at    timestamptz NOT NULL,
+    created_at     timestamptz NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_loan_records_user_id ON loan_records (user_id);
+CREATE INDEX IF NOT EXISTS idx_loan_records_book_id ON loan_records (book_id);
+CREATE INDEX IF NOT EXISTS idx_loan_records_borrowed_at ON loan_records (borrowed_at);
+
+CREATE TABLE IF NOT EXISTS feed_cursors (
+    name       text PRIMARY KEY,
+    cursor     bigint NOT NULL,
+    updated_at timestamptz NOT NULL
+);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_books_isbn;
+ALTER TABLE books DROP COLUMN IF EXISTS description;
+ALTER TABLE books DROP COLUMN IF EXISTS edition;
+ALTER TABLE books DROP COLUMN IF EXISTS page_count;
+ALTER TABLE books DROP COLUMN IF EXISTS language;
+ALTER TABLE books DROP COLUMN IF EXISTS publication_year;
+ALTER TABLE books DROP COLUMN IF EXISTS publisher;
+ALTER TABLE books DROP COLUMN IF EXISTS isbn;
@@ -0,0 +1,9 @@
+-- Bibliographic details, with the ISBN unique among books not deleted
+ALTER TABLE books ADD COLUMN IF NOT EXISTS isbn varchar(13);
+ALTER TABLE books ADD COLUMN IF NOT EXISTS publisher text;
+ALTER TABLE books ADD COLUMN IF NOT EXISTS publication_year integer;
+ALTER TABLE books ADD COLUMN IF NOT EXISTS language text;
+ALTER TABLE books ADD COLUMN IF NOT EXISTS page_count integer;
+ALTER TABLE books ADD COLUMN IF NOT EXISTS edition text;
+ALTER TABLE books ADD COLUMN IF NOT EXISTS description text;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_books_isbn ON books (isbn) WHERE isbn <> '' AND deleted_at IS NULL;
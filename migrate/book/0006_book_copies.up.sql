@@ -0,0 +1,14 @@
+-- Physical copies of a book, identified by barcode
+CREATE TABLE IF NOT EXISTS book_copies (
+    id             text PRIMARY KEY,
+    book_id        text NOT NULL,
+    barcode        varchar(64) NOT NULL,
+    shelf_location text,
+    condition      text NOT NULL,
+    status         text NOT NULL,
+    created_at     timestamptz NOT NULL,
+    updated_at     timestamptz NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_book_copies_barcode ON book_copies (barcode);
+CREATE INDEX IF NOT EXISTS idx_book_copies_book_id ON book_copies (book_id);
+CREATE INDEX IF NOT EXISTS idx_book_copies_status ON book_copies (status);
@@ -0,0 +1,14 @@
+-- The ledger of every change to a book's stock
+CREATE TABLE IF NOT EXISTS stock_movements (
+    id             bigserial PRIMARY KEY,
+    book_id        text NOT NULL,
+    delta          integer NOT NULL,
+    reason         text NOT NULL,
+    actor_id       text,
+    correlation_id text,
+    barcode        text,
+    created_at     timestamptz NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_stock_movements_book_id ON stock_movements (book_id);
+CREATE INDEX IF NOT EXISTS idx_stock_movements_actor_id ON stock_movements (actor_id);
+CREATE INDEX IF NOT EXISTS idx_stock_movements_correlation_id ON stock_movements (correlation_id);
@@ -0,0 +1,2 @@
+-- Optimistic locking of book updates
+ALTER TABLE books ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 1;
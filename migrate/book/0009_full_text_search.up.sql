@@ -0,0 +1,9 @@
+-- Full-text search over titles, authors and categories, weighting title over
+-- author over category. Databases where an earlier release added the column
+-- on startup already have both.
+ALTER TABLE books ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(author, '')), 'B') ||
+    setweight(to_tsvector('simple', coalesce(category, '')), 'C')
+) STORED;
+CREATE INDEX IF NOT EXISTS idx_books_search_vector ON books USING GIN (search_vector);
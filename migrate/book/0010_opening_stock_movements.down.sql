@@ -0,0 +1 @@
+DELETE FROM stock_movements WHERE reason = 'opening';
@@ -0,0 +1,5 @@
+-- Books created before the stock ledger existed start it with an opening
+-- movement for their current stock
+INSERT INTO stock_movements (book_id, delta, reason, created_at)
+SELECT id, stock, 'opening', now() FROM books
+WHERE stock <> 0 AND NOT EXISTS (SELECT 1 FROM stock_movements WHERE stock_movements.book_id = books.id);
@@ -0,0 +1,11 @@
+-- The stock each loan of the transaction service took, keyed by the loan so
+-- retried reservations and releases change the stock once
+CREATE TABLE IF NOT EXISTS stock_reservations (
+    transaction_id text PRIMARY KEY,
+    book_id        text NOT NULL,
+    barcode        varchar(64),
+    status         text NOT NULL,
+    created_at     timestamptz NOT NULL,
+    updated_at     timestamptz NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_stock_reservations_book_id ON stock_reservations (book_id);
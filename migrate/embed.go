@@ -0,0 +1,42 @@
+// Package migrate embeds the versioned SQL migrations of each service. Every
+// service has its own directory of NNNN_name.up.sql and NNNN_name.down.sql
+// files, applied in version order by the service's migrate subcommand.
+package migrate
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed user/*.sql
+var user embed.FS
+
+//go:embed book/*.sql
+var book embed.FS
+
+//go:embed transaction/*.sql
+var transaction embed.FS
+
+// User returns the migrations of the user service
+func User() fs.FS {
+	return sub(user, "user")
+}
+
+// Book returns the migrations of the book service
+func Book() fs.FS {
+	return sub(book, "book")
+}
+
+// Transaction returns the migrations of the transaction service
+func Transaction() fs.FS {
+	return sub(transaction, "transaction")
+}
+
+// sub returns the files of dir, which is always embedded
+func sub(fsys embed.FS, dir string) fs.FS {
+	files, err := fs.Sub(fsys, dir)
+	if err != nil {
+		panic(err)
+	}
+	return files
+}
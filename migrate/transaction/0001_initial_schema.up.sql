@@ -0,0 +1,17 @@
+-- Loans, exactly as the transaction service created them on startup before
+-- versioned migrations. Every later change is its own migration adding only
+-- what is missing, so a database created by any earlier release adopts the
+-- migrations with migrate up.
+CREATE TABLE IF NOT EXISTS transactions (
+    id          text PRIMARY KEY,
+    user_id     text NOT NULL,
+    book_id     text NOT NULL,
+    borrowed_at timestamptz NOT NULL,
+    returned_at timestamptz DEFAULT NULL,
+    created_at  timestamptz NOT NULL,
+    updated_at  timestamptz NOT NULL,
+    deleted_at  timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_transactions_user_id ON transactions (user_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_book_id ON transactions (book_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_deleted_at ON transactions (deleted_at);
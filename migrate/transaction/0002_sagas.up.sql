@@ -0,0 +1,16 @@
+-- The progress of borrow and return sagas, so they are finished or undone
+-- after a crash
+CREATE TABLE IF NOT EXISTS sagas (
+    id             text PRIMARY KEY,
+    type           text NOT NULL,
+    transaction_id text NOT NULL,
+    user_id        text NOT NULL,
+    book_id        text NOT NULL,
+    step           text NOT NULL,
+    status         text NOT NULL,
+    last_error     text,
+    created_at     timestamptz NOT NULL,
+    updated_at     timestamptz NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_sagas_transaction_id ON sagas (transaction_id);
+CREATE INDEX IF NOT EXISTS idx_sagas_status ON sagas (status);
@@ -0,0 +1,3 @@
+-- When a loan is due back
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS due_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_transactions_due_at ON transactions (due_at);
@@ -0,0 +1,2 @@
+-- How many times a loan has been renewed
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS renewal_count integer NOT NULL DEFAULT 0;
@@ -0,0 +1,16 @@
+-- Holds queued on books out of stock
+CREATE TABLE IF NOT EXISTS holds (
+    id         text PRIMARY KEY,
+    user_id    text NOT NULL,
+    book_id    text NOT NULL,
+    status     text NOT NULL,
+    ready_at   timestamptz,
+    expires_at timestamptz,
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_holds_user_id ON holds (user_id);
+CREATE INDEX IF NOT EXISTS idx_holds_book_id ON holds (book_id);
+CREATE INDEX IF NOT EXISTS idx_holds_status ON holds (status);
+CREATE INDEX IF NOT EXISTS idx_holds_expires_at ON holds (expires_at);
+CREATE INDEX IF NOT EXISTS idx_holds_created_at ON holds (created_at);
@@ -0,0 +1,13 @@
+-- The ledger of fines charged, paid and waived
+CREATE TABLE IF NOT EXISTS ledger_entries (
+    id             text PRIMARY KEY,
+    user_id        text NOT NULL,
+    transaction_id text,
+    type           text NOT NULL,
+    amount         bigint NOT NULL,
+    note           text,
+    created_at     timestamptz NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_user_id ON ledger_entries (user_id);
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_transaction_id ON ledger_entries (transaction_id);
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_created_at ON ledger_entries (created_at);
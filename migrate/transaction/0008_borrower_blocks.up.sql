@@ -0,0 +1,8 @@
+-- Users blocked from borrowing by an admin
+CREATE TABLE IF NOT EXISTS borrower_blocks (
+    user_id    text PRIMARY KEY,
+    reason     text NOT NULL,
+    expires_at timestamptz,
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL
+);
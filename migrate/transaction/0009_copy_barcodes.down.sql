@@ -0,0 +1,3 @@
+ALTER TABLE sagas DROP COLUMN IF EXISTS copy_barcode;
+DROP INDEX IF EXISTS idx_transactions_copy_barcode;
+ALTER TABLE transactions DROP COLUMN IF EXISTS copy_barcode;
@@ -0,0 +1,4 @@
+-- The copy lent by a loan, and the copy a saga works on
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS copy_barcode text;
+CREATE INDEX IF NOT EXISTS idx_transactions_copy_barcode ON transactions (copy_barcode);
+ALTER TABLE sagas ADD COLUMN IF NOT EXISTS copy_barcode text;
@@ -0,0 +1,5 @@
+CREATE INDEX IF NOT EXISTS idx_transactions_user_id ON transactions (user_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_book_id ON transactions (book_id);
+DROP INDEX IF EXISTS idx_transactions_borrowed_at;
+DROP INDEX IF EXISTS idx_transactions_book_borrowed_at;
+DROP INDEX IF EXISTS idx_transactions_user_borrowed_at;
@@ -0,0 +1,7 @@
+-- Loan history pages of a user or a book in borrow order, replacing the
+-- indexes on user_id and book_id alone
+CREATE INDEX IF NOT EXISTS idx_transactions_user_borrowed_at ON transactions (user_id, borrowed_at);
+CREATE INDEX IF NOT EXISTS idx_transactions_book_borrowed_at ON transactions (book_id, borrowed_at);
+CREATE INDEX IF NOT EXISTS idx_transactions_borrowed_at ON transactions (borrowed_at);
+DROP INDEX IF EXISTS idx_transactions_user_id;
+DROP INDEX IF EXISTS idx_transactions_book_id;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_transactions_status;
+ALTER TABLE transactions DROP COLUMN IF EXISTS replacement_fee;
+ALTER TABLE transactions DROP COLUMN IF EXISTS status;
@@ -0,0 +1,4 @@
+-- Loans closed as lost or damaged, and the replacement fee charged for them
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS status varchar(16) NOT NULL DEFAULT '';
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS replacement_fee bigint NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS idx_transactions_status ON transactions (status);
@@ -0,0 +1,17 @@
+-- Users, exactly as the user service created them on startup before
+-- versioned migrations. Every later change is its own migration adding only
+-- what is missing, so a database created by any earlier release adopts the
+-- migrations with migrate up.
+CREATE TABLE IF NOT EXISTS users (
+    id         bigserial PRIMARY KEY,
+    name       text NOT NULL,
+    email      varchar(255) NOT NULL,
+    password   varchar(255) NOT NULL,
+    role       text NOT NULL DEFAULT 'operation',
+    active     boolean DEFAULT true,
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL,
+    deleted_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users (email);
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at);
@@ -0,0 +1,2 @@
+-- Optimistic locking of user updates
+ALTER TABLE users ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 1;
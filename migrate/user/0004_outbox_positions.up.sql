@@ -0,0 +1,7 @@
+-- Event IDs are handed out as events are written, not as they commit, so
+-- subscribers resume from a position the relay assigns in commit order.
+-- Events written before keep their ID as position.
+ALTER TABLE outbox_events ADD COLUMN IF NOT EXISTS position bigint NOT NULL DEFAULT 0;
+UPDATE outbox_events SET position = id WHERE position = 0;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_outbox_events_position ON outbox_events (position) WHERE position > 0;
+CREATE INDEX IF NOT EXISTS idx_outbox_events_unpositioned ON outbox_events (id) WHERE position = 0;
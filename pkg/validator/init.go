@@ -62,3 +62,23 @@ func NewValidationGRPCError(err error) error {
 
 	return gstatus.FromProto(statusProto).Err()
 }
+
+// NewPreconditionGRPCError creates a FailedPrecondition gRPC error carrying a
+// machine-readable reason and its metadata as a detail
+func NewPreconditionGRPCError(reason, message string, metadata map[string]string) error {
+	statusProto := &spb.Status{
+		Code:    int32(codes.FailedPrecondition),
+		Message: message,
+	}
+
+	detail := &common.PreconditionFailure{
+		Reason:   reason,
+		Message:  message,
+		Metadata: metadata,
+	}
+	if anyTo, err := anypb.New(detail); err == nil {
+		statusProto.Details = append(statusProto.Details, anyTo)
+	}
+
+	return gstatus.FromProto(statusProto).Err()
+}